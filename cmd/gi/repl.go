@@ -25,6 +25,15 @@ func setCompilerVersion() {
 
 func main() {
 	setCompilerVersion()
+
+	// gi test [-v] [-run regexp] [-bench regexp] [packages]
+	if len(os.Args) > 1 && os.Args[1] == "test" {
+		cfg := compiler.NewGIConfig()
+		cfg.Quiet = true
+		cfg.ValidateConfig()
+		os.Exit(compiler.GoTest(cfg, os.Args[2:]))
+	}
+
 	myflags := flag.NewFlagSet("gi", flag.ExitOnError)
 	cfg := compiler.NewGIConfig()
 	cfg.DefineFlags(myflags)
@@ -34,6 +43,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("%s command line flag error: '%s'", ProgramName, err)
 	}
+	if cfg.VerifyPath != "" {
+		os.Exit(compiler.VerifyTranscripts(cfg, append([]string{cfg.VerifyPath}, myflags.Args()...)))
+	}
 	if !cfg.Quiet {
 		fmt.Printf(
 			`====================
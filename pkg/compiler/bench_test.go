@@ -0,0 +1,93 @@
+package compiler
+
+import (
+	"testing"
+	"time"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2020BenchReportsStatistics(t *testing.T) {
+
+	vm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer vm.Close()
+	inc := NewIncrState(vm, nil)
+	inc.TrackPos = true
+	r := &Repl{lvm: vm, inc: inc, chunks: make(map[string]*ChunkPosMap)}
+
+	cv.Convey(":bench runs a snippet in rounds after warmup, and reports ns/op, B/op, and the spread; -vs writes a Go benchmark carrying the REPL's declarations.", t, func() {
+		o, err := parseBenchArgs("-count 3 -benchtime=30ms -vs fib(10)")
+		panicOn(err)
+		cv.So(o.rounds, cv.ShouldEqual, 3)
+		cv.So(o.benchtime, cv.ShouldEqual, 30*time.Millisecond)
+		cv.So(o.vs, cv.ShouldBeTrue)
+		cv.So(o.snippet, cv.ShouldEqual, "fib(10)")
+		_, err = parseBenchArgs("-count")
+		cv.So(err, cv.ShouldNotBeNil)
+
+		cv.So(benchBody("fib(10)"), cv.ShouldEqual, "fib(10)")
+		cv.So(benchBody("a + b"), cv.ShouldEqual, "_ = a + b")
+		cv.So(benchBody("x++"), cv.ShouldEqual, "x++")
+
+		src := `
+func fib(n int) int {
+	if n < 2 {
+		return n
+	}
+	return fib(n-1) + fib(n-2)
+}
+type Pt struct{ X int }
+x := 0`
+		by, err := inc.Tr([]byte(src))
+		panicOn(err)
+		m := inc.LastPosMap
+		r.chunks[m.Name] = m
+		panicOn(LuaRunChunk(vm, string(by), m.Name))
+
+		res, err := r.bench("_ = fib(10)", 3, 30*time.Millisecond)
+		panicOn(err)
+		cv.So(res.N, cv.ShouldBeGreaterThan, 0)
+		cv.So(len(res.NsPerOp), cv.ShouldEqual, 3)
+		cv.So(res.Mean(), cv.ShouldBeGreaterThan, 0)
+		cv.So(res.Stddev(), cv.ShouldBeGreaterThanOrEqualTo, 0)
+		cv.So(res.String(), cv.ShouldContainSubstring, "ns/op")
+		cv.So(res.String(), cv.ShouldContainSubstring, "B/op")
+
+		// allocation is seen.
+		res, err = r.bench("_ = make([]int, 100)", 2, 20*time.Millisecond)
+		panicOn(err)
+		cv.So(res.BytesPerOp, cv.ShouldBeGreaterThan, 100)
+
+		// the closure timed is not left behind.
+		LuaRunAndReport(vm, `benchGone = (__gijit_bench == nil)`)
+		LuaMustBool(vm, "benchGone", true)
+
+		// only parsed, for goBenchSource.
+		r.chunks["gi:0"] = &ChunkPosMap{Name: "gi:0", GoSrc: `import "fmt"; import "sort"`}
+		r.chunks["gi:5"] = &ChunkPosMap{Name: "gi:5", Entry: 5, GoSrc: `const lo, hi = 1, 2
+var msg = "sort.Ints is not called"`}
+		r.chunks["gi:6"] = &ChunkPosMap{Name: "gi:6", Entry: 6, GoSrc: `const hi = 3`}
+
+		gosrc := r.goBenchSource("_ = fib(10)")
+		cv.So(gosrc, cv.ShouldContainSubstring, "func fib(n int) int {")
+		cv.So(gosrc, cv.ShouldContainSubstring, "type Pt struct")
+		cv.So(gosrc, cv.ShouldContainSubstring, "func BenchmarkEntry(b *testing.B) {")
+		cv.So(gosrc, cv.ShouldNotContainSubstring, "\"fmt\"")
+		// x := 0 declares x at package level and sets it up
+		// before the timing starts.
+		cv.So(gosrc, cv.ShouldContainSubstring, "var x int\n")
+		cv.So(gosrc, cv.ShouldNotContainSubstring, "x := 0")
+		cv.So(gosrc, cv.ShouldContainSubstring, "\tx = 0\n\tb.ResetTimer()\n")
+		cv.So(gosrc, cv.ShouldNotContainSubstring, benchFuncName)
+		// sort. in a string is no use of sort.
+		cv.So(gosrc, cv.ShouldNotContainSubstring, "\"sort\"")
+		// hi is declared again; lo stays.
+		cv.So(gosrc, cv.ShouldContainSubstring, "const lo = 1")
+		cv.So(gosrc, cv.ShouldNotContainSubstring, "hi = 2")
+		cv.So(gosrc, cv.ShouldContainSubstring, "const hi = 3")
+
+		gosrc = r.goBenchSource("_ = fmt.Sprint(fib(10))")
+		cv.So(gosrc, cv.ShouldContainSubstring, "\t\"fmt\"\n")
+	})
+}
@@ -55,12 +55,20 @@ func init() {
 //
 // jea: now it is half in translate.go, half here.
 
+// ImportCError is an import "C" that gijit cannot do:
+// one with a preamble we cannot read (see cgo.go), or
+// one in the cgo files of a package imported from
+// source, which are not compiled.
 type ImportCError struct {
 	pkgPath string
+	err     error
 }
 
 func (e *ImportCError) Error() string {
-	return e.pkgPath + `: importing "C" is not supported by gijit`
+	if e.err == nil {
+		return e.pkgPath + `: import "C" is only supported at the REPL, not in imported packages`
+	}
+	return e.pkgPath + `: import "C": ` + e.err.Error()
 }
 
 func NewBuildContext(installSuffix string, buildTags []string) *build.Context {
@@ -146,7 +154,7 @@ func (s *Session) importWithSrcDir(path string, srcDir string, mode build.Import
 	}
 
 	if len(pkg.CgoFiles) > 0 {
-		return nil, &ImportCError{path}
+		return nil, &ImportCError{pkgPath: path}
 	}
 
 	if pkg.IsCommand() {
@@ -385,6 +393,19 @@ func parseAndAugment(pkg *build.Package, isTest bool, fileSet *token.FileSet) (f
 			}
 		}
 
+		// test files get gijit's testing package; see `gi test`.
+		if isTest && strings.HasSuffix(name, "_test.go") {
+			for _, spec := range file.Imports {
+				path, _ := strconv.Unquote(spec.Path.Value)
+				if path == "testing" {
+					if spec.Name == nil {
+						spec.Name = ast.NewIdent("testing")
+					}
+					spec.Path.Value = strconv.Quote(GijitTestingPath)
+				}
+			}
+		}
+
 		for _, decl := range file.Nodes {
 			switch d := decl.(type) {
 			case *ast.FuncDecl:
@@ -781,7 +802,9 @@ func (s *Session) BuildPackage(pkg *PackageData, depth int) (*Archive, error) {
 
 	s.Archives[pkg.ImportPath] = archive
 
-	if pkg.PkgObj == "" || pkg.IsCommand() {
+	// test builds include the _test.go files; don't let them
+	// stand in for the package in its library archive.
+	if pkg.PkgObj == "" || pkg.IsCommand() || pkg.IsTest {
 		pp("\n\n returning early, pkg.PkgObj==\"\" or pkg.IsCommand()=%v, archive.Pkg='%#v'\n", pkg.IsCommand(), archive.Pkg)
 		return archive, nil
 	}
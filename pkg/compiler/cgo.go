@@ -0,0 +1,1205 @@
+package compiler
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/gijit/gi/pkg/ast"
+	"github.com/gijit/gi/pkg/constant"
+	"github.com/gijit/gi/pkg/token"
+	"github.com/gijit/gi/pkg/types"
+)
+
+// import "C", by way of the LuaJIT FFI.
+//
+// The comment just above import "C" is its cgo
+// preamble. gijit has no C compiler to build that
+// with, so instead we read the declarations in it --
+// function prototypes, structs, typedefs, enums, and
+// #defines of numbers -- into the package "C" that
+// the type checker sees, and hand the same
+// declarations to ffi.cdef. C.sqrt(x) is then a call
+// through ffi.C, or through the libraries that
+// `#cgo LDFLAGS: -L<dir> -l<lib>` lines ffi.load;
+// see prelude/cgo.lua. Everything declared stays
+// declared for the rest of the session, as it does
+// in LuaJIT.
+//
+// An #include is not read, save for the few system
+// headers in cgoHeaders; declare the rest of what
+// you call, as you would for ffi.cdef. A C function
+// body cannot be compiled, and is an error: build it
+// into a shared library, and -l that instead.
+
+// cgoBasics are the C numeric types, as named in
+// package C, with the C and Go types they stand for.
+var cgoBasics = []struct {
+	name  string
+	ctype string
+	kind  types.BasicKind
+}{
+	{"char", "char", types.Int8},
+	{"schar", "signed char", types.Int8},
+	{"uchar", "unsigned char", types.Uint8},
+	{"short", "short", types.Int16},
+	{"ushort", "unsigned short", types.Uint16},
+	{"int", "int", types.Int32},
+	{"uint", "unsigned int", types.Uint32},
+	{"long", "long", types.Int64},
+	{"ulong", "unsigned long", types.Uint64},
+	{"longlong", "long long", types.Int64},
+	{"ulonglong", "unsigned long long", types.Uint64},
+	{"float", "float", types.Float32},
+	{"double", "double", types.Float64},
+}
+
+// cgoTypedefs are the typedefs every preamble
+// may use, as the headers that declare them are
+// not read.
+var cgoTypedefs = map[string]string{
+	"int8_t":    "schar",
+	"uint8_t":   "uchar",
+	"int16_t":   "short",
+	"uint16_t":  "ushort",
+	"int32_t":   "int",
+	"uint32_t":  "uint",
+	"int64_t":   "longlong",
+	"uint64_t":  "ulonglong",
+	"size_t":    "ulong",
+	"ssize_t":   "long",
+	"intptr_t":  "long",
+	"uintptr_t": "ulong",
+	"ptrdiff_t": "long",
+}
+
+// cgoHeaders are the declarations we take an
+// #include of these system headers to make.
+var cgoHeaders = map[string]string{
+	"stdlib.h": `
+void *malloc(size_t size);
+void *calloc(size_t nmemb, size_t size);
+void *realloc(void *ptr, size_t size);
+void free(void *ptr);
+int abs(int j);
+long labs(long j);
+int atoi(const char *nptr);
+long atol(const char *nptr);
+double atof(const char *nptr);
+char *getenv(const char *name);
+int rand(void);
+void srand(unsigned int seed);
+`,
+	"string.h": `
+size_t strlen(const char *s);
+int strcmp(const char *s1, const char *s2);
+int strncmp(const char *s1, const char *s2, size_t n);
+char *strcpy(char *dest, const char *src);
+char *strncpy(char *dest, const char *src, size_t n);
+char *strcat(char *dest, const char *src);
+char *strdup(const char *s);
+char *strchr(const char *s, int c);
+char *strrchr(const char *s, int c);
+char *strstr(const char *haystack, const char *needle);
+void *memcpy(void *dest, const void *src, size_t n);
+void *memmove(void *dest, const void *src, size_t n);
+void *memset(void *s, int c, size_t n);
+int memcmp(const void *s1, const void *s2, size_t n);
+`,
+	"math.h": `
+double sqrt(double x);
+double cbrt(double x);
+double pow(double x, double y);
+double exp(double x);
+double exp2(double x);
+double log(double x);
+double log2(double x);
+double log10(double x);
+double sin(double x);
+double cos(double x);
+double tan(double x);
+double asin(double x);
+double acos(double x);
+double atan(double x);
+double atan2(double y, double x);
+double sinh(double x);
+double cosh(double x);
+double tanh(double x);
+double floor(double x);
+double ceil(double x);
+double round(double x);
+double trunc(double x);
+double fabs(double x);
+double fmod(double x, double y);
+double hypot(double x, double y);
+double frexp(double x, int *exp);
+double ldexp(double x, int exp);
+double modf(double x, double *iptr);
+`,
+	"stdio.h": `
+int puts(const char *s);
+int putchar(int c);
+`,
+	"stdint.h":  "",
+	"stddef.h":  "",
+	"stdbool.h": "",
+}
+
+// luaKeywords cannot name the fields of a C struct,
+// which are read and written as p.name in Lua.
+var luaKeywords = map[string]bool{
+	"and": true, "break": true, "do": true, "else": true, "elseif": true,
+	"end": true, "false": true, "for": true, "function": true, "goto": true,
+	"if": true, "in": true, "local": true, "nil": true, "not": true,
+	"or": true, "repeat": true, "return": true, "then": true, "true": true,
+	"until": true, "while": true,
+}
+
+// cgoState is the package C of a session.
+type cgoState struct {
+	pkg *types.Package
+
+	// the C spellings of the numeric types, and
+	// the ctypes of the struct types, of package C.
+	ctypes map[*types.Named]string
+
+	// the declaration of each struct, by tag,
+	// to tell a repeat from a redefinition.
+	structs map[string]string
+
+	// the named types not yet made at runtime.
+	unmade []*types.Named
+
+	// the preambles of the import "C"s
+	// that are yet to be imported.
+	pending []string
+}
+
+func newCgoState() *cgoState {
+	cg := &cgoState{
+		pkg:     types.NewPackage("C", "C"),
+		ctypes:  make(map[*types.Named]string),
+		structs: make(map[string]string),
+	}
+	scope := cg.pkg.Scope()
+	for _, b := range cgoBasics {
+		obj := types.NewTypeName(token.NoPos, cg.pkg, b.name, nil)
+		named := types.NewNamed(obj, types.Typ[b.kind], nil)
+		scope.Insert(obj)
+		cg.ctypes[named] = b.ctype
+		cg.unmade = append(cg.unmade, named)
+	}
+	for name, target := range cgoTypedefs {
+		t := scope.Lookup(target).Type()
+		scope.Insert(types.NewTypeName(token.NoPos, cg.pkg, name, t))
+	}
+	for _, name := range []string{"CString", "CBytes", "GoString", "GoStringN", "GoBytes"} {
+		scope.Insert(types.NewFunc(token.NoPos, cg.pkg, name, cg.builtinSig(name)))
+	}
+	cg.pkg.MarkComplete()
+	return cg
+}
+
+// builtinSig is the signature of cgo's
+// C.CString and friends.
+func (cg *cgoState) builtinSig(name string) *types.Signature {
+	param := func(t types.Type) *types.Var {
+		return types.NewParam(token.NoPos, cg.pkg, "", t)
+	}
+	tuple := func(ts ...types.Type) *types.Tuple {
+		var vs []*types.Var
+		for _, t := range ts {
+			vs = append(vs, param(t))
+		}
+		return types.NewTuple(vs...)
+	}
+	str := types.Typ[types.String]
+	ptr := types.Typ[types.UnsafePointer]
+	bytes := types.NewSlice(types.Typ[types.Byte])
+	cint := cg.pkg.Scope().Lookup("int").Type()
+	cchar := types.NewPointer(cg.pkg.Scope().Lookup("char").Type())
+	switch name {
+	case "CString":
+		return types.NewSignature(nil, tuple(str), tuple(cchar), false)
+	case "CBytes":
+		return types.NewSignature(nil, tuple(bytes), tuple(ptr), false)
+	case "GoString":
+		return types.NewSignature(nil, tuple(cchar), tuple(str), false)
+	case "GoStringN":
+		return types.NewSignature(nil, tuple(cchar, cint), tuple(str), false)
+	case "GoBytes":
+		return types.NewSignature(nil, tuple(ptr, cint), tuple(bytes), false)
+	}
+	panic("no cgo builtin " + name)
+}
+
+// cgoPreambles returns the preamble of each import "C"
+// in file, which must have been parsed with comments.
+func cgoPreambles(file *ast.File) (pre []string) {
+	for _, n := range file.Nodes {
+		d, ok := n.(*ast.GenDecl)
+		if !ok || d.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range d.Specs {
+			s := spec.(*ast.ImportSpec)
+			if s.Path.Value != `"C"` {
+				continue
+			}
+			// as cgo: the comment on the spec, or on
+			// the declaration, if it has only the one.
+			doc := s.Doc
+			if doc == nil && !d.Lparen.IsValid() {
+				doc = d.Doc
+			}
+			if doc != nil {
+				pre = append(pre, doc.Text())
+			}
+		}
+	}
+	return
+}
+
+// importsC reports whether file has an import "C".
+func importsC(file *ast.File) bool {
+	for _, s := range file.Imports {
+		if s.Path.Value == `"C"` {
+			return true
+		}
+	}
+	return false
+}
+
+// importC makes the package C, with the declarations
+// of the preambles since the last import "C" added.
+func (ic *IncrState) importC() (*Archive, error) {
+	if ic.cgo == nil {
+		ic.cgo = newCgoState()
+	}
+	cg := ic.cgo
+	pending := cg.pending
+	cg.pending = nil
+
+	imp := &cgoImport{cg: cg, objs: make(map[string]types.Object)}
+	for _, pre := range pending {
+		if err := imp.preamble(pre); err != nil {
+			return nil, &ImportCError{pkgPath: ic.CurPkg.pack.ImportPath, err: err}
+		}
+	}
+	imp.commit()
+
+	a := &Archive{
+		SavedArchive: SavedArchive{
+			ImportPath: "C",
+		},
+		NewCodeText: [][]byte{imp.lua()},
+		Pkg:         cg.pkg,
+	}
+	a.Pkg.ClientExtra = a
+	ic.CurPkg.importContext.Packages["C"] = cg.pkg
+	ic.Session.Archives["C"] = a
+	return a, nil
+}
+
+// cgoImport is what one import "C" adds to package C.
+type cgoImport struct {
+	cg *cgoState
+
+	// the new objects, in order, and by name.
+	order []types.Object
+	objs  map[string]types.Object
+
+	defs  []string // for ffi.cdef
+	libs  []string // for ffi.load
+	funcs []string // Lua for __cgoImport
+}
+
+// lookup finds name in package C, as it
+// will be once this import is done.
+func (imp *cgoImport) lookup(name string) types.Object {
+	if obj, ok := imp.objs[name]; ok {
+		return obj
+	}
+	return imp.cg.pkg.Scope().Lookup(name)
+}
+
+// declare adds obj to package C. Declaring again
+// what is already declared is fine, as a header
+// read twice would do; changing it is not.
+func (imp *cgoImport) declare(obj types.Object) (isNew bool, err error) {
+	prev := imp.lookup(obj.Name())
+	if prev == nil {
+		imp.objs[obj.Name()] = obj
+		imp.order = append(imp.order, obj)
+		return true, nil
+	}
+	same := false
+	switch prev := prev.(type) {
+	case *types.Const:
+		o, ok := obj.(*types.Const)
+		same = ok && constant.Compare(prev.Val(), token.EQL, o.Val())
+	case *types.Func, *types.TypeName:
+		same = fmt.Sprintf("%T", prev) == fmt.Sprintf("%T", obj) &&
+			types.Identical(prev.Type(), obj.Type())
+	}
+	if !same {
+		return false, fmt.Errorf("C.%s redefined", obj.Name())
+	}
+	return false, nil
+}
+
+func (imp *cgoImport) commit() {
+	scope := imp.cg.pkg.Scope()
+	for _, obj := range imp.order {
+		scope.Insert(obj)
+	}
+}
+
+// preamble reads the declarations of one preamble.
+func (imp *cgoImport) preamble(pre string) error {
+	var src []string
+	lines := strings.Split(pre, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "#") {
+			src = append(src, lines[i])
+			continue
+		}
+		for strings.HasSuffix(line, `\`) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + " " + strings.TrimSpace(lines[i])
+		}
+		hdr, err := imp.directive(strings.TrimSpace(line[1:]))
+		if err != nil {
+			return err
+		}
+		src = append(src, hdr)
+	}
+	toks := ctokens(strings.Join(src, "\n"))
+
+	// one declaration at a time.
+	depth, start := 0, 0
+	for i, t := range toks {
+		switch t {
+		case "{":
+			if depth == 0 && i > 0 && toks[i-1] == ")" {
+				return fmt.Errorf("cannot compile the C function body in %q; build it into a shared library and use #cgo LDFLAGS: -l", strings.Join(toks[start:i], " "))
+			}
+			depth++
+		case "}":
+			depth--
+		case ";":
+			if depth == 0 {
+				if i > start {
+					if err := imp.decl(toks[start:i]); err != nil {
+						return err
+					}
+				}
+				start = i + 1
+			}
+		}
+	}
+	if start < len(toks) {
+		return fmt.Errorf("missing ';' after %q", strings.Join(toks[start:], " "))
+	}
+	return nil
+}
+
+// directive handles a preprocessor line, less its #,
+// returning any C source it stands for.
+func (imp *cgoImport) directive(line string) (string, error) {
+	word := line
+	if i := strings.IndexAny(line, " \t<\""); i >= 0 {
+		word = line[:i]
+	}
+	rest := strings.TrimSpace(line[len(word):])
+	switch word {
+	case "cgo":
+		return "", imp.cgoFlags(rest)
+	case "include":
+		name := strings.Trim(rest, `<>"`)
+		return cgoHeaders[name], nil
+	case "define":
+		fields := strings.Fields(rest)
+		if len(fields) < 2 || strings.Contains(fields[0], "(") {
+			return "", nil
+		}
+		if val, ok := cgoNumber(strings.Join(fields[1:], "")); ok {
+			c := types.NewConst(token.NoPos, imp.cg.pkg, fields[0], untypedOf(val), val)
+			if _, err := imp.declare(c); err != nil {
+				return "", err
+			}
+		}
+	}
+	return "", nil
+}
+
+// cgoFlags handles a #cgo line: of its flags, only
+// the LDFLAGS -l and -L matter to us.
+func (imp *cgoImport) cgoFlags(line string) error {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return fmt.Errorf("malformed #cgo line: %q", line)
+	}
+	words := strings.Fields(line[:i])
+	if len(words) == 0 {
+		return fmt.Errorf("malformed #cgo line: %q", line)
+	}
+	if words[len(words)-1] != "LDFLAGS" || !cgoMatch(words[:len(words)-1]) {
+		return nil
+	}
+	wd, _ := os.Getwd()
+	flags := strings.Fields(strings.Replace(line[i+1:], "${SRCDIR}", wd, -1))
+	var dirs, libs []string
+	for j := 0; j < len(flags); j++ {
+		f := flags[j]
+		switch {
+		case f == "-L" && j+1 < len(flags):
+			j++
+			dirs = append(dirs, flags[j])
+		case strings.HasPrefix(f, "-L"):
+			dirs = append(dirs, f[2:])
+		case strings.HasPrefix(f, "-l"):
+			libs = append(libs, f[2:])
+		case strings.HasSuffix(f, ".so") || strings.Contains(f, ".so."):
+			imp.libs = append(imp.libs, f)
+		}
+	}
+	for _, lib := range libs {
+		// ffi.load looks only where dlopen does,
+		// so find the -L ones ourselves.
+		for _, dir := range dirs {
+			path := filepath.Join(dir, "lib"+lib+".so")
+			if _, err := os.Stat(path); err == nil {
+				lib = path
+				break
+			}
+		}
+		imp.libs = append(imp.libs, lib)
+	}
+	return nil
+}
+
+// cgoMatch reports whether the build constraints
+// before the flags of a #cgo line are satisfied.
+func cgoMatch(words []string) bool {
+	if len(words) == 0 {
+		return true
+	}
+	for _, opt := range words {
+		all := true
+		for _, term := range strings.Split(opt, ",") {
+			not := strings.HasPrefix(term, "!")
+			term = strings.TrimPrefix(term, "!")
+			if (term == runtime.GOOS || term == runtime.GOARCH) == not {
+				all = false
+			}
+		}
+		if all {
+			return true
+		}
+	}
+	return false
+}
+
+// cgoNumber parses the number literal s, as C
+// writes one, with any U and L suffixes.
+func cgoNumber(s string) (constant.Value, bool) {
+	s = strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if s == "" || s[0] < '0' || s[0] > '9' {
+		return nil, false
+	}
+	var val constant.Value
+	if n, err := strconv.ParseUint(strings.TrimRight(s, "uUlL"), 0, 64); err == nil {
+		val = constant.MakeUint64(n)
+	} else if f, err := strconv.ParseFloat(strings.TrimRight(s, "fFlL"), 64); err == nil {
+		val = constant.MakeFloat64(f)
+	} else {
+		return nil, false
+	}
+	if neg {
+		val = constant.UnaryOp(token.SUB, val, 0)
+	}
+	return val, true
+}
+
+func untypedOf(val constant.Value) types.Type {
+	if val.Kind() == constant.Float {
+		return types.Typ[types.UntypedFloat]
+	}
+	return types.Typ[types.UntypedInt]
+}
+
+// ctokens splits C source into tokens,
+// dropping its comments.
+func ctokens(src string) (toks []string) {
+	isIdent := func(c byte) bool {
+		return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+	}
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case strings.HasPrefix(src[i:], "//"):
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return
+			}
+			i += end + 4
+		case strings.HasPrefix(src[i:], "..."):
+			toks = append(toks, "...")
+			i += 3
+		case isIdent(c):
+			j := i
+			for j < len(src) && (isIdent(src[j]) || src[j] == '.' && c >= '0' && c <= '9') {
+				j++
+			}
+			toks = append(toks, src[i:j])
+			i = j
+		default:
+			toks = append(toks, string(c))
+			i++
+		}
+	}
+	return
+}
+
+// cgoParser reads one C declaration.
+type cgoParser struct {
+	imp  *cgoImport
+	toks []string
+	i    int
+}
+
+func (p *cgoParser) peek() string {
+	if p.i < len(p.toks) {
+		return p.toks[p.i]
+	}
+	return ""
+}
+
+func (p *cgoParser) next() string {
+	t := p.peek()
+	p.i++
+	return t
+}
+
+func (p *cgoParser) accept(t string) bool {
+	if p.peek() == t {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *cgoParser) expect(t string) error {
+	if !p.accept(t) {
+		return p.errorf("expected %q, found %q", t, p.peek())
+	}
+	return nil
+}
+
+func (p *cgoParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s, in: %s", fmt.Sprintf(format, args...), strings.Join(p.toks, " "))
+}
+
+func isCIdent(t string) bool {
+	return t != "" && (t[0] == '_' || t[0] >= 'a' && t[0] <= 'z' || t[0] >= 'A' && t[0] <= 'Z')
+}
+
+// cvoid is the type void, which only a pointer
+// or a function result can have.
+var cvoid = types.Typ[types.Invalid]
+
+// decl reads a declaration, less its ';'.
+func (imp *cgoImport) decl(toks []string) error {
+	imp.defs = append(imp.defs, strings.Join(toks, " ")+";")
+
+	p := &cgoParser{imp: imp, toks: toks}
+	typedef := p.accept("typedef")
+	base, err := p.baseType()
+	if err != nil {
+		return err
+	}
+	for p.peek() != "" {
+		name, t, sig, err := p.declarator(base)
+		if err != nil {
+			return err
+		}
+		switch {
+		case name == "":
+			return p.errorf("missing name")
+		case typedef:
+			if t == cvoid {
+				return p.errorf("typedef of void")
+			}
+			if _, err := imp.declare(types.NewTypeName(token.NoPos, imp.cg.pkg, name, t)); err != nil {
+				return err
+			}
+		case sig != nil:
+			if sig.Variadic() {
+				return p.errorf("cannot call the variadic C function %s from Go", name)
+			}
+			isNew, err := imp.declare(types.NewFunc(token.NoPos, imp.cg.pkg, name, sig))
+			if err != nil {
+				return err
+			}
+			if isNew {
+				imp.funcs = append(imp.funcs, fmt.Sprintf("%s = %s", name, imp.cg.funcSig(sig)))
+			}
+		default:
+			// a variable, which C.name
+			// cannot reach for now.
+		}
+		if p.peek() != "" {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// baseType reads the type that starts a declaration,
+// before any * or name, along with any struct or enum
+// it defines.
+func (p *cgoParser) baseType() (types.Type, error) {
+	var words []string
+	longs := 0
+	for {
+		t := p.peek()
+		switch t {
+		case "const", "volatile", "restrict", "extern", "static", "inline",
+			"__inline", "__inline__", "register", "__extension__":
+			p.next()
+			continue
+		case "signed", "unsigned", "short", "int", "char", "float", "double", "void", "_Bool", "bool":
+			p.next()
+			words = append(words, t)
+			continue
+		case "long":
+			p.next()
+			longs++
+			continue
+		case "struct", "union":
+			if len(words) > 0 || longs > 0 {
+				return nil, p.errorf("unexpected %s", t)
+			}
+			p.next()
+			return p.structType(t)
+		case "enum":
+			if len(words) > 0 || longs > 0 {
+				return nil, p.errorf("unexpected %s", t)
+			}
+			p.next()
+			return p.enumType()
+		}
+		if isCIdent(t) && len(words) == 0 && longs == 0 {
+			p.next()
+			obj, ok := p.imp.lookup(t).(*types.TypeName)
+			if !ok {
+				return nil, p.errorf("unknown C type %s", t)
+			}
+			p.skipQualifiers()
+			return obj.Type(), nil
+		}
+		break
+	}
+	has := func(w string) bool {
+		for _, x := range words {
+			if x == w {
+				return true
+			}
+		}
+		return false
+	}
+	unsigned := has("unsigned")
+	var name string
+	switch {
+	case has("void"):
+		return cvoid, nil
+	case has("_Bool") || has("bool"):
+		return types.Typ[types.Bool], nil
+	case has("float"):
+		name = "float"
+	case has("double"):
+		if longs > 0 {
+			return nil, p.errorf("long double is not supported")
+		}
+		name = "double"
+	case has("char"):
+		name = "char"
+		if unsigned {
+			name = "uchar"
+		} else if has("signed") {
+			name = "schar"
+		}
+	case has("short"):
+		name = "short"
+	case longs == 1:
+		name = "long"
+	case longs == 2:
+		name = "longlong"
+	case len(words) > 0:
+		name = "int"
+	default:
+		return nil, p.errorf("expected a type, found %q", p.peek())
+	}
+	if unsigned && name != "uchar" {
+		name = "u" + name
+	}
+	return p.imp.lookup(name).Type(), nil
+}
+
+func (p *cgoParser) skipQualifiers() {
+	for p.accept("const") || p.accept("volatile") || p.accept("restrict") {
+	}
+}
+
+// structType reads a struct or union, after the
+// keyword, giving the named type C.struct_<tag> for
+// it, or C.<name> for the untagged struct of a
+// typedef to name. A union is opaque, for use by
+// pointer only.
+func (p *cgoParser) structType(kw string) (types.Type, error) {
+	tag := ""
+	if isCIdent(p.peek()) {
+		tag = p.next()
+	}
+	var named *types.Named
+	if tag != "" {
+		named = p.structNamed(kw+"_"+tag, kw+" "+tag)
+	}
+	if !p.accept("{") {
+		if named == nil {
+			return nil, p.errorf("expected a tag or {, found %q", p.peek())
+		}
+		return named, nil
+	}
+	start := p.i - 1
+	var fields []*types.Var
+	for !p.accept("}") {
+		if p.peek() == "" {
+			return nil, p.errorf("unterminated %s", kw)
+		}
+		base, err := p.baseType()
+		if err != nil {
+			return nil, err
+		}
+		for {
+			fname, t, sig, err := p.declarator(base)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case fname == "" || sig != nil || t == cvoid:
+				return nil, p.errorf("unsupported field in %s", kw)
+			case luaKeywords[fname] || token.Lookup(fname).IsKeyword():
+				return nil, p.errorf("field %s is a reserved word in Go or Lua", fname)
+			case p.peek() == ":":
+				return nil, p.errorf("bit fields are not supported")
+			}
+			fields = append(fields, types.NewField(token.NoPos, p.imp.cg.pkg, fname, t, false))
+			if !p.accept(",") {
+				break
+			}
+		}
+		if err := p.expect(";"); err != nil {
+			return nil, err
+		}
+	}
+	def := strings.Join(p.toks[start:p.i], " ")
+	if named == nil {
+		// typedef struct { ... } name;
+		if p.toks[0] != "typedef" || kw != "struct" || !isCIdent(p.peek()) {
+			return nil, p.errorf("an untagged %s is only supported in a typedef", kw)
+		}
+		tag = p.peek()
+		named = p.structNamed(tag, tag)
+	}
+	if kw == "union" {
+		return named, nil
+	}
+	cg := p.imp.cg
+	if prev, ok := cg.structs[tag]; ok {
+		if prev != def {
+			return nil, p.errorf("C.%s redefined", named.Obj().Name())
+		}
+		return named, nil
+	}
+	cg.structs[tag] = def
+	named.SetUnderlying(types.NewStruct(fields, nil))
+	cg.unmade = append(cg.unmade, named)
+	return named, nil
+}
+
+// structNamed is the named type of a struct or
+// union, opaque until its fields are known.
+func (p *cgoParser) structNamed(name, ctype string) *types.Named {
+	if obj, ok := p.imp.lookup(name).(*types.TypeName); ok {
+		if named, ok := obj.Type().(*types.Named); ok && obj.Name() == named.Obj().Name() {
+			return named
+		}
+	}
+	obj := types.NewTypeName(token.NoPos, p.imp.cg.pkg, name, nil)
+	named := types.NewNamed(obj, types.NewStruct(nil, nil), nil)
+	p.imp.declare(obj)
+	p.imp.cg.ctypes[named] = ctype
+	p.imp.cg.unmade = append(p.imp.cg.unmade, named)
+	return named
+}
+
+// enumType reads an enum, after the keyword. Its
+// constants are untyped, and the type C.enum_<tag>
+// is C.uint, as cgo would have them in the main.
+func (p *cgoParser) enumType() (types.Type, error) {
+	cuint := p.imp.lookup("uint").Type()
+	if isCIdent(p.peek()) {
+		tag := p.next()
+		if _, err := p.imp.declare(types.NewTypeName(token.NoPos, p.imp.cg.pkg, "enum_"+tag, cuint)); err != nil {
+			return nil, err
+		}
+	}
+	if !p.accept("{") {
+		return cuint, nil
+	}
+	val := constant.MakeInt64(0)
+	for !p.accept("}") {
+		name := p.next()
+		if !isCIdent(name) {
+			return nil, p.errorf("expected an enumerator, found %q", name)
+		}
+		if p.accept("=") {
+			var s string
+			for p.peek() != "," && p.peek() != "}" && p.peek() != "" {
+				s += p.next()
+			}
+			v, ok := cgoNumber(s)
+			if c, isConst := p.imp.lookup(s).(*types.Const); isConst {
+				v, ok = c.Val(), true
+			}
+			if !ok {
+				return nil, p.errorf("cannot evaluate %s = %s", name, s)
+			}
+			val = v
+		}
+		c := types.NewConst(token.NoPos, p.imp.cg.pkg, name, types.Typ[types.UntypedInt], val)
+		if _, err := p.imp.declare(c); err != nil {
+			return nil, err
+		}
+		val = constant.BinaryOp(val, token.ADD, constant.MakeInt64(1))
+		if !p.accept(",") {
+			if err := p.expect("}"); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+	return cuint, nil
+}
+
+// declarator reads the pointers, name, array lengths
+// and parameters that follow a base type; sig is not
+// nil for a function.
+func (p *cgoParser) declarator(base types.Type) (name string, t types.Type, sig *types.Signature, err error) {
+	t = base
+	for p.accept("*") {
+		t = cgoPointer(t)
+		p.skipQualifiers()
+	}
+	if p.peek() == "(" && p.i+1 < len(p.toks) && p.toks[p.i+1] == "*" {
+		// a function pointer, which Go
+		// can only pass along.
+		p.next()
+		p.next()
+		if isCIdent(p.peek()) {
+			name = p.next()
+		}
+		if err = p.expect(")"); err != nil {
+			return
+		}
+		if err = p.expect("("); err != nil {
+			return
+		}
+		if _, err = p.params(); err != nil {
+			return
+		}
+		return name, types.Typ[types.UnsafePointer], nil, nil
+	}
+	if isCIdent(p.peek()) {
+		name = p.next()
+	}
+	if p.accept("(") {
+		params, err := p.params()
+		if err != nil {
+			return "", nil, nil, err
+		}
+		var results *types.Tuple
+		if t != cvoid {
+			results = types.NewTuple(types.NewParam(token.NoPos, p.imp.cg.pkg, "", t))
+		}
+		variadic := false
+		if n := len(params); n > 0 && params[n-1] == nil {
+			variadic = true
+			params = append(params[:n-1], types.NewParam(token.NoPos, p.imp.cg.pkg, "", types.NewSlice(&types.Interface{})))
+		}
+		sig = types.NewSignature(nil, types.NewTuple(params...), results, variadic)
+		p.skipAttributes()
+		return name, t, sig, nil
+	}
+	var dims []int64
+	for p.accept("[") {
+		n := int64(-1)
+		if !p.accept("]") {
+			v, ok := cgoNumber(p.next())
+			if !ok {
+				return "", nil, nil, p.errorf("array length must be a number")
+			}
+			n, _ = constant.Int64Val(v)
+			if err = p.expect("]"); err != nil {
+				return
+			}
+		}
+		dims = append(dims, n)
+	}
+	for i := len(dims) - 1; i >= 0; i-- {
+		if dims[i] < 0 {
+			t = cgoPointer(t)
+		} else {
+			t = types.NewArray(t, dims[i])
+		}
+	}
+	p.skipAttributes()
+	return name, t, nil, nil
+}
+
+// params reads a parameter list, after the "(". A
+// nil last parameter marks a variadic function.
+func (p *cgoParser) params() (params []*types.Var, err error) {
+	if p.accept(")") {
+		return nil, nil
+	}
+	if p.peek() == "void" && p.i+1 < len(p.toks) && p.toks[p.i+1] == ")" {
+		p.i += 2
+		return nil, nil
+	}
+	for {
+		if p.accept("...") {
+			params = append(params, nil)
+			return params, p.expect(")")
+		}
+		base, err := p.baseType()
+		if err != nil {
+			return nil, err
+		}
+		name, t, sig, err := p.declarator(base)
+		if err != nil {
+			return nil, err
+		}
+		if sig != nil {
+			t = types.Typ[types.UnsafePointer]
+		}
+		// C passes an array parameter as a pointer.
+		if a, isArray := t.(*types.Array); isArray {
+			t = cgoPointer(a.Elem())
+		}
+		if t == cvoid {
+			return nil, p.errorf("void parameter")
+		}
+		params = append(params, types.NewParam(token.NoPos, p.imp.cg.pkg, name, t))
+		if p.accept(")") {
+			return params, nil
+		}
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// skipAttributes skips any __attribute__((...))
+// or __asm__(...) at the end of a declarator.
+func (p *cgoParser) skipAttributes() {
+	for p.peek() == "__attribute__" || p.peek() == "__asm__" || p.peek() == "asm" {
+		p.next()
+		depth := 0
+		for p.peek() != "" {
+			t := p.next()
+			if t == "(" {
+				depth++
+			} else if t == ")" {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+	}
+}
+
+// cgoPointer is the Go type of a C pointer to t.
+func cgoPointer(t types.Type) types.Type {
+	if t == cvoid {
+		return types.Typ[types.UnsafePointer]
+	}
+	return types.NewPointer(t)
+}
+
+// ctype is the C spelling of t, a type of package C.
+func (cg *cgoState) ctype(t types.Type) (string, bool) {
+	switch t := t.(type) {
+	case *types.Named:
+		s, ok := cg.ctypes[t]
+		return s, ok
+	case *types.Pointer:
+		s, ok := cg.ctype(t.Elem())
+		return s + "*", ok
+	case *types.Basic:
+		switch t.Kind() {
+		case types.UnsafePointer:
+			return "void*", true
+		case types.Bool:
+			return "bool", true
+		}
+	}
+	return "", false
+}
+
+// cgoKind is the kind of conversion that a C value of
+// type t needs to be a gijit value: "i" for a signed
+// integer, "u" for an unsigned one, "" for none.
+func cgoKind(t types.Type) string {
+	if b, ok := t.Underlying().(*types.Basic); ok && isInteger(b) {
+		if isUnsigned(b) {
+			return "u"
+		}
+		return "i"
+	}
+	return ""
+}
+
+// funcSig is the Lua description of the C
+// function sig for cfunc, in prelude/cgo.lua.
+func (cg *cgoState) funcSig(sig *types.Signature) string {
+	ret := ""
+	if sig.Results().Len() == 1 {
+		ret = cgoKind(sig.Results().At(0).Type())
+	}
+	var ptrs []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		t := sig.Params().At(i).Type()
+		switch t := t.(type) {
+		case *types.Pointer:
+			ctype, ok := cg.ctype(t.Elem())
+			_, isStruct := t.Elem().Underlying().(*types.Struct)
+			if ok && !isStruct {
+				ptrs = append(ptrs, fmt.Sprintf("[%d] = {%q, %q}", i+1, ctype, cgoKind(t.Elem())))
+			} else {
+				ptrs = append(ptrs, fmt.Sprintf("[%d] = {}", i+1))
+			}
+		case *types.Basic:
+			if t.Kind() == types.UnsafePointer {
+				ptrs = append(ptrs, fmt.Sprintf("[%d] = {}", i+1))
+			}
+		}
+	}
+	if len(ptrs) == 0 {
+		return fmt.Sprintf("{%q}", ret)
+	}
+	return fmt.Sprintf("{%q, {%s}}", ret, strings.Join(ptrs, ", "))
+}
+
+// luaType is the Lua for the gijit type of t,
+// a type of package C.
+func luaType(t types.Type) string {
+	switch t := t.(type) {
+	case *types.Named:
+		return "__type__.C." + t.Obj().Name()
+	case *types.Basic:
+		return "__type__." + toJavaScriptType(t)
+	case *types.Pointer:
+		return fmt.Sprintf("__ptrType(%s)", luaType(t.Elem()))
+	case *types.Array:
+		return fmt.Sprintf("__arrayType(%s, %d)", luaType(t.Elem()), t.Len())
+	}
+	panic(fmt.Sprintf("no C type %v", t))
+}
+
+// isCgoPointerField reports whether lhs is a pointer
+// field of a C struct. A store to one is a store to
+// the struct's cdata, so the gijit pointer assigned
+// must first become a C pointer, by __cgoPtr.
+func (c *funcContext) isCgoPointerField(lhs ast.Expr) bool {
+	sel, ok := lhs.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	s, ok := c.p.SelectionOf(sel)
+	if !ok || s.Kind() != types.FieldVal {
+		return false
+	}
+	fld := s.Obj()
+	if fld.Pkg() == nil || fld.Pkg().Path() != "C" {
+		return false
+	}
+	switch t := fld.Type().Underlying().(type) {
+	case *types.Pointer:
+		return true
+	case *types.Basic:
+		return t.Kind() == types.UnsafePointer
+	}
+	return false
+}
+
+// lua is the code that runs the import.
+func (imp *cgoImport) lua() []byte {
+	cg := imp.cg
+	var b strings.Builder
+	b.WriteString("\t__type__.C = __type__.C or {};\n")
+
+	var defs, libs []string
+	for _, d := range imp.defs {
+		defs = append(defs, strconv.Quote(d))
+	}
+	for _, l := range imp.libs {
+		libs = append(libs, strconv.Quote(l))
+	}
+	fmt.Fprintf(&b, "\t__cgoImport({%s}, {%s}, {%s});\n",
+		strings.Join(defs, ", "), strings.Join(libs, ", "), strings.Join(imp.funcs, ", "))
+
+	made := make(map[*types.Named]bool)
+	for _, named := range cg.unmade {
+		if made[named] {
+			continue
+		}
+		made[named] = true
+		name := named.Obj().Name()
+		switch u := named.Underlying().(type) {
+		case *types.Basic:
+			fmt.Fprintf(&b, "\t__type__.C.%[1]s = __newType(%[2]d, %[3]s, \"C.%[1]s\", true, \"C\", false, nil);\n",
+				name, sizes64.Sizeof(u), typeKind(u))
+		case *types.Struct:
+			fmt.Fprintf(&b, "\t__type__.C.%[1]s = __newType(0, __kindStruct, \"C.%[1]s\", true, \"C\", false, nil);\n", name)
+			var fields []string
+			for i := 0; i < u.NumFields(); i++ {
+				f := u.Field(i)
+				fields = append(fields, fmt.Sprintf("{__prop= \"%[1]s\", __name= \"%[1]s\", __anonymous= false, __exported= %[2]t, __typ= %[3]s, __tag= \"\"}", f.Name(), f.Exported(), luaType(f.Type())))
+			}
+			fmt.Fprintf(&b, "\t__type__.C.%s.init(\"C\", {%s});\n", name, strings.Join(fields, ", "))
+			if u.NumFields() > 0 {
+				fmt.Fprintf(&b, "\t__cgoStruct(__type__.C.%s, %q);\n", name, cg.ctypes[named])
+			}
+		}
+	}
+	cg.unmade = nil
+	return []byte(b.String())
+}
@@ -0,0 +1,94 @@
+package compiler
+
+import (
+	"strings"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2210ImportCThroughTheFFI(t *testing.T) {
+
+	cv.Convey(`import "C" hands the cgo preamble to ffi.cdef and ffi.loads the #cgo LDFLAGS libraries: C functions, #defines, enums, structs, C.CString and C.GoString are checked by the type checker and called through the FFI; a pointer to a variable is written back after the call; a gijit pointer stored in a C struct becomes a C pointer.`, t, func() {
+
+		code := `
+/*
+#cgo LDFLAGS: -lm
+#include <math.h>
+#include <string.h>
+#include <stdlib.h>
+
+#define ANSWER 42
+
+enum color { RED, GREEN = 5, BLUE };
+
+struct point { int x; double y; struct point *next; };
+
+typedef struct { double re, im; } cplx;
+*/
+import "C"
+
+r := float64(C.sqrt(2))
+a := C.abs(-7)
+cs := C.CString("hello, C")
+n := C.strlen(cs)
+back := C.GoString(cs)
+C.free(unsafe.Pointer(cs))
+
+var e C.int
+m := C.frexp(48, &e)
+
+p := C.struct_point{x: 3, y: 1.5}
+q := C.struct_point{x: 4, next: &p}
+q.next = nil
+q.next = &p
+sum := 0.0
+for at := &q; at != nil; at = at.next { sum += float64(at.x) + float64(at.y) }
+
+z := C.cplx{re: 1, im: 2}
+zim := z.im
+ans, blue := C.ANSWER, C.BLUE
+var ci C.int = 3
+ci *= 2
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(`import "unsafe"`))
+		LuaRunAndReport(vm, string(translation))
+
+		translation = inc.trMust([]byte(code))
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustFloat64(vm, "r", 1.4142135623730951)
+		LuaMustInt64(vm, "a", 7)
+		LuaMustEvalToInt64(vm, `n == 8ULL and 1LL or 0LL`, 1) // size_t is unsigned
+		LuaMustString(vm, "back", "hello, C")
+		LuaMustFloat64(vm, "m", 0.75)
+		LuaMustInt64(vm, "e", 6)
+		LuaMustFloat64(vm, "sum", 8.5)
+		LuaMustFloat64(vm, "zim", 2)
+		LuaMustInt64(vm, "ans", 42)
+		LuaMustInt64(vm, "blue", 6)
+		LuaMustInt64(vm, "ci", 6)
+
+		// a later import "C" adds its declarations to the package.
+		translation = inc.trMust([]byte(`
+// double hypot(double x, double y);
+import "C"
+h := float64(C.hypot(3, 4))
+`))
+		LuaRunAndReport(vm, string(translation))
+		LuaMustFloat64(vm, "h", 5)
+
+		// gijit has no C compiler to build function bodies with.
+		_, err = inc.Tr([]byte(`
+// int twice(int x) { return 2*x; }
+import "C"
+`))
+		cv.So(err, cv.ShouldNotBeNil)
+		cv.So(strings.Contains(err.Error(), `import "C"`), cv.ShouldBeTrue)
+	})
+}
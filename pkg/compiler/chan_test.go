@@ -405,3 +405,44 @@ a := <-ch
 		cv.So(true, cv.ShouldBeTrue)
 	})
 }
+
+func Test911(t *testing.T) {
+
+	cv.Convey("all-lua system: a receive from a closed channel drains it, then gets the zero value and false; closing it again panics", t, func() {
+
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		code := `
+ch := make(chan int, 1)
+ch <- 7
+close(ch)
+a, aok := <-ch
+b, bok := <-ch
+c := <-ch
+msg := ""
+func closeAgain() {
+	defer func() {
+		msg = recover().(error).Error()
+	}()
+	close(ch)
+}
+closeAgain()
+`
+		translation, err := inc.Tr([]byte(code))
+		panicOn(err)
+		fmt.Printf("translation='%s'\n", string(translation))
+
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustInt64(vm, "a", 7)
+		LuaMustBool(vm, "aok", true)
+		LuaMustInt64(vm, "b", 0)
+		LuaMustBool(vm, "bok", false)
+		LuaMustInt64(vm, "c", 0)
+		LuaMustString(vm, "msg", "close of closed channel")
+		cv.So(true, cv.ShouldBeTrue)
+	})
+}
@@ -2,7 +2,7 @@ package compiler
 
 import (
 	"bytes"
-	"encoding/binary"
+	"strconv"
 	"strings"
 
 	"encoding/gob"
@@ -87,6 +87,8 @@ type Archive struct {
 	Config    *types.Config
 	Check     *types.Checker
 
+	// FuncSrcCache holds the source of each func,
+	// method (keyed "T.Name"), and type declared.
 	FuncSrcCache map[string]string
 }
 
@@ -245,25 +247,12 @@ func WriteProgramCode(pkgs []*Archive, w *SourceMapFilter, isMain bool) error {
 		return err
 	}
 
-	// write packages
+	// write packages. Each is reached through
+	// __packages, by import path; see pkgVarName.
 	for _, pkg := range pkgs {
 		if err := WritePkgCode(pkg, dceSelection, minify, w); err != nil {
 			return err
 		}
-
-		if !isMain {
-
-			// not Main: need to write the package to the global Lua env.
-
-			// avoid name conflict between types and package name by assigning
-			// directly to _G (types may have prior 'local' declarations?)
-			_, err := w.Write([]byte(fmt.Sprintf("\n _G.%s = __packages[\"%s\"];\n",
-				pkg.Name, string(pkg.ImportPath))))
-
-			if err != nil {
-				return err
-			}
-		}
 	}
 
 	_, err := w.Write([]byte(`
@@ -310,13 +299,13 @@ func WritePkgCode(pkg *Archive, dceSelection map[*Decl]struct{}, minify bool, w
 		return err
 	}
 	if _, err := w.Write(removeWhitespace([]byte(fmt.Sprintf(`
-__type__.%[2]s = __type__.%[2]s or {};
+%[2]s = %[2]s or {};
 __packages["%[1]s"] = (function()
 	local __pkg = {};
     setmetatable(__pkg, {__index = _G})
     setfenv(1, __pkg);
 
-`, pkg.ImportPath, pkg.Pkg.Name())), minify)); err != nil {
+`, pkg.ImportPath, typePkgVarName(pkg.ImportPath))), minify)); err != nil {
 		return err
 	}
 	//vars := []string{"__pkg", "__init"}
@@ -468,9 +457,10 @@ func (f *SourceMapFilter) Write(p []byte) (n int, err error) {
 			return
 		}
 		if f.MappingCallback != nil {
-			f.MappingCallback(f.line+1, f.column, f.fileSet.Position(token.Pos(binary.BigEndian.Uint32(p[i+1:i+5]))))
+			pos, _ := strconv.ParseUint(string(p[i+1:i+posMarkerLen]), 16, 32)
+			f.MappingCallback(f.line+1, f.column, f.fileSet.Position(token.Pos(pos)))
 		}
-		p = p[i+5:]
-		n += 5
+		p = p[i+posMarkerLen:]
+		n += posMarkerLen
 	}
 }
@@ -0,0 +1,126 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2190ContextDoneIsAChanLuaChannel(t *testing.T) {
+
+	cv.Convey(`import "context" gets contexts whose Done channel is a chan.lua channel: cancel, and the deadline of WithTimeout, kept by the scheduler's timers, wake a goroutine selecting on ctx.Done(); a receive after close returns at once; Value looks up the parents.`, t, func() {
+
+		r, done := newTestRepl()
+		defer done()
+
+		panicOn(r.inc.RunTimeGiImportFunc("context", "", 0))
+
+		// as select { case <-ctx.Done(): } is translated.
+		// Methods are called through the Context
+		// interface, so as ctx:Done().
+		panicOn(LuaRun(r.lvm, `
+local context = __packages["context"]
+__ctxLog = {}
+local ctx, cancel = context.WithCancel(context.Background())
+local child, _ = context.WithCancel(ctx)
+local done = __task.Channel:new(0)
+for _, c in ipairs({ctx, child}) do
+   __task.spawn(function()
+      __task.select({{c = c:Done(), op = __task.RECV}})
+      table.insert(__ctxLog, tostring(c:Err() == context.Canceled))
+      done:send(true)
+   end, {})
+end
+table.insert(__ctxLog, tostring(ctx:Err()))
+cancel()
+done:recv()
+done:recv()
+cancel()
+`, true))
+		cv.So(LuaToString(r.lvm, `table.concat(__ctxLog, " ")`), cv.ShouldEqual, "nil true true")
+
+		// the deadline fires while the waiter is parked.
+		panicOn(LuaRun(r.lvm, `
+local context = __packages["context"]
+local ctx, cancel = context.WithTimeout(context.Background(), 20000000LL)
+local t0 = __abs_now()
+local r = __task.select({{c = ctx:Done(), op = __task.RECV}})
+local waited = tonumber(__abs_now() - t0)
+local _, ok = ctx:Deadline()
+__ctxOut = tostring(ctx:Err() == context.DeadlineExceeded).." "..tostring(ok).." "..tostring(waited >= 15e6)
+cancel()
+`, true))
+		cv.So(LuaToString(r.lvm, "__ctxOut"), cv.ShouldEqual, "true true true")
+
+		// a receive after the close, and an
+		// already-canceled parent.
+		panicOn(LuaRun(r.lvm, `
+local context = __packages["context"]
+local ctx, cancel = context.WithCancel(context.Background())
+cancel()
+local v, ok = ctx:Done():recv()
+local late, _ = context.WithTimeout(ctx, 1000000000LL)
+__ctxOut = tostring(ok).." "..tostring(late:Err() == context.Canceled)
+`, true))
+		cv.So(LuaToString(r.lvm, "__ctxOut"), cv.ShouldEqual, "false true")
+
+		panicOn(LuaRun(r.lvm, `
+local context = __packages["context"]
+local a = context.WithValue(context.Background(), "k", "v")
+local b, cancel = context.WithCancel(a)
+local c = context.WithValue(b, 2LL, "two")
+__ctxOut = tostring(c:Value("k")).." "..tostring(c:Value(2LL)).." "..tostring(c:Value("x")).." "..tostring(c)
+`, true))
+		cv.So(LuaToString(r.lvm, "__ctxOut"), cv.ShouldEqual, "v two nil context.Background.WithValue.WithCancel.WithValue")
+
+		// the pending timer is not a goroutine.
+		cv.So(LuaToString(r.lvm, "tostring(#__task.goroutines())"), cv.ShouldEqual, "0")
+	})
+}
+
+func Test2191ContextFromGoSource(t *testing.T) {
+
+	cv.Convey(`From Go source, a goroutine in select { case <-ctx.Done(): } wakes when the context is canceled; Cause gives the cancel's or the deadline's cause, AfterFunc starts its func then, and WithoutCancel's context stays live.`, t, func() {
+
+		r, done := newTestRepl()
+		defer done()
+
+		panicOn(r.Eval(`import "context"
+import "errors"
+ctx, cancel := context.WithCancelCause(context.Background())
+kept := context.WithoutCancel(context.WithValue(ctx, "k", "v"))
+why := errors.New("why")
+woke := false
+go func() {
+	select {
+	case <-ctx.Done():
+		woke = true
+	}
+}()
+after := false
+stop := context.AfterFunc(ctx, func() {
+	after = true
+})`))
+		LuaMustBool(r.lvm, "woke", false)
+
+		panicOn(r.Eval("cancel(why)"))
+		panicOn(r.Eval(`canceled := ctx.Err() == context.Canceled
+isWhy := context.Cause(ctx) == why
+stopped := stop()
+keptErr := kept.Err() == nil
+keptVal := kept.Value("k").(string)
+late, lateCancel := context.WithTimeoutCause(context.Background(), 0, why)
+lateWhy := context.Cause(late) == why
+lateErr := late.Err() == context.DeadlineExceeded
+lateCancel()`))
+		LuaMustBool(r.lvm, "woke", true)
+		LuaMustBool(r.lvm, "after", true)
+		LuaMustBool(r.lvm, "canceled", true)
+		LuaMustBool(r.lvm, "isWhy", true)
+		LuaMustBool(r.lvm, "stopped", false)
+		LuaMustBool(r.lvm, "keptErr", true)
+		LuaMustString(r.lvm, "keptVal", "v")
+		LuaMustBool(r.lvm, "lateWhy", true)
+		LuaMustBool(r.lvm, "lateErr", true)
+	})
+}
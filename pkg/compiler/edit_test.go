@@ -0,0 +1,74 @@
+package compiler
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2120EditRedefinesFromEditor(t *testing.T) {
+
+	cv.Convey(":edit name opens the func, method or type source in $EDITOR, and evaluates what was saved; with no name it edits the last entry.", t, func() {
+
+		origHome := os.Getenv("HOME")
+		origEditor := os.Getenv("EDITOR")
+		tempdir, err := ioutil.TempDir("", "gijit-test")
+		panicOn(err)
+		defer os.RemoveAll(tempdir)
+		os.Setenv("HOME", tempdir)
+		defer os.Setenv("HOME", origHome)
+		defer os.Setenv("EDITOR", origEditor)
+
+		// our "editor" rewrites + 1 to + 2, and
+		// leaves a copy of what it was given.
+		ed := filepath.Join(tempdir, "ed.sh")
+		seen := filepath.Join(tempdir, "seen")
+		panicOn(ioutil.WriteFile(ed, []byte("#!/bin/sh\ncp \"$1\" "+seen+"\nsed -i 's/+ 1/+ 2/' \"$1\"\n"), 0700))
+		os.Setenv("EDITOR", ed)
+
+		myflags := flag.NewFlagSet("gi", flag.ExitOnError)
+		cfg := NewGIConfig()
+		cfg.DefineFlags(myflags)
+		panicOn(myflags.Parse([]string{"-q", "-no-liner"}))
+		panicOn(cfg.ValidateConfig())
+		r := NewRepl(cfg)
+		defer r.lvm.Close()
+
+		panicOn(r.Eval("type Pt struct{ X int }\nfunc (p *Pt) Inc() {\n\tp.X = p.X + 1\n}\nfunc inc(x int) int {\n\treturn x + 1\n}"))
+
+		src, err := r.editCmd([]string{"inc"})
+		panicOn(err)
+		by, err := ioutil.ReadFile(seen)
+		panicOn(err)
+		cv.So(string(by), cv.ShouldEqual, "func inc(x int) int {\n\treturn x + 1\n}\n")
+		cv.So(src, cv.ShouldEqual, "func inc(x int) int {\n\treturn x + 2\n}\n")
+		panicOn(r.Eval(src))
+
+		src, err = r.editCmd([]string{"(*Pt).Inc"})
+		panicOn(err)
+		cv.So(src, cv.ShouldEqual, "func (p *Pt) Inc() {\n\tp.X = p.X + 2\n}\n")
+		panicOn(r.Eval(src))
+
+		_, err = r.editCmd([]string{"Pt"})
+		panicOn(err)
+		by, err = ioutil.ReadFile(seen)
+		panicOn(err)
+		cv.So(string(by), cv.ShouldEqual, "type Pt struct{ X int }\n")
+
+		panicOn(r.Eval("p := &Pt{}; p.Inc(); a := inc(1) + p.X"))
+		LuaMustInt64(r.lvm, "a", 5)
+
+		// no name: the last entry.
+		panicOn(r.Eval("b := 10 + 1"))
+		src, err = r.editCmd(nil)
+		panicOn(err)
+		cv.So(src, cv.ShouldEqual, "b := 10 + 2\n")
+
+		_, err = r.editCmd([]string{"nope"})
+		cv.So(err, cv.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,96 @@
+package compiler
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2130ExportWritesABuildableMainPackage(t *testing.T) {
+
+	vm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer vm.Close()
+	inc := NewIncrState(vm, nil)
+	inc.TrackPos = true
+	r := &Repl{lvm: vm, inc: inc, chunks: make(map[string]*ChunkPosMap)}
+
+	run := func(src string) {
+		tr, err := TranslateAndCatchPanic(inc, []byte(src))
+		panicOn(err)
+		m := inc.LastPosMap
+		r.chunks[m.Name] = m
+		// as Eval notes it.
+		m.Failed = LuaRunChunk(vm, tr, m.Name) != nil || lastEvalErr(vm) != ""
+	}
+
+	cv.Convey(":export writes the latest declarations, package-level vars, and the top-level statements folded into main, as a main package that go build compiles.", t, func() {
+		run(`type Pt struct{ X int }`)
+		run(`func (p *Pt) Inc() { p.X = p.X + 1 }`)
+		run(`const Step = 2`)
+		// an iota group goes whole.
+		run("const (\n\tA = iota\n\tB\n\tC\n)")
+		run(`total := 0`)
+		run(`func add(n int) { total = total + n }`)
+		run(`p := &Pt{}; p.Inc()`)
+		run(`total`)
+		run(`add(0)`)
+		run(`p.X + Step`)
+		run(`for i := 0; i < 3; i++ { add(Step) }`)
+		// redefined: the latest wins.
+		run(`func add(n int) { total = total + 2*n }`)
+		run(`var names = []string{"a"}; names = append(names, "b")`)
+		// a statement that will not take the __gijit_ans
+		// wrapping keeps its own source.
+		run(`ch := make(chan int, 1)`)
+		run(`ch <- 1`)
+		// fails when run: left out, but for its var.
+		run(`boom := names[5]`)
+
+		by, err := r.exportSource()
+		panicOn(err)
+		src := string(by)
+		cv.So(src, cv.ShouldStartWith, "package main\n\ntype Pt struct{ X int }\n")
+		cv.So(src, cv.ShouldContainSubstring, "const Step = 2\n")
+		cv.So(src, cv.ShouldContainSubstring, "const (\n\tA = iota\n\tB\n\tC\n)\n")
+		cv.So(src, cv.ShouldContainSubstring, "var (\n\ttotal int\n\tp     *Pt\n\tnames []string\n\tch    chan int\n\tboom  string\n)\n")
+		cv.So(src, cv.ShouldContainSubstring, "func add(n int) { total = total + 2*n }\n")
+		cv.So(src, cv.ShouldNotContainSubstring, "total + n }")
+		cv.So(src, cv.ShouldEndWith, `func main() {
+	total = 0
+	p = &Pt{}
+	p.Inc()
+	add(0)
+	for i := 0; i < 3; i++ {
+		add(Step)
+	}
+	names = []string{"a"}
+	names = append(names, "b")
+	ch = make(chan int, 1)
+	ch <- 1
+}
+`)
+
+		gobin, err := exec.LookPath("go")
+		if err != nil {
+			return
+		}
+		dir, err := ioutil.TempDir("", "gi-export")
+		panicOn(err)
+		defer os.RemoveAll(dir)
+		fn, err := r.export(filepath.Join(dir, "prog"))
+		panicOn(err)
+		cv.So(fn, cv.ShouldEqual, filepath.Join(dir, "prog", "main.go"))
+
+		cmd := exec.Command(gobin, "build", "-o", os.DevNull, ".")
+		cmd.Dir = filepath.Join(dir, "prog")
+		cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=")
+		out, err := cmd.CombinedOutput()
+		cv.So(string(out), cv.ShouldEqual, "")
+		cv.So(err, cv.ShouldBeNil)
+	})
+}
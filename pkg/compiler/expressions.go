@@ -293,7 +293,7 @@ func (c *funcContext) translateExpr(expr ast.Expr, desiredType types.Type) (xprn
 					//vv("tn = '%s'", tn)
 					isShad, shortPkgAndTyp := isShadowStruct(tn)
 					if isShad {
-						elements[i] = fmt.Sprintf("__type__.%s()", shortPkgAndTyp)
+						elements[i] = shortPkgAndTyp + "()"
 					} else {
 						elements[i] = c.translateExpr(c.zeroValue(t.Field(i).Type()), nil).String()
 					}
@@ -701,7 +701,8 @@ func (c *funcContext) translateExpr(expr ast.Expr, desiredType types.Type) (xprn
 
 			//return c.formatExpr(`(%1s = %2e[%3s], %1s !== undefined ? %1s.v : %4e)`, c.newVariable("_entry"), e.X, key, c.zeroValue(t.Elem()))
 		case *types.Basic:
-			return c.formatExpr("__utf8.sub(%e,%f+1,%f+1)", e.X, e.Index, e.Index)
+			// a byte, not a one byte string.
+			return c.formatExpr("string.byte(%e,tonumber(%f)+1)", e.X, e.Index)
 		default:
 			panic(fmt.Sprintf("Unhandled IndexExpr: %T\n", t))
 		}
@@ -714,13 +715,14 @@ func (c *funcContext) translateExpr(expr ast.Expr, desiredType types.Type) (xprn
 			case e.Low == nil && e.High == nil:
 				return c.translateExpr(e.X, nil)
 			case e.Low == nil:
-				return c.formatExpr("string.sub(%e, 1, %f)", e.X, e.High)
+				// string.sub takes Lua numbers, not int64 cdata.
+				return c.formatExpr("string.sub(%e, 1, tonumber(%f))", e.X, e.High)
 				//return c.formatExpr("__substring(%e, 0, %f)", e.X, e.High)
 			case e.High == nil:
-				return c.formatExpr("string.sub(%e, %f+1)", e.X, e.Low)
+				return c.formatExpr("string.sub(%e, tonumber(%f)+1)", e.X, e.Low)
 				//return c.formatExpr("__substring(%e, %f)", e.X, e.Low)
 			default:
-				return c.formatExpr("string.sub(%e, %f+1, %f)", e.X, e.Low, e.High)
+				return c.formatExpr("string.sub(%e, tonumber(%f)+1, tonumber(%f))", e.X, e.Low, e.High)
 				//return c.formatExpr("__substring(%e, %f, %f)", e.X, e.Low, e.High)
 			}
 		}
@@ -837,6 +839,18 @@ func (c *funcContext) translateExpr(expr ast.Expr, desiredType types.Type) (xprn
 						return c.translateExpr(e.Args[0], nil)
 					}
 				}
+
+				if obj.Pkg() != nil {
+					if st, ok := staticTypedCalls[obj.Pkg().Path()+"."+obj.Name()]; ok && len(e.Args) == sig.Params().Len() {
+						return c.translateStaticTypedCall(e, sig, st)
+					}
+					if fn, ok := variadicTypedCalls[obj.Pkg().Path()+"."+obj.Name()]; ok {
+						return c.translateVariadicTypedCall(e, sig, fn)
+					}
+					if fn, ok := coroutineCalls[obj.Pkg().Path()+"."+obj.Name()]; ok {
+						return c.translateCall(e, sig, c.formatExpr("%s", fn))
+					}
+				}
 				return c.translateCall(e, sig, c.translateExpr(f, nil))
 			}
 
@@ -1014,7 +1028,8 @@ func (c *funcContext) translateExpr(expr ast.Expr, desiredType types.Type) (xprn
 			//return c.formatExpr("__assertType(%e, %s, true)", e.X, c.typeName(t,nil))
 		}
 		// jea, type assertion place 0: only return value, without the 2nd 'ok' return.
-		return c.formatExpr(`__assertType(%e, %s, 0)`, e.X, c.typeName(t, nil))
+		// The static type of e.X is for the panic message.
+		return c.formatExpr(`__assertType(%e, %s, 0, %s)`, e.X, c.typeName(t, nil), c.typeName(c.p.TypeOf(e.X), nil))
 
 	case *ast.Ident:
 		if e.Name == "_" {
@@ -1044,7 +1059,7 @@ func (c *funcContext) translateExpr(expr ast.Expr, desiredType types.Type) (xprn
 			case *types.Chan:
 				return c.formatExpr("__chanNil")
 			case *types.Map:
-				return c.formatExpr("false")
+				return c.formatExpr("__mapNil")
 			case *types.Interface:
 				return c.formatExpr("nil")
 			case *types.Signature:
@@ -1072,6 +1087,59 @@ func (c *funcContext) translateExpr(expr ast.Expr, desiredType types.Type) (xprn
 	}
 }
 
+// translateStaticTypedCall calls st.fn in place of the
+// shadowed Go function; see staticTypedCalls in import.go.
+func (c *funcContext) translateStaticTypedCall(e *ast.CallExpr, sig *types.Signature, st staticTypedCall) *expression {
+	args := c.translateArgs(sig, e.Args, false)
+	args = append(args, c.staticTypeName(c.p.TypeOf(e.Args[st.arg])))
+	return c.formatExpr("%s(%s)", st.fn, strings.Join(args, ", "))
+}
+
+// translateVariadicTypedCall calls fn in place of the
+// shadowed Go function, with a table of the static types
+// of the variadic arguments before them; see
+// variadicTypedCalls in import.go. Spread with ..., the
+// arguments are a []interface{}, and the table is empty.
+func (c *funcContext) translateVariadicTypedCall(e *ast.CallExpr, sig *types.Signature, fn string) *expression {
+	args := c.translateArgs(sig, e.Args, e.Ellipsis.IsValid())
+	nFixed := sig.Params().Len() - 1
+
+	var argTypes []types.Type
+	if len(e.Args) == 1 {
+		if tuple, isTuple := c.p.TypeOf(e.Args[0]).(*types.Tuple); isTuple {
+			for i := 0; i < tuple.Len(); i++ {
+				argTypes = append(argTypes, tuple.At(i).Type())
+			}
+		}
+	}
+	if argTypes == nil {
+		for _, arg := range e.Args {
+			argTypes = append(argTypes, c.p.TypeOf(arg))
+		}
+	}
+	var typs []string
+	if !e.Ellipsis.IsValid() {
+		for _, t := range argTypes[nFixed:] {
+			typs = append(typs, c.staticTypeName(t))
+		}
+	}
+	withTypes := append([]string{}, args[:nFixed]...)
+	withTypes = append(withTypes, "{"+strings.Join(typs, ", ")+"}")
+	withTypes = append(withTypes, args[nFixed:]...)
+	return c.formatExpr("%s(%s)", fn, strings.Join(withTypes, ", "))
+}
+
+// staticTypeName is the type that the prelude is
+// handed for a value of static type t: nil for an
+// interface or untyped nil, which have only the
+// dynamic type of the value.
+func (c *funcContext) staticTypeName(t types.Type) string {
+	if _, isIface := t.Underlying().(*types.Interface); isIface || types.Identical(t, types.Typ[types.UntypedNil]) {
+		return "nil"
+	}
+	return c.typeName(types.Default(t), nil)
+}
+
 func (c *funcContext) translateCall(e *ast.CallExpr, sig *types.Signature, fun *expression) *expression {
 	pp("top of translateCall, len(e.Args)='%v', e.Args='%#v'. call='%s'.", len(e.Args), e.Args, c.exprToString(e)) // , stack())
 	for i := range e.Args {
@@ -1562,7 +1630,19 @@ func (c *funcContext) translateImplicitConversion(expr ast.Expr, desiredType typ
 			pp("YYY 7 translateImplicitConversion exiting early")
 			//return c.formatExpr("%1e.__constructor.__elem(%1e)", expr)
 			//return c.formatExpr("%1e.__typ.elem(%1e)", expr)
-			return c.formatExpr("(%1e)", expr)
+
+			// a struct value is held by a pointer to it,
+			// which carries *T. Box a copy of the value,
+			// which carries T, so that x.(T) and x.(*T)
+			// tell them apart.
+			typName, isAnon, anonType, _, isShadow := c.typeNameWithAnonInfo(exprType, nil)
+			switch {
+			case isShadow:
+				return c.formatExpr("(%1e)", expr)
+			case isAnon:
+				typName = c.typeName(anonType.Type(), nil)
+			}
+			return c.formatExpr("__clone(%e, %s)", expr, typName)
 		}
 	}
 	pp("bottom of expressions.go:1250 calling c.translateExpr, for expr='%#v', exprType='%v'", expr, exprType)
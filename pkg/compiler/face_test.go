@@ -320,3 +320,113 @@ switch v.(type) {
 		fmt.Printf("past 5\n")
 	})
 }
+
+func Test2221TypeSwitchTakesTheMatchingCase(t *testing.T) {
+
+	cv.Convey(`a type switch takes the case matching the dynamic type, not the first whose zero value is truthy; and v := x.(type) binds basic values as they are`, t, func() {
+
+		code := `
+type P struct{ X int }
+func f(x interface{}) int {
+	switch v := x.(type) {
+	case int:
+		return v + 1
+	case string:
+		return len(v)
+	case P:
+		return v.X
+	}
+	return -1
+}
+a, b, c, d := f(41), f("abc"), f(P{7}), f(2.5)
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(code))
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustInt64(vm, "a", 42)
+		LuaMustInt(vm, "b", 3)
+		LuaMustInt64(vm, "c", 7)
+		LuaMustInt64(vm, "d", -1)
+	})
+}
+
+func Test2222VariadicMethodTypes(t *testing.T) {
+
+	cv.Convey(`a type with a variadic method gets its method set, with the type func(...int) int, and satisfies an interface declaring that method`, t, func() {
+
+		code := `
+type T struct{}
+func (T) Sum(xs ...int) int { return len(xs) }
+type Summer interface{ Sum(...int) int }
+var x interface{} = T{}
+_, ok := x.(Summer)
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(code))
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustBool(vm, "ok", true)
+		LuaRunAndReport(vm, `sumType = __type__.T.methods[1].__typ.__str`)
+		LuaMustString(vm, "sumType", "func(...int) int")
+	})
+}
+
+func Test2223AssertingAStructValue(t *testing.T) {
+
+	cv.Convey(`x.(T) and x.(T) with ok hold for a struct value of type T in an interface, and x.(*T) does not; a type switch tells T from *T; the value in the interface is a copy`, t, func() {
+
+		code := `
+type P struct{ X int }
+var i interface{} = P{7}
+p, ok := i.(P)
+x := p.X
+y := i.(P).X
+_, notInt := i.(int)
+_, notPtr := i.(*P)
+
+which := func(v interface{}) string {
+	switch v.(type) {
+	case *P:
+		return "*P"
+	case P:
+		return "P"
+	}
+	return "neither"
+}
+pv := P{8}
+var j interface{} = pv
+pv.X = 9
+p.X = 10
+forT := which(pv)
+forPtr := which(&pv)
+jx := j.(P).X
+ix := i.(P).X
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(code))
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustBool(vm, "ok", true)
+		LuaMustInt64(vm, "x", 7)
+		LuaMustInt64(vm, "y", 7)
+		LuaMustBool(vm, "notInt", false)
+		LuaMustBool(vm, "notPtr", false)
+		LuaMustString(vm, "forT", "P")
+		LuaMustString(vm, "forPtr", "*P")
+		LuaMustInt64(vm, "jx", 8)
+		LuaMustInt64(vm, "ix", 7)
+	})
+}
@@ -0,0 +1,98 @@
+package compiler
+
+import (
+	"fmt"
+	"github.com/gijit/gi/pkg/types"
+	"strings"
+)
+
+// plain-old-data structs as LuaJIT FFI cstructs.
+//
+// A named struct type whose fields are all numeric,
+// bool, fixed arrays of numbers, or other such structs
+// of the same package, gets an ffi.cdef declaration
+// alongside its __newType, and its values are cdata
+// rather than Lua tables of fields; see __cstructType
+// in tsys.lua. An []Point is then one calloc'd array of
+// points. Any other field -- a string, pointer, slice,
+// map, interface, func or chan -- leaves the type a
+// table, as before.
+//
+// The numeric kinds are those of __numericCtypes; the
+// small ints and float32 are not among them, as their
+// gijit values are not what an FFI field of them reads
+// back as.
+var cstructBasicCtypes = map[types.BasicKind]string{
+	types.Int:     "int64_t",
+	types.Int64:   "int64_t",
+	types.Uint:    "uint64_t",
+	types.Uint64:  "uint64_t",
+	types.Uintptr: "uint64_t",
+	types.Float64: "double",
+	types.Bool:    "bool",
+}
+
+// C words that fieldName leaves alone, but
+// which cannot name a field in a cdef.
+var cstructReserved = map[string]bool{
+	"auto": true, "bool": true, "extern": true, "inline": true,
+	"register": true, "restrict": true, "signed": true, "sizeof": true,
+	"struct": true, "typedef": true, "union": true, "unsigned": true,
+	"int64_t": true, "uint64_t": true, "_Bool": true,
+}
+
+// cstructDecl gives the body of the C declaration for
+// struct t, with a $ standing for each nested struct,
+// whose type names are in nested, in order. ok is false
+// if t is not plain old data. Array and struct fields
+// are declared with a __gi_ prefix, so that reads of
+// them go through the metatype, which hands back gijit
+// array values and anchored references.
+func (c *funcContext) cstructDecl(t *types.Struct) (decl string, nested []string, ok bool) {
+	if t.NumFields() == 0 {
+		return "", nil, false
+	}
+	var fields []string
+	for i := 0; i < t.NumFields(); i++ {
+		name := fieldName(t, i)
+		if cstructReserved[name] {
+			return "", nil, false
+		}
+		ft := t.Field(i).Type()
+		if named, isNamed := ft.(*types.Named); isNamed {
+			if st, isStruct := named.Underlying().(*types.Struct); isStruct {
+				obj := named.Obj()
+				if obj.Pkg() == nil || obj.Pkg().Path() != c.p.Pkg.Path() {
+					return "", nil, false
+				}
+				if _, _, isPOD := c.cstructDecl(st); !isPOD {
+					return "", nil, false
+				}
+				fields = append(fields, fmt.Sprintf("$ __gi_%s;", name))
+				nested = append(nested, c.typePrefix()+c.objectName(obj))
+				continue
+			}
+		}
+		switch u := ft.Underlying().(type) {
+		case *types.Basic:
+			ctype, isPOD := cstructBasicCtypes[u.Kind()]
+			if !isPOD {
+				return "", nil, false
+			}
+			fields = append(fields, fmt.Sprintf("%s %s;", ctype, name))
+		case *types.Array:
+			elem, isBasic := u.Elem().Underlying().(*types.Basic)
+			if !isBasic || elem.Kind() == types.Bool || u.Len() == 0 {
+				return "", nil, false
+			}
+			ctype, isPOD := cstructBasicCtypes[elem.Kind()]
+			if !isPOD {
+				return "", nil, false
+			}
+			fields = append(fields, fmt.Sprintf("%s __gi_%s[%d];", ctype, name, u.Len()))
+		default:
+			return "", nil, false
+		}
+	}
+	return strings.Join(fields, " "), nested, true
+}
@@ -0,0 +1,139 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	golua "github.com/glycerine/golua/lua"
+	"github.com/glycerine/luar"
+)
+
+// fmt for gijit values: fmt.Printf and friends go to
+// prelude/fmt.lua, with the static types of their
+// arguments (see variadicTypedCalls). fmt.lua is Go's
+// printer, walking structs, slices, maps and pointers
+// and calling Format, GoString, Error and String
+// methods as Go does; it leaves the leaves to the real
+// fmt through the helpers here. Each helper gets the
+// verb with all its flags, width and precision as a
+// spec, and a leaf of the Go type that the gijit value
+// stands for, so that padding, quoting and float
+// formatting come out as compiled Go has them.
+
+func registerFmtHelpers(vm *golua.State) {
+	luar.Register(vm, "", luar.Map{
+		"__gijit_fmtInt":     fmtInt,
+		"__gijit_fmtFloat":   fmtFloat,
+		"__gijit_fmtComplex": fmtComplex,
+		"__gijit_fmtString":  fmtString,
+		"__gijit_fmtBytes":   fmtBytes,
+		"__gijit_fmtBool":    fmtBool,
+		"__gijit_fmtGo":      fmtGo,
+		"__gijit_fmtWrite":   fmtWrite,
+		"__gijit_fmtFwrite":  fmtFwrite,
+		"__gijit_fmtError": func(s string) error {
+			return errors.New(s)
+		},
+	})
+}
+
+// fmtInt formats the integer of the given
+// reflect.Kind whose decimal digits are digits.
+func fmtInt(spec string, kind int, typName, digits string) string {
+	var x interface{}
+	switch k := reflect.Kind(kind); k {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u, _ := strconv.ParseUint(digits, 10, 64)
+		switch k {
+		case reflect.Uint:
+			x = uint(u)
+		case reflect.Uint8:
+			x = uint8(u)
+		case reflect.Uint16:
+			x = uint16(u)
+		case reflect.Uint32:
+			x = uint32(u)
+		case reflect.Uintptr:
+			x = uintptr(u)
+		default:
+			x = u
+		}
+	default:
+		n, _ := strconv.ParseInt(digits, 10, 64)
+		switch k {
+		case reflect.Int:
+			x = int(n)
+		case reflect.Int8:
+			x = int8(n)
+		case reflect.Int16:
+			x = int16(n)
+		case reflect.Int32:
+			x = int32(n)
+		default:
+			x = n
+		}
+	}
+	return fmtLeaf(spec, typName, x)
+}
+
+func fmtFloat(spec string, kind int, typName string, f float64) string {
+	if reflect.Kind(kind) == reflect.Float32 {
+		return fmtLeaf(spec, typName, float32(f))
+	}
+	return fmtLeaf(spec, typName, f)
+}
+
+func fmtComplex(spec string, kind int, typName string, re, im float64) string {
+	if reflect.Kind(kind) == reflect.Complex64 {
+		return fmtLeaf(spec, typName, complex64(complex(re, im)))
+	}
+	return fmtLeaf(spec, typName, complex(re, im))
+}
+
+func fmtString(spec, typName, s string) string {
+	return fmtLeaf(spec, typName, s)
+}
+
+// fmtBytes formats s as a []byte, nil or not.
+func fmtBytes(spec, typName, s string, isNil bool) string {
+	b := []byte(s)
+	if isNil {
+		b = nil
+	}
+	return fmtLeaf(spec, typName, b)
+}
+
+func fmtBool(spec, typName string, b bool) string {
+	return fmtLeaf(spec, typName, b)
+}
+
+// fmtGo formats x, a Go value that
+// gijit holds, as fmt would.
+func fmtGo(spec string, x interface{}) string {
+	return fmt.Sprintf(spec, x)
+}
+
+// fmtLeaf formats x by spec, naming
+// typName in place of x's type in a
+// bad verb, as in %!d(main.Celsius=1.5).
+func fmtLeaf(spec, typName string, x interface{}) string {
+	s := fmt.Sprintf(spec, x)
+	if !strings.HasPrefix(s, "%!") {
+		return s
+	}
+	goName := reflect.TypeOf(x).String()
+	return strings.Replace(s, "("+goName+"=", "("+typName+"=", 1)
+}
+
+func fmtWrite(s string) (int, error) {
+	return io.WriteString(os.Stdout, s)
+}
+
+func fmtFwrite(w io.Writer, s string) (int, error) {
+	return io.WriteString(w, s)
+}
@@ -0,0 +1,134 @@
+package compiler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+// the Go twins of the gijit types in Test2090,
+// to check that we print as Go does.
+type fmtTestInner struct{ Z int }
+
+type fmtTestCelsius float64
+
+type fmtTestTemp struct{ Deg int }
+
+func (t fmtTestTemp) String() string { return "deg" }
+
+type fmtTestErr struct{ Code int }
+
+func (e *fmtTestErr) Error() string { return "code " + strconv.Itoa(e.Code) }
+
+type fmtTestRec struct {
+	Name  string
+	Age   int
+	Tags  []string
+	Attrs map[string]int
+	Ptr   *fmtTestInner
+	Any   interface{}
+	note  string
+	Temp  fmtTestTemp
+	C     fmtTestCelsius
+	fmtTestInner
+}
+
+func Test2090FmtVerbsOnGijitValues(t *testing.T) {
+
+	cv.Convey("fmt verbs, flags, and Stringer and error methods print gijit values as compiled Go prints them.", t, func() {
+
+		src := `
+import "fmt"
+type Inner struct { Z int }
+type Celsius float64
+type Temp struct { Deg int }
+func (t Temp) String() string { return "deg" }
+type Err struct { Code int }
+func (e *Err) Error() string { return "code 7" }
+type Rec struct {
+	Name  string
+	Age   int
+	Tags  []string
+	Attrs map[string]int
+	Ptr   *Inner
+	Any   interface{}
+	note  string
+	Temp  Temp
+	C     Celsius
+	Inner
+}
+r := Rec{Name: "ann", Age: 3, Tags: []string{"a", "b c"}, Attrs: map[string]int{"y": 2, "x": 1}, Any: 1.5, note: "n", Temp: Temp{21}, C: 1.5}
+r.Z = 7
+var c Celsius = 21
+tp := Temp{21}
+e := &Err{Code: 7}
+m := map[int]string{3: "c", 1: "a", 2: "b"}
+sl := []int{1, 2}
+var nilsl []int
+b := []byte("hi")
+z := complex(1, -2)
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		goRec := fmtTestRec{Name: "ann", Age: 3, Tags: []string{"a", "b c"}, Attrs: map[string]int{"y": 2, "x": 1}, Any: 1.5, note: "n", Temp: fmtTestTemp{21}, C: 1.5}
+		goRec.Z = 7
+		var goC fmtTestCelsius = 21
+		goTp := fmtTestTemp{21}
+		goE := &fmtTestErr{Code: 7}
+		goM := map[int]string{3: "c", 1: "a", 2: "b"}
+		var goNilsl []int
+
+		// some formats are bad on purpose, which vet
+		// would flag in direct calls to fmt.Sprintf.
+		sprintf := fmt.Sprintf
+		cases := []struct{ src, want string }{
+			{`fmt.Sprintf("%v", r)`, sprintf("%v", goRec)},
+			{`fmt.Sprintf("%+v", r)`, sprintf("%+v", goRec)},
+			{`fmt.Sprintf("%#v", r)`, sprintf("%#v", goRec)},
+			{`fmt.Sprintf("%T %T", r, &r)`, sprintf("%T %T", goRec, &goRec)},
+			{`fmt.Sprintf("%v", &r)`, sprintf("%v", &goRec)},
+			{`fmt.Sprintf("%v|%s|%d|%-6v|%q|%+v", tp, tp, tp, tp, tp, &tp)`,
+				sprintf("%v|%s|%d|%-6v|%q|%+v", goTp, goTp, goTp, goTp, goTp, &goTp)},
+			{`fmt.Sprintf("%v|%6.2f|%d|%#v", c, c, c, c)`,
+				sprintf("%v|%6.2f|%d|%#v", goC, goC, goC, goC)},
+			{`fmt.Sprintf("%v %s %q", e, e, error(e))`, sprintf("%v %s %q", goE, goE, error(goE))},
+			{`fmt.Sprintf("%q|%x|% X|%8.3s|%-5s|", "hi\n", "hi", b, "abcdef", "ab")`,
+				sprintf("%q|%x|% X|%8.3s|%-5s|", "hi\n", "hi", []byte("hi"), "abcdef", "ab")},
+			{`fmt.Sprintf("%5.2f|%08.3f|%+d|%x|%#o|%c|%U|%e|%g", 3.14159, -2.5, 5, 255, 8, 'A', 0x1F600, 1234.5678, 1e21)`,
+				sprintf("%5.2f|%08.3f|%+d|%x|%#o|%c|%U|%e|%g", 3.14159, -2.5, 5, 255, 8, 'A', 0x1F600, 1234.5678, 1e21)},
+			{`fmt.Sprintf("%v %#v %v %#v %v", m, m, sl, nilsl, nilsl)`,
+				sprintf("%v %#v %v %#v %v", goM, goM, []int{1, 2}, goNilsl, goNilsl)},
+			{`fmt.Sprintf("%v %.1f %t", z, z, true)`,
+				sprintf("%v %.1f %t", complex(1, -2), complex(1, -2), true)},
+			{`fmt.Sprintf("%[2]d %[1]d|%d %d|%!|%*d|%-*d|%.*f", 1, 2, 3, 5, 42, -4, 7, 2, 3.14159)`,
+				sprintf("%[2]d %[1]d|%d %d|%!|%*d|%-*d|%.*f", 1, 2, 3, 5, 42, -4, 7, 2, 3.14159)},
+			{`fmt.Sprintf("%d %d", 1)`, sprintf("%d %d", 1)},
+			{`fmt.Sprintf("%d", 1, "x")`, sprintf("%d", 1, "x")},
+			{`fmt.Sprintf("%v %v %d %w", r, nil, nil, 1)`,
+				sprintf("%v %v %d %w", goRec, nil, nil, 1)},
+			{`fmt.Sprint("a", 1, 2, "b", tp, c)`, fmt.Sprint("a", 1, 2, "b", goTp, goC)},
+			{`fmt.Sprintln("a", 1, interface{}(tp))`, fmt.Sprintln("a", 1, goTp)},
+			{`fmt.Sprintf("%v %d", []interface{}{"x", 2}...)`,
+				sprintf("%v %d", []interface{}{"x", 2}...)},
+		}
+		// Go names the twins after this package.
+		names := strings.NewReplacer("compiler.fmtTest", "main.", "fmtTest", "")
+		for i, c := range cases {
+			got := fmt.Sprintf("got%d", i)
+			translation, err = inc.Tr([]byte(got + " := " + c.src))
+			panicOn(err)
+			LoadAndRunTestHelper(t, vm, translation)
+			LuaMustString(vm, got, names.Replace(c.want))
+		}
+	})
+}
@@ -172,7 +172,7 @@ func FullPackageCompile(importPath string, files []*ast.File, fileSet *token.Fil
 			// but now we do it here to maintain previous behavior.
 			continue
 		}
-		c.p.pkgVars[importedPkg.Path()] = c.newVariableWithLevel(importedPkg.Name(), true, false)
+		c.p.pkgVars[importedPkg.Path()] = pkgVarName(importedPkg.Path())
 		pp("importedPkg.Path() = '%s'; importedPkg='%#v'\n", importedPkg.Path(), importedPkg)
 		importedPaths = append(importedPaths, importedPkg.Path())
 	}
@@ -205,8 +205,8 @@ func FullPackageCompile(importPath string, files []*ast.File, fileSet *token.Fil
 				var by bytes.Buffer
 				err = printer.Fprint(&by, fileSet, d)
 				panicOn(err)
-				funcSrcCache[d.Name.Name] = by.String()
-				pp("stored in funcSrcCache['%s'] the value '%s'", d.Name.Name, funcSrcCache[d.Name.Name])
+				funcSrcCache[funcSrcKey(d)] = by.String()
+				pp("stored in funcSrcCache['%s'] the value '%s'", funcSrcKey(d), funcSrcCache[funcSrcKey(d)])
 
 				sig := c.p.Defs[d.Name].(*types.Func).Type().(*types.Signature)
 				var recvType types.Type
@@ -427,7 +427,7 @@ func FullPackageCompile(importPath string, files []*ast.File, fileSet *token.Fil
 		d.DceDeps = collectDependencies(func() {
 			d.DeclCode = c.CatchOutput(0, func() {
 				typeName := c.objectName(o)
-				lhsPre := fmt.Sprintf("__type__.%s", typesPkg.Name())
+				lhsPre := typePkgVarName(typesPkg.Path())
 				lhs := fmt.Sprintf("%s.%s", lhsPre, typeName)
 				// jea comment out for now... b/c getting stuff like:
 				//
@@ -499,7 +499,7 @@ func FullPackageCompile(importPath string, files []*ast.File, fileSet *token.Fil
 			switch t := o.Type().Underlying().(type) {
 			case *types.Array, *types.Chan, *types.Interface, *types.Map, *types.Pointer, *types.Slice, *types.Signature, *types.Struct:
 				d.TypeInitCode = c.CatchOutput(0, func() {
-					c.Printf("__type__.%s.%s.init(%s); --where: %s\n", typesPkg.Name(), c.objectName(o), c.initArgs(t), verb.FileLine(1))
+					c.Printf("%s.%s.init(%s); --where: %s\n", typePkgVarName(typesPkg.Path()), c.objectName(o), c.initArgs(t), verb.FileLine(1))
 				})
 			}
 		})
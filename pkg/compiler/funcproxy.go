@@ -0,0 +1,134 @@
+package compiler
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+
+	golua "github.com/glycerine/golua/lua"
+	"github.com/glycerine/luar"
+)
+
+// Func proxies: a gijit func literal passed to Go where
+// a Go func is wanted, as by sort.Slice or strings.Map,
+// is a Lua function, which luar cannot call through a
+// Go func type. The type checker has already matched
+// the literal against the parameter's types.Signature
+// in the shadow package; at the call, luar asks
+// gijitFuncProxy for a Go func of the parameter's
+// reflect type, which is that same signature. We make
+// one with reflect.MakeFunc, converting arguments and
+// results by the Go types, as for calls into Go.
+//
+// A gijit panic in the func becomes a Go panic with
+// the same value, which Go code can recover; if it
+// does not, it comes back to gijit as a panic in the
+// call into Go, as any Go panic there does. Likewise
+// a Go panic under the func, while it runs, is a
+// gijit panic there; only while such a func runs
+// does golua raise Go panics as Lua errors.
+//
+// As with the interface proxies, Go may keep the
+// func and call it from any goroutine, and it keeps
+// only the Lua function's registry ref: a call runs
+// on the state Lua is running, or on a ticket; see
+// (*Goro).onLuaThread. Once Go drops the func, the
+// Lua function is let go.
+
+// funcRef holds a Lua function in the registry of
+// the vm that goro runs, for as long as the Go func
+// made for it lives.
+type funcRef struct {
+	goro *Goro
+	ref  int
+}
+
+func init() {
+	luar.FuncHook = gijitFuncProxy
+}
+
+// gijitFuncProxy is luar's FuncHook.
+func gijitFuncProxy(L *golua.State, idx int, t reflect.Type) (reflect.Value, bool) {
+	r := goroFor(L)
+	if r == nil {
+		return reflect.Value{}, false // the vm is closed.
+	}
+	if idx < 0 {
+		idx = L.GetTop() + idx + 1
+	}
+	// like a LuaObject, the func holds
+	// the Lua function in the registry.
+	L.PushValue(idx)
+	h := &funcRef{goro: r, ref: L.Ref(golua.LUA_REGISTRYINDEX)}
+	runtime.SetFinalizer(h, func(h *funcRef) { h.goro.releaseRef(h.ref) })
+
+	fn := func(args []reflect.Value) (out []reflect.Value) {
+		h.goro.onLuaThread(func(L *golua.State) {
+			out = h.call(L, t, args)
+		})
+		return
+	}
+	return reflect.MakeFunc(t, fn), true
+}
+
+func (h *funcRef) call(L *golua.State, t reflect.Type, args []reflect.Value) []reflect.Value {
+	top := L.GetTop()
+	defer L.SetTop(top)
+
+	L.GetGlobal("__gijit_funcCall")
+	L.RawGeti(golua.LUA_REGISTRYINDEX, h.ref)
+	n := len(args)
+	if t.IsVariadic() {
+		// Go hands us the variadic arguments as a
+		// slice; gijit wants them spread.
+		n--
+	}
+	for _, a := range args[:n] {
+		pushFuncArg(L, a)
+	}
+	if t.IsVariadic() {
+		last := args[n]
+		for i := 0; i < last.Len(); i++ {
+			pushFuncArg(L, last.Index(i))
+		}
+		n += last.Len()
+	}
+	L.RaiseGoPanics(true)
+	err := L.Call(1+n, golua.LUA_MULTRET)
+	L.RaiseGoPanics(false)
+	if err != nil {
+		panic(fmt.Sprintf("gijit func as %v: %v", t, err))
+	}
+
+	if !L.ToBoolean(top + 1) {
+		var pv interface{}
+		luar.LuaToGo(L, top+2, &pv)
+		panic(pv)
+	}
+	out := make([]reflect.Value, t.NumOut())
+	for i := range out {
+		pv := reflect.New(t.Out(i))
+		_, err = luar.LuaToGo(L, top+2+i, pv.Interface())
+		if err != nil {
+			panic(fmt.Sprintf("gijit func as %v, result %d: %v", t, i, err))
+		}
+		out[i] = pv.Elem()
+	}
+	return out
+}
+
+// pushFuncArg pushes a, an argument from Go, as
+// gijit would have it. luar makes Go slices plain
+// tables; a slice of a basic type, which gijit has
+// a type for, we make a gijit slice.
+func pushFuncArg(L *golua.State, a reflect.Value) {
+	t := a.Type()
+	if t.Kind() != reflect.Slice || t.Elem().PkgPath() != "" || t.Elem().Name() == "" || a.IsNil() {
+		luar.GoToLua(L, a)
+		return
+	}
+	L.GetGlobal("__gijit_basicSlice")
+	L.PushString(t.Elem().Name())
+	luar.GoToLua(L, a)
+	L.MustCall(2, 1)
+}
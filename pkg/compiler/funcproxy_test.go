@@ -0,0 +1,196 @@
+package compiler
+
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2060GijitClosuresPassAsGoFuncs(t *testing.T) {
+
+	cv.Convey("a gijit func passed to Go where a Go func is wanted becomes a Go func of that type, and panics cross in both directions.", t, func() {
+
+		src := `
+// stub definitions, to keep the type checker happy;
+// we replace them with Go functions below.
+func mapRunes(f func(rune) rune, s string) string { return "" }
+func sortedBy(less func(a, b string) bool, words ...string) string { return "" }
+func sum(f func(xs []int) int) int { return 0 }
+func tryIt(f func()) string { return "" }
+func run(f func()) {}
+func goPanic() {}
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		tk := vm.goro.newTicket("", false)
+		tk.regmap["mapRunes"] = strings.Map
+		tk.regmap["sortedBy"] = func(less func(a, b string) bool, words ...string) string {
+			sort.Slice(words, func(i, j int) bool { return less(words[i], words[j]) })
+			return strings.Join(words, " ")
+		}
+		tk.regmap["sum"] = func(f func(xs []int) int) int { return f([]int{1, 2, 3}) }
+		tk.regmap["tryIt"] = func(f func()) (s string) {
+			defer func() {
+				s = fmt.Sprintf("recovered: %v", recover())
+			}()
+			f()
+			return "no panic"
+		}
+		tk.regmap["run"] = func(f func()) { f() }
+		tk.regmap["goPanic"] = func() { panic("from Go") }
+		panicOn(tk.Do())
+
+		src = `
+upper := mapRunes(func(r rune) rune {
+	if r >= 'a' && r <= 'z' { return r - 32 }
+	return r
+}, "hi there")
+byLen := sortedBy(func(a, b string) bool { return len(a) < len(b) }, "ccc", "a", "bb")
+total := sum(func(xs []int) int {
+	t := 0
+	for _, x := range xs { t += x }
+	return t
+})
+caught := tryIt(func() { panic("boom") })
+quiet := tryIt(func() {})
+`
+		translation, err = inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		LuaMustString(vm, "upper", "HI THERE")
+		LuaMustString(vm, "byLen", "a bb ccc")
+		LuaMustInt64(vm, "total", 6)
+		LuaMustString(vm, "caught", "recovered: boom")
+		LuaMustString(vm, "quiet", "recovered: <nil>")
+
+		// a Go panic under the gijit func is a gijit
+		// panic there, and so back to a Go panic.
+		translation, err = inc.Tr([]byte(`viaGo := tryIt(func() { goPanic() })`))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+		vm.vm.GetGlobal("viaGo")
+		cv.So(vm.vm.ToString(-1), cv.ShouldStartWith, "recovered: ")
+		cv.So(vm.vm.ToString(-1), cv.ShouldEndWith, "from Go")
+		vm.vm.Pop(1)
+
+		// unrecovered in Go, the panic comes back to
+		// gijit as an error from the call into Go.
+		translation, err = inc.Tr([]byte(`run(func() { panic("kaboom") }); reached := true`))
+		panicOn(err)
+		err = LuaRun(vm, string(translation), false)
+		cv.So(err, cv.ShouldNotBeNil)
+	})
+}
+
+func Test2061GoKeepsAndReleasesFuncs(t *testing.T) {
+
+	cv.Convey("a gijit func that Go keeps can be called later from another goroutine, and once Go drops it, the Lua function is released.", t, func() {
+
+		src := `
+func keep(f func(int) int) {}
+calls := 0
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		var kept func(int) int
+		tk := vm.goro.newTicket("", false)
+		tk.regmap["keep"] = func(f func(int) int) { kept = f }
+		panicOn(tk.Do())
+
+		translation, err = inc.Tr([]byte(`if calls == 0 { keep(func(x int) int { calls++; return 2*x }) }`))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		done := make(chan int)
+		go func() {
+			sum := 0
+			for i := 1; i <= 10; i++ {
+				sum += kept(i)
+			}
+			done <- sum
+		}()
+		cv.So(<-done, cv.ShouldEqual, 110)
+		LuaMustInt64(vm, "calls", 10)
+
+		kept = nil
+		pending := func() int {
+			vm.goro.mut.Lock()
+			defer vm.goro.mut.Unlock()
+			return len(vm.goro.unrefs)
+		}
+		for i := 0; i < 100 && pending() == 0; i++ {
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+		}
+		cv.So(pending(), cv.ShouldEqual, 1)
+		panicOn(LuaRun(vm, "", false))
+		cv.So(pending(), cv.ShouldEqual, 0)
+	})
+}
+
+func Test2062KeptFuncsCallBackOnTheRunningState(t *testing.T) {
+
+	cv.Convey("a gijit func kept from a goroutine that has since finished is called on the state Lua is running; and Go that Lua calls can wait on another goroutine calling it.", t, func() {
+
+		src := `
+func keep(f func(int) int) {}
+func useKept(x int) int { return 0 }
+func elsewhere(x int) int { return 0 }
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		var kept func(int) int
+		tk := vm.goro.newTicket("", false)
+		tk.regmap["keep"] = func(f func(int) int) { kept = f }
+		tk.regmap["useKept"] = func(x int) int { return kept(x) }
+		tk.regmap["elsewhere"] = func(x int) int {
+			ch := make(chan int)
+			go func() { ch <- kept(x) }()
+			return <-ch
+		}
+		panicOn(tk.Do())
+
+		src = `
+calls := 0
+done := make(chan bool)
+go func() { keep(func(x int) int { calls++; return 2*x }); done <- true }()
+<-done
+a := useKept(3)
+b := elsewhere(4)
+`
+		translation, err = inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		LuaMustInt64(vm, "a", 6)
+		LuaMustInt64(vm, "b", 8)
+		LuaMustInt64(vm, "calls", 2)
+	})
+}
@@ -1,8 +1,12 @@
 package compiler
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
 
 	"path/filepath"
 
@@ -36,20 +40,11 @@ func GenShadowImport(importPath, dirForVendor, residentPkg, outDir string) error
 
 	pkgName := pkg.Name()
 
-	o, err := os.Create(outDir + string(os.PathSeparator) + pkgName + ".genimp.go")
-	if err != nil {
-		return err
-	}
-
-	fmt.Fprintf(o, `package shadow_%s
-
-import "%s"
-
-var Pkg = make(map[string]interface{})
-var Ctor = make(map[string]interface{})
-
-func init() {
-`, base, importPath)
+	// the init body goes to o first, as the
+	// proxies may need more imports.
+	o := &bytes.Buffer{}
+	imports := map[string]bool{importPath: true}
+	proxies := 0
 
 	scope := pkg.Scope()
 	nms := scope.Names()
@@ -116,6 +111,9 @@ func init() {
 				switch obj.(type) {
 				case *types.TypeName:
 					ifaceTemplate(o, obj, nm, pkgName, oty, under, &atEnd)
+					if proxyTemplate(o, pkg, nm, pkgName, under.(*types.Interface), imports, &atEnd) {
+						proxies++
+					}
 				case *types.Var:
 					direct(o, nm, pkgName)
 				default:
@@ -131,6 +129,37 @@ func init() {
 	}
 	fmt.Fprintf(o, "\n}")
 
+	f, err := os.Create(outDir + string(os.PathSeparator) + pkgName + ".genimp.go")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fmt.Fprintf(f, "package shadow_%s\n\n", base)
+	if len(imports) == 1 {
+		fmt.Fprintf(f, "import \"%s\"\n", importPath)
+	} else {
+		paths := []string{}
+		for path := range imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		fmt.Fprintf(f, "import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(f, "\t\"%s\"\n", path)
+		}
+		fmt.Fprintf(f, ")\n")
+	}
+	fmt.Fprintf(f, `
+var Pkg = make(map[string]interface{})
+var Ctor = make(map[string]interface{})
+`)
+	if proxies > 0 {
+		fmt.Fprintf(f, "var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})\n")
+	}
+	fmt.Fprintf(f, "\nfunc init() {\n")
+	o.WriteTo(f)
+	o = &bytes.Buffer{}
+
 	for _, s := range atEnd {
 		fmt.Fprintf(o, "%s\n", s)
 	}
@@ -139,13 +168,13 @@ func init() {
 
 	// write the InitLua() function that
 	// sets up the native struct (copy) constructors.
-	fmt.Fprintf(o, "%s", genInitLuaStart(pkgName))
+	fmt.Fprintf(o, "%s", genInitLuaStart(importPath))
 	for _, r := range structs {
-		fmt.Fprintf(o, "%s", perStructInitLua(pkgName, r))
+		fmt.Fprintf(o, "%s", perStructInitLua(pkgName, importPath, r))
 	}
 	fmt.Fprintf(o, "%s", genInitLuaFinish(pkgName))
-
-	return nil
+	_, err = o.WriteTo(f)
+	return err
 }
 
 /* make a function like:
@@ -181,11 +210,11 @@ func %s(x interface{}) %s.%s {
 	return
 }
 
-func direct(o *os.File, nm, pkgName string) {
+func direct(o io.Writer, nm, pkgName string) {
 	fmt.Fprintf(o, "    Pkg[\"%s\"] = %s.%s\n", nm, pkgName, nm)
 }
 
-func ctor(o *os.File, nm, pkgName string) {
+func ctor(o io.Writer, nm, pkgName string) {
 	fmt.Fprintf(o, "    Ctor[\"%[1]s\"] = GijitShadow_NewStruct_%[1]s\n", nm)
 }
 
@@ -198,7 +227,7 @@ func GijitShadow_CtorStruct_Time(src *time.Time) *time.Time {
 	return &a
 }
 */
-func structTemplate(o *os.File, obj types.Object, nm, pkgName string, oty, under types.Type, atEnd *[]string) {
+func structTemplate(o io.Writer, obj types.Object, nm, pkgName string, oty, under types.Type, atEnd *[]string) {
 	// example from "io":
 	/*
 		type PipeReader struct {
@@ -223,7 +252,7 @@ func GijitShadow_NewStruct_%[2]s(src *%[1]s.%[2]s) *%[1]s.%[2]s {
 
 }
 
-func ifaceTemplate(o *os.File, obj types.Object, nm, pkgName string, oty, under types.Type, atEnd *[]string) {
+func ifaceTemplate(o io.Writer, obj types.Object, nm, pkgName string, oty, under types.Type, atEnd *[]string) {
 
 	//pp("ifaceTemplate:: we see Named '%s'\n. oty:'%#v',\n under:'%#v',\n, obj='%#v', \n", nm, oty, under, obj)
 
@@ -233,24 +262,163 @@ func ifaceTemplate(o *os.File, obj types.Object, nm, pkgName string, oty, under
 	//fmt.Fprintf(o, "    Pkg[\"%s\"] = %s\n", nm, funcName1)
 }
 
-func genInitLuaStart(shortPkg string) string {
+/* make a proxy for the interface, like, for io.Reader:
+type GijitShadow_Proxy_Reader struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Reader(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Reader{call: call}
+}
+
+func (x *GijitShadow_Proxy_Reader) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+registered in Proxy, for the compiler's proxy.go, which
+passes the LuaProxy's Call as call. We skip interfaces
+that code outside the package cannot implement, or name
+the types of.
+*/
+func proxyTemplate(o io.Writer, pkg *types.Package, nm, pkgName string, it *types.Interface, imports map[string]bool, atEnd *[]string) bool {
+	need := map[string]bool{}
+	ok := true
+	qual := func(p *types.Package) string {
+		path := p.Path()
+		if i := strings.LastIndex(path, "/vendor/"); i >= 0 {
+			path = path[i+len("/vendor/"):]
+		}
+		if path == "internal" || strings.HasPrefix(path, "internal/") || strings.Contains(path, "/internal") {
+			ok = false
+		}
+		need[path] = true
+		return p.Name()
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, `
+type GijitShadow_Proxy_%[1]s struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_%[1]s(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_%[1]s{call: call}
+}
+`, nm)
+	for i := 0; i < it.NumMethods(); i++ {
+		m := it.Method(i)
+		if !m.Exported() {
+			return false
+		}
+		sig := m.Type().(*types.Signature)
+		params := []string{}
+		args := []string{fmt.Sprintf("%q", m.Name())}
+		for j := 0; j < sig.Params().Len(); j++ {
+			t := sig.Params().At(j).Type()
+			if !proxyableType(t) {
+				return false
+			}
+			ts := types.TypeString(t, qual)
+			if sig.Variadic() && j == sig.Params().Len()-1 {
+				ts = "..." + types.TypeString(t.(*types.Slice).Elem(), qual)
+			}
+			params = append(params, fmt.Sprintf("a%d %s", j, ts))
+			args = append(args, fmt.Sprintf("a%d", j))
+		}
+		results := []string{}
+		for j := 0; j < sig.Results().Len(); j++ {
+			t := sig.Results().At(j).Type()
+			if !proxyableType(t) {
+				return false
+			}
+			results = append(results, types.TypeString(t, qual))
+		}
+		res := strings.Join(results, ", ")
+		if len(results) > 1 {
+			res = "(" + res + ")"
+		}
+		fmt.Fprintf(&b, "\nfunc (x *GijitShadow_Proxy_%s) %s(%s) %s {\n",
+			nm, m.Name(), strings.Join(params, ", "), res)
+		if len(results) == 0 {
+			fmt.Fprintf(&b, "\tx.call(%s)\n}\n", strings.Join(args, ", "))
+			continue
+		}
+		fmt.Fprintf(&b, "\tout := x.call(%s)\n", strings.Join(args, ", "))
+		rs := []string{}
+		for j, r := range results {
+			fmt.Fprintf(&b, "\tr%d, _ := out[%d].Interface().(%s)\n", j, j, r)
+			rs = append(rs, fmt.Sprintf("r%d", j))
+		}
+		fmt.Fprintf(&b, "\treturn %s\n}\n", strings.Join(rs, ", "))
+	}
+	if !ok {
+		return false
+	}
+	for path := range need {
+		imports[path] = true
+	}
+	imports["reflect"] = true
+	*atEnd = append(*atEnd, b.String())
+	fmt.Fprintf(o, "    Proxy[reflect.TypeOf((*%[2]s.%[1]s)(nil)).Elem()] = GijitShadow_NewProxy_%[1]s\n", nm, pkgName)
+	return true
+}
+
+// proxyableType reports whether t names only
+// exported types, so a proxy can spell it.
+func proxyableType(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Named:
+		return t.Obj().Exported() || t.Obj().Pkg() == nil
+	case *types.Pointer:
+		return proxyableType(t.Elem())
+	case *types.Slice:
+		return proxyableType(t.Elem())
+	case *types.Array:
+		return proxyableType(t.Elem())
+	case *types.Chan:
+		return proxyableType(t.Elem())
+	case *types.Map:
+		return proxyableType(t.Key()) && proxyableType(t.Elem())
+	case *types.Signature:
+		for _, tup := range []*types.Tuple{t.Params(), t.Results()} {
+			for i := 0; i < tup.Len(); i++ {
+				if !proxyableType(tup.At(i).Type()) {
+					return false
+				}
+			}
+		}
+		return true
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if !t.Field(i).Exported() || !proxyableType(t.Field(i).Type()) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+func genInitLuaStart(importPath string) string {
 
 	return fmt.Sprintf("\n\n func InitLua() string {\n  "+
-		"return `\n__type__.%s ={};\n", shortPkg)
+		"return `\n%s ={};\n", typePkgVarName(importPath))
 }
 
 func genInitLuaFinish(shortPkg string) string {
 	return "\n`}"
 }
 
-func perStructInitLua(shortPkg, structName string) string {
+func perStructInitLua(shortPkg, importPath, structName string) string {
 
 	return fmt.Sprintf(`
 -----------------
 -- struct %[2]s
 -----------------
 
-__type__.%[1]s.%[2]s = {
+%[4]s.%[2]s = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "%[2]s",
@@ -260,7 +428,7 @@ __type__.%[1]s.%[2]s = {
    return __ctor__%[1]s.%[2]s(src)
  end,
 };
-setmetatable(__type__.%[1]s.%[2]s, __type__.%[1]s.%[2]s);
+setmetatable(%[4]s.%[2]s, %[4]s.%[2]s);
 
-`, shortPkg, structName, ast.IsExported(structName))
+`, shortPkg, structName, ast.IsExported(structName), typePkgVarName(importPath))
 }
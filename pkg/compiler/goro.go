@@ -3,7 +3,6 @@ package compiler
 import (
 	"fmt"
 	"sync"
-	"sync/atomic"
 
 	"time"
 
@@ -22,20 +21,39 @@ type Goro struct {
 	lvm      *LuaVm
 	vm       *golua.State
 	halt     *idem.Halter
-	beat     time.Duration
+	beat     time.Duration // how often idle runs at the prompt
 	doticket chan *ticket
 	mut      sync.Mutex
 	started  bool
 
-	manualHeartbeat chan bool
-	heartbeatsOff   chan bool
-	heartbeatsOn    chan bool
+	// vmMut serializes tickets when the vm has no
+	// goroutine of its own.
+	vmMut sync.Mutex
 
-	beatCount int64
+	// unrefs are registry refs held by Go values
+	// that have been garbage collected; the next
+	// ticket frees them, on the vm's goroutine.
+	unrefs []int
+
+	// lent are the lendings of the vm to Go that
+	// Lua has called, innermost last; see lend.
+	lent []*lending
 
 	Ready chan struct{}
 }
 
+// goros finds the Goro for a Lua state, by its
+// main coroutine, for Go code that Lua has handed
+// a state to, such as the proxies.
+var goros = make(map[*golua.State]*Goro)
+var gorosMut sync.Mutex
+
+func goroFor(L *golua.State) *Goro {
+	gorosMut.Lock()
+	defer gorosMut.Unlock()
+	return goros[L.MainCo]
+}
+
 type GoroConfig struct {
 	GiCfg *GIConfig
 }
@@ -48,10 +66,23 @@ type ticket struct {
 	regns  string
 	regmap luar.Map
 
+	//input
+	// packages to enter into __packages, by import
+	// path, optional
+	regpkgs luar.Map
+
 	//input
 	// what to do after any registrations, optional
 	run []byte
 
+	//input
+	// Go to call with the vm after run, optional,
+	// for Go that works the Lua stack itself, as
+	// the proxies do. A panic in call is raised
+	// again by Do.
+	call      func(L *golua.State)
+	callPanic interface{}
+
 	//input
 	// what to fetch for return, optional;
 	// one of register, run, or varname should be
@@ -61,6 +92,10 @@ type ticket struct {
 	leaveOnTop       bool
 	useEvalCoroutine bool
 
+	// optional Lua chunkname for run, when
+	// useEvalCoroutine; see ChunkPosMap.
+	chunkName string
+
 	//output
 	runErr error
 	getErr error
@@ -70,9 +105,6 @@ type ticket struct {
 	done chan struct{}
 }
 
-func (r *Goro) StartBeat() {
-	r.heartbeatsOn <- true
-}
 func (r *Goro) newTicket(run string, useEvalCoroutine bool) *ticket {
 	//fmt.Printf("goro.newTicket: top \n")
 
@@ -82,6 +114,7 @@ func (r *Goro) newTicket(run string, useEvalCoroutine bool) *ticket {
 	t := &ticket{
 		myGoro:           r,
 		regmap:           make(luar.Map),
+		regpkgs:          make(luar.Map),
 		varname:          make(map[string]interface{}),
 		done:             make(chan struct{}),
 		run:              []byte(run),
@@ -125,17 +158,18 @@ func NewGoro(lvm *LuaVm, cfg *GoroConfig) (*Goro, error) {
 	}
 
 	r := &Goro{
-		cfg:             cfg,
-		lvm:             lvm,
-		vm:              lvm.vm,
-		halt:            idem.NewHalter(),
-		doticket:        make(chan *ticket),
-		beat:            10 * time.Millisecond,
-		Ready:           make(chan struct{}),
-		manualHeartbeat: make(chan bool),
-		heartbeatsOff:   make(chan bool),
-		heartbeatsOn:    make(chan bool),
+		cfg:      cfg,
+		lvm:      lvm,
+		vm:       lvm.vm,
+		halt:     idem.NewHalter(),
+		doticket: make(chan *ticket),
+		beat:     10 * time.Millisecond,
+		Ready:    make(chan struct{}),
 	}
+	gorosMut.Lock()
+	goros[lvm.vm] = r
+	gorosMut.Unlock()
+
 	// run r.Start() on the main thread
 	//r.Start()
 	return r, nil
@@ -159,30 +193,16 @@ func (r *Goro) Start() {
 
 	func() {
 		defer func() {
+			gorosMut.Lock()
+			delete(goros, r.vm)
+			gorosMut.Unlock()
 			r.lvm.vm.Close()
 			r.halt.MarkDone()
 		}()
 
-		//fmt.Printf("\n r.beat is %v on r = %p\n", r.beat, r)
-		//var heartbeat <-chan time.Time
 		close(r.Ready)
 		for {
 			select {
-			/*case <-heartbeat:
-				r.handleHeartbeat()
-				heartbeat = time.After(r.beat)
-
-			case <-r.heartbeatsOn:
-				r.handleHeartbeat()
-				heartbeat = time.After(r.beat)
-
-			case <-r.heartbeatsOff:
-				heartbeat = nil
-
-			case <-r.manualHeartbeat:
-				fmt.Printf("manualHeartbeat arrived.\n")
-				r.handleHeartbeat()
-			*/
 			case <-r.halt.ReqStop.Chan:
 				return
 			case t := <-r.doticket:
@@ -192,29 +212,50 @@ func (r *Goro) Start() {
 	}()
 }
 
-var resumeSchedBytes = []byte("__task.resume_scheduler();")
-
-func (r *Goro) handleHeartbeat() {
-	fmt.Printf("goro heartbeat! on r=%p, at %v\n", r, time.Now())
-
-	cur := atomic.AddInt64(&r.beatCount, 1)
-	if cur%30 == 0 {
-		fmt.Printf("goro heartbeat cur = %v. on r=%p, at %v\n", cur, r, time.Now())
+// idle runs the goroutines that can make progress
+// without new input, and returns what they printed
+// (see prelude/idle.lua). The REPL calls it every
+// r.beat while the prompt waits for a key. Being a
+// ticket like any other, it never lands in between
+// the REPL's own runs and its inspection of the vm,
+// as the old free-running heartbeat did.
+func (r *Goro) idle() (string, error) {
+	tk := r.newTicket("__gijit_idleOut = __gijit_idle()", false)
+	tk.varname["__gijit_idleOut"] = nil
+	tk.gettyp = GetString
+	if err := tk.Do(); err != nil {
+		return "", err
 	}
-	err := r.privateRun(resumeSchedBytes, true)
-	panicOn(err)
+	return tk.varname["__gijit_idleOut"].(string), nil
 }
 
 func (r *Goro) handleTicket(t *ticket) {
 	//fmt.Printf("goro.handleTicket: top \n")
 
+	r.mut.Lock()
+	unrefs := r.unrefs
+	r.unrefs = nil
+	r.mut.Unlock()
+	for _, ref := range unrefs {
+		r.vm.Unref(golua.LUA_REGISTRYINDEX, ref)
+	}
+
 	if len(t.regmap) > 0 {
 		luar.Register(r.vm, t.regns, t.regmap)
 		//fmt.Printf("jea debug, back from luar.Register with regns: '%s', map: '%#v'\n", t.regns, t.regmap)
 	}
+	if len(t.regpkgs) > 0 {
+		luar.Register(r.vm, "__packages", t.regpkgs)
+	}
 
 	if len(t.run) > 0 {
-		t.runErr = r.privateRun(t.run, t.useEvalCoroutine)
+		t.runErr = r.privateRunChunk(t.run, t.useEvalCoroutine, t.chunkName)
+	}
+	if t.runErr == nil && t.call != nil {
+		func() {
+			defer func() { t.callPanic = recover() }()
+			t.call(r.vm)
+		}()
 	}
 	if t.runErr == nil && len(t.varname) > 0 {
 		for key := range t.varname {
@@ -246,17 +287,124 @@ func (r *Goro) handleTicket(t *ticket) {
 }
 
 func (r *Goro) do(t *ticket) {
-	if reserveMainThread {
+	switch {
+	case r.withLending(func(*golua.State) { r.handleTicket(t) }):
+		// Lua has called into Go, which may be
+		// waiting on this ticket: the vm is lent.
+	case reserveMainThread:
 		r.doticket <- t
 		<-t.done
-	} else {
+	default:
+		r.vmMut.Lock()
+		defer r.vmMut.Unlock()
 		r.handleTicket(t)
 	}
 }
 
+// a lending is the vm, lent to Go while Lua calls
+// into it. L is the state Lua is running, the main
+// coroutine's or another's, which luar hands to
+// the Go function called. Go values that hold Lua
+// values call back on L, one at a time, holding
+// mut; see (*Goro).onLuaThread.
+type lending struct {
+	L    *golua.State
+	mut  sync.Mutex
+	over bool
+}
+
+func init() {
+	luar.GoCallHook = lend
+}
+
+// lend is luar's GoCallHook: it lends the vm, by L,
+// for the Go call starting, and takes it back once
+// the call returns, after any callback on L ends.
+func lend(L *golua.State) (done func()) {
+	r := goroFor(L)
+	if r == nil {
+		return func() {}
+	}
+	l := &lending{L: L}
+	r.mut.Lock()
+	r.lent = append(r.lent, l)
+	r.mut.Unlock()
+	return func() {
+		l.mut.Lock()
+		defer l.mut.Unlock()
+		l.over = true
+		r.mut.Lock()
+		defer r.mut.Unlock()
+		for i := len(r.lent) - 1; i >= 0; i-- {
+			if r.lent[i] == l {
+				r.lent = append(r.lent[:i], r.lent[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// onLuaThread calls f with the state that Lua is
+// running, if Lua has called into Go (see lend),
+// whichever goroutine we are on; else with the vm's
+// main state, on a ticket, once the vm is free. Go
+// keeps the values Lua hands it, and may use them
+// later, from any goroutine.
+func (r *Goro) onLuaThread(f func(L *golua.State)) {
+	if r.withLending(f) {
+		return
+	}
+	t := r.newTicket("", false)
+	t.call = f
+	t.Do()
+}
+
+// withLending calls f with the state of the
+// innermost lending, and reports true, if Lua has
+// called into Go; else it reports false.
+func (r *Goro) withLending(f func(L *golua.State)) bool {
+	for {
+		r.mut.Lock()
+		var l *lending
+		if n := len(r.lent); n > 0 {
+			l = r.lent[n-1]
+		}
+		r.mut.Unlock()
+		if l == nil {
+			return false
+		}
+		l.mut.Lock()
+		if !l.over {
+			defer l.mut.Unlock()
+			f(l.L)
+			return true
+		}
+		// the call returned meanwhile; look again.
+		l.mut.Unlock()
+	}
+}
+
+// releaseRef frees ref, in the vm's registry, on
+// the next ticket; for finalizers, which run on a
+// goroutine of their own.
+func (r *Goro) releaseRef(ref int) {
+	gorosMut.Lock()
+	closed := goros[r.vm] != r
+	gorosMut.Unlock()
+	if closed {
+		return // the vm is closed, registry and all.
+	}
+	r.mut.Lock()
+	r.unrefs = append(r.unrefs, ref)
+	r.mut.Unlock()
+}
+
 func (t *ticket) Do() error {
 	pp("ticket.Do() called, run='%s'", string(t.run))
 	t.myGoro.do(t)
+	if t.callPanic != nil {
+		panic(t.callPanic)
+	}
 	if t.runErr != nil {
 		return t.runErr
 	}
@@ -271,6 +419,13 @@ func (t *ticket) Do() error {
 // should be calling the LuaJIT vm). This is
 // where code actually gets run on the vm.
 func (goro *Goro) privateRun(run []byte, useEvalCoroutine bool) error {
+	return goro.privateRunChunk(run, useEvalCoroutine, "")
+}
+
+// privateRunChunk is privateRun with a chunkName for
+// the code, which LuaJIT then uses in tracebacks,
+// profiles, and trace reports.
+func (goro *Goro) privateRunChunk(run []byte, useEvalCoroutine bool, chunkName string) error {
 
 	lvm := goro.lvm
 
@@ -291,6 +446,11 @@ func (goro *Goro) privateRun(run []byte, useEvalCoroutine bool) error {
 		eval := vm.ToPointer(-1)
 		_ = eval
 		vm.PushString(s)
+		narg := 1
+		if chunkName != "" {
+			vm.PushString("=" + chunkName)
+			narg++
+		}
 
 		//fmt.Printf("good: found __eval (0x%x). it is at -2 of the stack, our running code at -1. running '%s'\n", eval, s)
 		if verb.VerboseVerbose {
@@ -298,7 +458,7 @@ func (goro *Goro) privateRun(run []byte, useEvalCoroutine bool) error {
 			showLuaStacks(vm)
 		}
 
-		vm.Call(1, 0)
+		vm.Call(narg, 0)
 		// if things crash, this is the first place
 		// to check for an error: dump the Lua stack.
 		// With high probability, it will yield clues to the problem.
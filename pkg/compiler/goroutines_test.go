@@ -0,0 +1,56 @@
+package compiler
+
+import (
+	"testing"
+	"time"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2160GoroutinesAndDeadlock(t *testing.T) {
+
+	cv.Convey(":goroutines lists each goroutine with its id, state and the Go line it is parked on; an entry that blocks with nothing left to run reports Go's deadlock, instead of hanging, and the REPL goes on.", t, func() {
+
+		r, done := newTestRepl()
+		defer done()
+
+		panicOn(r.Eval("ch := make(chan int)"))
+		panicOn(r.Eval("func producer() {\n\tch <- 1\n\tch <- 2\n}"))
+		panicOn(r.Eval("go producer()"))
+
+		dump, err := r.goroutineDump()
+		panicOn(err)
+		cv.So(dump, cv.ShouldContainSubstring, "[chan send]:\n\tmain.producer line 2 (gi:")
+		cv.So(dump, cv.ShouldContainSubstring, "): ch <- 1\n")
+
+		panicOn(r.Eval("a := <-ch"))
+		LuaMustInt64(r.lvm, "a", 1)
+		dump, err = r.goroutineDump()
+		panicOn(err)
+		cv.So(dump, cv.ShouldContainSubstring, "): ch <- 2\n")
+
+		panicOn(r.Eval("done := make(chan bool)"))
+		panicOn(r.Eval("b := <-done"))
+		fatal := LuaToString(r.lvm, "__lastEvalErr")
+		cv.So(fatal, cv.ShouldStartWith, "fatal error: all goroutines are asleep - deadlock!\n\n")
+		cv.So(fatal, cv.ShouldContainSubstring, "[chan receive]:\n\tmain.entry")
+		cv.So(fatal, cv.ShouldContainSubstring, "): b := <-done\n")
+		cv.So(fatal, cv.ShouldContainSubstring, "): ch <- 2\n")
+
+		// the REPL goes on, without the abandoned entry.
+		panicOn(r.Eval("c := <-ch + 40"))
+		LuaMustInt64(r.lvm, "c", 42)
+		dump, err = r.goroutineDump()
+		panicOn(err)
+		cv.So(dump, cv.ShouldEqual, "")
+
+		// a sleeping goroutine lets the others
+		// run, and shows as sleeping.
+		panicOn(LuaRun(r.lvm, `__task.spawn(function() __task.sleep(50000000LL) end, {})`, true))
+		cv.So(LuaToString(r.lvm, "__task.goroutine_dump()"), cv.ShouldContainSubstring, "[sleep]:")
+		t0 := time.Now()
+		panicOn(LuaRun(r.lvm, `__task.sleep(30000000LL); __slept = "yes"`, true))
+		cv.So(time.Since(t0), cv.ShouldBeGreaterThan, 25*time.Millisecond)
+		cv.So(LuaToString(r.lvm, "__slept"), cv.ShouldEqual, "yes")
+	})
+}
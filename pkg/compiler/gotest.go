@@ -0,0 +1,593 @@
+package compiler
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	goast "go/ast"
+	godoc "go/doc"
+	goparser "go/parser"
+	gotoken "go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gijit/gi/pkg/gostd/build"
+	"github.com/gijit/gi/pkg/token"
+)
+
+// gi test [-v] [-run regexp] [-bench regexp] [-benchtime d] [packages]
+//
+// Runs the tests, benchmarks, and examples of Go
+// packages under the Lua runtime, reporting in the
+// manner of `go test`. The package is source imported
+// together with its _test.go files, whose "testing"
+// import is given gijit's own, in pkg/testing. The
+// test main that `go test` would generate is played
+// here: each TestXxx is called through
+// testing.RunTest, one snippet at a time, on a
+// fresh VM per package.
+
+// GijitTestingPath is the package that stands in for
+// "testing" in the _test.go files run by `gi test`.
+const GijitTestingPath = "github.com/gijit/gi/pkg/testing"
+
+type goTestOpts struct {
+	verbose   bool
+	run       *regexp.Regexp
+	bench     *regexp.Regexp
+	benchtime time.Duration
+}
+
+// testFunc is a TestXxx, BenchmarkXxx, or
+// ExampleXxx found in a package's _test.go files.
+type testFunc struct {
+	Name string
+	Pkg  string // the package it is called through: pkg, or pkg_test.
+
+	// for examples
+	Output    string
+	HasOutput bool
+	Unordered bool
+}
+
+// testPkg is a package to test, and what it has to run.
+type testPkg struct {
+	*PackageData
+	tests       []testFunc
+	benchmarks  []testFunc
+	examples    []testFunc
+	hasTestMain bool
+}
+
+// GoTest runs `gi test` with args, the command line
+// after "test", returning the exit code.
+func GoTest(cfg *GIConfig, args []string) int {
+	fs := flag.NewFlagSet("gi test", flag.ContinueOnError)
+	o := &goTestOpts{}
+	fs.BoolVar(&o.verbose, "v", false, "verbose: log all tests as they are run, with their output")
+	run := fs.String("run", "", "run only the tests and examples matching the regular expression")
+	bench := fs.String("bench", "", "run only the benchmarks matching the regular expression; '.' for all")
+	fs.DurationVar(&o.benchtime, "benchtime", time.Second, "run each benchmark for about this long")
+
+	// as with go test, flags may follow the packages.
+	var paths []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return 2
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		paths = append(paths, args[0])
+		args = args[1:]
+	}
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	var err error
+	if *run != "" {
+		o.run, err = regexp.Compile(*run)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gi test: bad -run '%s': %v\n", *run, err)
+			return 2
+		}
+	}
+	if *bench != "" {
+		o.bench, err = regexp.Compile(*bench)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gi test: bad -bench '%s': %v\n", *bench, err)
+			return 2
+		}
+	}
+
+	status := 0
+	for _, path := range paths {
+		if !goTestPackage(cfg, path, o) {
+			status = 1
+		}
+	}
+	return status
+}
+
+// goTestPackage tests one package, on a VM of its own,
+// and reports if it passed.
+func goTestPackage(cfg *GIConfig, path string, o *goTestOpts) bool {
+	start := time.Now()
+	vm, err := NewLuaVmWithPrelude(cfg)
+	if err != nil {
+		fmt.Printf("FAIL\t%s [setup failed]\n%v\n", path, err)
+		return false
+	}
+	defer vm.Close()
+	inc := NewIncrState(vm, cfg)
+
+	tp, err := findTestFuncs(inc.Session, path)
+	if err != nil {
+		fmt.Printf("FAIL\t%s [setup failed]\n%v\n", path, err)
+		return false
+	}
+	path = tp.ImportPath
+	if len(tp.TestGoFiles)+len(tp.XTestGoFiles) == 0 {
+		fmt.Printf("?   \t%s\t[no test files]\n", path)
+		return true
+	}
+	if tp.hasTestMain {
+		fmt.Fprintf(os.Stderr, "gi test: %s: TestMain is not supported, and was not called.\n", path)
+	}
+
+	tr := &testRunner{vm: vm, inc: inc, o: o}
+	err = tr.load(tp)
+	if err != nil {
+		fmt.Printf("# %s\n%v\nFAIL\t%s [build failed]\n", path, err, path)
+		return false
+	}
+	ok, err := tr.runAll(tp)
+	if err != nil {
+		fmt.Printf("%v\n", err)
+		ok = false
+	}
+	elapsed := time.Since(start).Seconds()
+	if !ok {
+		fmt.Printf("FAIL\nFAIL\t%s\t%.3fs\n", path, elapsed)
+		return false
+	}
+	if o.verbose || tr.benched {
+		fmt.Printf("PASS\n")
+	}
+	fmt.Printf("ok  \t%s\t%.3fs\n", path, elapsed)
+	return true
+}
+
+// findTestFuncs locates the package at path, and the
+// test functions in its _test.go files, in source order.
+func findTestFuncs(s *Session, path string) (*testPkg, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := s.importWithSrcDir(path, cwd, 0, s.InstallSuffix(), s.options.BuildTags, 0)
+	if err != nil {
+		return nil, err
+	}
+	tp := &testPkg{PackageData: pkg}
+
+	fset := gotoken.NewFileSet()
+	scan := func(files []string, local string) error {
+		var parsed []*goast.File
+		for _, name := range files {
+			f, err := goparser.ParseFile(fset, filepath.Join(pkg.Dir, name), nil, goparser.ParseComments)
+			if err != nil {
+				return err
+			}
+			parsed = append(parsed, f)
+			for _, d := range f.Decls {
+				fn, ok := d.(*goast.FuncDecl)
+				if !ok || fn.Recv != nil || fn.Type.Params.NumFields() != 1 {
+					continue
+				}
+				name := fn.Name.Name
+				switch {
+				case name == "TestMain":
+					tp.hasTestMain = true
+				case isTestName(name, "Test"):
+					tp.tests = append(tp.tests, testFunc{Name: name, Pkg: local})
+				case isTestName(name, "Benchmark"):
+					tp.benchmarks = append(tp.benchmarks, testFunc{Name: name, Pkg: local})
+				}
+			}
+		}
+		for _, ex := range godoc.Examples(parsed...) {
+			tp.examples = append(tp.examples, testFunc{
+				Name:      "Example" + ex.Name,
+				Pkg:       local,
+				Output:    ex.Output,
+				HasOutput: ex.Output != "" || ex.EmptyOutput,
+				Unordered: ex.Unordered,
+			})
+		}
+		return nil
+	}
+	if err := scan(pkg.TestGoFiles, pkg.Name); err != nil {
+		return nil, err
+	}
+	if err := scan(pkg.XTestGoFiles, pkg.Name+"_test"); err != nil {
+		return nil, err
+	}
+	return tp, nil
+}
+
+// isTestName reports if name is prefix, or prefix
+// followed by anything but a lower case letter, as
+// `go test` requires of TestXxx and BenchmarkXxx.
+func isTestName(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	c := name[len(prefix)]
+	return !('a' <= c && c <= 'z')
+}
+
+// testImports merges the imports of a package with
+// those of its tests, with "testing" replaced by
+// GijitTestingPath, as parseAndAugment does in the
+// source.
+func testImports(imports []string, pos map[string][]token.Position, more ...[]string) ([]string, map[string][]token.Position) {
+	seen := make(map[string]bool)
+	var res []string
+	resPos := make(map[string][]token.Position)
+	for _, list := range append([][]string{imports}, more...) {
+		for _, path := range list {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			to := path
+			if path == "testing" {
+				to = GijitTestingPath
+			}
+			if _, dup := resPos[to]; !dup {
+				res = append(res, to)
+			}
+			resPos[to] = append(resPos[to], pos[path]...)
+		}
+	}
+	return res, resPos
+}
+
+// testRunner runs the snippets of the test main.
+type testRunner struct {
+	vm      *LuaVm
+	inc     *IncrState
+	o       *goTestOpts
+	benched bool
+
+	// results of the last snippet, from __gitest_report.
+	reported bool
+	failed   bool
+	skipped  bool
+	ns       float64
+	running  string
+	output   string
+}
+
+// load builds the package with its internal tests,
+// and the external (package x_test) tests if any,
+// then imports them, and gijit's testing, at top level.
+func (tr *testRunner) load(tp *testPkg) error {
+	bp := *tp.Package
+	bp.GoFiles = append(append([]string(nil), tp.GoFiles...), tp.TestGoFiles...)
+	allPos := make(map[string][]token.Position)
+	for _, m := range []map[string][]token.Position{tp.ImportPos, tp.TestImportPos} {
+		for k, v := range m {
+			allPos[k] = append(allPos[k], v...)
+		}
+	}
+	bp.Imports, bp.ImportPos = testImports(tp.Imports, allPos, tp.TestImports)
+	err := tr.build(&PackageData{Package: &bp, JSFiles: tp.JSFiles, IsTest: true})
+	if err != nil {
+		return err
+	}
+	imports := fmt.Sprintf("import %s %q\n", tp.Name, tp.ImportPath)
+
+	if len(tp.XTestGoFiles) > 0 {
+		xp := &build.Package{
+			Dir:        tp.Dir,
+			Name:       tp.Name + "_test",
+			ImportPath: tp.ImportPath + "_test",
+			PkgObj:     tp.PkgObj,
+			GoFiles:    tp.XTestGoFiles,
+		}
+		xp.Imports, xp.ImportPos = testImports(tp.XTestImports, tp.XTestImportPos)
+		err = tr.build(&PackageData{Package: xp, IsTest: true})
+		if err != nil {
+			return err
+		}
+		imports += fmt.Sprintf("import %s %q\n", xp.Name, xp.ImportPath)
+	}
+	imports += fmt.Sprintf("import testing %q\n", GijitTestingPath)
+
+	report := func(failed, skipped bool, ns float64, running, output string) {
+		tr.reported = true
+		tr.failed, tr.skipped, tr.ns = failed, skipped, ns
+		tr.running, tr.output = running, output
+	}
+	write := func(s string) {
+		os.Stdout.WriteString(s)
+	}
+	tk := tr.vm.goro.newTicket("", false)
+	tk.regmap["__gitest_report"] = report
+	tk.regmap["__gitest_write"] = write
+	err = tk.Do()
+	if err != nil {
+		return err
+	}
+	// not on the eval coroutine, which would only print
+	// the errors from the packages' init.
+	return tr.eval(imports, "", false)
+}
+
+// build compiles pkg into the session's archives, where
+// a later import of its path will find it.
+func (tr *testRunner) build(pkg *PackageData) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	s := tr.inc.Session
+	archive, err := s.BuildPackage(pkg, 0)
+	if err != nil {
+		return err
+	}
+	archive.Pkg.ClientExtra = archive
+	s.Archives[pkg.ImportPath] = archive
+	tr.inc.CurPkg.importContext.Packages[pkg.ImportPath] = archive.Pkg
+	return nil
+}
+
+// eval translates and runs the Go src, then the Lua after.
+func (tr *testRunner) eval(src, after string, useEvalCoroutine bool) error {
+	translation, err := TranslateAndCatchPanic(tr.inc, []byte(src))
+	if err != nil {
+		return err
+	}
+	return LuaRun(tr.vm, translation+"\n"+after, useEvalCoroutine)
+}
+
+// evalReport runs a snippet that ends by calling
+// __gitest_report. The eval coroutine prints, rather
+// than returns, a Lua error; the report is then
+// missing, and the test is failed.
+func (tr *testRunner) evalReport(src string) error {
+	tr.reported = false
+	err := tr.eval(src, gitestReport, true)
+	if err == nil && !tr.reported {
+		tr.failed = true
+		tr.output = "    the test did not finish; see the error above.\n"
+	}
+	return err
+}
+
+// runAll runs the tests and examples that match -run,
+// then the benchmarks that match -bench, reporting
+// if all passed.
+func (tr *testRunner) runAll(tp *testPkg) (ok bool, err error) {
+	ok = true
+	o := tr.o
+	for _, t := range tp.tests {
+		if o.run != nil && !o.run.MatchString(t.Name) {
+			continue
+		}
+		pass, err := tr.runTest(t)
+		if err != nil {
+			return false, err
+		}
+		ok = ok && pass
+	}
+	for _, ex := range tp.examples {
+		if !ex.HasOutput || (o.run != nil && !o.run.MatchString(ex.Name)) {
+			continue
+		}
+		pass, err := tr.runExample(ex)
+		if err != nil {
+			return false, err
+		}
+		ok = ok && pass
+	}
+	if o.bench == nil || !ok {
+		return ok, nil
+	}
+	var benches []testFunc
+	width := 0
+	for _, b := range tp.benchmarks {
+		if o.bench.MatchString(b.Name) {
+			benches = append(benches, b)
+			if len(b.Name) > width {
+				width = len(b.Name)
+			}
+		}
+	}
+	if len(benches) > 0 {
+		fmt.Printf("goos: %s\ngoarch: %s\npkg: %s\n", runtime.GOOS, runtime.GOARCH, tp.ImportPath)
+	}
+	for _, b := range benches {
+		tr.benched = true
+		pass, err := tr.runBenchmark(b, width)
+		if err != nil {
+			return false, err
+		}
+		ok = ok && pass
+	}
+	return ok, nil
+}
+
+const gitestReport = "__gitest_report(__gitest_failed, __gitest_skipped, tonumber(__gitest_ns), __gitest_running, __gitest_output)"
+
+func (tr *testRunner) runTest(t testFunc) (bool, error) {
+	if tr.o.verbose {
+		fmt.Printf("=== RUN   %s\n", t.Name)
+	}
+	src := fmt.Sprintf("__gitest_ns := 0\n__gitest_failed, __gitest_skipped, __gitest_running, __gitest_output := testing.RunTest(%q, %s.%s, %v)\n",
+		t.Name, t.Pkg, t.Name, tr.o.verbose)
+	start := time.Now()
+	err := tr.evalReport(src)
+	d := time.Since(start)
+	if err != nil {
+		return false, fmt.Errorf("--- FAIL: %s (%.2fs)\n    %v", t.Name, d.Seconds(), err)
+	}
+	status := "PASS"
+	switch {
+	case tr.failed:
+		status = "FAIL"
+	case tr.skipped:
+		status = "SKIP"
+	}
+	fmt.Print(tr.running)
+	if tr.failed || tr.o.verbose {
+		fmt.Printf("--- %s: %s (%.2fs)\n%s", status, t.Name, d.Seconds(), tr.output)
+	}
+	return !tr.failed, nil
+}
+
+// runExample runs ex, comparing what it prints with its
+// Output comment. Both fmt's printing, to os.Stdout,
+// and the print and println builtins, which go to
+// Lua's print, are captured; the latter are written
+// as println would write them.
+func (tr *testRunner) runExample(ex testFunc) (bool, error) {
+	if tr.o.verbose {
+		fmt.Printf("=== RUN   %s\n", ex.Name)
+	}
+	err := LuaRun(tr.vm, `__gitest_print = print
+print = function(...)
+   local n = select("#", ...)
+   local t = {}
+   for i = 1, n do
+      local v = select(i, ...)
+      t[i] = tostring(v)
+      if type(v) == "cdata" then
+         -- 4LL prints as 4, as in Go.
+         t[i] = string.gsub(t[i], "U?LL$", "")
+      end
+   end
+   __gitest_write(table.concat(t, " ").."\n")
+end`, true)
+	if err != nil {
+		return false, err
+	}
+	start := time.Now()
+	got, err := captureStdout(func() error {
+		return tr.eval(fmt.Sprintf("%s.%s()\n", ex.Pkg, ex.Name), "", true)
+	})
+	d := time.Since(start)
+	if rerr := LuaRun(tr.vm, "print = __gitest_print", true); err == nil {
+		err = rerr
+	}
+
+	fail := ""
+	if err != nil {
+		fail = fmt.Sprintf("%v\n", err)
+	} else {
+		got, want := strings.TrimSpace(got), strings.TrimSpace(ex.Output)
+		if ex.Unordered {
+			got, want = sortLines(got), sortLines(want)
+		}
+		if got != want {
+			fail = fmt.Sprintf("got:\n%s\nwant:\n%s\n", got, want)
+		}
+	}
+	if fail != "" {
+		fmt.Printf("--- FAIL: %s (%.2fs)\n%s", ex.Name, d.Seconds(), fail)
+		return false, nil
+	}
+	if tr.o.verbose {
+		fmt.Printf("--- PASS: %s (%.2fs)\n", ex.Name, d.Seconds())
+	}
+	return true, nil
+}
+
+// captureStdout returns what f writes to os.Stdout.
+func captureStdout(f func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", err
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	got := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		r.Close()
+		got <- buf.String()
+	}()
+	err = f()
+	os.Stdout = saved
+	w.Close()
+	return <-got, err
+}
+
+func sortLines(s string) string {
+	lines := strings.Split(s, "\n")
+	sort.Strings(lines)
+	return strings.Join(lines, "\n")
+}
+
+// runBenchmark sizes b.N as testing.B does, growing
+// it until a run takes -benchtime, then reports
+// the last run.
+func (tr *testRunner) runBenchmark(b testFunc, width int) (bool, error) {
+	target := float64(tr.o.benchtime)
+	n := 1
+	for {
+		src := fmt.Sprintf("__gitest_running := \"\"\n__gitest_failed, __gitest_skipped, __gitest_ns, __gitest_output := testing.RunBenchmark(%q, %s.%s, %d)\n",
+			b.Name, b.Pkg, b.Name, n)
+		err := tr.evalReport(src)
+		if err != nil {
+			return false, fmt.Errorf("--- FAIL: %s\n    %v", b.Name, err)
+		}
+		if tr.failed || tr.skipped || tr.ns >= target || n >= 1e9 {
+			break
+		}
+		// aim 20% past the target, but grow
+		// by no more than 100x at a time.
+		next := n * 100
+		if tr.ns > 0 {
+			if guess := int(float64(n) * target * 1.2 / tr.ns); guess < next {
+				next = guess
+			}
+		}
+		if next <= n {
+			next = n + 1
+		}
+		if next > 1e9 {
+			next = 1e9
+		}
+		n = next
+	}
+	switch {
+	case tr.failed:
+		fmt.Printf("--- FAIL: %s\n%s", b.Name, tr.output)
+		return false, nil
+	case tr.skipped:
+		if tr.o.verbose {
+			fmt.Printf("--- SKIP: %s\n%s", b.Name, tr.output)
+		}
+		return true, nil
+	}
+	fmt.Printf("%-*s\t%10d\t%10s ns/op\n", width, b.Name, n, fmtNs(tr.ns/float64(n)))
+	if tr.output != "" {
+		fmt.Printf("--- BENCH: %s\n%s", b.Name, tr.output)
+	}
+	return true, nil
+}
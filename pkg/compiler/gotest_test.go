@@ -0,0 +1,38 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2030GiTestRunsPackageTests(t *testing.T) {
+
+	cv.Convey("gi test runs a package's Test, Benchmark, and Example functions under the Lua runtime, reporting in go test's format, with -run and -v.", t, func() {
+		const path = "github.com/gijit/gi/pkg/compiler/spkg_tst6"
+		rc := 0
+		out, err := captureStdout(func() error {
+			rc = GoTest(nil, []string{"-v", path})
+			return nil
+		})
+		panicOn(err)
+		cv.So(rc, cv.ShouldEqual, 0)
+		cv.So(out, cv.ShouldContainSubstring, "=== RUN   TestAdd\n--- PASS: TestAdd (")
+		cv.So(out, cv.ShouldContainSubstring, "    add ok\n")
+		cv.So(out, cv.ShouldContainSubstring, "=== RUN   TestSub/one_two\n")
+		cv.So(out, cv.ShouldContainSubstring, "    --- SKIP: TestSub/skips (")
+		cv.So(out, cv.ShouldContainSubstring, "--- PASS: ExampleAdd (")
+		cv.So(out, cv.ShouldContainSubstring, "ok  \t"+path+"\t")
+
+		out, err = captureStdout(func() error {
+			rc = GoTest(nil, []string{"-run", "Add$", "-bench", ".", "-benchtime", "10ms", path})
+			return nil
+		})
+		panicOn(err)
+		cv.So(rc, cv.ShouldEqual, 0)
+		cv.So(out, cv.ShouldNotContainSubstring, "TestSub")
+		cv.So(out, cv.ShouldNotContainSubstring, "=== RUN")
+		cv.So(out, cv.ShouldContainSubstring, "BenchmarkAdd\t")
+		cv.So(out, cv.ShouldContainSubstring, " ns/op\n")
+	})
+}
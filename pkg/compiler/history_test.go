@@ -0,0 +1,96 @@
+package compiler
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2140HistoryKeepsWholeEntries(t *testing.T) {
+
+	cv.Convey("history keeps each multi-line entry whole, with when, where and whether it failed, per project; :h /re searches it, and replay ranges count entries, not lines.", t, func() {
+
+		origHome := os.Getenv("HOME")
+		origDir, err := os.Getwd()
+		panicOn(err)
+		tempdir, err := ioutil.TempDir("", "gijit-test")
+		panicOn(err)
+		defer os.RemoveAll(tempdir)
+		os.Setenv("HOME", tempdir)
+		defer os.Setenv("HOME", origHome)
+
+		proj := filepath.Join(tempdir, "proj")
+		sub := filepath.Join(proj, "sub")
+		panicOn(os.MkdirAll(sub, 0755))
+		panicOn(ioutil.WriteFile(filepath.Join(proj, "go.mod"), []byte("module proj\n"), 0644))
+		panicOn(os.Chdir(sub))
+		defer os.Chdir(origDir)
+
+		histFn := histFileFor(tempdir, sub)
+		cv.So(filepath.Dir(histFn), cv.ShouldEqual, filepath.Join(tempdir, ".gijit", "hist"))
+		cv.So(histFileFor(tempdir, tempdir), cv.ShouldEqual, filepath.Join(tempdir, ".gijit.hist"))
+
+		myflags := flag.NewFlagSet("gi", flag.ExitOnError)
+		cfg := NewGIConfig()
+		cfg.DefineFlags(myflags)
+		panicOn(myflags.Parse([]string{"-q", "-no-liner"}))
+		panicOn(cfg.ValidateConfig())
+		r := NewRepl(cfg)
+		cv.So(r.histFn, cv.ShouldEqual, histFn)
+
+		add := "func add(a, b int) int {\n\treturn a + b\n}"
+		panicOn(r.Eval(add))
+		panicOn(r.Eval("x := add(1, 2)"))
+		cv.So(r.Eval("y := undefinedThing"), cv.ShouldNotBeNil)
+		// a panic at run time is caught inside the
+		// eval, yet the entry failed all the same.
+		panicOn(r.Eval(`panic("boom")`))
+		r.lvm.Close()
+
+		cv.So(len(r.history), cv.ShouldEqual, 4)
+		cv.So(r.history[0].Src, cv.ShouldEqual, add)
+		cv.So(r.history[0].OK, cv.ShouldBeTrue)
+		cv.So(r.history[0].Dir, cv.ShouldEqual, sub)
+		cv.So(r.history[0].When.IsZero(), cv.ShouldBeFalse)
+		cv.So(r.history[2].OK, cv.ShouldBeFalse)
+		cv.So(r.history[3].OK, cv.ShouldBeFalse)
+
+		// the newlines survive the file.
+		back, err := readHistory(histFn)
+		panicOn(err)
+		cv.So(len(back), cv.ShouldEqual, 4)
+		cv.So(back[0].Src, cv.ShouldEqual, add)
+		cv.So(back[2].OK, cv.ShouldBeFalse)
+		cv.So(back[3].OK, cv.ShouldBeFalse)
+
+		// ranges are of entries.
+		num, err := getHistoryRange("1-2", back)
+		panicOn(err)
+		cv.So(historySrc(back, num[0], num[1]), cv.ShouldEqual, add+"\nx := add(1, 2)\n")
+		_, err = getHistoryRange("5", back)
+		cv.So(err, cv.ShouldNotBeNil)
+
+		found, err := searchHistory(back, `return a \+`)
+		panicOn(err)
+		cv.So(found, cv.ShouldStartWith, "001: [")
+		cv.So(found, cv.ShouldContainSubstring, sub+"] func add(a, b int) int {\n     \treturn a + b\n     }\n")
+		cv.So(strings.Count(found, "\n"), cv.ShouldEqual, 3)
+		found, err = searchHistory(back, "undefined")
+		panicOn(err)
+		cv.So(found, cv.ShouldEndWith, "y := undefinedThing   // failed\n")
+
+		// lines in the older format read back as entries.
+		old := filepath.Join(tempdir, ".gijit.hist")
+		panicOn(ioutil.WriteFile(old, []byte("a := 1\nb := a * 2\n"), 0600))
+		back, err = readHistory(old)
+		panicOn(err)
+		cv.So(len(back), cv.ShouldEqual, 2)
+		cv.So(back[1].Src, cv.ShouldEqual, "b := a * 2")
+		cv.So(back[1].OK, cv.ShouldBeTrue)
+	})
+}
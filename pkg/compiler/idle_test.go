@@ -0,0 +1,61 @@
+package compiler
+
+import (
+	"testing"
+	"time"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2150IdleRunsBackgroundGoroutines(t *testing.T) {
+
+	cv.Convey("while the prompt waits, idle resumes goroutines that can make progress, such as those whose timeouts have come, and returns what they printed instead of writing it.", t, func() {
+
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+
+		// nothing to run yet.
+		out, err := vm.goro.idle()
+		panicOn(err)
+		cv.So(out, cv.ShouldEqual, "")
+
+		// a goroutine waiting 20 msec on a channel
+		// no one sends on, then printing.
+		panicOn(LuaRun(vm, `
+__idleTestPrint = print
+__task.spawn(function()
+   local c = __task.Channel:new(0)
+   print("waiting")
+   c:recv(20000000LL)
+   print("woke", 1)
+   io.write("io", "\n")
+   __gijit_fmtPrintln({__type__.string}, "fmt")
+end, {})
+`, false))
+
+		out, err = vm.goro.idle()
+		panicOn(err)
+		cv.So(out, cv.ShouldEqual, "waiting\n")
+
+		t0 := time.Now()
+		got := ""
+		for time.Since(t0) < 5*time.Second {
+			out, err = vm.goro.idle()
+			panicOn(err)
+			got += out
+			if got != "" {
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		cv.So(time.Since(t0), cv.ShouldBeGreaterThan, 15*time.Millisecond)
+		cv.So(got, cv.ShouldEqual, "woke\t1\nio\nfmt\n")
+
+		// print is itself again, and nothing is left to run.
+		panicOn(LuaRun(vm, `assert(print == __idleTestPrint)`, false))
+		out, err = vm.goro.idle()
+		panicOn(err)
+		cv.So(out, cv.ShouldEqual, "")
+	})
+}
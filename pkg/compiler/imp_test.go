@@ -159,7 +159,7 @@ func Test061CallFmtSummerWithDots(t *testing.T) {
 					cv.So(string(translation), matchesLuaSrc, `
 			  	__type__.anon_sliceType = __sliceType(__type__.int); -- IMMEDIATE anon type printing.
 			  	b = __type__.anon_sliceType({[0]=8LL, 9LL});
-			  	a = gitesting.SummerAny(__lazy_ellipsis(b));
+			  	a = __packages["gitesting"].SummerAny(__lazy_ellipsis(b));
 			`)
 		*/
 		LoadAndRunTestHelper(t, vm, translation)
@@ -187,11 +187,11 @@ func Test062SprintfOneSlice(t *testing.T) {
 		/*
 					cv.So(string(translation), matchesLuaSrc, `
 			__type__.anon_sliceType = __sliceType(__type__.int); -- IMMEDIATE anon type printing.
-			  	a = fmt.Sprintf("yip %#v eee\n", __type__.anon_sliceType({[0]=4LL, 5LL, 6LL}));`)
+			  	a = __packages["fmt"].Sprintf("yip %#v eee\n", __type__.anon_sliceType({[0]=4LL, 5LL, 6LL}));`)
 		*/
 		LoadAndRunTestHelper(t, vm, translation)
 
-		LuaMustString(vm, "a", "yip []interface {}{4, 5, 6} eee\n")
+		LuaMustString(vm, "a", "yip []int{4, 5, 6} eee\n")
 	})
 }
 
@@ -213,7 +213,7 @@ func Test063SprintfOneSlice(t *testing.T) {
 		/*
 					cv.So(string(translation), matchesLuaSrc, `
 			  	__type__.anon_sliceType = __sliceType(__type__.emptyInterface);
-			     a = fmt.Sprintf("yee %v %v %v haw\n", __lazy_ellipsis(__type__.anon_sliceType({[0]=4LL, 5LL, 6LL})));
+			     a = __packages["fmt"].Sprintf("yee %v %v %v haw\n", __lazy_ellipsis(__type__.anon_sliceType({[0]=4LL, 5LL, 6LL})));
 						`)
 		*/
 
@@ -241,7 +241,7 @@ func Test064SprintfOneSlice(t *testing.T) {
 		/*
 					cv.So(string(translation), matchesLuaSrc, `
 			  	     __type__.anon_sliceType = __sliceType(__type__.int);
-			      	 a = fmt.Sprintf("%v %v\n", "hello", __type__.anon_sliceType({[0]=4LL, 5LL, 6LL}));
+			      	 a = __packages["fmt"].Sprintf("%v %v\n", "hello", __type__.anon_sliceType({[0]=4LL, 5LL, 6LL}));
 			        `)
 		*/
 		LoadAndRunTestHelper(t, vm, translation)
@@ -306,7 +306,7 @@ func Test087ShadowRegexp(t *testing.T) {
 		/*cv.So(string(translation), matchesLuaSrc,
 					`
 			a = regexp.MustCompile("llo");
-		    __type__.anon_ptrType = __ptrType(__type__.regexp.Regexp);
+		    __type__.anon_ptrType = __ptrType(__type__["regexp"].Regexp);
 			loc = a.FindStringIndex("hello");
 			lenloc =  #loc;
 			a0 = __gi_GetRangeCheck(loc, 0);
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"runtime/debug"
 	"strings"
+	"time"
 
 	"github.com/gijit/gi/pkg/importer"
 	"github.com/gijit/gi/pkg/token"
@@ -18,7 +19,9 @@ import (
 	// shadow_ imports: available inside the REPL
 
 	shadow_bytes "github.com/gijit/gi/pkg/compiler/shadow/bytes"
+	shadow_context "github.com/gijit/gi/pkg/compiler/shadow/context"
 	shadow_encoding_binary "github.com/gijit/gi/pkg/compiler/shadow/encoding/binary"
+	shadow_encoding_json "github.com/gijit/gi/pkg/compiler/shadow/encoding/json"
 	shadow_errors "github.com/gijit/gi/pkg/compiler/shadow/errors"
 	shadow_fmt "github.com/gijit/gi/pkg/compiler/shadow/fmt"
 	shadow_io "github.com/gijit/gi/pkg/compiler/shadow/io"
@@ -33,7 +36,6 @@ import (
 	shadow_strconv "github.com/gijit/gi/pkg/compiler/shadow/strconv"
 	shadow_strings "github.com/gijit/gi/pkg/compiler/shadow/strings"
 	shadow_sync "github.com/gijit/gi/pkg/compiler/shadow/sync"
-	shadow_sync_atomic "github.com/gijit/gi/pkg/compiler/shadow/sync/atomic"
 	shadow_time "github.com/gijit/gi/pkg/compiler/shadow/time"
 
 	// gonum
@@ -96,9 +98,22 @@ func registerLuarReqs(vm *golua.State) {
 
 	luar.Register(vm, "", luar.Map{
 		"__refSelCaseVal": refSelCaseVal,
+
+		// for the chan.lua scheduler, to wait on timeouts.
+		"__gijit_sleepNs": func(ns float64) {
+			time.Sleep(time.Duration(ns))
+		},
+
+		// for __task.set_preempt, to set the count hook
+		// that preempts CPU-bound goroutines.
+		"__gijit_setPreempt": func(budget int) {
+			vm.SetPreempt(budget)
+		},
 	})
 
 	registerBasicReflectTypes(vm)
+	registerJSONHelpers(vm)
+	registerFmtHelpers(vm)
 
 }
 
@@ -108,7 +123,7 @@ func (ic *IncrState) EnableImportsFromLua() {
 
 	// running an import means calling the
 	// package's __init() function, and entering
-	// any Luar bindings into the global namespace.
+	// any Luar bindings into __packages.
 	goRunImportFromLua := func(path string) {
 		// __go_run_import calls here.
 		ic.RunTimeGiImportFunc(path, "", 0)
@@ -157,7 +172,7 @@ func (ic *IncrState) EnableImportsFromLua() {
 /////////////////
 /////       //////      Running an import means calling the
 /////        //////  	package's __init() function, and entering
-////          //////    any Luar bindings into the global namespace.
+////          //////    any Luar bindings into __packages.
 ////           //////
 func (ic *IncrState) RunTimeGiImportFunc(path, pkgDir string, depth int) error {
 	pp("RunTimeGiImportFunc called with path = '%s'...", path)
@@ -180,130 +195,167 @@ func (ic *IncrState) RunTimeGiImportFunc(path, pkgDir string, depth int) error {
 		if ic.cfg.IsTestMode {
 			//fmt.Print("\n registering gitesting.SumArrayInt64! \n")
 
-			t0.regns = "gitesting"
-			t0.regmap["SumArrayInt64"] = sumArrayInt64
-			t0.regmap["Summer"] = Summer
-			t0.regmap["SummerAny"] = SummerAny
-			t0.regmap["Incr"] = Incr
+			t0.regpkgs[path] = luar.Map{
+				"SumArrayInt64": sumArrayInt64,
+				"Summer":        Summer,
+				"SummerAny":     SummerAny,
+				"Incr":          Incr,
+			}
 			err := t0.Do()
 			panicOn(err)
 			return err
 		}
 
 	case "bytes":
-		t0.regmap["bytes"] = shadow_bytes.Pkg
+		t0.regpkgs[path] = shadow_bytes.Pkg
 		t0.regmap["__ctor__bytes"] = shadow_bytes.Ctor
 		t0.run = append(t0.run, shadow_bytes.InitLua()...)
 
+	case "context":
+		// prelude/context.lua gives contexts whose Done
+		// channels the chan.lua scheduler can wait on;
+		// Go's are closed where no goroutine would see.
+		t0.regmap["__gijit_context"] = shadow_context.Pkg
+		t0.regmap["__gijit_untilNs"] = func(t time.Time) float64 {
+			return float64(time.Until(t))
+		}
+		t0.regmap["__gijit_timeAfterNs"] = func(ns float64) time.Time {
+			return time.Now().Add(time.Duration(ns))
+		}
+		t0.regmap["__gijit_zeroTime"] = time.Time{}
+		// a Context's Deadline gives a time.Time, so
+		// code using one needs time's types.
+		t0.regpkgs["time"] = shadow_time.Pkg
+		t0.regmap["__ctor__time"] = shadow_time.Ctor
+		t0.run = append(t0.run, "\nif __type__[\"time\"] == nil then\n"...)
+		t0.run = append(t0.run, shadow_time.InitLua()...)
+		t0.run = append(t0.run, "\nend\n"...)
+		t0.run = append(t0.run, shadow_context.InitLua()...)
+		t0.run = append(t0.run, "\n__gijit_installContext();\n"...)
+
 	case "encoding/binary":
-		t0.regmap["binary"] = shadow_encoding_binary.Pkg
+		t0.regpkgs[path] = shadow_encoding_binary.Pkg
 		t0.regmap["__ctor__binary"] = shadow_encoding_binary.Ctor
 		t0.run = append(t0.run, shadow_encoding_binary.InitLua()...)
 
+	case "encoding/json":
+		t0.regpkgs[path] = shadow_encoding_json.Pkg
+		t0.regmap["__ctor__json"] = shadow_encoding_json.Ctor
+		t0.run = append(t0.run, shadow_encoding_json.InitLua()...)
+
 	case "errors":
-		t0.regmap["errors"] = shadow_errors.Pkg
+		t0.regpkgs[path] = shadow_errors.Pkg
 		t0.regmap["__ctor__errors"] = shadow_errors.Ctor
 		t0.run = append(t0.run, shadow_errors.InitLua()...)
 
 	case "fmt":
 		pp("RunTimeGiImportFunc sees 'fmt', known and shadowed.")
-		t0.regmap["fmt"] = shadow_fmt.Pkg
+		t0.regpkgs[path] = shadow_fmt.Pkg
 		t0.regmap["__ctor__fmt"] = shadow_fmt.Ctor
 		t0.run = append(t0.run, shadow_fmt.InitLua()...)
 	case "io":
-		t0.regmap["io"] = shadow_io.Pkg
+		t0.regpkgs[path] = shadow_io.Pkg
 		t0.regmap["__ctor__io"] = shadow_io.Ctor
 		t0.run = append(t0.run, shadow_io.InitLua()...)
 	case "math":
-		t0.regmap["math"] = shadow_math.Pkg
+		t0.regpkgs[path] = shadow_math.Pkg
 		t0.regmap["__ctor__math"] = shadow_math.Ctor
 		t0.run = append(t0.run, shadow_math.InitLua()...)
 	case "math/rand":
-		t0.regmap["rand"] = shadow_math_rand.Pkg
+		t0.regpkgs[path] = shadow_math_rand.Pkg
 		t0.regmap["__ctor__math_rand"] = shadow_math_rand.Ctor
 		t0.run = append(t0.run, shadow_math_rand.InitLua()...)
 	case "os":
-		t0.regmap["os"] = shadow_os.Pkg
+		t0.regpkgs[path] = shadow_os.Pkg
 		t0.regmap["__ctor__os"] = shadow_os.Ctor
 		t0.run = append(t0.run, shadow_os.InitLua()...)
 
 	case "reflect":
-		t0.regmap["reflect"] = shadow_reflect.Pkg
+		t0.regpkgs[path] = shadow_reflect.Pkg
 		t0.regmap["__ctor__reflect"] = shadow_reflect.Ctor
 		t0.run = append(t0.run, shadow_reflect.InitLua()...)
 
 	case "regexp":
-		t0.regmap["regexp"] = shadow_regexp.Pkg
+		t0.regpkgs[path] = shadow_regexp.Pkg
 		t0.regmap["__ctor__regexp"] = shadow_regexp.Ctor
 		t0.run = append(t0.run, shadow_regexp.InitLua()...)
 
 	case "sync":
-		t0.regmap["sync"] = shadow_sync.Pkg
+		// Go's Mutex, WaitGroup and the like would block
+		// the one OS thread that all goroutines run on;
+		// prelude/sync.lua replaces them with ones that
+		// park in the chan.lua scheduler. Map and Pool,
+		// which never block, stay Go's.
 		t0.regmap["__ctor__sync"] = shadow_sync.Ctor
 		t0.run = append(t0.run, shadow_sync.InitLua()...)
+		t0.run = append(t0.run, "\n__gijit_installSync();\n"...)
 
 	case "sync/atomic":
-		t0.regmap["atomic"] = shadow_sync_atomic.Pkg
-		t0.regmap["__ctor__atomic"] = shadow_sync_atomic.Ctor
-		t0.run = append(t0.run, shadow_sync_atomic.InitLua()...)
+		// all in prelude/sync.lua: the Go functions
+		// cannot reach through gijit pointers.
+		t0.run = append(t0.run, "\n__gijit_installAtomic();\n"...)
 
 	case "time":
-		t0.regmap["time"] = shadow_time.Pkg
+		t0.regpkgs[path] = shadow_time.Pkg
 		t0.regmap["__ctor__time"] = shadow_time.Ctor
 		t0.run = append(t0.run, shadow_time.InitLua()...)
 
 	case "runtime":
-		t0.regmap["runtime"] = shadow_runtime.Pkg
+		t0.regpkgs[path] = shadow_runtime.Pkg
 		t0.regmap["__ctor__runtime"] = shadow_runtime.Ctor
 		t0.run = append(t0.run, shadow_runtime.InitLua()...)
+		// runtime.Error and *runtime.TypeAssertionError
+		// are the types of gijit's runtime panics; see
+		// "runtime errors" in prelude/tsys.lua.
+		t0.run = append(t0.run, "\n__gijit_installRuntime();\n"...)
 
 	case "runtime/debug":
-		t0.regmap["debug"] = shadow_runtime_debug.Pkg
+		t0.regpkgs[path] = shadow_runtime_debug.Pkg
 		t0.regmap["__ctor__debug"] = shadow_runtime_debug.Ctor
 		t0.run = append(t0.run, shadow_runtime_debug.InitLua()...)
 
 	case "strconv":
-		t0.regmap["strconv"] = shadow_strconv.Pkg
+		t0.regpkgs[path] = shadow_strconv.Pkg
 		t0.regmap["__ctor__strconv"] = shadow_strconv.Ctor
 		t0.run = append(t0.run, shadow_strconv.InitLua()...)
 
 	case "strings":
-		t0.regmap["strings"] = shadow_strings.Pkg
+		t0.regpkgs[path] = shadow_strings.Pkg
 		t0.regmap["__ctor__strings"] = shadow_strings.Ctor
 		t0.run = append(t0.run, shadow_strings.InitLua()...)
 
 	case "io/ioutil":
-		t0.regmap["ioutil"] = shadow_io_ioutil.Pkg
+		t0.regpkgs[path] = shadow_io_ioutil.Pkg
 		t0.regmap["__ctor__ioutil"] = shadow_io_ioutil.Ctor
 		t0.run = append(t0.run, shadow_io_ioutil.InitLua()...)
 
 		// gonum:
 	case "gonum.org/v1/gonum/blas":
-		t0.regmap["blas"] = shadow_blas.Pkg
+		t0.regpkgs[path] = shadow_blas.Pkg
 	case "gonum.org/v1/gonum/fd":
-		t0.regmap["fd"] = shadow_fd.Pkg
+		t0.regpkgs[path] = shadow_fd.Pkg
 	case "gonum.org/v1/gonum/floats":
-		t0.regmap["floats"] = shadow_floats.Pkg
+		t0.regpkgs[path] = shadow_floats.Pkg
 	case "gonum.org/v1/gonum/graph":
-		t0.regmap["graph"] = shadow_graph.Pkg
+		t0.regpkgs[path] = shadow_graph.Pkg
 	case "gonum.org/v1/gonum/integrate":
-		t0.regmap["integrate"] = shadow_integrate.Pkg
+		t0.regpkgs[path] = shadow_integrate.Pkg
 	case "gonum.org/v1/gonum/lapack":
-		t0.regmap["lapack"] = shadow_lapack.Pkg
+		t0.regpkgs[path] = shadow_lapack.Pkg
 	case "gonum.org/v1/gonum/mat":
-		t0.regmap["mat"] = shadow_mat.Pkg
+		t0.regpkgs[path] = shadow_mat.Pkg
 	case "gonum.org/v1/gonum/optimize":
-		t0.regmap["optimize"] = shadow_optimize.Pkg
+		t0.regpkgs[path] = shadow_optimize.Pkg
 	case "gonum.org/v1/gonum/stat":
-		t0.regmap["stat"] = shadow_stat.Pkg
+		t0.regpkgs[path] = shadow_stat.Pkg
 	case "gonum.org/v1/gonum/unit":
-		t0.regmap["unit"] = shadow_unit.Pkg
+		t0.regpkgs[path] = shadow_unit.Pkg
 
 	default:
 		// source import
 		srcImport = true
 		// don't need to compile again, just call pkg.__init()
-		t0.run = []byte(fmt.Sprintf("%s.__init();", omitAnyShadowPathPrefix(path, true)))
+		t0.run = []byte(fmt.Sprintf("%s.__init();", pkgVarName(path)))
 	}
 	if !srcImport {
 		binaryPackage[path] = true
@@ -336,7 +388,7 @@ func (ic *IncrState) CompileTimeGiImportFunc(path, pkgDir string, depth int) (*A
 	*/
 	pp("no cache hit for path '%s'", path)
 
-	code := []byte(fmt.Sprintf("\t __go_run_import(\"%[1]s\");\n\t __type__.%[2]s = __type__.%[2]s or {};\n", omitAnyShadowPathPrefix(path, false), omitAnyShadowPathPrefix(path, true)))
+	code := []byte(fmt.Sprintf("\t __go_run_import(\"%[1]s\");\n\t %[2]s = %[2]s or {};\n", omitAnyShadowPathPrefix(path, false), typePkgVarName(path)))
 	//code := []byte(fmt.Sprintf("\t __go_run_import(\"%[1]s\");\n\t __type__.%[2]s = __type__.%[2]s or {};\n\t local %[2]s = _G.%[2]s;\n", omitAnyShadowPathPrefix(path, false), omitAnyShadowPathPrefix(path, true)))
 
 	switch path {
@@ -345,7 +397,9 @@ func (ic *IncrState) CompileTimeGiImportFunc(path, pkgDir string, depth int) (*A
 
 	// gen-gijit-shadow outputs to pkg/compiler/shadow/...
 	case "bytes":
+	case "context":
 	case "encoding/binary":
+	case "encoding/json":
 	case "errors":
 	case "fmt":
 	case "io":
@@ -377,6 +431,10 @@ func (ic *IncrState) CompileTimeGiImportFunc(path, pkgDir string, depth int) (*A
 		// we need to load the type-checking info into arch.Pkg
 		// now so that the compile can complete.
 
+	case "C":
+		// cgo, by way of the FFI; see cgo.go.
+		return ic.importC()
+
 	case "gitesting":
 		// test only:
 		fmt.Printf("ic.cfg.IsTestMode = %v\n", ic.cfg.IsTestMode)
@@ -475,6 +533,27 @@ func (ic *IncrState) CompileTimeGiImportFunc(path, pkgDir string, depth int) (*A
 	return a, nil
 }
 
+// pkgVarName is the Lua expression for the package
+// imported from path. Packages live in __packages,
+// by import path, rather than in globals named for
+// the package, so math/rand and crypto/rand, or
+// runtime/debug and Lua's own debug library, never
+// collide, and a variable named time at the prompt
+// cannot clobber package time. Renamed and dot
+// imports need nothing more: the translation of m.F
+// or of a dot-imported F names the package by path.
+func pkgVarName(path string) string {
+	return fmt.Sprintf(`__packages["%s"]`, omitAnyShadowPathPrefix(path, false))
+}
+
+// typePkgVarName is the Lua expression for the table of
+// the types of the package imported from path. Like the
+// packages themselves, types are keyed by import path,
+// so that a/rand.T and b/rand.T are two types.
+func typePkgVarName(path string) string {
+	return fmt.Sprintf(`__type__["%s"]`, omitAnyShadowPathPrefix(path, false))
+}
+
 func omitAnyShadowPathPrefix(pth string, base bool) string {
 	const prefix = "github.com/gijit/gi/pkg/compiler/shadow/"
 	if strings.HasPrefix(pth, prefix) {
@@ -489,6 +568,54 @@ func omitAnyShadowPathPrefix(pth string, base bool) string {
 	return pth
 }
 
+// staticTypedCall is a call into a shadowed package
+// that gijit values must not reach as Lua values, as
+// reflect.TypeOf(x) would see only a table. We call
+// fn, in the prelude, instead, with the static type
+// of argument arg appended to the arguments, or nil
+// when that argument is an interface.
+type staticTypedCall struct {
+	fn  string
+	arg int
+}
+
+var staticTypedCalls = map[string]staticTypedCall{
+	"github.com/gijit/gi/pkg/compiler/shadow/reflect.TypeOf":  {"__gijit_reflectTypeOf", 0},
+	"github.com/gijit/gi/pkg/compiler/shadow/reflect.ValueOf": {"__gijit_reflectValueOf", 0},
+
+	"github.com/gijit/gi/pkg/compiler/shadow/encoding/json.Marshal":       {"__gijit_jsonMarshal", 0},
+	"github.com/gijit/gi/pkg/compiler/shadow/encoding/json.MarshalIndent": {"__gijit_jsonMarshalIndent", 0},
+	"github.com/gijit/gi/pkg/compiler/shadow/encoding/json.Unmarshal":     {"__gijit_jsonUnmarshal", 1},
+}
+
+// coroutineCalls are the shadowed functions that would
+// block the one OS thread that LuaJIT runs on, and all
+// the goroutines with it. We call fn, in the chan.lua
+// scheduler, instead, which parks only the caller.
+var coroutineCalls = map[string]string{
+	"github.com/gijit/gi/pkg/compiler/shadow/time.Sleep": "__task.sleep",
+	"github.com/gijit/gi/pkg/compiler/shadow/runtime.Gosched": "__task.gosched",
+}
+
+// variadicTypedCalls are the shadowed fmt functions.
+// fmt must print gijit values as compiled Go does, so
+// we call fn, in prelude/fmt.lua, instead, with a table
+// of the static types of the variadic arguments just
+// before them; an interface-typed argument has nil
+// there, and its dynamic type is used.
+var variadicTypedCalls = map[string]string{
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Errorf":   "__gijit_fmtErrorf",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Fprint":   "__gijit_fmtFprint",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Fprintf":  "__gijit_fmtFprintf",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Fprintln": "__gijit_fmtFprintln",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Print":    "__gijit_fmtPrint",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Printf":   "__gijit_fmtPrintf",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Println":  "__gijit_fmtPrintln",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Sprint":   "__gijit_fmtSprint",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Sprintf":  "__gijit_fmtSprintf",
+	"github.com/gijit/gi/pkg/compiler/shadow/fmt.Sprintln": "__gijit_fmtSprintln",
+}
+
 func getFunForSprintf(pkg *types.Package) *types.Func {
 	// func Sprintf(format string, a ...interface{}) string
 	var recv *types.Var
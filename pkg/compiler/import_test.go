@@ -243,8 +243,92 @@ b := Incr(1)
 		panicOn(err)
 		fmt.Printf("\n translation2='%s'\n", translation2)
 		cv.So(string(translation2), matchesLuaSrc,
-			`  	c = spkg_tst5.Incr(4LL);`)
+			`  	c = __packages["github.com/gijit/gi/pkg/compiler/spkg_tst5"].Incr(4LL);`)
 		LuaRunAndReport(vm, string(translation2))
 		LuaMustInt64(vm, "c", 5)
 	})
 }
+
+func Test1007RenamedDotAndBlankImports(t *testing.T) {
+
+	cv.Convey(`import m "path", import . "path" and import _ "path" work; two packages both named rand don't collide, nor do their types, and neither does a variable named after a package, since packages and their types are keyed by import path`, t, func() {
+
+		code := `
+import ra "github.com/gijit/gi/pkg/compiler/spkg_tst7/a/rand"
+import rb "github.com/gijit/gi/pkg/compiler/spkg_tst7/b/rand"
+import . "github.com/gijit/gi/pkg/compiler/spkg_tst4"
+import _ "github.com/gijit/gi/pkg/compiler/spkg_tst"
+ab := ra.Name() + rb.Name()
+world := NewR().Get1()
+`
+		code2 := `
+rand := "mine"
+ab2 := ra.Name() + rb.Name() + rand
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(code))
+		panicOn(err)
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustString(vm, "ab", "ab")
+		LuaMustString(vm, "world", "world")
+
+		translation2, err := inc.Tr([]byte(code2))
+		panicOn(err)
+		cv.So(string(translation2), matchesLuaSrc,
+			`rand = "mine";
+             ab2 = __packages["github.com/gijit/gi/pkg/compiler/spkg_tst7/a/rand"].Name() .. __packages["github.com/gijit/gi/pkg/compiler/spkg_tst7/b/rand"].Name() .. rand;`)
+		LuaRunAndReport(vm, string(translation2))
+		LuaMustString(vm, "ab2", "abmine")
+
+		// types are by import path too: a/rand.Source
+		// and b/rand.Source are two types.
+		code3 := `
+sa := ra.Source{Seed: 1}
+sb := rb.Source{Seed: 2}
+var x interface{} = sa
+_, isA := x.(ra.Source)
+_, isB := x.(rb.Source)
+who := sa.Who() + sb.Who()
+seeds := sa.Seed + sb.Seed
+`
+		translation3, err := inc.Tr([]byte(code3))
+		panicOn(err)
+		LuaRunAndReport(vm, string(translation3))
+		LuaMustBool(vm, "isA", true)
+		LuaMustBool(vm, "isB", false)
+		LuaMustString(vm, "who", "ab")
+		LuaMustInt64(vm, "seeds", 3)
+
+		// the blank import ran, and left no global.
+		panicOn(LuaRun(vm, `
+__blankImported = __packages["github.com/gijit/gi/pkg/compiler/spkg_tst"] ~= nil and spkg_tst == nil
+`, false))
+		LuaMustBool(vm, "__blankImported", true)
+	})
+}
+
+func Test1008ImportedPackageNamesUniverseAndOtherPackagesTypes(t *testing.T) {
+
+	cv.Convey(`code in an imported package refers to the basic types, error and interface{} by their universal names, and to another package's types by that package's, so its type switches match`, t, func() {
+
+		code := `
+import "github.com/gijit/gi/pkg/compiler/spkg_tst8"
+kinds := spkg_tst8.Kinds()
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(code))
+		panicOn(err)
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustString(vm, "kinds", "int string error R other")
+	})
+}
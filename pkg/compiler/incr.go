@@ -195,6 +195,8 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 			minify:       minify,
 			fileSet:      fileSet,
 			files:        files,
+			emitPos:      importContext.EmitPos,
+			emitPreempt:  importContext.EmitPreempt,
 		},
 		allVars:      make(map[string]int),
 		flowDatas:    map[*types.Label]*flowData{nil: {}},
@@ -221,7 +223,7 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 			// but now we do it here to maintain previous behavior.
 			continue
 		}
-		c.p.pkgVars[importedPkg.Path()] = c.newVariableWithLevel(importedPkg.Name(), true, false)
+		c.p.pkgVars[importedPkg.Path()] = pkgVarName(importedPkg.Path())
 		c.p.importedPackages[importedPkg.Path()] = importedPkg
 		importedPaths = append(importedPaths, importedPkg.Path())
 	}
@@ -328,8 +330,8 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 				var by bytes.Buffer
 				err = printer.Fprint(&by, fileSet, d)
 				panicOn(err)
-				funcSrcCache[d.Name.Name] = by.String()
-				pp("stored in c.p.funcSrcCache['%s'] the value '%s'", d.Name.Name, funcSrcCache[d.Name.Name])
+				funcSrcCache[funcSrcKey(d)] = by.String()
+				pp("stored in c.p.funcSrcCache['%s'] the value '%s'", funcSrcKey(d), funcSrcCache[funcSrcKey(d)])
 
 				//pp("with AST:")
 				//if verb.Verbose {
@@ -429,6 +431,13 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 					for _, spec := range d.Specs {
 						o := c.p.Defs[spec.(*ast.TypeSpec).Name].(*types.TypeName)
 						c.p.typeNames = append(c.p.typeNames, o)
+
+						// cache the source for :edit
+						var tsrc bytes.Buffer
+						one := &ast.GenDecl{Tok: token.TYPE, Specs: []ast.Spec{spec}}
+						err = printer.Fprint(&tsrc, fileSet, one)
+						panicOn(err)
+						funcSrcCache[o.Name()] = tsrc.String()
 						c.objectName(o) // register toplevel name
 
 						// jea: codegen here and now, in order.
@@ -477,8 +486,8 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 										if isShad, typShortName := isShadowStruct(typStr); isShad {
 											//vv("type '%s' is a binary struct", typStr)
 											// binary, call the ctor
-											// ex: __type__.time.Time()
-											x = "__type__." + typShortName + "()"
+											// ex: __type__["time"].Time()
+											x = typShortName + "()"
 										} else {
 											//vv("type '%s' is not binary struct", typStr)
 											x = c.translateExpr(c.zeroValue(o.Type()), nil).String()
@@ -594,11 +603,12 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 					}
 
 					n := len(c.output)
-					var ele string
+					var ele, markers string
 					if bytes.HasSuffix(c.output, []byte(";\n")) {
-						ele = string(bytes.TrimLeft(c.output[:n-2], " \t"))
+						markers, ele = trimPosMarkers(string(c.output[:n-2]))
+						ele = strings.TrimLeft(ele, " \t")
 					} else {
-						ele = string(c.output)
+						markers, ele = trimPosMarkers(string(c.output))
 					}
 					var tmp string
 					if !wrapWithPrint || strings.HasPrefix(ele, "print") {
@@ -629,7 +639,7 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 							}
 						}
 					}
-					newCodeText = append(newCodeText, []byte(tmp))
+					newCodeText = append(newCodeText, []byte(markers+tmp))
 				}
 				pp("place5, appending to newCodeText: c.output='%s'", string(c.output))
 				c.output = nil
@@ -763,10 +773,27 @@ func IncrementallyCompile(a *Archive, importPath string, files []*ast.File, file
 		a.Check = check
 		a.NewCodeText = newCodeText
 		a.FuncSrcCache = funcSrcCache
+		a.Declarations = append(a.Declarations, allDecls...)
 	}
 	return a, nil
 }
 
+// funcSrcKey is d's key in the FuncSrcCache:
+// its name, or for a method, "T.Name".
+func funcSrcKey(d *ast.FuncDecl) string {
+	if d.Recv == nil || len(d.Recv.List) == 0 {
+		return d.Name.Name
+	}
+	t := d.Recv.List[0].Type
+	if star, ok := t.(*ast.StarExpr); ok {
+		t = star.X
+	}
+	if id, ok := t.(*ast.Ident); ok {
+		return id.Name + "." + d.Name.Name
+	}
+	return d.Name.Name
+}
+
 // range over c.p.typeNames
 func (c *funcContext) namedTypes(typeDecls []*Decl, collectDependencies func(f func()) []string) ([]*Decl, []byte) {
 	var allby []byte
@@ -793,6 +820,7 @@ func (c *funcContext) oneNamedType(collectDependencies func(f func()) []string,
 	}
 	set_constructor := ""
 	constructor := ""
+	set_cstruct := ""
 	d.DceDeps = collectDependencies(func() {
 		// interface Dog getting codegen here
 		d.DeclCode = c.CatchOutput(0, func() {
@@ -872,6 +900,11 @@ func (c *funcContext) oneNamedType(collectDependencies func(f func()) []string,
 					constructor += fmt.Sprintf("\t\t\t return %s; \n\t\t end;\n", selfVar)
 				}
 				set_constructor = fmt.Sprintf("\n\t %s.__constructor = %s;\n", typeName, constructor)
+
+				// plain old data gets an FFI struct.
+				if decl, nested, ok := c.cstructDecl(t); ok {
+					set_cstruct = fmt.Sprintf("\n\t__cstructType(%s, \"%s\"%s);\n", typeName, decl, strings.Join(append([]string{""}, nested...), ", "))
+				}
 			case *types.Basic, *types.Array, *types.Slice, *types.Chan, *types.Signature, *types.Interface, *types.Pointer, *types.Map:
 				//size = sizes32.Sizeof(t)
 				size = sizes64.Sizeof(t)
@@ -974,6 +1007,9 @@ func (c *funcContext) oneNamedType(collectDependencies func(f func()) []string,
 				if set_constructor != "" {
 					c.Printf(set_constructor)
 				}
+				if set_cstruct != "" {
+					c.Printf("%s", set_cstruct)
+				}
 			})
 			// example of what is generated:
 			// Dog.init([{prop: "Write", name: "Write", pkg: "", typ: __funcType([String], [String], false)}]);
@@ -1021,5 +1057,13 @@ func (c *funcContext) getMethodDetailsSig(method *types.Func) (entry string) {
 		pkgPath = method.Pkg().Path()
 	}
 	t := method.Type().(*types.Signature)
-	return fmt.Sprintf(`{prop= "%s", __name= "%s", __pkg="%s", __typ= __funcType(%s)}`, name, method.Name(), pkgPath, c.initArgs(t))
+	// the method set of T lacks the methods with
+	// receiver *T; reflect needs to tell them apart.
+	ptrRecv := ""
+	if recv := t.Recv(); recv != nil {
+		if _, isPtr := recv.Type().(*types.Pointer); isPtr {
+			ptrRecv = ", __ptrRecv= true"
+		}
+	}
+	return fmt.Sprintf(`{prop= "%s", __name= "%s", __pkg="%s", __typ= __funcType(%s)%s}`, name, method.Name(), pkgPath, c.initArgs(t), ptrRecv)
 }
@@ -0,0 +1,94 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2110TypeMethodsAndDocIntrospection(t *testing.T) {
+
+	vm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer vm.Close()
+	inc := NewIncrState(vm, nil)
+	r := &Repl{lvm: vm, inc: inc, chunks: make(map[string]*ChunkPosMap)}
+
+	cv.Convey(":type shows an expression's type and method set without running it; :methods includes promoted methods; :doc renders package docs from source.", t, func() {
+		src := `
+type Inner struct{ Z int }
+func (i Inner) Hi() string { return "hi" }
+func (i *Inner) SetZ(z int) { i.Z = z }
+type Pt struct {
+	X, Y int
+	Inner
+}
+func (p Pt) String() string { return "pt" }
+func (p *Pt) Move(dx int) { p.X += dx }
+const Big = 1 << 10
+p := Pt{}
+`
+		_, err := inc.Tr([]byte(src))
+		panicOn(err)
+
+		s, err := r.typeOf("p")
+		panicOn(err)
+		cv.So(s, cv.ShouldEqual, `Pt
+method set of Pt:
+    func (Inner) Hi() string  // promoted from Inner
+    func (Pt) String() string
+method set of *Pt adds:
+    func (*Pt) Move(dx int)
+    func (*Inner) SetZ(z int)  // promoted from Inner
+`)
+		s, err = r.typeOf("p.X + 1")
+		panicOn(err)
+		cv.So(s, cv.ShouldEqual, "int\n")
+
+		s, err = r.typeOf("Big")
+		panicOn(err)
+		cv.So(s, cv.ShouldEqual, "untyped int = 1024\n")
+
+		s, err = r.typeOf("Inner")
+		panicOn(err)
+		cv.So(s, cv.ShouldStartWith, "type Inner\n    struct{Z int}\nmethod set of Inner:\n")
+
+		s, err = r.typeOf("p.Move")
+		panicOn(err)
+		cv.So(s, cv.ShouldEqual, "func(dx int)\n")
+
+		_, err = r.typeOf("p.Nope")
+		cv.So(err, cv.ShouldNotBeNil)
+
+		s, err = r.methodsOf("Pt")
+		panicOn(err)
+		cv.So(s, cv.ShouldEqual, `func (Inner) Hi() string  // promoted from Inner
+func (*Pt) Move(dx int)
+func (*Inner) SetZ(z int)  // promoted from Inner
+func (Pt) String() string
+`)
+		s, err = r.methodsOf("[]int")
+		panicOn(err)
+		cv.So(s, cv.ShouldEqual, "[]int has no methods\n")
+
+		path, sym := splitDocArg("net/http.Client.Do")
+		cv.So(path, cv.ShouldEqual, "net/http")
+		cv.So(sym, cv.ShouldEqual, "Client.Do")
+
+		s, err = r.docOf("strings.Split")
+		panicOn(err)
+		cv.So(s, cv.ShouldStartWith, "func Split(s, sep string) []string\n    Split slices s")
+
+		s, err = r.docOf("strings.Builder.Len")
+		panicOn(err)
+		cv.So(s, cv.ShouldStartWith, "func (b *Builder) Len() int\n")
+
+		s, err = r.docOf("strings")
+		panicOn(err)
+		cv.So(s, cv.ShouldStartWith, "package strings // import \"strings\"\n")
+		cv.So(s, cv.ShouldContainSubstring, "type Builder\n")
+
+		_, err = r.docOf("strings.NoSuchThing")
+		cv.So(err, cv.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,61 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2010JitReportLocatesGoLoops(t *testing.T) {
+
+	vm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer vm.Close()
+	inc := NewIncrState(vm, nil)
+	inc.TrackPos = true
+	r := &Repl{lvm: vm, inc: inc, chunks: make(map[string]*ChunkPosMap)}
+
+	run := func(src string) {
+		by, err := inc.Tr([]byte(src))
+		panicOn(err)
+		m := inc.LastPosMap
+		r.chunks[m.Name] = m
+		panicOn(LuaRunChunk(vm, string(by), m.Name))
+	}
+
+	cv.Convey(":jit on records traces, and :jit report names the Go loops that compiled, and explains those that aborted.", t, func() {
+		_, err := r.jitCmd([]string{"on"})
+		panicOn(err)
+
+		run(`
+func sum(n int) int {
+	tot := 0
+	for i := 0; i < n; i++ {
+		tot += i
+	}
+	return tot
+}
+func closures(n int) int {
+	tot := 0
+	for i := 0; i < n; i++ {
+		f := func(y int) int { return y + 1 }
+		tot += f(i)
+	}
+	return tot
+}`)
+		run(`a := sum(1000); b := closures(1000)`)
+		LuaMustInt64(vm, "a", 499500)
+		LuaMustInt64(vm, "b", 500500)
+
+		_, err = r.jitCmd([]string{"off"})
+		panicOn(err)
+
+		evs, err := r.jitEvents()
+		panicOn(err)
+		rep := r.jitReport(evs)
+		cv.So(rep, cv.ShouldContainSubstring, "main.sum line 4 (gi:1): for i := 0; i < n; i++ {")
+		cv.So(rep, cv.ShouldContainSubstring, "NYI: bytecode FNEW")
+		cv.So(rep, cv.ShouldContainSubstring, "main.closures line 11")
+		cv.So(rep, cv.ShouldContainSubstring, "Declare the closure once")
+	})
+}
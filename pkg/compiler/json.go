@@ -0,0 +1,108 @@
+package compiler
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+
+	golua "github.com/glycerine/golua/lua"
+	"github.com/glycerine/luar"
+)
+
+// JSON for gijit values: json.Marshal and Unmarshal of a
+// gijit value go to prelude/json.lua, with the static
+// type, as reflect.TypeOf does (see staticTypedCalls).
+// json.lua walks the value by its type, and leaves
+// the leaves to encoding/json through the helpers here,
+// so that strings, floats, []byte and error messages
+// come out byte for byte as compiled Go has them. As
+// for any call into Go, gijit sees errors as strings.
+//
+// Only the package functions are routed so. The
+// methods of json.Encoder and json.Decoder are
+// encoding/json's own, and see a gijit value only as
+// luar hands it to Go, without its Go type: tags and
+// field names are lost. Until they go to json.lua too,
+// use json.Marshal and write the bytes, or read them
+// and use json.Unmarshal.
+
+func registerJSONHelpers(vm *golua.State) {
+	luar.Register(vm, "", luar.Map{
+		"__gijit_jsonQuote":    jsonQuote,
+		"__gijit_jsonFloat":    jsonFloat,
+		"__gijit_jsonBase64":   jsonBase64,
+		"__gijit_jsonUnbase64": jsonUnbase64,
+		"__gijit_jsonUnquote":  jsonUnquote,
+		"__gijit_jsonCompact":  jsonCompact,
+		"__gijit_jsonIndent":   jsonIndent,
+		"__gijit_jsonCheck":    jsonCheck,
+		"__gijit_jsonGo":       jsonGo,
+		"__gijit_jsonGoUnmarshal": func(s string, x interface{}) error {
+			return json.Unmarshal([]byte(s), x)
+		},
+	})
+}
+
+// jsonQuote returns s as a JSON string.
+func jsonQuote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+// jsonFloat formats f as encoding/json does a
+// float of the given bits.
+func jsonFloat(f float64, bits int) (string, error) {
+	var b []byte
+	var err error
+	if bits == 32 {
+		b, err = json.Marshal(float32(f))
+	} else {
+		b, err = json.Marshal(f)
+	}
+	return string(b), err
+}
+
+func jsonBase64(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func jsonUnbase64(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	return string(b), err
+}
+
+// jsonUnquote returns the string that the JSON
+// string literal s, already checked, holds.
+func jsonUnquote(s string) string {
+	var u string
+	json.Unmarshal([]byte(s), &u)
+	return u
+}
+
+// jsonCompact compacts s, the result of a
+// MarshalJSON method, as json.Marshal does.
+func jsonCompact(s string) (string, error) {
+	var buf bytes.Buffer
+	err := json.Compact(&buf, []byte(s))
+	return buf.String(), err
+}
+
+func jsonIndent(s, prefix, indent string) string {
+	var buf bytes.Buffer
+	json.Indent(&buf, []byte(s), prefix, indent)
+	return buf.String()
+}
+
+// jsonCheck returns the syntax error that
+// json.Unmarshal would for s, or nil.
+func jsonCheck(s string) error {
+	var raw json.RawMessage
+	return json.Unmarshal([]byte(s), &raw)
+}
+
+// jsonGo marshals x, a Go value inside a
+// gijit one, with encoding/json.
+func jsonGo(x interface{}) (string, error) {
+	b, err := json.Marshal(x)
+	return string(b), err
+}
@@ -0,0 +1,115 @@
+package compiler
+
+import (
+	"encoding/json"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+// the Go twins of the gijit types in Test2080,
+// to check that we marshal byte for byte as Go.
+type jsonTestInner struct {
+	Z      int    `json:"z"`
+	Hidden string `json:"-"`
+}
+
+type jsonTestRec struct {
+	Name  string         `json:"name"`
+	Age   int            `json:"age,omitempty"`
+	Score float64        `json:"score"`
+	Tags  []string       `json:"tags"`
+	Attrs map[string]int `json:"attrs,omitempty"`
+	Ptr   *jsonTestInner `json:"ptr"`
+	Raw   []byte         `json:"raw"`
+	ID    int64          `json:"id,string"`
+	Small float32
+	Any   interface{} `json:"any"`
+	note  string
+	jsonTestInner
+}
+
+func Test2080JsonMarshalOfGijitStructs(t *testing.T) {
+
+	cv.Convey("json.Marshal, MarshalIndent, and Unmarshal of gijit structs honor json tags, and match compiled Go byte for byte.", t, func() {
+
+		src := `
+import "encoding/json"
+type Inner struct {
+	Z      int    ` + "`json:\"z\"`" + `
+	Hidden string ` + "`json:\"-\"`" + `
+}
+type Rec struct {
+	Name  string         ` + "`json:\"name\"`" + `
+	Age   int            ` + "`json:\"age,omitempty\"`" + `
+	Score float64        ` + "`json:\"score\"`" + `
+	Tags  []string       ` + "`json:\"tags\"`" + `
+	Attrs map[string]int ` + "`json:\"attrs,omitempty\"`" + `
+	Ptr   *Inner         ` + "`json:\"ptr\"`" + `
+	Raw   []byte         ` + "`json:\"raw\"`" + `
+	ID    int64          ` + "`json:\"id,string\"`" + `
+	Small float32
+	Any   interface{}    ` + "`json:\"any\"`" + `
+	note  string
+	Inner
+}
+r := &Rec{Name: "<ann> & \"bo\"", Score: 1e-7, Tags: []string{"a", "b\n"}, Attrs: map[string]int{"y": 2, "x": 1}, Raw: []byte("hi!"), ID: 42, Small: 0.1, Any: "s", note: "x"}
+r.Z = 7
+r.Hidden = "h"
+back := &Rec{}
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		goRec := &jsonTestRec{Name: "<ann> & \"bo\"", Score: 1e-7, Tags: []string{"a", "b\n"}, Attrs: map[string]int{"y": 2, "x": 1}, Raw: []byte("hi!"), ID: 42, Small: 0.1, Any: "s", note: "x"}
+		goRec.Z = 7
+		goRec.Hidden = "h"
+		want, err := json.Marshal(goRec)
+		panicOn(err)
+		wantIndent, err := json.MarshalIndent(goRec, ">", "  ")
+		panicOn(err)
+		var goBack jsonTestRec
+		goSyntaxErr := json.Unmarshal([]byte(`{"name": }`), &goBack)
+
+		translation, err = inc.Tr([]byte(`
+b, err := json.Marshal(r)
+got := string(b)
+marshalErr := err == nil
+bi, _ := json.MarshalIndent(r, ">", "  ")
+gotIndent := string(bi)
+unmarshalErr := json.Unmarshal(b, back) == nil
+b2, _ := json.Marshal(back)
+again := string(b2)
+syntaxErr := json.Unmarshal([]byte("{\"name\": }"), back)
+typeErr := json.Unmarshal([]byte("{\"age\": \"old\", \"z\": 8}"), back)
+var rv Rec
+nonPtrErr := json.Unmarshal([]byte("{}"), rv)
+`))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		LuaMustString(vm, "got", string(want))
+		LuaMustBool(vm, "marshalErr", true)
+		LuaMustString(vm, "gotIndent", string(wantIndent))
+		LuaMustBool(vm, "unmarshalErr", true)
+		LuaMustString(vm, "again", string(want))
+		// as from any call into Go, errors are strings.
+		LuaMustString(vm, "syntaxErr", goSyntaxErr.Error())
+		LuaMustString(vm, "typeErr", "json: cannot unmarshal string into Go struct field Rec.age of type int")
+		LuaMustString(vm, "nonPtrErr", "json: Unmarshal(non-pointer main.Rec)")
+
+		// decoding went on past the type error.
+		translation, err = inc.Tr([]byte(`bz := back.Z; bp := back.Ptr == nil; bn := back.Name`))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+		LuaMustInt64(vm, "bz", 8)
+		LuaMustBool(vm, "bp", true)
+		LuaMustString(vm, "bn", "<ann> & \"bo\"")
+	})
+}
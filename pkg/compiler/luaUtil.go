@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"math"
@@ -211,19 +212,13 @@ func NewLuaVmWithPrelude(cfg *GIConfig) (lvm *LuaVm, err error) {
 		"__lua2go": lua2GoProxy,
 	})
 	//fmt.Printf("registered __lua2go with luar.\n")
-	// only now that __eval is available can we start heartbeat.
-
-	// Even when LuaJIT is locked to main thread, still
-	// heartbeats seem to to mix up the stack that we
-	// query during inspection of the vm... e.g. when
-	// we find we have a new coroutine come in
-	// run in between our previous code and our querying the
-	// state of the VM.
-	// Leaving heartbeats off after running our test code
-	// appears, at least for now, to resolve the issue.
-	//
-	// But no application currently, so leave them off.
-	// lvm.goro.StartBeat()
+
+	// There is no free-running heartbeat: one would mix
+	// up the stack that we query during inspection of
+	// the vm, running a new coroutine in between our
+	// previous code and our querying the state of the VM.
+	// The scheduler runs in the background only while the
+	// REPL's prompt waits for input; see Goro.idle.
 	return lvm, err
 }
 
@@ -441,6 +436,47 @@ func LuaToInt64(lvm *LuaVm, xpr string) int64 {
 	return value_int
 }
 
+// LuaToString evaluates the Lua expression xpr,
+// on the vm but not on the eval coroutine, and
+// returns it as a string.
+func LuaToString(lvm *LuaVm, xpr string) string {
+	s, err := luaEvalString(lvm, xpr)
+	panicOn(err)
+	return s
+}
+
+func luaEvalString(lvm *LuaVm, xpr string) (string, error) {
+	tk := lvm.goro.newTicket("__gijit_evalOut = "+xpr, false)
+	tk.varname["__gijit_evalOut"] = nil
+	tk.gettyp = GetString
+	if err := tk.Do(); err != nil {
+		return "", err
+	}
+	return tk.varname["__gijit_evalOut"].(string), nil
+}
+
+// newTestRepl makes a quiet Repl with flags args,
+// and a HOME of its own, so that tests leave the
+// user's history alone; done closes it.
+func newTestRepl(args ...string) (r *Repl, done func()) {
+	origHome := os.Getenv("HOME")
+	tempdir, err := ioutil.TempDir("", "gijit-test")
+	panicOn(err)
+	os.Setenv("HOME", tempdir)
+
+	myflags := flag.NewFlagSet("gi", flag.ExitOnError)
+	cfg := NewGIConfig()
+	cfg.DefineFlags(myflags)
+	panicOn(myflags.Parse(append([]string{"-q", "-no-liner", "-d"}, args...)))
+	panicOn(cfg.ValidateConfig())
+	r = NewRepl(cfg)
+	return r, func() {
+		r.lvm.Close()
+		os.Setenv("HOME", origHome)
+		os.RemoveAll(tempdir)
+	}
+}
+
 func LuaInGlobalEnv(lvm *LuaVm, varname string) bool {
 
 	vm := lvm.vm
@@ -535,11 +571,9 @@ func LuaIsNil(lvm *LuaVm, varname string) (bool, string) {
 	return isNil, golua.LuaStackPosToString(vm, top)
 }
 
-// turns heartbeats on only during the run,
-// then turns them off so they don't mess
-// up our vm inspection.
+// LuaRunAndReport runs s on the eval
+// coroutine, panicing on error.
 func LuaRunAndReport(lvm *LuaVm, s string) {
-	//lvm.goro.heartbeatsOn <- true
 	err := LuaRun(lvm, s, true)
 
 	if err != nil {
@@ -547,7 +581,6 @@ func LuaRunAndReport(lvm *LuaVm, s string) {
 			err, s)
 		panic(err)
 	}
-	//lvm.goro.heartbeatsOff <- true
 }
 
 // useEvalCoroutine may need to be false to bootstrap, but
@@ -557,6 +590,22 @@ func LuaRun(lvm *LuaVm, s string, useEvalCoroutine bool) error {
 	return tk.Do()
 }
 
+// LuaRunChunk is LuaRun on the eval coroutine, naming the
+// code chunkName for LuaJIT's tracebacks and profiles.
+func LuaRunChunk(lvm *LuaVm, s string, chunkName string) error {
+	tk := lvm.goro.newTicket(s, true)
+	tk.chunkName = chunkName
+	return tk.Do()
+}
+
+// lastEvalErr is the error that ended the last chunk
+// run on the eval coroutine, or "" if it ran to the
+// end; __eval prints it rather than returning it.
+func lastEvalErr(lvm *LuaVm) string {
+	s, _ := luaEvalString(lvm, "tostring(__lastEvalErr)")
+	return s
+}
+
 func dumpTableString(L *golua.State, index int) (s string) {
 
 	// Push another reference to the table on top of the stack (so we know
@@ -42,6 +42,14 @@ type pkgContext struct {
 	fileSet      *token.FileSet
 	files        []*ast.File
 	errList      ErrorList
+
+	// emitPos requests Go source position markers
+	// in the output; see writePos.
+	emitPos bool
+
+	// emitPreempt requests a preemption safe
+	// point at the top of each loop iteration.
+	emitPreempt bool
 }
 
 func (p *pkgContext) SelectionOf(e *ast.SelectorExpr) (selection, bool) {
@@ -113,6 +121,16 @@ type flowData struct {
 type ImportContext struct {
 	Packages map[string]*types.Package
 	Import   func(path, pkgDir string, depth int) (*Archive, error)
+
+	// EmitPos asks IncrementallyCompile to embed Go
+	// source positions in the generated Lua, for
+	// recovery with a SourceMapFilter.
+	EmitPos bool
+
+	// EmitPreempt asks for the safe points at which
+	// a goroutine out of budget yields; see
+	// IncrState.Preempt.
+	EmitPreempt bool
 }
 
 // packageImporter implements go/types.Importer interface.
@@ -266,8 +284,6 @@ func (c *funcContext) initArgs(ty types.Type) string {
 func (c *funcContext) translateToplevelFunction(fun *ast.FuncDecl, info *analysis.FuncInfo) []byte {
 	pp("translateToplevelFunction called! fun.Name.Name='%s'", fun.Name.Name)
 
-	pkgName := c.getPkgName()
-
 	o := c.p.Defs[fun.Name].(*types.Func)
 	sig := o.Type().(*types.Signature)
 	var recv *ast.Ident
@@ -306,7 +322,7 @@ func (c *funcContext) translateToplevelFunction(fun *ast.FuncDecl, info *analysi
 	if isPointer {
 		namedRecvType = ptr.Elem().(*types.Named)
 	}
-	typeName := "__type__." + pkgName + c.objectName(namedRecvType.Obj())
+	typeName := c.typePrefix() + c.objectName(namedRecvType.Obj())
 	funName := fun.Name.Name
 	if reservedKeywords[funName] {
 		funName += "_"
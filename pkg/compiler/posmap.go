@@ -0,0 +1,197 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gijit/gi/pkg/ast"
+	"github.com/gijit/gi/pkg/token"
+)
+
+// A ChunkPosMap records, for one chunk of Lua
+// generated from a REPL entry, which line of the
+// Go source produced each line of the Lua.
+// LuaJIT reports profiler samples and trace
+// locations as chunkname:line, so this is how
+// we get back to the user's Go.
+type ChunkPosMap struct {
+	// Name is the Lua chunkname (without the
+	// leading '='), e.g. "gi:12".
+	Name string
+
+	// Entry is the REPL entry number.
+	Entry int
+
+	// GoSrc is the Go source as submitted.
+	GoSrc string
+
+	// Failed is set when running the
+	// translation raised an error.
+	Failed bool
+
+	// decls are the entries that the chunk
+	// added to Archive.Declarations.
+	decls []*Decl
+
+	// lua line -> go line
+	line map[int]int
+
+	// the Go functions declared in GoSrc.
+	funcs []goFuncSpan
+}
+
+// goFuncSpan notes the lines spanned by
+// a function declaration or literal.
+type goFuncSpan struct {
+	name string
+	beg  int
+	end  int
+}
+
+// newChunkPosMap strips the position markers out of
+// code, returning the clean Lua and the line map.
+func newChunkPosMap(entry int, goSrc string, code []byte, fileSet *token.FileSet, file *ast.File) (*ChunkPosMap, []byte) {
+	m := &ChunkPosMap{
+		Name:  "gi:" + strconv.Itoa(entry),
+		Entry: entry,
+		GoSrc: goSrc,
+		line:  make(map[int]int),
+	}
+	var clean bytes.Buffer
+	w := &SourceMapFilter{
+		Writer:  &clean,
+		fileSet: fileSet,
+		MappingCallback: func(generatedLine, generatedColumn int, originalPos token.Position) {
+			if _, already := m.line[generatedLine]; !already && originalPos.Line > 0 {
+				m.line[generatedLine] = originalPos.Line
+			}
+		},
+	}
+	w.Write(code)
+	if file != nil {
+		m.funcs = goFuncSpans(fileSet, file)
+	}
+	return m, clean.Bytes()
+}
+
+// GoLine returns the Go source line that generated
+// luaLine. Lines without a marker of their own
+// belong to the nearest marked line above them.
+func (m *ChunkPosMap) GoLine(luaLine int) int {
+	for i := luaLine; i > 0; i-- {
+		if ln, ok := m.line[i]; ok {
+			return ln
+		}
+	}
+	return 0
+}
+
+// FuncName returns the name of the innermost Go function
+// containing goLine; top level statements of the
+// entry are reported as main.entryN.
+func (m *ChunkPosMap) FuncName(goLine int) string {
+	best := -1
+	for i, f := range m.funcs {
+		if goLine >= f.beg && goLine <= f.end {
+			if best < 0 || f.beg >= m.funcs[best].beg {
+				best = i
+			}
+		}
+	}
+	if best < 0 {
+		return fmt.Sprintf("main.entry%d", m.Entry)
+	}
+	return m.funcs[best].name
+}
+
+// SrcLine returns the text of goLine, trimmed.
+func (m *ChunkPosMap) SrcLine(goLine int) string {
+	lines := strings.Split(m.GoSrc, "\n")
+	if goLine < 1 || goLine > len(lines) {
+		return ""
+	}
+	return strings.TrimSpace(lines[goLine-1])
+}
+
+func goFuncSpans(fileSet *token.FileSet, file *ast.File) (spans []goFuncSpan) {
+	var outer []string
+	var lits []int
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch d := n.(type) {
+		case *ast.FuncDecl:
+			name := "main." + d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				name = "main." + recvTypeString(d.Recv.List[0].Type) + "." + d.Name.Name
+			}
+			spans = append(spans, goFuncSpan{
+				name: name,
+				beg:  fileSet.Position(d.Pos()).Line,
+				end:  fileSet.Position(d.End()).Line,
+			})
+			outer = append(outer, name)
+			lits = append(lits, 0)
+			if d.Body != nil {
+				ast.Inspect(d.Body, visit)
+			}
+			outer = outer[:len(outer)-1]
+			lits = lits[:len(lits)-1]
+			return false
+		case *ast.FuncLit:
+			parent := "main.main"
+			if len(outer) > 0 {
+				parent = outer[len(outer)-1]
+				lits[len(lits)-1]++
+			}
+			name := fmt.Sprintf("%s.func%d", parent, lits[len(lits)-1])
+			spans = append(spans, goFuncSpan{
+				name: name,
+				beg:  fileSet.Position(d.Pos()).Line,
+				end:  fileSet.Position(d.End()).Line,
+			})
+			outer = append(outer, name)
+			lits = append(lits, 0)
+			ast.Inspect(d.Body, visit)
+			outer = outer[:len(outer)-1]
+			lits = lits[:len(lits)-1]
+			return false
+		}
+		return true
+	}
+	outer = append(outer, "main.main")
+	lits = append(lits, 0)
+	for _, n := range file.Nodes {
+		ast.Inspect(n, visit)
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].beg < spans[j].beg })
+	return
+}
+
+// recvTypeString renders a receiver type the way
+// the Go runtime names methods: T or (*T).
+func recvTypeString(x ast.Expr) string {
+	switch t := x.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "(*" + recvTypeString(t.X) + ")"
+	}
+	return "?"
+}
+
+// parseChunkLine splits LuaJIT's "chunkname:line"
+// location format.
+func parseChunkLine(loc string) (chunk string, line int, ok bool) {
+	i := strings.LastIndex(loc, ":")
+	if i < 0 {
+		return "", 0, false
+	}
+	line, err := strconv.Atoi(loc[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return loc[:i], line, true
+}
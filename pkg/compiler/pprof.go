@@ -0,0 +1,215 @@
+package compiler
+
+import (
+	"compress/gzip"
+	"io"
+	"time"
+)
+
+// A minimal writer for the pprof profile format, a
+// gzipped protocol buffer described by
+// https://github.com/google/pprof/blob/master/proto/profile.proto
+// We only need a handful of its fields, so rather
+// than vendor the protobuf runtime, we encode by hand.
+
+// pprofFrame is one stack frame of a sample.
+type pprofFrame struct {
+	Func      string
+	File      string
+	Line      int
+	StartLine int
+}
+
+// pprofSample is a stack, innermost frame first,
+// seen Count times.
+type pprofSample struct {
+	Stack []pprofFrame
+	Count int64
+}
+
+// field numbers from profile.proto
+const (
+	pbProfileSampleType    = 1
+	pbProfileSample        = 2
+	pbProfileLocation      = 4
+	pbProfileFunction      = 5
+	pbProfileStringTable   = 6
+	pbProfileTimeNanos     = 9
+	pbProfileDurationNanos = 10
+	pbProfilePeriodType    = 11
+	pbProfilePeriod        = 12
+
+	pbValueTypeType = 1
+	pbValueTypeUnit = 2
+
+	pbSampleLocationID = 1
+	pbSampleValue      = 2
+
+	pbLocationID   = 1
+	pbLocationLine = 4
+
+	pbLineFunctionID = 1
+	pbLineLine       = 2
+
+	pbFunctionID         = 1
+	pbFunctionName       = 2
+	pbFunctionSystemName = 3
+	pbFunctionFilename   = 4
+	pbFunctionStartLine  = 5
+)
+
+type protobuf struct {
+	data []byte
+}
+
+func (b *protobuf) varint(x uint64) {
+	for x >= 0x80 {
+		b.data = append(b.data, byte(x)|0x80)
+		x >>= 7
+	}
+	b.data = append(b.data, byte(x))
+}
+
+func (b *protobuf) key(field, wiretype int) {
+	b.varint(uint64(field)<<3 | uint64(wiretype))
+}
+
+func (b *protobuf) uint64(field int, x uint64) {
+	if x == 0 {
+		return
+	}
+	b.key(field, 0)
+	b.varint(x)
+}
+
+func (b *protobuf) int64(field int, x int64) {
+	b.uint64(field, uint64(x))
+}
+
+func (b *protobuf) uint64s(field int, xs []uint64) {
+	var p protobuf
+	for _, x := range xs {
+		p.varint(x)
+	}
+	b.bytes(field, p.data)
+}
+
+func (b *protobuf) int64s(field int, xs []int64) {
+	var p protobuf
+	for _, x := range xs {
+		p.varint(uint64(x))
+	}
+	b.bytes(field, p.data)
+}
+
+func (b *protobuf) bytes(field int, x []byte) {
+	b.key(field, 2)
+	b.varint(uint64(len(x)))
+	b.data = append(b.data, x...)
+}
+
+func (b *protobuf) string(field int, s string) {
+	b.bytes(field, []byte(s))
+}
+
+// pprofBuilder interns strings, functions, and
+// locations as samples are added.
+type pprofBuilder struct {
+	pb      protobuf
+	strings map[string]int64
+	strtab  []string
+	funcs   map[pprofFrame]uint64 // keyed with Line zeroed
+	locs    map[pprofFrame]uint64
+}
+
+func (pb *pprofBuilder) str(s string) int64 {
+	if i, ok := pb.strings[s]; ok {
+		return i
+	}
+	i := int64(len(pb.strtab))
+	pb.strings[s] = i
+	pb.strtab = append(pb.strtab, s)
+	return i
+}
+
+func (pb *pprofBuilder) valueType(field int, typ, unit string) {
+	var v protobuf
+	v.int64(pbValueTypeType, pb.str(typ))
+	v.int64(pbValueTypeUnit, pb.str(unit))
+	pb.pb.bytes(field, v.data)
+}
+
+func (pb *pprofBuilder) function(f pprofFrame) uint64 {
+	f.Line = 0
+	if id, ok := pb.funcs[f]; ok {
+		return id
+	}
+	id := uint64(len(pb.funcs) + 1)
+	pb.funcs[f] = id
+	var v protobuf
+	v.uint64(pbFunctionID, id)
+	v.int64(pbFunctionName, pb.str(f.Func))
+	v.int64(pbFunctionSystemName, pb.str(f.Func))
+	v.int64(pbFunctionFilename, pb.str(f.File))
+	v.int64(pbFunctionStartLine, int64(f.StartLine))
+	pb.pb.bytes(pbProfileFunction, v.data)
+	return id
+}
+
+func (pb *pprofBuilder) location(f pprofFrame) uint64 {
+	if id, ok := pb.locs[f]; ok {
+		return id
+	}
+	id := uint64(len(pb.locs) + 1)
+	pb.locs[f] = id
+	var line protobuf
+	line.uint64(pbLineFunctionID, pb.function(f))
+	line.int64(pbLineLine, int64(f.Line))
+	var v protobuf
+	v.uint64(pbLocationID, id)
+	v.bytes(pbLocationLine, line.data)
+	pb.pb.bytes(pbProfileLocation, v.data)
+	return id
+}
+
+// writePprof writes samples taken every period, over
+// dur starting at start, to w as a gzipped pprof
+// profile with sample counts and cpu time.
+func writePprof(w io.Writer, samples []pprofSample, period time.Duration, start time.Time, dur time.Duration) error {
+	pb := &pprofBuilder{
+		strings: make(map[string]int64),
+		funcs:   make(map[pprofFrame]uint64),
+		locs:    make(map[pprofFrame]uint64),
+	}
+	// string_table[0] must be "".
+	pb.str("")
+
+	pb.valueType(pbProfileSampleType, "samples", "count")
+	pb.valueType(pbProfileSampleType, "cpu", "nanoseconds")
+	for _, s := range samples {
+		ids := make([]uint64, len(s.Stack))
+		for i, f := range s.Stack {
+			ids[i] = pb.location(f)
+		}
+		var v protobuf
+		v.uint64s(pbSampleLocationID, ids)
+		v.int64s(pbSampleValue, []int64{s.Count, s.Count * int64(period)})
+		pb.pb.bytes(pbProfileSample, v.data)
+	}
+	pb.pb.int64(pbProfileTimeNanos, start.UnixNano())
+	pb.pb.int64(pbProfileDurationNanos, int64(dur))
+	pb.valueType(pbProfilePeriodType, "cpu", "nanoseconds")
+	pb.pb.int64(pbProfilePeriod, int64(period))
+
+	// the string table goes last, once everything
+	// has been interned.
+	for _, s := range pb.strtab {
+		pb.pb.string(pbProfileStringTable, s)
+	}
+
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(pb.pb.data); err != nil {
+		return err
+	}
+	return zw.Close()
+}
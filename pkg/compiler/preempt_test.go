@@ -0,0 +1,76 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2170PreemptCPUBoundGoroutines(t *testing.T) {
+
+	cv.Convey("runtime.Gosched yields to the other goroutines; with a budget set, CPU-bound goroutines are preempted and take turns, and the REPL's entries go on; with eval preemption, an entry spinning on them does too.", t, func() {
+
+		r, done := newTestRepl()
+		defer done()
+
+		// Gosched, without preemption: each goroutine
+		// runs to its Gosched, then waits for the next
+		// pass of the scheduler, after the others.
+		panicOn(LuaRun(r.lvm, `
+__schedLog = {}
+for _, name in ipairs({"a", "b"}) do
+   __task.spawn(function()
+      for i = 1, 3 do
+         table.insert(__schedLog, name..i)
+         __task.gosched()
+      end
+   end, {})
+end
+__task.resume_scheduler()
+table.sort(__schedLog)
+`, false))
+		cv.So(LuaToString(r.lvm, `table.concat(__schedLog, " ")`), cv.ShouldEqual, "a1 b1")
+		panicOn(LuaRun(r.lvm, `__task.resume_scheduler(); __task.resume_scheduler()`, false))
+		cv.So(LuaToString(r.lvm, `table.concat(__schedLog, " ")`), cv.ShouldEqual, "a1 b1 a2 b2 a3 b3")
+		cv.So(coroutineCalls["github.com/gijit/gi/pkg/compiler/shadow/runtime.Gosched"], cv.ShouldEqual, "__task.gosched")
+
+		budget, eval, err := r.preemptState()
+		panicOn(err)
+		cv.So(budget, cv.ShouldEqual, 0)
+		cv.So(eval, cv.ShouldBeFalse)
+
+		// loops check for preemption only while it is on.
+		loop := []byte("for i := 0; i < 1; i++ {\n}")
+		tr, err := r.inc.Tr(loop)
+		panicOn(err)
+		cv.So(string(tr), cv.ShouldNotContainSubstring, "__gijit_preemptDue")
+
+		// two spinning goroutines.
+		panicOn(r.setPreempt(1000, false))
+		tr, err = r.inc.Tr(loop)
+		panicOn(err)
+		cv.So(string(tr), cv.ShouldContainSubstring, "if __gijit_preemptDue then __task.preempt(); end")
+		panicOn(r.Eval("stop := false\nn1 := 0\nn2 := 0"))
+		panicOn(r.Eval("go func() {\n\tfor !stop {\n\t\tn1++\n\t}\n}()"))
+		panicOn(r.Eval("go func() {\n\tfor !stop {\n\t\tn2++\n\t}\n}()"))
+		panicOn(r.Eval("a := 1"))
+		LuaMustInt64(r.lvm, "a", 1)
+		panicOn(r.Eval("b := 2"))
+		LuaMustInt64(r.lvm, "b", 2)
+
+		panicOn(r.setPreempt(1000, true))
+		budget, eval, err = r.preemptState()
+		panicOn(err)
+		cv.So(budget, cv.ShouldEqual, 1000)
+		cv.So(eval, cv.ShouldBeTrue)
+		panicOn(r.Eval("m1, m2 := n1, n2\nfor n1 < m1+10 || n2 < m2+10 {\n}\nstop = true"))
+		LuaMustBool(r.lvm, "stop", true)
+
+		// they see stop, and end.
+		panicOn(r.Eval("c := 3"))
+		dump, err := r.goroutineDump()
+		panicOn(err)
+		cv.So(dump, cv.ShouldEqual, "")
+		panicOn(r.setPreempt(0, false))
+	})
+}
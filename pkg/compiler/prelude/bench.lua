@@ -0,0 +1,59 @@
+-- bench.lua
+--
+-- support for the :bench command at the REPL.
+-- Like testing.B, we grow the iteration count n
+-- until a round takes long enough to time well;
+-- those sizing rounds also warm up the JIT.
+
+-- __bench_round runs f n times, returning
+-- the elapsed nanoseconds.
+function __bench_round(f, n)
+   local t0 = __abs_now()
+   for i = 1, n do
+      f()
+   end
+   return tonumber(__abs_now() - t0)
+end
+
+-- __bench_alloc runs f n times with the garbage
+-- collector stopped, and returns the bytes allocated.
+function __bench_alloc(f, n)
+   collectgarbage("collect")
+   collectgarbage("stop")
+   local k0 = collectgarbage("count")
+   for i = 1, n do
+      f()
+   end
+   local k1 = collectgarbage("count")
+   collectgarbage("restart")
+   return (k1 - k0) * 1024
+end
+
+-- __bench runs f for rounds rounds of about
+-- roundNs nanoseconds each, calling report(n, ns)
+-- after each, and then done(n, bytesPerOp).
+function __bench(f, roundNs, rounds, report, done)
+   local n = 1
+   while n < 1e9 do
+      local ns = __bench_round(f, n)
+      if ns >= roundNs then
+         break
+      end
+      -- aim 20% past the target, but grow
+      -- by no more than 100x at a time.
+      local nextn = n * 100
+      if ns > 0 then
+         nextn = math.min(nextn, math.floor(n * roundNs * 1.2 / ns))
+      end
+      n = math.max(nextn, n + 1)
+   end
+
+   for r = 1, rounds do
+      report(n, __bench_round(f, n))
+   end
+
+   -- allocations are counted over a bounded run,
+   -- since the collector is off meanwhile.
+   local an = math.min(n, 10000)
+   done(n, __bench_alloc(f, an) / an)
+end
@@ -0,0 +1,261 @@
+-- cgo.lua: import "C", by way of the LuaJIT FFI.
+--
+-- gijit has no C compiler to build a cgo preamble
+-- with, but LuaJIT can read its declarations. So
+-- import "C" hands those to ffi.cdef, ffi.loads the
+-- libraries named by its #cgo LDFLAGS, and fills
+-- __packages["C"] with functions that call through
+-- them. cgo.go makes the package "C" that the type
+-- checker sees, and the code calling us here.
+--
+-- The functions convert between gijit values and C's.
+-- C integers come back as the int64 and uint64 cdata
+-- that gijit's integers are. A gijit pointer to a
+-- variable, as from &n, is passed as a pointer to a
+-- copy of n, which is copied back into n after the
+-- call; a pointer to a struct or to an array of
+-- numbers is passed as a pointer to its own memory.
+-- So &a[0] is a pointer to one number, a copy; to
+-- give C all of a, convert unsafe.Pointer(&a).
+
+-- the libraries loaded, by the name given
+-- to -l, and in the order loaded.
+local libs = {}
+local libOrder = {}
+
+-- lookup finds the C function name among the
+-- libraries loaded, or else in the executable.
+local function lookup(name)
+   local get = function(lib)
+      return lib[name]
+   end
+   for _, lib in ipairs(libOrder) do
+      local ok, f = pcall(get, lib)
+      if ok then
+         return f
+      end
+   end
+   local ok, f = pcall(get, __ffi.C)
+   if ok then
+      return f
+   end
+   error("C."..name..": no such symbol in the executable"..
+            " or in the libraries of #cgo LDFLAGS", 3)
+end
+
+-- fromC converts v, a C value of kind k, to the
+-- gijit value. The kinds are those of cgoKind in
+-- cgo.go: "i" for a signed integer, "u" for an
+-- unsigned one, and "" for anything else.
+local function fromC(k, v)
+   if k == "i" then
+      return __ffi.cast("int64_t", v)
+   elseif k == "u" then
+      return __ffi.cast("uint64_t", v)
+   end
+   return v
+end
+
+-- cfunc returns the Go function C.name, which
+-- returns a C value of kind ret. ptrs, if not nil,
+-- has the pointer parameters, by position: each is
+-- the ctype and kind of what the pointer points to,
+-- or an empty table if the pointee is no number.
+local function cfunc(name, ret, ptrs)
+   local f
+   if ptrs == nil then
+      return function(...)
+         f = f or lookup(name)
+         return fromC(ret, f(...))
+      end
+   end
+   return function(...)
+      f = f or lookup(name)
+      local n = select("#", ...)
+      local args = {...}
+      local copies
+      for i, p in pairs(ptrs) do
+         local a = args[i]
+         if type(a) == "table" then
+            -- rawget, as arrays refuse other keys.
+            local v = rawget(a, "__val")
+            local data = rawget(a, "__data")
+            if data == nil and type(v) == "table" then
+               data = rawget(v, "__data")
+            end
+            if data ~= nil then
+               -- an array of numbers.
+               args[i] = data
+            elseif p[1] ~= nil and rawget(a, "__get") ~= nil then
+               local tmp = __ffi.new(p[1].."[1]", a.__get())
+               args[i] = tmp
+               copies = copies or {}
+               copies[i] = tmp
+            elseif type(v) == "cdata" then
+               -- a struct that is plain old data.
+               args[i] = v
+            end
+         end
+      end
+      local r = fromC(ret, f(unpack(args, 1, n)))
+      if copies ~= nil then
+         for i, tmp in pairs(copies) do
+            local a = select(i, ...)
+            a.__set(fromC(ptrs[i][2], tmp[0]))
+         end
+      end
+      return r
+   end
+end
+
+-- the functions of package C that are
+-- cgo's own, rather than from the preamble.
+local function builtins()
+   return {
+      CString = function(s)
+         local n = #s
+         local p = __ffi.cast("char*", __ffi.C.calloc(n + 1, 1))
+         __ffi.copy(p, s, n)
+         return p
+      end,
+
+      CBytes = function(b)
+         local s = __bytesToString(b)
+         local p = __ffi.C.calloc(#s + 1, 1)
+         __ffi.copy(p, s, #s)
+         return p
+      end,
+
+      GoString = function(p)
+         if p == nil then
+            return ""
+         end
+         return __ffi.string(p)
+      end,
+
+      GoStringN = function(p, n)
+         if p == nil then
+            return ""
+         end
+         return __ffi.string(p, tonumber(n))
+      end,
+
+      GoBytes = function(p, n)
+         local s = ""
+         if p ~= nil then
+            s = __ffi.string(p, tonumber(n))
+         end
+         return __sliceType(__type__.uint8)(__stringToBytes(s))
+      end,
+   }
+end
+
+-- __cgoImport runs an import "C". defs are the C
+-- declarations for ffi.cdef, each on its own; one
+-- already made is left as it was. ldlibs are the
+-- libraries to ffi.load, and funcs the C functions
+-- declared, by name, each as the ret and ptrs of
+-- cfunc.
+function __cgoImport(defs, ldlibs, funcs)
+   if __packages["C"] == nil then
+      __packages["C"] = builtins()
+   end
+   local pkg = __packages["C"]
+
+   for _, d in ipairs(defs) do
+      local ok, err = pcall(__ffi.cdef, d)
+      if not ok and not string.find(err, "attempt to redefine", 1, true) then
+         error('import "C": '..tostring(err)..": "..d, 2)
+      end
+   end
+   for _, l in ipairs(ldlibs) do
+      if libs[l] == nil then
+         libs[l] = __ffi.load(l)
+         table.insert(libOrder, libs[l])
+      end
+   end
+   for name, sig in pairs(funcs) do
+      pkg[name] = cfunc(name, sig[1], sig[2])
+   end
+end
+
+-- __cgoPtr gives the C pointer for the gijit
+-- pointer x, to store in a pointer field of a C
+-- struct: nil for a nil pointer, the cdata of a
+-- struct, or the memory of an array of numbers.
+-- A pointer from C is that already.
+function __cgoPtr(x)
+   if type(x) ~= "table" then
+      return x
+   end
+   local v = rawget(x, "__val")
+   if type(v) == "cdata" then
+      return v
+   end
+   local data = rawget(x, "__data")
+   if data == nil and type(v) == "table" then
+      data = rawget(v, "__data")
+   end
+   return data
+end
+
+-- __cgoStruct makes the values of typ, the
+-- gijit type of C.struct_<tag>, cdata of
+-- ctype, which is "struct <tag>".
+function __cgoStruct(typ, ctype)
+   local ct = __ffi.typeof(ctype)
+   local fields = typ.fields
+   typ.__constructor = function(...)
+      local this = {}
+      for i, f in ipairs(fields) do
+         local v = (select(i, ...))
+         local k = f.__typ.kind
+         if k == __kindPtr or k == __kindUnsafePointer then
+            v = __cgoPtr(v)
+         end
+         this[f.__prop] = v
+      end
+      return this
+   end
+
+   -- a pointer to ct, as from C, shares the
+   -- metatype, so p == nil comes to __eq too.
+   local ptr = __ffi.typeof("$ *", ct)
+   local addr = function(x)
+      if type(x) == "table" then
+         -- a gijit pointer: nil, or one to a ct.
+         x = rawget(x, "__val")
+         if type(x) ~= "cdata" then
+            x = nil
+         end
+      end
+      return __ffi.cast("void *", x)
+   end
+
+   local proto = typ.prototype
+   __ffi.metatype(ct, {
+      __index = function(me, k)
+         if k == "__val" then
+            return me
+         elseif k == "__typ" then
+            return typ
+         elseif k == "__name" then
+            return "__structValue"
+         end
+         return proto[k]
+      end,
+      __eq = function(a, b)
+         if __ffi.istype(ptr, a) or __ffi.istype(ptr, b) then
+            return addr(a) == addr(b)
+         end
+         if __cstructOf(a) ~= typ or __cstructOf(b) ~= typ then
+            return false
+         end
+         return __equal(a, b, typ)
+      end,
+      __tostring = function(me)
+         return proto.__tostring(me)
+      end,
+   })
+   __cstructAdopt(typ, ct)
+end
@@ -79,7 +79,9 @@ local TIMEOUT = {err = "TIMEOUT"}
 
 -- Global objects for scheduler
 local tasks_runnable = {}       -- list of coroutines ready to be resumed
+local tasks_preempted = {}      -- preempted this pass; runnable at its end
 local tasks_to = {}             -- all the timeout tasks
+local tasks_timers = {}         -- functions to call at a time, as keys
 local altexec
 
 __all_coro = {} -- array
@@ -88,7 +90,7 @@ __cleanupDeadCoro = function()
    local keepers_all = {}
    local keepers_notes = {}
    for i,co in ipairs(__all_coro) do
-      if coroutine.status(co) ~= "dead" then
+      if coroutine.status(co) ~= "dead" and not __coro2notes[co].abandoned then
          table.insert(keepers_all, co)
          local v = __coro2notes[co]
          v.__loc = #keepers_all
@@ -132,6 +134,33 @@ __coro2notes[main_coro]={__loc=#__all_coro, __name="main"}
 local scheduler_co
 local __resume_scheduler
 local task_park
+
+-- goroutine ids, as Go numbers them from 1. The
+-- REPL's eval coroutines get them too.
+local last_goid = 0
+
+-- Preemption. With a budget set, a count hook (see
+-- SetPreempt in golua) sets __gijit_preemptDue after a
+-- coroutine marked here has run that many instructions;
+-- each loop iteration checks it, and calls
+-- __task.preempt, which yields, setting
+-- __gijit_preempted. The scheduler runs the coroutine
+-- again in its next pass, so a CPU-bound goroutine
+-- cannot starve the others. Goroutines are marked; the
+-- REPL's eval coroutines only if preempt_eval.
+__gijit_preemptible = setmetatable({}, {__mode = "k"})
+__gijit_preemptDue = nil
+__gijit_preempted = nil
+local preempt_budget = 0
+local preempt_eval = false
+local jit_was_on
+
+-- __task_register notes co as a new goroutine named name.
+function __task_register(co, name)
+   table.insert(__all_coro, co)
+   last_goid = last_goid + 1
+   __coro2notes[co]={__loc=#__all_coro, __name=name, id=last_goid}
+end
 ----------------------------------------------------------------------------
 --- Helpers
 
@@ -262,7 +291,15 @@ local function scheduler()
 
       -- and resume co
       --print("scheduler: coroutine.resume about to be called on co="..__costring(co))
+      __gijit_preemptDue = nil
+      __gijit_preempted = nil
       local back = {coroutine.resume(co, "scheduler")}
+      if __gijit_preempted then
+         -- out of budget, or Gosched: run again, but
+         -- after the others have had their turn.
+         __gijit_preempted = nil
+         table.insert(tasks_preempted, co)
+      end
       --print("scheduler: got back from resume of "..__costring(co)..": ", unpack(back))
       
       local okay, emsg = unpack(back)
@@ -273,6 +310,10 @@ local function scheduler()
       i = i + 1
       --print("scheduler: resume was okay, i is now = ", i)      
    end
+   for _, co in ipairs(tasks_preempted) do
+      table.insert(tasks_runnable, co)
+   end
+   tasks_preempted = {}
 
    local now = __abs_now()
    --print("scheduler: checking for timeouts, here is tasks_to: "..type(tasks_to))
@@ -288,6 +329,12 @@ local function scheduler()
          alt.c:_get_alts(RECV):remove(alt)
       end
    end
+   for t in pairs(tasks_timers) do
+      if now >= t.when then
+         tasks_timers[t] = nil
+         t.f()
+      end
+   end
    
    --print("end of scheduler, we ran i tasks, returning i=", i)   
    return i
@@ -318,9 +365,8 @@ local function spawn(fun, args)
       end
    end
    local co = coroutine.create(f)
-   table.insert(__all_coro, co)
-   local n=#__all_coro
-   __coro2notes[co]={__loc=n, __name="spawn #"..tostring(n)}
+   __task_register(co, "spawn #"..tostring(#__all_coro+1))
+   __gijit_preemptible[co] = true
    
    __task_ready(co)
    
@@ -397,9 +443,24 @@ local function altalldequeue(alt_array)
    end
 end
 
+-- closedValue is what a receive from c gets once
+-- c is closed and drained: the zero value of its
+-- element type, when it has one.
+local function closedValue(c)
+   local t = c.__elemTyp
+   if t ~= nil and t.zero ~= nil then
+      return t.zero()
+   end
+   return nil
+end
+
 -- Can this Alt be execed without blocking?
 local function altcanexec(a)
    local c, op = a.c, a.op
+   if op == RECV and c._closed then
+      -- a closed channel never blocks a receiver
+      return true
+   end
    if c._buf.size == 0 then
       if op ~= NOP then
          return c:_get_other_alts(op):len() > 0
@@ -417,6 +478,13 @@ end
 altexec = function (a)
    local c, op = a.c, a.op
 
+   if op == RECV and c._closed and c._buf:len() == 0 then
+      -- closed and drained: the zero value, and not ok.
+      a.alt_array.value = closedValue(c)
+      a.alt_array.closed = true
+      return
+   end
+
    --print("top of altexec, a=")
    --__st(a,"a")
    --print("top of altexec, op=")
@@ -453,6 +521,32 @@ local function __fldcnt(t)
 end
 
 local select_inner
+
+-- wait_state is the state, in the Go runtime's
+-- terms, of a goroutine blocked on alt_array.
+local function wait_state(alt_array, note)
+   if note.sleeping then
+      return "sleep"
+   end
+   if note.sync then
+      -- parked in sync.lua, e.g. "sync.WaitGroup.Wait"
+      return note.sync
+   end
+   local n, op = 0, nil
+   for i = 1, #alt_array do
+      local a = alt_array[i]
+      if a.op == RECV or a.op == SEND then
+         n = n + 1
+         op = a.op
+      end
+   end
+   if n == 1 and op == RECV then
+      return "chan receive"
+   elseif n == 1 then
+      return "chan send"
+   end
+   return "select"
+end
    
 -- The main entry point. Call it `alt` or `select` or just a
 -- multiplexing statement. This is user facing function so make sure
@@ -500,6 +594,10 @@ select_inner = function(alt_array)
 
       local thisCo = coroutine.running()
       task_park(thisCo)
+      local note = __coro2notes[thisCo]
+      if note then
+         note.wait = "select (no cases)"
+      end
       coroutine.yield() -- go back to scheduler
    end
 
@@ -590,8 +688,18 @@ select_inner = function(alt_array)
    local current_co, is_main = coroutine.running()  
    --print("about to yield from (is_main? ",is_main," co=", current_co, " / ", __costring(current_co))
    
+   -- note what we wait for, for :goroutines
+   -- and the deadlock report.
+   local note = __coro2notes[current_co]
+   if note then
+      note.wait, note.alts = wait_state(alt_array, note), alt_array
+   end
+
    local who = coroutine.yield()
    --print("select: resumed by who='"..who.."'")
+   if note then
+      note.wait, note.alts = nil, nil
+   end
    
    assert(alt_array.resolved > 0)
 
@@ -639,11 +747,24 @@ local Channel = {
       return select({{c = self, op = RECV}}, false)
    end,
 
+   -- close wakes every receiver waiting now, and
+   -- any receive after it returns at once, as in Go.
    close = function(self)
-      local alts = self:_get_alts(RECV)
-      for _, v in ipairs(alts.l) do
-         v.closed = true
-         altexec(v)
+      if self._closed then
+         __throwPlainError("close of closed channel")
+      end
+      self._closed = true
+      local waiting = {}
+      for _, a in ipairs(self:_get_alts(RECV).l) do
+         table.insert(waiting, a)
+      end
+      for _, a in ipairs(waiting) do
+         local alt_array = a.alt_array
+         altalldequeue(alt_array)
+         alt_array.value = closedValue(self)
+         alt_array.closed = true
+         alt_array.resolved = a.alt_index
+         __task_ready(alt_array.task)
       end
    end,
 
@@ -728,7 +849,231 @@ __task = __M
 
 __task.resume_scheduler = __resume_scheduler
 
+-- idle_pending reports whether the scheduler has work
+-- that needs no new input from the REPL: goroutines
+-- ready to run, or waiting on a timeout.
+__task.idle_pending = function()
+   if coroutine.status(scheduler_co) ~= "suspended" then
+      return false
+   end
+   return #tasks_runnable > 0 or next(tasks_to) ~= nil or next(tasks_timers) ~= nil
+end
+
+-- after_func calls f, which must not block, from the
+-- scheduler once ns nanoseconds have passed, unless
+-- stop_timer is called first with what it returns.
+__task.after_func = function(ns, f)
+   local t = {when = __abs_now() + ns, f = f}
+   tasks_timers[t] = true
+   return t
+end
+
+-- stop_timer reports whether it stopped t before
+-- t's function was called.
+__task.stop_timer = function(t)
+   local pending = tasks_timers[t] ~= nil
+   tasks_timers[t] = nil
+   return pending
+end
+
 __task.scheduler = scheduler
+
+-- set_preempt sets the instruction budget after which
+-- a goroutine is preempted, 0 for none, and whether the
+-- REPL's eval coroutines are preempted too. Compiled
+-- traces do not count instructions, so the JIT is off
+-- while preempting.
+__task.set_preempt = function(budget, eval)
+   budget = tonumber(budget)
+   if budget > 0 and preempt_budget <= 0 then
+      jit_was_on = jit.status()
+      jit.off()
+      jit.flush()
+   elseif budget <= 0 and preempt_budget > 0 and jit_was_on then
+      jit.on()
+   end
+   preempt_budget = budget
+   preempt_eval = eval and true or false
+   __gijit_setPreempt(budget)
+end
+
+-- preempt_state returns the budget, and whether
+-- the eval coroutines are preempted.
+__task.preempt_state = function()
+   return preempt_budget, preempt_eval
+end
+
+-- gosched yields the running goroutine, letting the
+-- others run before it goes on; runtime.Gosched calls
+-- here. Off the goroutines it runs them once.
+__task.gosched = function()
+   local co, is_main = coroutine.running()
+   local note = co and __coro2notes[co]
+   if co == nil or is_main or note == nil or note.id == nil then
+      __resume_scheduler()
+      return
+   end
+   __gijit_preempted = true
+   coroutine.yield()
+end
+
+-- preempt yields the running goroutine, when
+-- __gijit_preemptDue says it is out of budget.
+__task.preempt = function()
+   __gijit_preemptDue = nil
+   local co = coroutine.running()
+   if not __gijit_preemptible[co] or not coroutine.isyieldable() then
+      return
+   end
+   __gijit_preempted = true
+   coroutine.yield()
+end
+
+-- sleep parks the running goroutine for ns nanoseconds,
+-- letting the others run; time.Sleep calls here. Off
+-- the goroutines, on the main thread, it just sleeps.
+__task.sleep = function(ns)
+   if ns <= 0 then
+      return
+   end
+   local co, is_main = coroutine.running()
+   local note = co and __coro2notes[co]
+   if co == nil or is_main or note == nil or co == scheduler_co then
+      __gijit_sleepNs(tonumber(ns))
+      return
+   end
+   note.sleeping = true
+   Channel:new(0):recv(ns)
+   note.sleeping = nil
+end
+
+-- goroutine_frames returns the Lua locations, innermost
+-- first, of where co is; user says if each is in code
+-- from the REPL, whose chunknames start with '='.
+local function goroutine_frames(co)
+   local frames = {}
+   local level = 0
+   while true do
+      local info = debug.getinfo(co, level, "Sl")
+      if info == nil then
+         break
+      end
+      if info.currentline and info.currentline > 0 then
+         table.insert(frames, {
+            loc = info.short_src..":"..tostring(info.currentline),
+            user = string.sub(info.source, 1, 1) == "=",
+         })
+      end
+      level = level + 1
+   end
+   return frames
+end
+
+-- goroutines lists the live goroutines, by id, with
+-- their states and frames.
+__task.goroutines = function()
+   local running = coroutine.running()
+   local ready = {}
+   for _, co in ipairs(tasks_runnable) do
+      ready[co] = true
+   end
+   for _, co in ipairs(tasks_preempted) do
+      ready[co] = true
+   end
+   local gs = {}
+   for _, co in ipairs(__all_coro) do
+      local note = __coro2notes[co]
+      if note and note.id and not note.abandoned and coroutine.status(co) ~= "dead" then
+         local state = note.wait or "waiting"
+         if co == running then
+            state = "running"
+         elseif ready[co] then
+            state = "runnable"
+         end
+         table.insert(gs, {id = note.id, state = state, frames = goroutine_frames(co)})
+      end
+   end
+   table.sort(gs, function(a, b) return a.id < b.id end)
+   return gs
+end
+
+-- goroutine_dump formats the goroutines as the Go
+-- runtime does in a traceback, with locations in the
+-- Go source where the REPL has provided
+-- __gijit_goLoc to map them.
+__task.goroutine_dump = function()
+   local out = {}
+   for _, g in ipairs(__task.goroutines()) do
+      table.insert(out, "goroutine "..tostring(g.id).." ["..g.state.."]:\n")
+      local shown = 0
+      for _, f in ipairs(g.frames) do
+         if f.user then
+            local loc = f.loc
+            if __gijit_goLoc ~= nil then
+               loc = __gijit_goLoc(loc)
+            end
+            table.insert(out, "\t"..loc.."\n")
+            shown = shown + 1
+         end
+      end
+      if shown == 0 and #g.frames > 0 then
+         table.insert(out, "\t"..g.frames[1].loc.."\n")
+      end
+      table.insert(out, "\n")
+   end
+   return table.concat(out)
+end
+
+-- abandon drops co, blocked for good, from the
+-- channels and timeouts it waits on.
+local function abandon(co)
+   local note = __coro2notes[co]
+   if note.alts then
+      altalldequeue(note.alts)
+   end
+   tasks_to[co] = nil
+   task_park(co)
+   note.abandoned = true
+end
+
+-- run_eval runs co, the REPL's eval coroutine, with the
+-- other goroutines, until co is done, sleeping until the
+-- next timeout when all are waiting on one. If co blocks
+-- and no goroutine can run, nor timeout come, that is Go's
+-- deadlock: we return Go's fatal error for it, with the
+-- goroutines, and abandon co, so the REPL can go on.
+__task.run_eval = function(co)
+   __gijit_preemptible[co] = preempt_eval or nil
+   __task_ready(co)
+   while coroutine.status(co) ~= "dead" do
+      if #tasks_runnable == 0 then
+         local soonest
+         for _, alt in pairs(tasks_to) do
+            if soonest == nil or alt.to < soonest then
+               soonest = alt.to
+            end
+         end
+         for t in pairs(tasks_timers) do
+            if soonest == nil or t.when < soonest then
+               soonest = t.when
+            end
+         end
+         if soonest == nil then
+            local dump = __task.goroutine_dump()
+            abandon(co)
+            return "fatal error: all goroutines are asleep - deadlock!\n\n"..dump
+         end
+         local wait = soonest - __abs_now()
+         if wait > 0 then
+            __gijit_sleepNs(tonumber(wait))
+         end
+      end
+      if not __resume_scheduler() then
+         break
+      end
+   end
+   return nil
+end
 __task.spawn     = spawn
 __task.Channel   = Channel
 __task.select    = select
@@ -0,0 +1,313 @@
+-- context.lua: context for gijit goroutines.
+--
+-- Go's own contexts close their Done channels from
+-- other OS threads, and time out on the Go runtime's
+-- timers, neither of which a goroutine parked in the
+-- chan.lua scheduler would ever see. So import "context"
+-- gets contexts from here, whose Done channels are
+-- chan.lua channels, and whose deadlines are kept by the
+-- scheduler's timers. Then
+--
+--    select { case <-ctx.Done(): ... }
+--
+-- works as it does in Go. The errors are Go's own
+-- context.Canceled and context.DeadlineExceeded, so
+-- comparing with them works too.
+
+local Canceled, DeadlineExceeded
+
+-- ctx holds the methods of every context. The
+-- fields used are:
+--
+--   parent   the context this one derives from.
+--   done     the Done channel; nil to use the parent's.
+--   err      set, once, when done is closed.
+--   cause    set with err; see context.Cause.
+--   children the cancelable contexts to cancel with this.
+--   afters   the AfterFunc calls to start with this.
+--   detached set by WithoutCancel: only Value looks
+--            past it, to the parent.
+--   when     the deadline, on the __abs_now() clock.
+--   deadline the deadline, as a Go time.Time.
+--   timer    from __task.after_func, for the deadline.
+--   key, val for WithValue.
+local ctx = {}
+ctx.__index = ctx
+
+ctx.__tostring = function(self)
+   return self.name
+end
+
+function ctx:Done()
+   if self.done ~= nil then
+      return self.done
+   end
+   return self.parent:Done()
+end
+
+function ctx:Err()
+   if self.done ~= nil then
+      return self.err
+   end
+   return self.parent:Err()
+end
+
+function ctx:Deadline()
+   if self.when ~= nil then
+      return self.deadline, true
+   end
+   if self.parent ~= nil and not self.detached then
+      return self.parent:Deadline()
+   end
+   return __gijit_zeroTime, false
+end
+
+function ctx:Value(key)
+   local c = self
+   while c ~= nil do
+      if c.key ~= nil and c.key == key then
+         return c.val
+      end
+      c = c.parent
+   end
+   return nil
+end
+
+-- the deadline of c, on the __abs_now() clock,
+-- or nil for none.
+local function when(c)
+   while c ~= nil and not c.detached do
+      if c.when ~= nil then
+         return c.when
+      end
+      c = c.parent
+   end
+   return nil
+end
+
+-- the nearest context, from c up, with its own Done
+-- channel: the one that c is done with.
+local function owner(c)
+   while c.done == nil do
+      c = c.parent
+   end
+   return c
+end
+
+local function cancel(c, err, cause)
+   if c.err ~= nil then
+      return
+   end
+   c.err = err
+   c.cause = cause or err
+   c.done:close()
+   if c.timer ~= nil then
+      __task.stop_timer(c.timer)
+      c.timer = nil
+   end
+   local children = c.children
+   c.children = {}
+   for child in pairs(children) do
+      cancel(child, err, c.cause)
+   end
+   local afters = c.afters
+   c.afters = {}
+   for a in pairs(afters) do
+      a.started = true
+      __task.spawn(a.f, {})
+   end
+   if c.canceler ~= nil then
+      c.canceler.children[c] = nil
+      c.canceler = nil
+   end
+end
+
+-- newCancelCtx returns a context that is done when
+-- its parent is, or once cancel is called with it.
+local function newCancelCtx(parent, name)
+   local c = setmetatable({
+      parent = parent,
+      done = __task.Channel:new(0),
+      children = {},
+      afters = {},
+      name = name,
+   }, ctx)
+
+   -- find the nearest context above that can be
+   -- canceled; Background, TODO and those of
+   -- WithoutCancel never are.
+   local p = owner(parent)
+   if p.children ~= nil then
+      if p.err ~= nil then
+         cancel(c, p.err, p.cause)
+      else
+         p.children[c] = true
+         c.canceler = p
+      end
+   end
+   return c
+end
+
+local function cancelFunc(c)
+   return function()
+      cancel(c, Canceled)
+   end
+end
+
+local function cancelCauseFunc(c)
+   return function(cause)
+      cancel(c, Canceled, cause)
+   end
+end
+
+local function withDeadline(parent, d, name, cause)
+   local parentWhen = when(parent)
+   local ns = __gijit_untilNs(d)
+   local w = __abs_now() + ns
+   local c = newCancelCtx(parent, name)
+   if parentWhen ~= nil and parentWhen <= w then
+      -- the parent's deadline is sooner; it will do.
+      return c, cancelFunc(c)
+   end
+   c.when, c.deadline = w, d
+   if c.err == nil then
+      if ns <= 0 then
+         cancel(c, DeadlineExceeded, cause)
+      else
+         c.timer = __task.after_func(ns, function()
+            cancel(c, DeadlineExceeded, cause)
+         end)
+      end
+   end
+   return c, cancelFunc(c)
+end
+
+local function checkParent(parent)
+   if parent == nil then
+      error("cannot create context from nil parent", 3)
+   end
+end
+
+----------------------------------------------------------------------------
+-- import "context"
+
+function __gijit_installContext()
+   Canceled = __gijit_context.Canceled
+   DeadlineExceeded = __gijit_context.DeadlineExceeded
+
+   local background = setmetatable({
+      name = "context.Background",
+      done = __task.Channel:new(0), -- never closed
+   }, ctx)
+   local todo = setmetatable({
+      name = "context.TODO",
+      done = __task.Channel:new(0),
+   }, ctx)
+
+   __packages["context"] = {
+      Canceled = Canceled,
+      DeadlineExceeded = DeadlineExceeded,
+
+      Background = function()
+         return background
+      end,
+
+      TODO = function()
+         return todo
+      end,
+
+      WithCancel = function(parent)
+         checkParent(parent)
+         local c = newCancelCtx(parent, tostring(parent)..".WithCancel")
+         return c, cancelFunc(c)
+      end,
+
+      WithCancelCause = function(parent)
+         checkParent(parent)
+         local c = newCancelCtx(parent, tostring(parent)..".WithCancel")
+         return c, cancelCauseFunc(c)
+      end,
+
+      WithDeadline = function(parent, d)
+         checkParent(parent)
+         return withDeadline(parent, d, tostring(parent)..".WithDeadline")
+      end,
+
+      WithDeadlineCause = function(parent, d, cause)
+         checkParent(parent)
+         local c = withDeadline(parent, d, tostring(parent)..".WithDeadline", cause)
+         return c, cancelFunc(c)
+      end,
+
+      WithTimeout = function(parent, timeout)
+         checkParent(parent)
+         local d = __gijit_timeAfterNs(tonumber(timeout))
+         return withDeadline(parent, d, tostring(parent)..".WithDeadline")
+      end,
+
+      WithTimeoutCause = function(parent, timeout, cause)
+         checkParent(parent)
+         local d = __gijit_timeAfterNs(tonumber(timeout))
+         local c = withDeadline(parent, d, tostring(parent)..".WithDeadline", cause)
+         return c, cancelFunc(c)
+      end,
+
+      -- WithoutCancel's context is never done, and
+      -- has no deadline, but has the parent's values.
+      WithoutCancel = function(parent)
+         checkParent(parent)
+         return setmetatable({
+            parent = parent,
+            done = __task.Channel:new(0), -- never closed
+            detached = true,
+            name = tostring(parent)..".WithoutCancel",
+         }, ctx)
+      end,
+
+      -- Cause is why c was canceled: the cause given
+      -- its cancel function, else c:Err(); nil while
+      -- c is not done.
+      Cause = function(c)
+         return owner(c).cause
+      end,
+
+      -- AfterFunc starts f in a goroutine of its own
+      -- once c is done; stop keeps it from starting,
+      -- and reports whether it did.
+      AfterFunc = function(c, f)
+         local p = owner(c)
+         local a = {f = f}
+         if p.afters ~= nil then
+            if p.err ~= nil then
+               a.started = true
+               __task.spawn(f, {})
+            else
+               p.afters[a] = true
+            end
+         end
+         return function()
+            if a.started or a.stopped then
+               return false
+            end
+            a.stopped = true
+            if p.afters ~= nil then
+               p.afters[a] = nil
+            end
+            return true
+         end
+      end,
+
+      WithValue = function(parent, key, val)
+         checkParent(parent)
+         if key == nil then
+            error("nil key", 2)
+         end
+         return setmetatable({
+            parent = parent,
+            key = key,
+            val = val,
+            name = tostring(parent)..".WithValue",
+         }, ctx)
+      end,
+   }
+end
@@ -21,7 +21,13 @@ end
 -- This would be preferred to repeating them in every function.
 
 -- string viewing of panic value
-__recovMT = {__tostring = function(v) return 'a-panic-value:' .. tostring(v[1]) end}
+__recovMT = {__tostring = function(v)
+   if __isRuntimeError(v[1]) then
+      -- as Go's runtime prints it
+      return 'panic: ' .. v[1]:Error()
+   end
+   return 'a-panic-value:' .. tostring(v[1])
+end}
 
 -- __recoverVal will be nill if no panic,
 --              or if panic happened and
@@ -36,8 +42,22 @@ __recovMT = {__tostring = function(v) return 'a-panic-value:' .. tostring(v[1])
 
 __recoverVal = nil
 
+-- __fatalMT marks Go's fatal errors, as __fatal throws
+-- them: recover does not stop them, and they unwind
+-- the goroutine, as Go's kill the program.
+__fatalMT = {__tostring = function(v)
+   return "fatal error: " .. v.msg
+end}
+
+__fatal = function(msg)
+   error(setmetatable({msg = msg}, __fatalMT))
+end
+
 recover = function()
    --print("debug: top of recover()")
+   if type(__recoverVal) == "table" and getmetatable(__recoverVal) == __fatalMT then
+      return nil
+   end
    local stack = debug.traceback()
    if not __isDirectDefer(stack) then
       --print("debug `recover()`: was not direct defer, leaving __recoverVal=", __recoverVal)
@@ -51,9 +71,21 @@ recover = function()
       local unwrap = cp[1]; -- unwrap from array, so raw value is returned
       return unwrap
    end
+   if type(cp) == "string" and __isNilDerefError(cp) then
+      -- Lua's own error, from a nil pointer or func.
+      return __type__.__runtime.errorString.ptrToNewlyConstructed("invalid memory address or nil pointer dereference")
+   end
    return cp
 end
 
+-- __isNilDerefError reports whether msg, a Lua error,
+-- comes of using nil, as Go code only does through a
+-- nil pointer or func.
+__isNilDerefError = function(msg)
+   return string.find(msg, "attempt to index [^(]*%(a nil value%)") ~= nil or
+      string.find(msg, "attempt to call [^(]*%(a nil value%)") ~= nil
+end
+
 panic = function(err)
    --print("panic() called with err = ", err)
    -- wrap err in table to prevent conversion to string by error()
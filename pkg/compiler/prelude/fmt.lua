@@ -0,0 +1,953 @@
+-- fmt.lua
+--
+-- fmt for gijit values; see fmt.go. The compiler turns
+-- fmt.Printf(format, a...) and the other printing
+-- functions into the __gijit_fmt functions here, with
+-- a table of the static types of a just before a, as
+-- it does for reflect and json. What follows is Go's
+-- printer, from fmt/print.go: it parses the format,
+-- walks each value by its type, calls the Format,
+-- GoString, Error and String methods where Go would,
+-- and hands the leaves to Go's fmt with their verb,
+-- flags, width and precision.
+--
+-- An interface holding a gijit struct cannot tell us
+-- whether it holds the struct or a pointer to it; as
+-- reflect does, we print the struct, and call the
+-- methods of either.
+
+local bit = require("bit")
+
+local function isNil(typ, x)
+   return x == nil or x == __ifaceNil or x == typ.__nil
+end
+
+local function isInt(kind)
+   return kind >= __kindInt and kind <= __kindUintptr
+end
+
+local function isFloat(kind)
+   return kind == __kindFloat32 or kind == __kindFloat64
+end
+
+local function isComplex(kind)
+   return kind == __kindComplex64 or kind == __kindComplex128
+end
+
+-- dynamicType returns the type to print x as, when
+-- its static type is an interface, or nil for a Go
+-- value that Go's fmt should print.
+local function dynamicType(x)
+   if type(x) == "cdata" and __ffi.istype("complex", x) then
+      return __type__.complex128
+   end
+   return __gijit_reflectDynamicType(x)
+end
+
+local function intDigits(x)
+   if type(x) == "number" then
+      return string.format("%d", x)
+   end
+   return (string.match(tostring(x), "^-?%d+"))
+end
+
+-- address returns the address of x, which
+-- stands for a pointer, chan or func.
+local function address(x)
+   local hex = string.match(tostring(x), "0x(%x+)")
+   if hex == nil then
+      return 0
+   end
+   return tonumber(hex, 16)
+end
+
+-- deref returns what pointer x points to. A
+-- pointer to a struct or array is the table
+-- that holds it; others have __get.
+local function deref(x)
+   if type(x) == "table" then
+      local get = rawget(x, "__get")
+      if get == nil and getmetatable(x) ~= nil then
+         get = x.__get
+      end
+      if type(get) == "function" then
+         return get()
+      end
+   end
+   return x
+end
+
+-- findMethod returns typ's method called name, if a
+-- value of typ has it; an addressable one, or one
+-- from an interface, also has the methods with
+-- pointer receivers.
+local function findMethod(typ, name, addr)
+   for _, m in ipairs(__methodSet(typ)) do
+      if m.__name == name and (typ.kind == __kindPtr or addr or not m.__ptrRecv) then
+         return m
+      end
+   end
+   return nil
+end
+
+local function callMethod(typ, x, m, ...)
+   local k = typ.kind
+   if k == __kindStruct or (k == __kindPtr and typ.elem.kind == __kindStruct) then
+      return x[m.prop](x, ...)
+   end
+   if k == __kindPtr and not m.__ptrRecv then
+      return typ.elem.prototype[m.prop](deref(x), ...)
+   end
+   return typ.prototype[m.prop](x, ...)
+end
+
+-- sortedKeys returns the keys of map x in the
+-- order Go's fmt prints them.
+local compare
+local function sortedKeys(typ, x)
+   local keys = {}
+   for k, v in pairs(x) do
+      table.insert(keys, {k, v})
+   end
+   table.sort(keys, function(a, b) return compare(typ.key, a[1], b[1]) < 0 end)
+   return keys
+end
+
+compare = function(typ, a, b)
+   local k = typ.kind
+   if k == __kindInterface then
+      if a == nil or b == nil then
+         if a == b then
+            return 0
+         elseif a == nil then
+            return -1
+         end
+         return 1
+      end
+      local ta, tb = dynamicType(a), dynamicType(b)
+      if ta == nil or tb == nil or ta ~= tb then
+         local sa, sb = ta and ta.__str or "", tb and tb.__str or ""
+         if sa < sb then
+            return -1
+         elseif sa > sb then
+            return 1
+         end
+         return 0
+      end
+      return compare(ta, a, b)
+   elseif k == __kindBool then
+      if a == b then
+         return 0
+      elseif not a then
+         return -1
+      end
+      return 1
+   elseif isInt(k) or isFloat(k) or k == __kindString then
+      if a < b then
+         return -1
+      elseif a > b then
+         return 1
+      elseif a == b then
+         return 0
+      elseif a ~= a then
+         -- NaN sorts first.
+         if b ~= b then
+            return 0
+         end
+         return -1
+      end
+      return 1
+   elseif isComplex(k) then
+      local c = compare(__type__.float64, a.re, b.re)
+      if c ~= 0 then
+         return c
+      end
+      return compare(__type__.float64, a.im, b.im)
+   elseif k == __kindStruct then
+      for _, f in ipairs(typ.fields) do
+         local c = compare(f.__typ, a[f.__prop], b[f.__prop])
+         if c ~= 0 then
+            return c
+         end
+      end
+      return 0
+   elseif k == __kindArray then
+      for i = 0, typ.len - 1 do
+         local c = compare(typ.elem, __gi_GetRangeCheck(a, i), __gi_GetRangeCheck(b, i))
+         if c ~= 0 then
+            return c
+         end
+      end
+      return 0
+   elseif k == __kindPtr or k == __kindChan then
+      local ua, ub = 0, 0
+      if not isNil(typ, a) then ua = address(a) end
+      if not isNil(typ, b) then ub = address(b) end
+      return compare(__type__.uintptr, ua, ub)
+   end
+   return 0
+end
+
+---------------------
+-- the printer
+---------------------
+
+local printer = {}
+printer.__index = printer
+
+local function newPrinter()
+   local p = setmetatable({buf = {}}, printer)
+   p:clearflags()
+   return p
+end
+
+function printer:write(s)
+   table.insert(self.buf, s)
+end
+
+function printer:string()
+   return table.concat(self.buf)
+end
+
+function printer:clearflags()
+   self.plus, self.minus, self.sharp, self.space, self.zero = false, false, false, false, false
+   self.plusV, self.sharpV = false, false
+   self.wid, self.widPresent = 0, false
+   self.prec, self.precPresent = 0, false
+end
+
+-- spec returns the format for verb under the current
+-- flags, for Go to parse again at a leaf. The plus
+-- and sharp that a %v took for itself go only to v.
+function printer:spec(verb, sharp, noPrec)
+   local s = {"%"}
+   if self.plus or (self.plusV and verb == "v") then
+      table.insert(s, "+")
+   end
+   if self.minus then
+      table.insert(s, "-")
+   end
+   if sharp == nil then
+      sharp = self.sharp or (self.sharpV and verb == "v")
+   end
+   if sharp then
+      table.insert(s, "#")
+   end
+   if self.space then
+      table.insert(s, " ")
+   end
+   if self.zero then
+      table.insert(s, "0")
+   end
+   if self.widPresent then
+      table.insert(s, tostring(self.wid))
+   end
+   if self.precPresent and not noPrec then
+      table.insert(s, "." .. tostring(self.prec))
+   end
+   table.insert(s, verb)
+   return table.concat(s)
+end
+
+function printer:padString(s)
+   self:write(__gijit_fmtString(self:spec("s", false, true), "string", s))
+end
+
+function printer:fmtS(s)
+   self:write(__gijit_fmtString(self:spec("s", false), "string", s))
+end
+
+function printer:fmt0x64(u, leading0x)
+   self:write(__gijit_fmtInt(self:spec("x", leading0x), __kindUintptr, "uintptr", intDigits(u)))
+end
+
+function printer:badVerb(verb, x, typ)
+   self.erroring = true
+   self:write("%!" .. verb .. "(")
+   if typ ~= nil then
+      self:write(typ.__str .. "=")
+      self:printValue(x, typ, "v", 0, false)
+   else
+      self:write("<nil>")
+   end
+   self:write(")")
+   self.erroring = false
+end
+
+function printer:fmtPointer(x, typ, verb)
+   local u = 0
+   if not isNil(typ, x) then
+      u = address(x)
+   end
+   if verb == "v" then
+      if self.sharpV then
+         self:write("(" .. typ.__str .. ")(")
+         if u == 0 then
+            self:write("nil")
+         else
+            self:fmt0x64(u, true)
+         end
+         self:write(")")
+      elseif u == 0 then
+         self:padString("<nil>")
+      else
+         self:fmt0x64(u, not self.sharp)
+      end
+   elseif verb == "p" then
+      self:fmt0x64(u, not self.sharp)
+   elseif verb == "b" or verb == "o" or verb == "d" or verb == "x" or verb == "X" then
+      self:write(__gijit_fmtInt(self:spec(verb), __kindUintptr, "uintptr", intDigits(u)))
+   else
+      self:badVerb(verb, x, typ)
+   end
+end
+
+-- state is the fmt.State that a Format method writes to.
+local stateMT = {__index = {}}
+local state = stateMT.__index
+
+function state:Write(b)
+   local s = __bytesToString(b)
+   self.__p:write(s)
+   return 0LL + #s, nil
+end
+
+function state:Width()
+   return 0LL + self.__p.wid, self.__p.widPresent
+end
+
+function state:Precision()
+   return 0LL + self.__p.prec, self.__p.precPresent
+end
+
+function state:Flag(c)
+   local p = self.__p
+   local f = string.char(tonumber(c))
+   if f == "-" then
+      return p.minus
+   elseif f == "+" then
+      return p.plus or p.plusV
+   elseif f == "#" then
+      return p.sharp or p.sharpV
+   elseif f == " " then
+      return p.space
+   elseif f == "0" then
+      return p.zero
+   end
+   return false
+end
+
+-- catchPanic calls method m of x, and on a panic
+-- prints it as Go's catchPanic does.
+function printer:catchPanic(x, typ, m, verb, ...)
+   local res = {pcall(callMethod, typ, x, m, ...)}
+   if res[1] then
+      return true, res[2]
+   end
+   local e = res[2]
+   if type(e) == "table" and getmetatable(e) == __recovMT then
+      e = e[1]
+      __recoverVal = nil
+   end
+   if typ.kind == __kindPtr and isNil(typ, x) then
+      self:write("<nil>")
+      return false
+   end
+   if self.panicking then
+      error(e)
+   end
+   local p = newPrinter()
+   p.panicking = true
+   p:printArg(e, nil, "v")
+   self:write("%!" .. verb .. "(PANIC=" .. m.__name .. " method: " .. p:string() .. ")")
+   return false
+end
+
+-- runeOf returns the code point of verb,
+-- a UTF-8 character, as a rune.
+local function runeOf(verb)
+   local c = string.byte(verb, 1)
+   if #verb == 1 then
+      return 0LL + c
+   end
+   local r = bit.band(c, bit.rshift(0x7f, #verb))
+   for i = 2, #verb do
+      r = r * 64 + bit.band(string.byte(verb, i), 0x3f)
+   end
+   return 0LL + r
+end
+
+-- handleMethods prints x with its Format, GoString,
+-- Error or String method, and reports whether
+-- it did.
+function printer:handleMethods(x, typ, verb, addr)
+   if self.erroring then
+      return false
+   end
+   if verb == "w" then
+      if not self.wrapErrs or findMethod(typ, "Error", addr) == nil then
+         self:badVerb(verb, x, typ)
+         return true
+      end
+      verb = "v"
+   end
+   local m = findMethod(typ, "Format", addr)
+   if m ~= nil then
+      self:catchPanic(x, typ, m, verb, setmetatable({__p = self}, stateMT), runeOf(verb))
+      return true
+   end
+   if self.sharpV then
+      m = findMethod(typ, "GoString", addr)
+      if m ~= nil then
+         local ok, s = self:catchPanic(x, typ, m, verb)
+         if ok then
+            self:fmtS(s)
+         end
+         return true
+      end
+      return false
+   end
+   if verb == "v" or verb == "s" or verb == "x" or verb == "X" or verb == "q" then
+      m = findMethod(typ, "Error", addr) or findMethod(typ, "String", addr)
+      if m ~= nil then
+         local ok, s = self:catchPanic(x, typ, m, verb)
+         if ok then
+            self:write(__gijit_fmtString(self:spec(verb), "string", s))
+         end
+         return true
+      end
+   end
+   return false
+end
+
+-- printArg prints argument x, of static type typ,
+-- or nil for an interface.
+function printer:printArg(x, typ, verb)
+   local addr = false
+   if typ == nil then
+      if x == nil or x == __ifaceNil then
+         if verb == "T" or verb == "v" then
+            self:padString("<nil>")
+         else
+            self:badVerb(verb, nil, nil)
+         end
+         return
+      end
+      if verb == "w" and self.wrapErrs and type(x) == "string" then
+         -- an error from Go, which we see as its message.
+         verb = "v"
+      end
+      typ = dynamicType(x)
+      if typ == nil and type(x) == "function" then
+         typ = __funcType({}, {}, false)
+      end
+      if typ == nil then
+         self:write(__gijit_fmtGo(self:spec(verb), x))
+         return
+      end
+      addr = true
+   end
+   if verb == "T" then
+      self:fmtS(typ.__str)
+      return
+   elseif verb == "p" then
+      self:fmtPointer(x, typ, "p")
+      return
+   end
+   if typ.kind == __kindSlice and not typ.named and typ.elem == __type__.uint8 then
+      -- Go's fmt prints a []byte itself.
+      if not self:handleMethods(x, typ, verb, addr) then
+         local isnil = isNil(typ, x)
+         self:write(__gijit_fmtBytes(self:spec(verb), typ.__str, isnil and "" or __bytesToString(x), isnil))
+      end
+      return
+   end
+   if not self:handleMethods(x, typ, verb, addr) then
+      self:printValue(x, typ, verb, 0, true, addr)
+   end
+end
+
+-- printValue is Go's printValue; canIface is
+-- false under an unexported field, where Go
+-- does not call methods, and addr is true for
+-- a value that came out of an interface.
+function printer:printValue(x, typ, verb, depth, canIface, addr)
+   if depth > 0 and canIface and self:handleMethods(x, typ, verb, addr) then
+      return
+   end
+   local k = typ.kind
+   if k == __kindBool then
+      self:write(__gijit_fmtBool(self:spec(verb), typ.__str, x))
+   elseif isInt(k) then
+      self:write(__gijit_fmtInt(self:spec(verb), k, typ.__str, intDigits(x)))
+   elseif isFloat(k) then
+      self:write(__gijit_fmtFloat(self:spec(verb), k, typ.__str, tonumber(x)))
+   elseif isComplex(k) then
+      self:write(__gijit_fmtComplex(self:spec(verb), k, typ.__str, x.re, x.im))
+   elseif k == __kindString then
+      self:write(__gijit_fmtString(self:spec(verb), typ.__str, x))
+   elseif k == __kindMap then
+      if self.sharpV then
+         self:write(typ.__str)
+         if isNil(typ, x) then
+            self:write("(nil)")
+            return
+         end
+         self:write("{")
+      else
+         self:write("map[")
+      end
+      if not isNil(typ, x) then
+         for i, kv in ipairs(sortedKeys(typ, x)) do
+            if i > 1 then
+               self:write(self.sharpV and ", " or " ")
+            end
+            self:printValue(kv[1], typ.key, verb, depth+1, canIface)
+            self:write(":")
+            self:printValue(kv[2], typ.elem, verb, depth+1, canIface)
+         end
+      end
+      self:write(self.sharpV and "}" or "]")
+   elseif k == __kindStruct then
+      if self.sharpV then
+         self:write(typ.__str)
+      end
+      self:write("{")
+      for i, f in ipairs(typ.fields) do
+         if i > 1 then
+            self:write(self.sharpV and ", " or " ")
+         end
+         if self.plusV or self.sharpV then
+            self:write(f.__name .. ":")
+         end
+         self:printValue(x[f.__prop], f.__typ, verb, depth+1, canIface and f.__exported)
+      end
+      self:write("}")
+   elseif k == __kindInterface then
+      if x == nil or x == __ifaceNil then
+         if self.sharpV then
+            self:write(typ.__str .. "(nil)")
+         else
+            self:write("<nil>")
+         end
+         return
+      end
+      local dyn = dynamicType(x)
+      if dyn == nil then
+         self:write(__gijit_fmtGo(self:spec(verb), x))
+         return
+      end
+      self:printValue(x, dyn, verb, depth+1, canIface, true)
+   elseif k == __kindArray or k == __kindSlice then
+      local n = typ.len
+      if k == __kindSlice then
+         n = 0
+         if not isNil(typ, x) then
+            n = #x
+         end
+      end
+      if (verb == "s" or verb == "q" or verb == "x" or verb == "X") and typ.elem.kind == __kindUint8 then
+         local b = {}
+         for i = 0, n - 1 do
+            b[i+1] = string.char(tonumber(__gi_GetRangeCheck(x, i)))
+         end
+         self:write(__gijit_fmtBytes(self:spec(verb), typ.__str, table.concat(b), false))
+         return
+      end
+      if self.sharpV then
+         self:write(typ.__str)
+         if k == __kindSlice and isNil(typ, x) then
+            self:write("(nil)")
+            return
+         end
+         self:write("{")
+      else
+         self:write("[")
+      end
+      for i = 0, n - 1 do
+         if i > 0 then
+            self:write(self.sharpV and ", " or " ")
+         end
+         self:printValue(__gi_GetRangeCheck(x, i), typ.elem, verb, depth+1, canIface)
+      end
+      self:write(self.sharpV and "}" or "]")
+   elseif k == __kindPtr then
+      -- pointer to array, slice, struct or map? Only
+      -- at the top level, as Go does, lest we loop.
+      local ek = typ.elem.kind
+      if depth == 0 and not isNil(typ, x) and
+         (ek == __kindArray or ek == __kindSlice or ek == __kindStruct or ek == __kindMap) then
+         self:write("&")
+         self:printValue(deref(x), typ.elem, verb, depth+1, canIface)
+         return
+      end
+      self:fmtPointer(x, typ, verb)
+   elseif k == __kindChan or k == __kindFunc or k == __kindUnsafePointer then
+      self:fmtPointer(x, typ, verb)
+   elseif depth == 0 then
+      self:write("<invalid reflect.Value>")
+   elseif verb == "v" then
+      self:write("<nil>")
+   else
+      self:badVerb(verb, nil, nil)
+   end
+end
+
+---------------------
+-- Printf and Print
+---------------------
+
+local function parsenum(s, start, stop)
+   if start >= stop then
+      return 0, false, stop
+   end
+   local num, isnum, newi = 0, false, start
+   while newi < stop do
+      local c = string.byte(s, newi+1)
+      if c < 48 or c > 57 then
+         break
+      end
+      if num > 1e6 then
+         -- overflow; a crazy long number, most likely.
+         return 0, false, stop
+      end
+      num = num*10 + (c - 48)
+      isnum = true
+      newi = newi + 1
+   end
+   return num, isnum, newi
+end
+
+-- intFromArg takes a * width or precision from
+-- argument argNum.
+local function intFromArg(args, types, argNum)
+   local num, ok = 0, false
+   if argNum < args.n then
+      local x = args[argNum+1]
+      local typ = types[argNum+1]
+      if typ == nil and x ~= nil then
+         typ = dynamicType(x)
+      end
+      if typ ~= nil and isInt(typ.kind) then
+         local n = tonumber(x)
+         if n == math.floor(n) then
+            num, ok = n, true
+         end
+      end
+      argNum = argNum + 1
+      if num > 1e6 or num < -1e6 then
+         num, ok = 0, false
+      end
+   end
+   return num, ok, argNum
+end
+
+function printer:argNumber(argNum, format, i, numArgs)
+   if #format <= i or string.sub(format, i+1, i+1) ~= "[" then
+      return argNum, i, false
+   end
+   self.reordered = true
+   local close = nil
+   if #format - i >= 3 then
+      close = string.find(format, "]", i+2, true)
+   end
+   if close == nil then
+      self.goodArgNum = false
+      return argNum, i + 1, false
+   end
+   -- close is the 1-based index of ]; 0-based, close-1.
+   local width, ok, newi = parsenum(format, i+1, close-1)
+   if not ok or newi ~= close-1 then
+      self.goodArgNum = false
+      return argNum, close, false
+   end
+   local index = width - 1
+   if index >= 0 and index < numArgs then
+      return index, close, true
+   end
+   self.goodArgNum = false
+   return argNum, close, true
+end
+
+function printer:doPrintf(format, args, types)
+   local stop = #format
+   local argNum = 0
+   local afterIndex = false
+   self.reordered = false
+   local i = 0
+   while i < stop do
+      self.goodArgNum = true
+      local lasti = i
+      while i < stop and string.byte(format, i+1) ~= 37 do
+         i = i + 1
+      end
+      if i > lasti then
+         self:write(string.sub(format, lasti+1, i))
+      end
+      if i >= stop then
+         break
+      end
+      -- skip the %
+      i = i + 1
+      self:clearflags()
+      while i < stop do
+         local c = string.sub(format, i+1, i+1)
+         if c == "#" then
+            self.sharp = true
+         elseif c == "0" then
+            self.zero = true
+         elseif c == "+" then
+            self.plus = true
+         elseif c == "-" then
+            self.minus = true
+         elseif c == " " then
+            self.space = true
+         else
+            break
+         end
+         i = i + 1
+      end
+
+      argNum, i, afterIndex = self:argNumber(argNum, format, i, args.n)
+
+      if i < stop and string.sub(format, i+1, i+1) == "*" then
+         i = i + 1
+         self.wid, self.widPresent, argNum = intFromArg(args, types, argNum)
+         if not self.widPresent then
+            self:write("%!(BADWIDTH)")
+         end
+         if self.wid < 0 then
+            self.wid = -self.wid
+            self.minus = true
+            self.zero = false
+         end
+         afterIndex = false
+      else
+         self.wid, self.widPresent, i = parsenum(format, i, stop)
+         if afterIndex and self.widPresent then
+            self.goodArgNum = false
+         end
+      end
+
+      if i+1 < stop and string.sub(format, i+1, i+1) == "." then
+         i = i + 1
+         if afterIndex then
+            self.goodArgNum = false
+         end
+         argNum, i, afterIndex = self:argNumber(argNum, format, i, args.n)
+         if i < stop and string.sub(format, i+1, i+1) == "*" then
+            i = i + 1
+            self.prec, self.precPresent, argNum = intFromArg(args, types, argNum)
+            if self.prec < 0 then
+               self.prec = 0
+               self.precPresent = false
+            end
+            if not self.precPresent then
+               self:write("%!(BADPREC)")
+            end
+            afterIndex = false
+         else
+            self.prec, self.precPresent, i = parsenum(format, i, stop)
+            if not self.precPresent then
+               self.prec = 0
+               self.precPresent = true
+            end
+         end
+      end
+
+      if not afterIndex then
+         argNum, i, afterIndex = self:argNumber(argNum, format, i, args.n)
+      end
+
+      if i >= stop then
+         self:write("%!(NOVERB)")
+         break
+      end
+
+      local verb = string.match(format, "^[%z\1-\127\192-\255][\128-\191]*", i+1)
+      i = i + #verb
+
+      if verb == "%" then
+         self:write("%")
+      elseif not self.goodArgNum then
+         self:write("%!" .. verb .. "(BADINDEX)")
+      elseif argNum >= args.n then
+         self:write("%!" .. verb .. "(MISSING)")
+      else
+         if verb == "v" or verb == "w" then
+            self.sharpV, self.sharp = self.sharp, false
+            self.plusV, self.plus = self.plus, false
+         end
+         self:printArg(args[argNum+1], types[argNum+1], verb)
+         argNum = argNum + 1
+      end
+   end
+
+   if not self.reordered and argNum < args.n then
+      self:clearflags()
+      self:write("%!(EXTRA ")
+      for j = argNum, args.n - 1 do
+         if j > argNum then
+            self:write(", ")
+         end
+         local x, typ = args[j+1], types[j+1]
+         if typ == nil and x ~= nil and x ~= __ifaceNil then
+            typ = dynamicType(x)
+         end
+         if x == nil or x == __ifaceNil then
+            self:write("<nil>")
+         elseif typ == nil then
+            self:write(__gijit_fmtGo("%T=%v", x))
+         else
+            self:write(typ.__str .. "=")
+            self:printArg(x, typ, "v")
+         end
+      end
+      self:write(")")
+   end
+end
+
+local function isString(x, typ)
+   if typ == nil then
+      if x == nil or x == __ifaceNil then
+         return false
+      end
+      typ = dynamicType(x)
+      if typ == nil then
+         return type(x) == "string"
+      end
+   end
+   return typ.kind == __kindString
+end
+
+function printer:doPrint(args, types)
+   local prevString = false
+   for j = 1, args.n do
+      local x, typ = args[j], types[j]
+      local isStr = isString(x, typ)
+      if j > 1 and not isStr and not prevString then
+         self:write(" ")
+      end
+      self:printArg(x, typ, "v")
+      prevString = isStr
+   end
+end
+
+function printer:doPrintln(args, types)
+   for j = 1, args.n do
+      if j > 1 then
+         self:write(" ")
+      end
+      self:printArg(args[j], types[j], "v")
+   end
+   self:write("\n")
+end
+
+-- packArgs collects the variadic arguments; spread
+-- from a []interface{} with ..., they come as one
+-- __lazy_ellipsis, and have no static types. As
+-- luar does for Go, we take what is not a slice in
+-- there, like the lone argument that a gijit
+-- variadic func has for its ...interface{}, as
+-- itself.
+local function packArgs(types, ...)
+   local args = {n = select("#", ...), ...}
+   if args.n == 1 and type(args[1]) == "table" and rawget(args[1], "__name") == "__lazy_ellipsis_instance" then
+      local s = args[1]()
+      args = {n = 0}
+      types = {}
+      if type(s) ~= "table" or s.__name == nil then
+         if s ~= nil then
+            args = {n = 1, s}
+         end
+      elseif s.__length ~= nil then
+         args.n = #s
+         for i = 0, args.n - 1 do
+            args[i+1] = __gi_GetRangeCheck(s, i)
+         end
+      end
+   end
+   return args, types
+end
+
+local function sprintf(wrapErrs, format, types, ...)
+   local args
+   args, types = packArgs(types, ...)
+   local p = newPrinter()
+   p.wrapErrs = wrapErrs
+   p:doPrintf(format, args, types)
+   return p:string()
+end
+
+local function sprint(types, ...)
+   local args
+   args, types = packArgs(types, ...)
+   local p = newPrinter()
+   p:doPrint(args, types)
+   return p:string()
+end
+
+local function sprintln(types, ...)
+   local args
+   args, types = packArgs(types, ...)
+   local p = newPrinter()
+   p:doPrintln(args, types)
+   return p:string()
+end
+
+-- fwrite writes s to w: the fmt.State of a Format
+-- method, a gijit io.Writer, or a Go one.
+local function fwrite(w, s)
+   if type(w) == "table" and getmetatable(w) == stateMT then
+      w.__p:write(s)
+      return 0LL + #s, nil
+   end
+   local typ = __gijit_reflectDynamicType(w)
+   if typ ~= nil then
+      local m = findMethod(typ, "Write", true)
+      if m ~= nil then
+         return callMethod(typ, w, m, __sliceType(__type__.uint8)(__stringToBytes(s)))
+      end
+   end
+   return __gijit_fmtFwrite(w, s)
+end
+
+function __gijit_fmtSprintf(format, types, ...)
+   return sprintf(false, format, types, ...)
+end
+
+function __gijit_fmtSprint(types, ...)
+   return sprint(types, ...)
+end
+
+function __gijit_fmtSprintln(types, ...)
+   return sprintln(types, ...)
+end
+
+function __gijit_fmtErrorf(format, types, ...)
+   return __gijit_fmtError(sprintf(true, format, types, ...))
+end
+
+function __gijit_fmtPrintf(format, types, ...)
+   return __gijit_fmtWrite(sprintf(false, format, types, ...))
+end
+
+function __gijit_fmtPrint(types, ...)
+   return __gijit_fmtWrite(sprint(types, ...))
+end
+
+function __gijit_fmtPrintln(types, ...)
+   return __gijit_fmtWrite(sprintln(types, ...))
+end
+
+function __gijit_fmtFprintf(w, format, types, ...)
+   return fwrite(w, sprintf(false, format, types, ...))
+end
+
+function __gijit_fmtFprint(w, types, ...)
+   return fwrite(w, sprint(types, ...))
+end
+
+function __gijit_fmtFprintln(w, types, ...)
+   return fwrite(w, sprintln(types, ...))
+end
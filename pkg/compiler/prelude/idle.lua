@@ -0,0 +1,44 @@
+-- idle.lua: running goroutines while the REPL waits.
+--
+-- Between keystrokes the REPL calls __gijit_idle, which
+-- resumes the scheduler if any goroutine can make
+-- progress. What the goroutines print, by print(),
+-- io.write, or fmt, is collected rather than written,
+-- and returned, so the REPL can show it above the line
+-- being edited instead of in the middle of it.
+
+-- __gijit_idle returns what the goroutines printed,
+-- or "" if none ran or none printed.
+function __gijit_idle()
+   if not __task.idle_pending() then
+      return ""
+   end
+   local out = {}
+   local print0, write0, fmtWrite0 = print, io.write, __gijit_fmtWrite
+
+   print = function(...)
+      local s = {}
+      for i = 1, select("#", ...) do
+         s[i] = tostring((select(i, ...)))
+      end
+      out[#out+1] = table.concat(s, "\t").."\n"
+   end
+   io.write = function(...)
+      for i = 1, select("#", ...) do
+         out[#out+1] = tostring((select(i, ...)))
+      end
+      return io.stdout
+   end
+   __gijit_fmtWrite = function(s)
+      out[#out+1] = s
+      return #s, nil
+   end
+
+   local ok, err = pcall(__task.resume_scheduler)
+
+   print, io.write, __gijit_fmtWrite = print0, write0, fmtWrite0
+   if not ok then
+      out[#out+1] = "error in goroutine: "..tostring(err).."\n"
+   end
+   return table.concat(out)
+end
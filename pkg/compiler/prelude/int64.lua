@@ -92,8 +92,20 @@ __stringToBytes = function(str)
 end;
 
 __bytesToString = function(ba)
-   if ba.__array ~= nil then
-      return string.sub(ffi.string(ba.__array.__bytes), 1, ba.__array.__sz)
+   local arr = ba.__array
+   if arr ~= nil then
+      -- only the slice's own window of the array.
+      local off = tonumber(ba.__offset)
+      local n = tonumber(ba.__length)
+      if arr.__bytes ~= nil then
+         return ffi.string(arr.__bytes + off, n)
+      end
+      -- arrays from make([]byte, n) are tables.
+      local t = {}
+      for i = 0, n-1 do
+         t[i+1] = string.char(tonumber(arr[off+i]))
+      end
+      return table.concat(t)
    end
    if type(ba) == "userdata" then
       -- most likely a proxy
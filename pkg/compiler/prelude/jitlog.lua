@@ -0,0 +1,189 @@
+-- jitlog.lua
+--
+-- support for the :jit command at the REPL, which
+-- records LuaJIT trace events via jit.attach, so we
+-- can report which Go loops compiled, and why the
+-- others aborted. Locations are chunk:line, which
+-- the Go side maps back to Go source.
+
+local __jitutil = require("jit.util")
+
+__jitlog = {on = false, events = {}}
+
+-- trace error messages, indexed by error code,
+-- in the order of LuaJIT's lj_traceerr.h. (The
+-- jit.vmdef module that v.lua uses for these is
+-- generated at LuaJIT build time, and we don't ship it.)
+__jitlog_traceerr = {
+   [0] = "error thrown or hook called during recording",
+   "trace too short",
+   "trace too long",
+   "trace too deep",
+   "too many snapshots",
+   "blacklisted",
+   "retry recording",
+   "NYI: bytecode %s",
+   "leaving loop in root trace",
+   "inner loop in root trace",
+   "loop unroll limit reached",
+   "bad argument type",
+   "JIT compilation disabled for function",
+   "call unroll limit reached",
+   "down-recursion, restarting",
+   "NYI: unsupported variant of FastFunc %s",
+   "NYI: return to lower frame",
+   "store with nil or NaN key",
+   "missing metamethod",
+   "looping index lookup",
+   "NYI: mixed sparse/dense table",
+   "symbol not in cache",
+   "NYI: unsupported C type conversion",
+   "NYI: unsupported C function type",
+   "guard would always fail",
+   "too many PHIs",
+   "persistent type instability",
+   "failed to allocate mcode memory",
+   "machine code too long",
+   "hit mcode limit (retrying)",
+   "too many spill slots",
+   "inconsistent register allocation",
+   "NYI: cannot assemble IR instruction %s",
+   "NYI: PHI shuffling too complex",
+   "NYI: register coalescing too complex",
+}
+local NYIBC = 7
+
+-- bytecode names, in the order of lj_bc.h.
+__jitlog_bcnames = {[0] = "ISLT",
+   "ISGE", "ISLE", "ISGT", "ISEQV", "ISNEV", "ISEQS", "ISNES", "ISEQN",
+   "ISNEN", "ISEQP", "ISNEP", "ISTC", "ISFC", "IST", "ISF", "ISTYPE",
+   "ISNUM", "MOV", "NOT", "UNM", "LEN", "ADDVN", "SUBVN", "MULVN",
+   "DIVVN", "MODVN", "ADDNV", "SUBNV", "MULNV", "DIVNV", "MODNV", "ADDVV",
+   "SUBVV", "MULVV", "DIVVV", "MODVV", "POW", "CAT", "KSTR", "KCDATA",
+   "KSHORT", "KNUM", "KPRI", "KNIL", "UGET", "USETV", "USETS", "USETN",
+   "USETP", "UCLO", "FNEW", "TNEW", "TDUP", "GGET", "GSET", "TGETV",
+   "TGETS", "TGETB", "TGETR", "TSETV", "TSETS", "TSETB", "TSETM", "TSETR",
+   "CALLM", "CALL", "CALLMT", "CALLT", "ITERC", "ITERN", "VARG", "ISNEXT",
+   "RETM", "RET", "RET0", "RET1", "FORI", "JFORI", "FORL", "IFORL",
+   "JFORL", "ITERL", "IITERL", "JITERL", "LOOP", "ILOOP", "JLOOP", "JMP",
+   "FUNCF", "IFUNCF", "JFUNCF", "FUNCV", "IFUNCV", "JFUNCV", "FUNCC", "FUNCCW",
+}
+
+-- builtin function value -> name, e.g. "string.format",
+-- built on first use.
+local __jitlog_ffnames
+
+local function ffname(func)
+   if __jitlog_ffnames == nil then
+      __jitlog_ffnames = {}
+      for _, lib in ipairs({"string", "table", "math", "coroutine", "bit", "io", "os", "jit", "debug"}) do
+         local t = _G[lib]
+         if type(t) == "table" then
+            for k, v in pairs(t) do
+               if type(v) == "function" then
+                  __jitlog_ffnames[v] = lib.."."..k
+               end
+            end
+         end
+      end
+      for k, v in pairs(_G) do
+         if type(v) == "function" and __jitlog_ffnames[v] == nil then
+            __jitlog_ffnames[v] = k
+         end
+      end
+   end
+   return __jitlog_ffnames[func] or "builtin"
+end
+
+local function fmtfunc(func, pc)
+   local fi = __jitutil.funcinfo(func, pc)
+   if fi.loc then
+      return fi.loc
+   elseif fi.ffid then
+      return ffname(func)
+   elseif fi.addr then
+      return string.format("C:%x", fi.addr)
+   end
+   return "(?)"
+end
+
+local function fmterr(err, info)
+   if type(err) ~= "number" then
+      return tostring(err)
+   end
+   local msg = __jitlog_traceerr[err]
+   if msg == nil then
+      return "trace error "..tostring(err)
+   end
+   if type(info) == "function" then
+      info = fmtfunc(info)
+   elseif err == NYIBC then
+      info = __jitlog_bcnames[info] or info
+   end
+   return string.format(msg, tostring(info))
+end
+
+local __jitlog_cur = {}
+
+local function __jitlog_trace(what, tr, func, pc, otr, oex)
+   if what == "start" then
+      __jitlog_cur = {
+         tr = tr,
+         startloc = fmtfunc(func, pc),
+         parent = otr or 0,
+         exit = oex or 0,
+      }
+      return
+   end
+   local ev = {what = what, tr = tr or 0, parent = 0, exit = 0,
+               startloc = "", loc = "", reason = "", linktype = "", link = 0}
+   if what == "stop" or what == "abort" then
+      local cur = __jitlog_cur
+      ev.parent = cur.parent or 0
+      ev.exit = cur.exit or 0
+      ev.startloc = cur.startloc or ""
+      ev.loc = fmtfunc(func, pc)
+   end
+   if what == "abort" then
+      ev.reason = fmterr(otr, oex)
+   elseif what == "stop" then
+      local info = __jitutil.traceinfo(tr)
+      if info then
+         ev.link = info.link
+         ev.linktype = info.linktype
+      end
+   end
+   table.insert(__jitlog.events, ev)
+end
+
+-- __jitlog_on starts recording trace events,
+-- discarding any earlier ones. Compiled traces are
+-- flushed, so that already hot loops are
+-- recompiled, and reported, afresh.
+function __jitlog_on()
+   if __jitlog.on then
+      jit.attach(__jitlog_trace)
+   end
+   __jitlog = {on = true, events = {}}
+   jit.flush()
+   jit.attach(__jitlog_trace, "trace")
+end
+
+function __jitlog_off()
+   if __jitlog.on then
+      jit.attach(__jitlog_trace)
+      __jitlog.on = false
+   end
+end
+
+-- __jitlog_report calls
+-- report(what, tr, parent, exit, startloc, loc, reason, linktype, link)
+-- for each event recorded, in order.
+-- what is "stop" for a compiled trace, "abort",
+-- or "flush".
+function __jitlog_report(report)
+   for _, ev in ipairs(__jitlog.events) do
+      report(ev.what, ev.tr, ev.parent, ev.exit, ev.startloc, ev.loc, ev.reason, ev.linktype, ev.link)
+   end
+   return #__jitlog.events
+end
@@ -0,0 +1,939 @@
+-- json.lua
+--
+-- encoding/json for gijit values; see json.go. The
+-- compiler turns json.Marshal(x), MarshalIndent and
+-- Unmarshal into the __gijit_json functions here,
+-- passing the static type of x, as for reflect.
+-- We follow encoding/json: exported fields, promoted
+-- through embedded structs by Go's rules, under the
+-- names and options of their json tags; maps with
+-- sorted keys; []byte as base64; MarshalJSON and
+-- UnmarshalJSON methods on gijit structs.
+
+-- the type to marshal x as, when its static
+-- type is an interface.
+local function valueType(x)
+   if type(x) == "table" then
+      local typ = x.__typ
+      if type(typ) == "table" and typ.kind ~= nil then
+         return typ
+      end
+   end
+   return __gijit_reflectDynamicType(x)
+end
+
+local function isSigned(kind)
+   return kind >= __kindInt and kind <= __kindInt64
+end
+
+local function isUnsigned(kind)
+   return kind >= __kindUint and kind <= __kindUintptr
+end
+
+local function isNil(typ, x)
+   return x == nil or x == __ifaceNil or x == typ.__nil
+end
+
+local function intString(x)
+   if type(x) == "number" then
+      return string.format("%d", x)
+   end
+   return (string.match(tostring(x), "^-?%d+"))
+end
+
+local function byteSlice(s)
+   return __sliceType(__type__.uint8)(__stringToBytes(s))
+end
+
+-- jsonMethod returns typ's method called name,
+-- if a value of typ has it; an addressable one
+-- also has the methods with pointer receivers.
+-- Only gijit structs have methods we can call.
+local function jsonMethod(typ, name, addr)
+   local st = typ
+   if typ.kind == __kindPtr then
+      st = typ.elem
+   end
+   if st.kind ~= __kindStruct then
+      return nil
+   end
+   for _, m in ipairs(__methodSet(st)) do
+      if m.__name == name and (typ.kind == __kindPtr or addr or not m.__ptrRecv) then
+         return m
+      end
+   end
+   return nil
+end
+
+---------------------
+-- struct fields
+---------------------
+
+local function parseTag(tag)
+   local name, rest = string.match(tag, "^([^,]*)(.*)$")
+   local opts = {}
+   for opt in string.gmatch(rest, ",([^,]*)") do
+      opts[opt] = true
+   end
+   return name, opts
+end
+
+local function isValidTag(s)
+   if s == "" then
+      return false
+   end
+   for i = 1, #s do
+      local c = string.sub(s, i, i)
+      if not (string.find("!#$%&()*+-./:<=>?@[]^_{|}~ ", c, 1, true)
+              or string.match(c, "%w") or string.byte(c) >= 0x80) then
+         return false
+      end
+   end
+   return true
+end
+
+local function lessIndex(a, b)
+   for i = 1, math.min(#a, #b) do
+      if a[i] ~= b[i] then
+         return a[i] < b[i]
+      end
+   end
+   return #a < #b
+end
+
+-- typeFields returns the fields that encoding/json
+-- sees in struct type t, in the order it does.
+local function typeFields(t)
+   local cached = rawget(t, "__jsonFields")
+   if cached ~= nil then
+      return cached
+   end
+   local fields = {}
+   local next = {{typ = t, index = {}}}
+   local count, nextCount = {}, {}
+   local visited = {}
+   while #next > 0 do
+      local current = next
+      next = {}
+      count, nextCount = nextCount, {}
+      for _, f in ipairs(current) do
+         if not visited[f.typ] then
+            visited[f.typ] = true
+            for i, sf in ipairs(f.typ.fields) do
+               local ft = sf.__typ
+               local skip = false
+               if sf.__anonymous then
+                  local et = ft
+                  if et.kind == __kindPtr then
+                     et = et.elem
+                  end
+                  if not sf.__exported and et.kind ~= __kindStruct then
+                     skip = true
+                  end
+               elseif not sf.__exported then
+                  skip = true
+               end
+               local tag = setmetatable({__tag = sf.__tag or ""}, __gijit_structTagMT):Get("json")
+               if tag == "-" then
+                  skip = true
+               end
+               if not skip then
+                  local name, opts = parseTag(tag)
+                  if not isValidTag(name) then
+                     name = ""
+                  end
+                  local index = {unpack(f.index)}
+                  table.insert(index, i-1)
+                  if not ft.named and ft.kind == __kindPtr then
+                     ft = ft.elem
+                  end
+                  local quoted = false
+                  if opts.string then
+                     local k = ft.kind
+                     quoted = k == __kindBool or isSigned(k) or isUnsigned(k) or
+                        k == __kindFloat32 or k == __kindFloat64 or k == __kindString
+                  end
+                  if name ~= "" or not sf.__anonymous or ft.kind ~= __kindStruct then
+                     local field = {
+                        name = name ~= "" and name or sf.__name,
+                        tag = name ~= "",
+                        index = index,
+                        typ = sf.__typ,
+                        omitEmpty = opts.omitempty == true,
+                        quoted = quoted,
+                     }
+                     field.key = __gijit_jsonQuote(field.name) .. ":"
+                     table.insert(fields, field)
+                     if (count[f.typ] or 0) > 1 then
+                        -- a second copy, so that the
+                        -- duplicate annihilates below.
+                        table.insert(fields, field)
+                     end
+                  else
+                     nextCount[ft] = (nextCount[ft] or 0) + 1
+                     if nextCount[ft] == 1 then
+                        table.insert(next, {typ = ft, index = index})
+                     end
+                  end
+               end
+            end
+         end
+      end
+   end
+
+   table.sort(fields, function(a, b)
+      if a.name ~= b.name then
+         return a.name < b.name
+      end
+      if #a.index ~= #b.index then
+         return #a.index < #b.index
+      end
+      if a.tag ~= b.tag then
+         return a.tag
+      end
+      return lessIndex(a.index, b.index)
+   end)
+
+   -- of the fields with one name, the shallowest
+   -- wins, tagged or not; a tie hides them all.
+   local out = {}
+   local i = 1
+   while i <= #fields do
+      local j = i + 1
+      while j <= #fields and fields[j].name == fields[i].name do
+         j = j + 1
+      end
+      local a, b = fields[i], fields[i+1]
+      if j - i == 1 or #a.index ~= #b.index or a.tag ~= b.tag then
+         table.insert(out, a)
+      end
+      i = j
+   end
+   table.sort(out, function(a, b) return lessIndex(a.index, b.index) end)
+
+   rawset(t, "__jsonFields", out)
+   return out
+end
+
+---------------------
+-- Marshal
+---------------------
+
+-- errString is the message of err, as luar
+-- hands gijit errors from Go: a string.
+local function errString(err)
+   if type(err) == "string" then
+      return err
+   end
+   return err:Error()
+end
+
+local function fail(err)
+   error({__jsonErr = err}, 0)
+end
+
+local function unsupportedType(typ)
+   fail("json: unsupported type: " .. typ.__str)
+end
+
+-- fieldValue follows index from struct x of
+-- type st, returning nil if it passes through
+-- a nil embedded pointer.
+local function fieldValue(st, x, index)
+   local typ = st
+   for _, j in ipairs(index) do
+      if typ.kind == __kindPtr then
+         if isNil(typ, x) then
+            return nil, false
+         end
+         typ = typ.elem
+      end
+      local f = typ.fields[j+1]
+      x = x[f.__prop]
+      typ = f.__typ
+   end
+   return x, true
+end
+
+local function isEmpty(typ, x)
+   local k = typ.kind
+   if k == __kindArray then
+      return typ.len == 0
+   elseif k == __kindMap then
+      return isNil(typ, x) or #x == 0
+   elseif k == __kindSlice then
+      return isNil(typ, x) or #x == 0
+   elseif k == __kindString then
+      return x == ""
+   elseif k == __kindBool then
+      return not x
+   elseif isSigned(k) or isUnsigned(k) or k == __kindFloat32 or k == __kindFloat64 then
+      return x == 0
+   elseif k == __kindInterface or k == __kindPtr then
+      return isNil(typ, x)
+   end
+   return false
+end
+
+local encode
+
+local function callMarshaler(b, typ, x, m, what)
+   local res, err = x[m.prop](x)
+   if err ~= nil then
+      fail("json: error calling " .. what .. " for type " .. typ.__str .. ": " .. errString(err))
+   end
+   local s = __bytesToString(res)
+   if what == "MarshalText" then
+      table.insert(b, __gijit_jsonQuote(s))
+      return
+   end
+   local c, cerr = __gijit_jsonCompact(s)
+   if cerr ~= nil then
+      fail("json: error calling MarshalJSON for type " .. typ.__str .. ": " .. cerr)
+   end
+   table.insert(b, c)
+end
+
+local function encodeStruct(b, typ, x, addr)
+   table.insert(b, "{")
+   local first = true
+   for _, f in ipairs(typeFields(typ)) do
+      local fx, ok = fieldValue(typ, x, f.index)
+      if ok and not (f.omitEmpty and isEmpty(f.typ, fx)) then
+         if not first then
+            table.insert(b, ",")
+         end
+         first = false
+         table.insert(b, f.key)
+         encode(b, f.typ, fx, addr, f.quoted)
+      end
+   end
+   table.insert(b, "}")
+end
+
+local function encodeMap(b, typ, x)
+   if isNil(typ, x) then
+      table.insert(b, "null")
+      return
+   end
+   local kk = typ.key.kind
+   local keys = {}
+   for k, v in pairs(x) do
+      local ks
+      if kk == __kindString then
+         ks = k
+      elseif isSigned(kk) or isUnsigned(kk) then
+         ks = intString(k)
+      else
+         unsupportedType(typ)
+      end
+      table.insert(keys, {ks, v})
+   end
+   table.sort(keys, function(a, b) return a[1] < b[1] end)
+   table.insert(b, "{")
+   for i, kv in ipairs(keys) do
+      if i > 1 then
+         table.insert(b, ",")
+      end
+      table.insert(b, __gijit_jsonQuote(kv[1]))
+      table.insert(b, ":")
+      encode(b, typ.elem, kv[2], false, false)
+   end
+   table.insert(b, "}")
+end
+
+encode = function(b, typ, x, addr, quoted)
+   local k = typ.kind
+   if k == __kindPtr and isNil(typ, x) then
+      table.insert(b, "null")
+      return
+   end
+   local m = jsonMethod(typ, "MarshalJSON", addr)
+   if m ~= nil then
+      callMarshaler(b, typ, x, m, "MarshalJSON")
+      return
+   end
+   m = jsonMethod(typ, "MarshalText", addr)
+   if m ~= nil then
+      callMarshaler(b, typ, x, m, "MarshalText")
+      return
+   end
+
+   local s
+   if k == __kindBool then
+      s = x and "true" or "false"
+   elseif isSigned(k) or isUnsigned(k) then
+      s = intString(x)
+   elseif k == __kindFloat32 or k == __kindFloat64 then
+      local err
+      s, err = __gijit_jsonFloat(tonumber(x), k == __kindFloat32 and 32 or 64)
+      if err ~= nil then
+         fail(err)
+      end
+   elseif k == __kindString then
+      s = __gijit_jsonQuote(x)
+      if quoted then
+         s = __gijit_jsonQuote(s)
+      end
+      table.insert(b, s)
+      return
+   end
+   if s ~= nil then
+      if quoted then
+         s = '"' .. s .. '"'
+      end
+      table.insert(b, s)
+      return
+   end
+
+   if k == __kindInterface then
+      if isNil(typ, x) then
+         table.insert(b, "null")
+         return
+      end
+      local dyn = valueType(x)
+      if dyn == nil then
+         -- a Go value; let Go do it.
+         local js, err = __gijit_jsonGo(x)
+         if err ~= nil then
+            fail(err)
+         end
+         table.insert(b, js)
+         return
+      end
+      encode(b, dyn, x, false, false)
+   elseif k == __kindStruct then
+      encodeStruct(b, typ, x, addr)
+   elseif k == __kindMap then
+      encodeMap(b, typ, x)
+   elseif k == __kindSlice then
+      if isNil(typ, x) then
+         table.insert(b, "null")
+         return
+      end
+      if typ.elem.kind == __kindUint8 then
+         table.insert(b, '"' .. __gijit_jsonBase64(__bytesToString(x)) .. '"')
+         return
+      end
+      table.insert(b, "[")
+      for i = 0, #x - 1 do
+         if i > 0 then
+            table.insert(b, ",")
+         end
+         encode(b, typ.elem, __gi_GetRangeCheck(x, i), true, false)
+      end
+      table.insert(b, "]")
+   elseif k == __kindArray then
+      table.insert(b, "[")
+      for i = 0, typ.len - 1 do
+         if i > 0 then
+            table.insert(b, ",")
+         end
+         encode(b, typ.elem, x[i], addr, false)
+      end
+      table.insert(b, "]")
+   elseif k == __kindPtr then
+      -- gijit holds structs by pointer, so
+      -- the pointer is the struct.
+      if typ.elem.kind == __kindStruct then
+         encode(b, typ.elem, x, true, quoted)
+      else
+         encode(b, typ.elem, x.__get(), true, quoted)
+      end
+   else
+      unsupportedType(typ)
+   end
+end
+
+local function marshal(x, typ)
+   if typ == nil then
+      if x == nil then
+         return "null", nil
+      end
+      typ = valueType(x)
+      if typ == nil then
+         return __gijit_jsonGo(x)
+      end
+   end
+   local b = {}
+   local ok, e = pcall(encode, b, typ, x, false, false)
+   if not ok then
+      if type(e) == "table" and e.__jsonErr ~= nil then
+         return nil, e.__jsonErr
+      end
+      error(e, 0)
+   end
+   return table.concat(b), nil
+end
+
+function __gijit_jsonMarshal(x, typ)
+   local s, err = marshal(x, typ)
+   if err ~= nil then
+      return __sliceType(__type__.uint8).__nil, err
+   end
+   return byteSlice(s), nil
+end
+
+function __gijit_jsonMarshalIndent(x, prefix, indent, typ)
+   local s, err = marshal(x, typ)
+   if err ~= nil then
+      return __sliceType(__type__.uint8).__nil, err
+   end
+   return byteSlice(__gijit_jsonIndent(s, prefix, indent)), nil
+end
+
+---------------------
+-- Unmarshal
+---------------------
+
+-- parse reads the JSON value in s at i, already
+-- checked by Go, as a node that keeps its text.
+local function skipSpace(s, i)
+   return string.find(s, "[^ \t\r\n]", i) or #s + 1
+end
+
+local parse
+
+local function parseString(s, i)
+   local j = i + 1
+   while true do
+      local q = string.find(s, '["\\]', j)
+      if string.sub(s, q, q) == '"' then
+         return {kind = "string", raw = string.sub(s, i, q)}, q + 1
+      end
+      j = q + 2
+   end
+end
+
+parse = function(s, i)
+   i = skipSpace(s, i)
+   local c = string.sub(s, i, i)
+   if c == "{" then
+      local node = {kind = "object", keys = {}, vals = {}}
+      local from = i
+      i = skipSpace(s, i + 1)
+      if string.sub(s, i, i) == "}" then
+         node.raw = string.sub(s, from, i)
+         return node, i + 1
+      end
+      while true do
+         local key, val
+         key, i = parseString(s, skipSpace(s, i))
+         i = skipSpace(s, i) + 1 -- the colon
+         val, i = parse(s, i)
+         table.insert(node.keys, key)
+         table.insert(node.vals, val)
+         i = skipSpace(s, i)
+         if string.sub(s, i, i) == "}" then
+            node.raw = string.sub(s, from, i)
+            return node, i + 1
+         end
+         i = i + 1 -- the comma
+      end
+   elseif c == "[" then
+      local node = {kind = "array", vals = {}}
+      local from = i
+      i = skipSpace(s, i + 1)
+      if string.sub(s, i, i) == "]" then
+         node.raw = string.sub(s, from, i)
+         return node, i + 1
+      end
+      while true do
+         local val
+         val, i = parse(s, i)
+         table.insert(node.vals, val)
+         i = skipSpace(s, i)
+         if string.sub(s, i, i) == "]" then
+            node.raw = string.sub(s, from, i)
+            return node, i + 1
+         end
+         i = i + 1
+      end
+   elseif c == '"' then
+      return parseString(s, i)
+   elseif c == "t" then
+      return {kind = "bool", value = true, raw = "true"}, i + 4
+   elseif c == "f" then
+      return {kind = "bool", value = false, raw = "false"}, i + 5
+   elseif c == "n" then
+      return {kind = "null", raw = "null"}, i + 4
+   end
+   local j = string.find(s, "[^-+.eE%d]", i) or #s + 1
+   return {kind = "number", raw = string.sub(s, i, j - 1)}, j
+end
+
+local function unquote(node)
+   local raw = node.raw
+   if not string.find(raw, "[\\\128-\255]") then
+      return string.sub(raw, 2, -2)
+   end
+   return __gijit_jsonUnquote(raw)
+end
+
+-- what a node is, for errors, as Go says.
+local function what(node)
+   if node.kind == "null" then
+      return "null"
+   end
+   return node.kind
+end
+
+-- parseInt reads raw as an integer of the given
+-- bits, or returns nil if it is not one.
+local function parseInt(raw, signed, bits)
+   local neg = string.sub(raw, 1, 1) == "-"
+   local digits = neg and string.sub(raw, 2) or raw
+   if not string.match(digits, "^%d+$") or (neg and not signed) then
+      return nil
+   end
+   local lim
+   if signed then
+      lim = 0ULL + 2^(bits-1)
+      if not neg then
+         lim = lim - 1
+      end
+   elseif bits == 64 then
+      lim = 0xffffffffffffffffULL
+   else
+      lim = 0ULL + 2^bits - 1
+   end
+   local v = 0ULL
+   for i = 1, #digits do
+      local d = string.byte(digits, i) - 48
+      if v > (lim - d) / 10 then
+         return nil
+      end
+      v = v * 10 + d
+   end
+   if not signed then
+      return v
+   end
+   local r = __ffi.cast("int64_t", v)
+   if neg then
+      r = -r
+   end
+   return 0LL + r
+end
+
+local function bitsOf(kind)
+   if kind == __kindInt8 or kind == __kindUint8 then
+      return 8
+   elseif kind == __kindInt16 or kind == __kindUint16 then
+      return 16
+   elseif kind == __kindInt32 or kind == __kindUint32 then
+      return 32
+   end
+   return 64
+end
+
+local function typeError(d, value, typ)
+   if d.err ~= nil then
+      return
+   end
+   if d.struct ~= nil then
+      d.err = "json: cannot unmarshal " .. value .. " into Go struct field " .. d.struct .. "." .. d.field .. " of type " .. typ.__str
+   else
+      d.err = "json: cannot unmarshal " .. value .. " into Go value of type " .. typ.__str
+   end
+end
+
+-- generic is node as an interface{} holds it.
+local function generic(node)
+   local k = node.kind
+   if k == "object" then
+      local mty = __mapType(__type__.string, __type__.emptyInterface)
+      local m = __makeMap({}, __type__.string, __type__.emptyInterface, mty)
+      for i, key in ipairs(node.keys) do
+         m[unquote(key)] = generic(node.vals[i])
+      end
+      return m
+   elseif k == "array" then
+      local s = __makeSlice(__sliceType(__type__.emptyInterface), #node.vals)
+      for i, v in ipairs(node.vals) do
+         __gi_SetRangeCheck(s, i-1, generic(v))
+      end
+      return s
+   elseif k == "string" then
+      return unquote(node)
+   elseif k == "number" then
+      return tonumber(node.raw)
+   elseif k == "bool" then
+      return node.value
+   end
+   return nil
+end
+
+-- zero is typ's zero value, as a field holds it.
+local function zero(typ)
+   if typ.kind == __kindMap then
+      return false
+   end
+   return typ.zero()
+end
+
+local decode
+
+local function callUnmarshaler(d, node, p, m)
+   local arg = node.raw
+   if m.__name == "UnmarshalText" then
+      arg = unquote(node)
+   end
+   local err = p[m.prop](p, byteSlice(arg))
+   if err ~= nil and d.err == nil then
+      d.err = err
+   end
+end
+
+local function decodeStruct(d, node, typ, x)
+   if node.kind ~= "object" then
+      typeError(d, what(node), typ)
+      return
+   end
+   local fields = typeFields(typ)
+   for i, keyNode in ipairs(node.keys) do
+      local key = unquote(keyNode)
+      local f
+      for _, ff in ipairs(fields) do
+         if ff.name == key then
+            f = ff
+            break
+         end
+      end
+      if f == nil then
+         local lower = string.lower(key)
+         for _, ff in ipairs(fields) do
+            if string.lower(ff.name) == lower then
+               f = ff
+               break
+            end
+         end
+      end
+      if f ~= nil then
+         -- find the struct holding the field,
+         -- making any nil embedded pointers.
+         local owner, otyp = x, typ
+         for n = 1, #f.index - 1 do
+            local sf = otyp.fields[f.index[n]+1]
+            local v = owner[sf.__prop]
+            local vt = sf.__typ
+            if vt.kind == __kindPtr then
+               if isNil(vt, v) then
+                  v = vt.elem.zero()
+                  owner[sf.__prop] = v
+               end
+               vt = vt.elem
+            end
+            owner, otyp = v, vt
+         end
+         local sf = otyp.fields[f.index[#f.index]+1]
+         local val = node.vals[i]
+         local saveStruct, saveField = d.struct, d.field
+         d.struct = string.match(typ.__str, "[^%.]*$")
+         d.field = f.name
+         if f.quoted and val.kind == "string" then
+            local inner = unquote(val)
+            local ok, lit = pcall(parse, inner, 1)
+            if ok and lit.kind ~= "object" and lit.kind ~= "array" and lit.raw == inner then
+               val = lit
+            else
+               if d.err == nil then
+                  d.err = string.format("json: invalid use of ,string struct tag, trying to unmarshal %s into %s", val.raw, sf.__typ.__str)
+               end
+               val = nil
+            end
+         end
+         if val ~= nil then
+            decode(d, val, sf.__typ,
+                   function() return owner[sf.__prop] end,
+                   function(y) owner[sf.__prop] = y end)
+         end
+         d.struct, d.field = saveStruct, saveField
+      end
+   end
+end
+
+decode = function(d, node, typ, get, set)
+   local k = typ.kind
+   if node.kind == "null" then
+      if k == __kindInterface or k == __kindPtr or k == __kindMap or k == __kindSlice then
+         set(zero(typ))
+      end
+      return
+   end
+
+   -- UnmarshalJSON and UnmarshalText, on
+   -- a struct or a pointer to one.
+   if k == __kindStruct or (k == __kindPtr and typ.elem.kind == __kindStruct) then
+      local m = jsonMethod(typ, "UnmarshalJSON", true)
+      if m == nil and node.kind == "string" then
+         m = jsonMethod(typ, "UnmarshalText", true)
+      end
+      if m ~= nil then
+         local p = get()
+         if isNil(typ, p) then
+            p = typ.elem.zero()
+            set(p)
+         end
+         callUnmarshaler(d, node, p, m)
+         return
+      end
+   end
+
+   if k == __kindPtr then
+      local p = get()
+      if isNil(typ, p) then
+         if typ.elem.kind == __kindStruct then
+            p = typ.elem.zero()
+         else
+            p = __newDataPointer(zero(typ.elem), typ)
+         end
+         set(p)
+      end
+      if typ.elem.kind == __kindStruct then
+         decode(d, node, typ.elem, function() return p end, nil)
+      else
+         decode(d, node, typ.elem, function() return p.__get() end, function(y) p.__set(y) end)
+      end
+   elseif k == __kindInterface then
+      if #(typ.methods or {}) > 0 then
+         typeError(d, what(node), typ)
+         return
+      end
+      set(generic(node))
+   elseif k == __kindStruct then
+      decodeStruct(d, node, typ, get())
+   elseif k == __kindMap then
+      if node.kind ~= "object" then
+         typeError(d, what(node), typ)
+         return
+      end
+      local kk = typ.key.kind
+      if kk ~= __kindString and not isSigned(kk) and not isUnsigned(kk) then
+         typeError(d, "object", typ)
+         return
+      end
+      local m = get()
+      if isNil(typ, m) then
+         m = __makeMap({}, typ.key, typ.elem, typ)
+         set(m)
+      end
+      for i, keyNode in ipairs(node.keys) do
+         local key = unquote(keyNode)
+         if kk ~= __kindString then
+            local n = parseInt(key, isSigned(kk), bitsOf(kk))
+            if n == nil then
+               typeError(d, "number " .. key, typ.key)
+            end
+            key = n
+         end
+         if key ~= nil then
+            local val = zero(typ.elem)
+            decode(d, node.vals[i], typ.elem,
+                   function() return val end,
+                   function(y) val = y end)
+            m[key] = val
+         end
+      end
+   elseif k == __kindSlice then
+      if node.kind == "string" and typ.elem.kind == __kindUint8 then
+         local s, err = __gijit_jsonUnbase64(unquote(node))
+         if err ~= nil then
+            if d.err == nil then
+               d.err = err
+            end
+            return
+         end
+         set(typ(__stringToBytes(s)))
+         return
+      end
+      if node.kind ~= "array" then
+         typeError(d, what(node), typ)
+         return
+      end
+      local sl = __makeSlice(typ, #node.vals)
+      for i, v in ipairs(node.vals) do
+         decode(d, v, typ.elem,
+                function() return __gi_GetRangeCheck(sl, i-1) end,
+                function(y) __gi_SetRangeCheck(sl, i-1, y) end)
+      end
+      set(sl)
+   elseif k == __kindArray then
+      if node.kind ~= "array" then
+         typeError(d, what(node), typ)
+         return
+      end
+      local arr = get()
+      for i = 0, typ.len - 1 do
+         local v = node.vals[i+1]
+         if v ~= nil then
+            decode(d, v, typ.elem,
+                   function() return arr[i] end,
+                   function(y) arr[i] = y end)
+         else
+            arr[i] = zero(typ.elem)
+         end
+      end
+   elseif k == __kindBool then
+      if node.kind ~= "bool" then
+         typeError(d, what(node), typ)
+         return
+      end
+      set(node.value)
+   elseif k == __kindString then
+      if node.kind ~= "string" then
+         typeError(d, what(node), typ)
+         return
+      end
+      set(unquote(node))
+   elseif isSigned(k) or isUnsigned(k) then
+      if node.kind ~= "number" then
+         typeError(d, what(node), typ)
+         return
+      end
+      local n = parseInt(node.raw, isSigned(k), bitsOf(k))
+      if n == nil then
+         typeError(d, "number " .. node.raw, typ)
+         return
+      end
+      set(n)
+   elseif k == __kindFloat32 or k == __kindFloat64 then
+      if node.kind ~= "number" then
+         typeError(d, what(node), typ)
+         return
+      end
+      local f = tonumber(node.raw)
+      if k == __kindFloat32 then
+         f = tonumber(__ffi.new("float", f))
+      end
+      set(f)
+   else
+      typeError(d, what(node), typ)
+   end
+end
+
+function __gijit_jsonUnmarshal(data, x, typ)
+   local s = __bytesToString(data)
+   local err = __gijit_jsonCheck(s)
+   if err ~= nil then
+      return err
+   end
+   if typ == nil then
+      if x == nil then
+         return "json: Unmarshal(nil)"
+      end
+      typ = valueType(x)
+      if typ == nil then
+         -- a Go value; let Go do it.
+         return __gijit_jsonGoUnmarshal(s, x)
+      end
+   end
+   if typ.kind ~= __kindPtr then
+      return "json: Unmarshal(non-pointer " .. typ.__str .. ")"
+   end
+   if isNil(typ, x) then
+      return "json: Unmarshal(nil " .. typ.__str .. ")"
+   end
+   local d = {}
+   decode(d, parse(s, 1), typ, function() return x end, function() end)
+   return d.err
+end
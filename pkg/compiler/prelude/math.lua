@@ -28,7 +28,7 @@ end
 
 __integerByZeroCheck = function(x)
    if not __builtin_math.finite(x) then
-      error("integer divide by zero")
+      __throwRuntimeError("integer divide by zero")
    end
    -- eliminate any fractional part
    if x >= 0 then
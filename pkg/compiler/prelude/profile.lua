@@ -0,0 +1,50 @@
+-- profile.lua
+--
+-- support for the :profile command at the REPL,
+-- a sampling profiler built on LuaJIT's jit.profile.
+-- Samples are aggregated here by stack, and handed
+-- to Go on __profile_stop, which maps the Lua
+-- chunk:line locations back to Go source.
+
+local __jitprofile = require("jit.profile")
+
+__profile = {running = false, total = 0}
+
+-- __profile_start begins sampling every intervalMs
+-- milliseconds. Any profile in progress is discarded.
+function __profile_start(intervalMs)
+   if __profile.running then
+      __jitprofile.stop()
+   end
+   local stacks = {}
+   local p = {running = true, stacks = stacks, total = 0}
+   __profile = p
+
+   -- "l": line level granularity; "i": the interval.
+   __jitprofile.start("li"..tostring(intervalMs), function(th, samples, vmstate)
+      -- each frame as "function|chunk:line", innermost
+      -- first, separated by ';'. "Z" drops the final ';'.
+      local stk = __jitprofile.dumpstack(th, "F|lZ;", 100)
+      local key = vmstate..stk
+      stacks[key] = (stacks[key] or 0) + samples
+      p.total = p.total + samples
+   end)
+end
+
+-- __profile_stop ends sampling and calls
+-- report(count, vmstate, stack) for each distinct
+-- stack seen. vmstate is one of N (JIT compiled
+-- code), I (interpreted), C (C code), G (garbage
+-- collector), or J (JIT compiler).
+function __profile_stop(report)
+   local p = __profile
+   if not p.running then
+      return 0
+   end
+   __jitprofile.stop()
+   p.running = false
+   for key, n in pairs(p.stacks) do
+      report(n, string.sub(key, 1, 1), string.sub(key, 2))
+   end
+   return p.total
+end
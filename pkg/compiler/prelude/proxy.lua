@@ -0,0 +1,97 @@
+-- proxy.lua
+--
+-- support for Go interface proxies; see proxy.go.
+-- When a gijit value is passed to Go where a Go
+-- interface is wanted, e.g. an io.Reader, Go wraps
+-- it in a proxy whose methods call back here.
+
+-- __gijit_proxyMethod returns the type of
+-- v's method called name, or nil.
+function __gijit_proxyMethod(v, name)
+   if type(v) ~= "table" or v.__typ == nil then
+      return nil
+   end
+   for _, m in ipairs(__methodSet(v.__typ)) do
+      if m.__name == name then
+         return m.__typ
+      end
+   end
+   return nil
+end
+
+-- __gijit_proxyMissing returns the first of the
+-- method names that v lacks, or nil if v has
+-- them all.
+function __gijit_proxyMissing(v, ...)
+   for _, name in ipairs({...}) do
+      if __gijit_proxyMethod(v, name) == nil then
+         return name
+      end
+   end
+   return nil
+end
+
+-- __gijit_tableToSlice makes a gijit slice of
+-- type typ from t, a Go slice as luar converts
+-- it: a plain table, from 1.
+function __gijit_tableToSlice(typ, t)
+   local s = __makeSlice(typ, #t)
+   for j = 1, #t do
+      __gi_SetRangeCheck(s, j-1, t[j])
+   end
+   return s
+end
+
+-- __gijit_proxyCall calls v's method name with
+-- the arguments from Go. Go slices arrive as
+-- plain tables, and are passed as gijit slices;
+-- after the method's results, we return each
+-- slice argument again, so that Go can copy
+-- back what the method wrote into it.
+function __gijit_proxyCall(v, name, ...)
+   local ft = __gijit_proxyMethod(v, name)
+   local n = select("#", ...)
+   local args = {...}
+   local slices = {}
+   for i = 1, n do
+      local pt = ft.params[i]
+      if pt ~= nil and pt.kind == __kindSlice then
+         local t = args[i]
+         if type(t) == "table" and t.__array == nil then
+            args[i] = __gijit_tableToSlice(pt, t)
+         end
+         table.insert(slices, args[i])
+      end
+   end
+
+   local res = {v[name](v, unpack(args, 1, n))}
+   local nres = #ft.results
+   for i, s in ipairs(slices) do
+      res[nres+i] = s
+   end
+   return unpack(res, 1, nres + #slices)
+end
+
+-- __gijit_basicSlice is __gijit_tableToSlice for
+-- a slice of the basic type named elem.
+function __gijit_basicSlice(elem, t)
+   return __gijit_tableToSlice(__sliceType(__type__[elem]), t)
+end
+
+-- __gijit_funcCall calls f, a gijit func that Go
+-- holds as a Go func; see funcproxy.go. It returns
+-- true and f's results, or false and the value f
+-- panicked with, so that Go can panic with it.
+function __gijit_funcCall(f, ...)
+   local res = {pcall(f, ...)}
+   if not res[1] then
+      local e = res[2]
+      if type(e) == "table" and getmetatable(e) == __recovMT then
+         -- a gijit panic; Go takes it from here.
+         e = e[1]
+         __recoverVal = nil
+      end
+      return false, e
+   end
+   return unpack(res, 1, table.maxn(res))
+end
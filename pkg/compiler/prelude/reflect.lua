@@ -0,0 +1,742 @@
+-- reflect.lua
+--
+-- reflect on gijit values. To luar's reflect, a
+-- gijit value is just a Lua table or number, so the
+-- compiler turns reflect.TypeOf(x) and
+-- reflect.ValueOf(x) into __gijit_reflectTypeOf(x, typ)
+-- and __gijit_reflectValueOf(x, typ), where typ is
+-- the static type of x from the tsys.lua type
+-- descriptors, or nil when x is an interface. The
+-- Type and Value returned answer from typ as
+-- compiled Go would for the same declarations.
+--
+-- Without a static type, we look at the value, which
+-- cannot tell a struct from a pointer to it, since
+-- gijit holds struct values by pointer; we answer for
+-- the struct. Values that are not gijit's, such as Go
+-- values from luar, go to luar's reflect as before.
+
+local __luarTypeOf = reflect.TypeOf
+local __luarValueOf = reflect.ValueOf
+
+-- the basic type of each kind, for values
+-- seen without a static type.
+local function kindType(kind)
+   local names = {
+      [__kindBool] = "bool", [__kindInt] = "int", [__kindInt8] = "int8",
+      [__kindInt16] = "int16", [__kindInt32] = "int32", [__kindInt64] = "int64",
+      [__kindUint] = "uint", [__kindUint8] = "uint8", [__kindUint16] = "uint16",
+      [__kindUint32] = "uint32", [__kindUint64] = "uint64", [__kindUintptr] = "uintptr",
+      [__kindFloat32] = "float32", [__kindFloat64] = "float64", [__kindString] = "string",
+   }
+   local name = names[kind]
+   if name == nil then
+      return nil
+   end
+   return __type__[name]
+end
+
+-- __gijit_reflectDynamicType returns the type
+-- descriptor for x, or nil if x is not gijit's.
+function __gijit_reflectDynamicType(x)
+   local ty = type(x)
+   if ty == "table" then
+      local typ = rawget(x, "__typ")
+      if typ == nil and getmetatable(x) ~= nil then
+         typ = x.__typ
+      end
+      if type(typ) ~= "table" or typ.kind == nil then
+         return nil
+      end
+      if typ.kind == __kindPtr and typ.elem.kind == __kindStruct then
+         -- a struct value, or a pointer to one.
+         return typ.elem
+      end
+      return typ
+   elseif ty == "cdata" or ty == "number" or ty == "string" or ty == "boolean" then
+      local cst = __cstructOf(x)
+      if cst ~= nil then
+         return cst
+      end
+      return kindType(__basicValue2kind(x))
+   end
+   return nil
+end
+
+local function intSlice(index)
+   local t = {}
+   for i, v in ipairs(index) do
+      t[i-1] = 0LL + v
+   end
+   return __sliceType(__type__.int)(t)
+end
+
+---------------------
+-- reflect.StructTag
+---------------------
+
+__gijit_structTagMT = {
+   __index = {},
+   __tostring = function(t) return t.__tag end,
+   __concat = function(a, b) return tostring(a) .. tostring(b) end,
+   __eq = function(a, b) return tostring(a) == tostring(b) end,
+}
+local structTag = __gijit_structTagMT.__index
+
+local function newStructTag(s)
+   return setmetatable({__tag = s or ""}, __gijit_structTagMT)
+end
+
+-- Lookup follows reflect.StructTag.Lookup: the
+-- tag is a list of key:"value" pairs, separated
+-- by spaces, each value a Go quoted string.
+function structTag:Lookup(key)
+   local tag = self.__tag
+   local i = 1
+   local n = #tag
+   while i <= n do
+      while i <= n and string.sub(tag, i, i) == " " do
+         i = i + 1
+      end
+      if i > n then
+         break
+      end
+      local j = i
+      while j <= n do
+         local c = string.sub(tag, j, j)
+         if c <= " " or c == ":" or c == '"' or string.byte(c) == 0x7f then
+            break
+         end
+         j = j + 1
+      end
+      if j == i or j + 1 > n or string.sub(tag, j, j+1) ~= ':"' then
+         break
+      end
+      local name = string.sub(tag, i, j-1)
+      i = j + 2
+      local parts = {}
+      while i <= n and string.sub(tag, i, i) ~= '"' do
+         local c = string.sub(tag, i, i)
+         if c == "\\" then
+            i = i + 1
+            c = string.sub(tag, i, i)
+            if c == "n" then c = "\n" elseif c == "t" then c = "\t" end
+         end
+         table.insert(parts, c)
+         i = i + 1
+      end
+      if i > n then
+         break
+      end
+      i = i + 1
+      if name == key then
+         return table.concat(parts), true
+      end
+   end
+   return "", false
+end
+
+function structTag:Get(key)
+   local v = self:Lookup(key)
+   return v
+end
+
+---------------------
+-- reflect.Type
+---------------------
+
+__gijit_rtypeMT = {
+   __index = {},
+   __tostring = function(t) return t.__rtyp.__str end,
+}
+local rtype = __gijit_rtypeMT.__index
+
+-- __gijit_rtype returns the reflect.Type for typ,
+-- the same one each time, so that Types compare
+-- equal as in Go.
+function __gijit_rtype(typ)
+   if typ == nil then
+      return nil
+   end
+   local t = rawget(typ, "__reflectType")
+   if t == nil then
+      t = setmetatable({__rtyp = typ}, __gijit_rtypeMT)
+      rawset(typ, "__reflectType", t)
+   end
+   return t
+end
+
+function __gijit_reflectTypeOf(x, typ)
+   if x == nil and typ == nil then
+      return nil
+   end
+   typ = typ or __gijit_reflectDynamicType(x)
+   if typ == nil then
+      return __luarTypeOf(x)
+   end
+   return __gijit_rtype(typ)
+end
+
+local function checkKind(typ, what, ...)
+   for _, k in ipairs({...}) do
+      if typ.kind == k then
+         return
+      end
+   end
+   panic("reflect: " .. what .. " of invalid type " .. typ.__str)
+end
+
+function rtype:Kind()
+   return 0ULL + self.__rtyp.kind
+end
+
+function rtype:Name()
+   local typ = self.__rtyp
+   if not typ.named then
+      return ""
+   end
+   return (string.match(typ.__str, "[^%.]*$"))
+end
+
+function rtype:PkgPath()
+   local typ = self.__rtyp
+   if not typ.named then
+      return ""
+   end
+   return typ.pkg or ""
+end
+
+function rtype:String()
+   return self.__rtyp.__str
+end
+
+function rtype:Comparable()
+   return self.__rtyp.comparable ~= false
+end
+
+function rtype:Elem()
+   local typ = self.__rtyp
+   checkKind(typ, "Elem", __kindArray, __kindChan, __kindMap, __kindPtr, __kindSlice)
+   return __gijit_rtype(typ.elem)
+end
+
+function rtype:Key()
+   local typ = self.__rtyp
+   checkKind(typ, "Key", __kindMap)
+   return __gijit_rtype(typ.key)
+end
+
+function rtype:Len()
+   local typ = self.__rtyp
+   checkKind(typ, "Len", __kindArray)
+   return 0LL + typ.len
+end
+
+function rtype:NumIn()
+   local typ = self.__rtyp
+   checkKind(typ, "NumIn", __kindFunc)
+   return 0LL + #typ.params
+end
+
+function rtype:In(i)
+   local typ = self.__rtyp
+   checkKind(typ, "In", __kindFunc)
+   return __gijit_rtype(typ.params[tonumber(i)+1])
+end
+
+function rtype:NumOut()
+   local typ = self.__rtyp
+   checkKind(typ, "NumOut", __kindFunc)
+   return 0LL + #typ.results
+end
+
+function rtype:Out(i)
+   local typ = self.__rtyp
+   checkKind(typ, "Out", __kindFunc)
+   return __gijit_rtype(typ.results[tonumber(i)+1])
+end
+
+function rtype:IsVariadic()
+   local typ = self.__rtyp
+   checkKind(typ, "IsVariadic", __kindFunc)
+   return typ.variadic == true
+end
+
+local function structField(st, j, index)
+   local f = st.fields[j]
+   local pkgPath = ""
+   if not f.__exported then
+      pkgPath = st.pkgPath or st.pkg or ""
+   end
+   return {
+      Name = f.__name,
+      PkgPath = pkgPath,
+      Type = __gijit_rtype(f.__typ),
+      Tag = newStructTag(f.__tag),
+      Index = intSlice(index),
+      Anonymous = f.__anonymous == true,
+   }
+end
+
+function rtype:NumField()
+   local typ = self.__rtyp
+   checkKind(typ, "NumField", __kindStruct)
+   return 0LL + #typ.fields
+end
+
+function rtype:Field(i)
+   local typ = self.__rtyp
+   checkKind(typ, "Field", __kindStruct)
+   local j = tonumber(i) + 1
+   if typ.fields[j] == nil then
+      panic("reflect: Field index out of bounds")
+   end
+   return structField(typ, j, {j-1})
+end
+
+-- fieldIndex finds the field called name, breadth
+-- first through embedded structs as Go does, and
+-- returns the struct holding it, its position
+-- there, and its index path from typ.
+local function fieldIndex(typ, name)
+   local level = {{typ = typ, index = {}}}
+   local seen = {}
+   while #level > 0 do
+      local next = {}
+      for _, e in ipairs(level) do
+         local st = e.typ
+         if st.kind == __kindPtr then
+            st = st.elem
+         end
+         if st.kind == __kindStruct and not seen[st] then
+            seen[st] = true
+            for j, f in ipairs(st.fields) do
+               local index = {unpack(e.index)}
+               table.insert(index, j-1)
+               if f.__name == name then
+                  return st, j, index
+               end
+               if f.__anonymous then
+                  table.insert(next, {typ = f.__typ, index = index})
+               end
+            end
+         end
+      end
+      level = next
+   end
+   return nil
+end
+
+function rtype:FieldByName(name)
+   local typ = self.__rtyp
+   checkKind(typ, "FieldByName", __kindStruct)
+   local st, j, index = fieldIndex(typ, name)
+   if st == nil then
+      return {Name = "", PkgPath = "", Tag = newStructTag(""), Index = intSlice({}), Anonymous = false}, false
+   end
+   return structField(st, j, index), true
+end
+
+-- methods returns typ's exported methods, sorted
+-- by name as reflect has them. The method set of a
+-- non-pointer type lacks those with receiver *T.
+local function methods(typ)
+   local byName = {}
+   local names = {}
+   local list
+   if typ.kind == __kindInterface then
+      list = typ.methods or {}
+   else
+      list = __methodSet(typ)
+   end
+   for _, m in ipairs(list) do
+      local exported = m.__pkg == nil or m.__pkg == ""
+      if exported and (typ.kind == __kindPtr or not m.__ptrRecv) then
+         if byName[m.__name] == nil then
+            table.insert(names, m.__name)
+         end
+         byName[m.__name] = m
+      end
+   end
+   table.sort(names)
+   local ms = {}
+   for i, name in ipairs(names) do
+      ms[i] = byName[name]
+   end
+   return ms
+end
+
+local function method(typ, ms, j)
+   local m = ms[j]
+   local ft = m.__typ
+   if typ.kind ~= __kindInterface then
+      -- as in Go, the type of a method
+      -- of a concrete type has the
+      -- receiver as its first argument.
+      local params = {typ}
+      for _, p in ipairs(ft.params) do
+         table.insert(params, p)
+      end
+      ft = __funcType(params, ft.results, ft.variadic)
+   end
+   return {
+      Name = m.__name,
+      PkgPath = "",
+      Type = __gijit_rtype(ft),
+      Index = 0LL + (j-1),
+   }
+end
+
+function rtype:NumMethod()
+   return 0LL + #methods(self.__rtyp)
+end
+
+function rtype:Method(i)
+   local typ = self.__rtyp
+   local ms = methods(typ)
+   local j = tonumber(i) + 1
+   if ms[j] == nil then
+      panic("reflect: Method index out of range")
+   end
+   return method(typ, ms, j)
+end
+
+function rtype:MethodByName(name)
+   local typ = self.__rtyp
+   local ms = methods(typ)
+   for j, m in ipairs(ms) do
+      if m.__name == name then
+         return method(typ, ms, j), true
+      end
+   end
+   return {Name = "", PkgPath = "", Index = 0LL}, false
+end
+
+function rtype:Implements(u)
+   if u == nil or u.__rtyp == nil or u.__rtyp.kind ~= __kindInterface then
+      panic("reflect: non-interface type passed to Type.Implements")
+   end
+   local have = {}
+   for _, m in ipairs(methods(self.__rtyp)) do
+      have[m.__name] = true
+   end
+   for _, m in ipairs(methods(u.__rtyp)) do
+      if not have[m.__name] then
+         return false
+      end
+   end
+   return true
+end
+
+---------------------
+-- reflect.Value
+---------------------
+
+__gijit_rvalueMT = {
+   __index = {},
+   __tostring = function(v)
+      if v.__rtyp == nil then
+         return "<invalid Value>"
+      end
+      return "<" .. v.__rtyp.__str .. " Value>"
+   end,
+}
+local rvalue = __gijit_rvalueMT.__index
+
+-- a Value is a type, a getter, and, when the
+-- Value is settable, a setter.
+local function newValue(typ, get, set)
+   return setmetatable({__rtyp = typ, __rget = get, __rset = set}, __gijit_rvalueMT)
+end
+
+function __gijit_reflectValueOf(x, typ)
+   if x == nil and typ == nil then
+      return newValue(nil, function() return nil end, nil)
+   end
+   typ = typ or __gijit_reflectDynamicType(x)
+   if typ == nil then
+      return __luarValueOf(x)
+   end
+   return newValue(typ, function() return x end, nil)
+end
+
+local function valueKind(v, what, ...)
+   if v.__rtyp == nil then
+      panic("reflect: call of reflect.Value." .. what .. " on zero Value")
+   end
+   for _, k in ipairs({...}) do
+      if v.__rtyp.kind == k then
+         return
+      end
+   end
+   panic("reflect: call of reflect.Value." .. what .. " on " .. v.__rtyp.__str .. " Value")
+end
+
+local function mustSet(v, what)
+   if v.__rset == nil then
+      panic("reflect: reflect.Value." .. what .. " using unaddressable value")
+   end
+end
+
+-- tsys.lua, which defines the kinds, loads after us.
+local function ints()
+   return __kindInt, __kindInt8, __kindInt16, __kindInt32, __kindInt64
+end
+local function uints()
+   return __kindUint, __kindUint8, __kindUint16, __kindUint32, __kindUint64, __kindUintptr
+end
+
+function rvalue:IsValid()
+   return self.__rtyp ~= nil
+end
+
+function rvalue:Kind()
+   if self.__rtyp == nil then
+      return 0ULL
+   end
+   return 0ULL + self.__rtyp.kind
+end
+
+function rvalue:Type()
+   if self.__rtyp == nil then
+      panic("reflect: call of reflect.Value.Type on zero Value")
+   end
+   return __gijit_rtype(self.__rtyp)
+end
+
+function rvalue:Interface()
+   return self.__rget()
+end
+
+function rvalue:CanSet()
+   return self.__rset ~= nil
+end
+
+function rvalue:CanAddr()
+   return self.__rset ~= nil
+end
+
+function rvalue:Int()
+   valueKind(self, "Int", ints())
+   return 0LL + self.__rget()
+end
+
+function rvalue:Uint()
+   valueKind(self, "Uint", uints())
+   return 0ULL + self.__rget()
+end
+
+function rvalue:Float()
+   valueKind(self, "Float", __kindFloat32, __kindFloat64)
+   return tonumber(self.__rget())
+end
+
+function rvalue:Bool()
+   valueKind(self, "Bool", __kindBool)
+   return self.__rget()
+end
+
+function rvalue:String()
+   if self.__rtyp ~= nil and self.__rtyp.kind == __kindString then
+      return self.__rget()
+   end
+   return tostring(self)
+end
+
+function rvalue:IsNil()
+   valueKind(self, "IsNil", __kindChan, __kindFunc, __kindInterface, __kindMap, __kindPtr, __kindSlice)
+   local x = self.__rget()
+   return x == nil or x == self.__rtyp.__nil
+end
+
+function rvalue:Len()
+   valueKind(self, "Len", __kindArray, __kindChan, __kindMap, __kindSlice, __kindString)
+   local x = self.__rget()
+   local k = self.__rtyp.kind
+   if k == __kindArray then
+      return 0LL + self.__rtyp.len
+   elseif k == __kindString then
+      return 0LL + #x
+   elseif k == __kindSlice then
+      return 0LL + (x.__length or 0)
+   elseif k == __kindMap then
+      if not x then
+         return 0LL
+      end
+      return 0LL + #x
+   end
+   return 0LL + #x
+end
+
+function rvalue:Index(i)
+   valueKind(self, "Index", __kindArray, __kindSlice, __kindString)
+   local typ = self.__rtyp
+   local get = self.__rget
+   if typ.kind == __kindString then
+      return newValue(__type__.uint8, function()
+         return 0ULL + string.byte(get(), tonumber(i)+1)
+      end, nil)
+   end
+   local set = nil
+   if typ.kind == __kindSlice or self.__rset ~= nil then
+      -- slice elements are settable even
+      -- when the slice is not; see Go's Index.
+      set = function(x) __gi_SetRangeCheck(get(), i, x) end
+   end
+   return newValue(typ.elem, function() return __gi_GetRangeCheck(get(), i) end, set)
+end
+
+local function field(v, st, j)
+   local f = st.fields[j]
+   local get = v.__rget
+   local set = nil
+   if v.__rset ~= nil and f.__exported then
+      set = function(x) get()[f.__prop] = x end
+   end
+   return newValue(f.__typ, function() return get()[f.__prop] end, set)
+end
+
+function rvalue:NumField()
+   valueKind(self, "NumField", __kindStruct)
+   return 0LL + #self.__rtyp.fields
+end
+
+function rvalue:Field(i)
+   valueKind(self, "Field", __kindStruct)
+   local j = tonumber(i) + 1
+   if self.__rtyp.fields[j] == nil then
+      panic("reflect: Field index out of range")
+   end
+   return field(self, self.__rtyp, j)
+end
+
+function rvalue:FieldByName(name)
+   valueKind(self, "FieldByName", __kindStruct)
+   local st, j, index = fieldIndex(self.__rtyp, name)
+   if st == nil then
+      return newValue(nil, function() return nil end, nil)
+   end
+   local v = self
+   for k = 1, #index - 1 do
+      v = v:Field(index[k])
+      if v.__rtyp.kind == __kindPtr then
+         v = v:Elem()
+      end
+   end
+   return field(v, st, index[#index] + 1)
+end
+
+function rvalue:Elem()
+   valueKind(self, "Elem", __kindInterface, __kindPtr)
+   local typ = self.__rtyp
+   local get = self.__rget
+   if typ.kind == __kindInterface then
+      return __gijit_reflectValueOf(get(), nil)
+   end
+   if typ.elem.kind == __kindStruct then
+      -- the pointer is the struct, as gijit
+      -- holds struct values by pointer.
+      return newValue(typ.elem, get, function(x)
+         local p = get()
+         for _, f in ipairs(typ.elem.fields) do
+            p[f.__prop] = x[f.__prop]
+         end
+      end)
+   end
+   return newValue(typ.elem,
+                   function() return get().__get() end,
+                   function(x) get().__set(x) end)
+end
+
+function rvalue:Set(w)
+   mustSet(self, "Set")
+   self.__rset(w.__rget())
+end
+
+function rvalue:SetInt(x)
+   valueKind(self, "SetInt", ints())
+   mustSet(self, "SetInt")
+   self.__rset(0LL + x)
+end
+
+function rvalue:SetUint(x)
+   valueKind(self, "SetUint", uints())
+   mustSet(self, "SetUint")
+   self.__rset(0ULL + x)
+end
+
+function rvalue:SetFloat(x)
+   valueKind(self, "SetFloat", __kindFloat32, __kindFloat64)
+   mustSet(self, "SetFloat")
+   self.__rset(tonumber(x))
+end
+
+function rvalue:SetBool(x)
+   valueKind(self, "SetBool", __kindBool)
+   mustSet(self, "SetBool")
+   self.__rset(x)
+end
+
+function rvalue:SetString(x)
+   valueKind(self, "SetString", __kindString)
+   mustSet(self, "SetString")
+   self.__rset(x)
+end
+
+function rvalue:NumMethod()
+   if self.__rtyp == nil then
+      panic("reflect: call of reflect.Value.NumMethod on zero Value")
+   end
+   return 0LL + #methods(self.__rtyp)
+end
+
+-- methodValue is the Value of the method m
+-- bound to v's value, as a func.
+local function methodValue(v, m)
+   local get = v.__rget
+   return newValue(m.__typ, function()
+      local recv = get()
+      return function(...) return recv[m.prop](recv, ...) end
+   end, nil)
+end
+
+function rvalue:Method(i)
+   local ms = methods(self.__rtyp)
+   local m = ms[tonumber(i)+1]
+   if m == nil then
+      panic("reflect: Method index out of range")
+   end
+   return methodValue(self, m)
+end
+
+function rvalue:MethodByName(name)
+   for _, m in ipairs(methods(self.__rtyp)) do
+      if m.__name == name then
+         return methodValue(self, m)
+      end
+   end
+   return newValue(nil, function() return nil end, nil)
+end
+
+-- Call calls a func Value with the Values in the
+-- slice in, returning its results as a slice of
+-- Values.
+function rvalue:Call(in_)
+   valueKind(self, "Call", __kindFunc)
+   local ft = self.__rtyp
+   local args = {}
+   local n = 0
+   if in_ ~= nil then
+      n = tonumber(in_.__length or 0)
+      for i = 1, n do
+         args[i] = __gi_GetRangeCheck(in_, i-1).__rget()
+      end
+   end
+   local res = {self.__rget()(unpack(args, 1, n))}
+   local out = {}
+   for i, rt in ipairs(ft.results) do
+      local x = res[i]
+      out[i-1] = newValue(rt, function() return x end, nil)
+   end
+   return __sliceType(__type__.emptyInterface)(out)
+end
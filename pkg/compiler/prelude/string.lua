@@ -34,7 +34,7 @@ end;
 __copyString = function(dst, src)
   local n = __min(#src, dst.__length);
   for i = 0,n-1 do
-    dst.__array[dst.__offset + i] = src.charCodeAt(i);
+    dst.__array[dst.__offset + i] = string.byte(src, i+1);
   end
   return n;
 end;
@@ -0,0 +1,415 @@
+-- sync.lua: sync and sync/atomic for gijit goroutines.
+--
+-- Go's own sync.WaitGroup.Wait, or a contended
+-- sync.Mutex.Lock, would block the one OS thread that
+-- LuaJIT runs on, and every goroutine with it. So
+-- import "sync" gets Mutex, RWMutex, WaitGroup, Once and
+-- Cond from here, which park only the calling goroutine
+-- in the chan.lua scheduler; Map and Pool, which never
+-- block, stay Go's. import "sync/atomic" is all here.
+-- There is one OS thread, and a goroutine is only ever
+-- switched out where it blocks or loops, so plain
+-- reads and writes are atomic already.
+
+-- waitq: goroutines waiting for a change of state.
+-- Each parks on its own channel, with a buffer of one,
+-- so a wake never blocks the waker. Waiters look at
+-- the state again when woken, as Go's own do.
+
+local function waitq_new()
+   return {}
+end
+
+-- waitq_enqueue adds the running goroutine to q,
+-- returning what to park on with waitq_park.
+local function waitq_enqueue(q)
+   local c = __task.Channel:new(1)
+   table.insert(q, c)
+   return c
+end
+
+-- waitq_park blocks on c, showing state in :goroutines
+-- and in the deadlock report, as Go's traceback would.
+local function waitq_park(c, state)
+   local co = coroutine.running()
+   local note = co and __coro2notes[co]
+   if note then
+      note.sync = state
+   end
+   c:recv()
+   if note then
+      note.sync = nil
+   end
+end
+
+local function waitq_wait(q, state)
+   waitq_park(waitq_enqueue(q), state)
+end
+
+local function waitq_wake_one(q)
+   local c = table.remove(q, 1)
+   if c ~= nil then
+      __task.select({{c = c, op = __task.SEND, p = true}, {}})
+   end
+end
+
+local function waitq_wake_all(q)
+   while #q > 0 do
+      waitq_wake_one(q)
+   end
+end
+
+-- fatal is Go's unrecoverable runtime throw.
+local fatal = __fatal
+
+-- bind gives o each of methods, bound to o. Calls on
+-- a shadowed package's types are translated as o.M(...),
+-- but through an interface, such as sync.Locker, as
+-- o:M(...); the bound method takes either.
+local function bind(o, methods)
+   for k, f in pairs(methods) do
+      if type(f) == "function" and string.sub(k, 1, 2) ~= "__" then
+         o[k] = function(a, ...)
+            if rawequal(a, o) then
+               return f(o, ...)
+            end
+            return f(o, a, ...)
+         end
+      end
+   end
+   return o
+end
+
+-- syncType makes a sync type: calling it, as
+-- __type__.sync.T(src) is called for each zero value,
+-- returns a new T, or a copy of src.
+local function syncType(name, methods, new)
+   local typ = {
+      id = 0,
+      __name = "gijit_sync_type",
+      __str = "sync."..name,
+      exported = true,
+   }
+   methods.__index = methods
+   methods.__tostring = function(self)
+      return "sync."..name.."{}"
+   end
+   typ.__call = function(t, src)
+      local o = setmetatable({}, methods)
+      new(o, src)
+      return bind(o, methods)
+   end
+   return setmetatable(typ, typ)
+end
+
+----------------------------------------------------------------------------
+-- Mutex
+
+local Mutex = {}
+
+function Mutex:Lock()
+   while self.locked do
+      waitq_wait(self.q, "sync.Mutex.Lock")
+   end
+   self.locked = true
+end
+
+function Mutex:TryLock()
+   if self.locked then
+      return false
+   end
+   self.locked = true
+   return true
+end
+
+function Mutex:Unlock()
+   if not self.locked then
+      fatal("sync: unlock of unlocked mutex")
+   end
+   self.locked = false
+   waitq_wake_one(self.q)
+end
+
+----------------------------------------------------------------------------
+-- RWMutex. A waiting writer holds off new readers,
+-- as in Go, so readers cannot starve it.
+
+local RWMutex = {}
+
+function RWMutex:Lock()
+   self.writersWaiting = self.writersWaiting + 1
+   while self.writer or self.readers > 0 do
+      waitq_wait(self.q, "sync.RWMutex.Lock")
+   end
+   self.writersWaiting = self.writersWaiting - 1
+   self.writer = true
+end
+
+function RWMutex:TryLock()
+   if self.writer or self.readers > 0 then
+      return false
+   end
+   self.writer = true
+   return true
+end
+
+function RWMutex:Unlock()
+   if not self.writer then
+      fatal("sync: Unlock of unlocked RWMutex")
+   end
+   self.writer = false
+   waitq_wake_all(self.q)
+end
+
+function RWMutex:RLock()
+   while self.writer or self.writersWaiting > 0 do
+      waitq_wait(self.q, "sync.RWMutex.RLock")
+   end
+   self.readers = self.readers + 1
+end
+
+function RWMutex:TryRLock()
+   if self.writer or self.writersWaiting > 0 then
+      return false
+   end
+   self.readers = self.readers + 1
+   return true
+end
+
+function RWMutex:RUnlock()
+   if self.readers <= 0 then
+      fatal("sync: RUnlock of unlocked RWMutex")
+   end
+   self.readers = self.readers - 1
+   if self.readers == 0 then
+      waitq_wake_all(self.q)
+   end
+end
+
+-- RLocker returns a Locker whose Lock and
+-- Unlock are RLock and RUnlock.
+function RWMutex:RLocker()
+   local rw = self
+   return {
+      Lock = function(_) rw:RLock() end,
+      Unlock = function(_) rw:RUnlock() end,
+   }
+end
+
+----------------------------------------------------------------------------
+-- WaitGroup
+
+local WaitGroup = {}
+
+function WaitGroup:Add(delta)
+   self.n = self.n + tonumber(delta)
+   if self.n < 0 then
+      error("sync: negative WaitGroup counter", 2)
+   end
+   if self.n == 0 then
+      waitq_wake_all(self.q)
+   end
+end
+
+function WaitGroup:Done()
+   self:Add(-1)
+end
+
+function WaitGroup:Wait()
+   while self.n > 0 do
+      waitq_wait(self.q, "sync.WaitGroup.Wait")
+   end
+end
+
+----------------------------------------------------------------------------
+-- Once. Do is done once f returns, even by
+-- panicking, and later calls wait until it has.
+
+local Once = {}
+
+function Once:Do(f)
+   if self.done then
+      return
+   end
+   self.m:Lock()
+   if not self.done then
+      local ok, err = pcall(f)
+      self.done = true
+      if not ok then
+         self.m:Unlock()
+         error(err, 0)
+      end
+   end
+   self.m:Unlock()
+end
+
+----------------------------------------------------------------------------
+-- Cond
+
+local Cond = {}
+
+-- Wait joins the waiters before it unlocks L,
+-- so no Signal in between is lost.
+function Cond:Wait()
+   local c = waitq_enqueue(self.q)
+   self.L:Unlock()
+   waitq_park(c, "sync.Cond.Wait")
+   self.L:Lock()
+end
+
+function Cond:Signal()
+   waitq_wake_one(self.q)
+end
+
+function Cond:Broadcast()
+   waitq_wake_all(self.q)
+end
+
+----------------------------------------------------------------------------
+-- import "sync"
+
+function __gijit_installSync()
+   __type__.sync = __type__.sync or {}
+   __type__.sync.Mutex = syncType("Mutex", Mutex, function(o, src)
+      o.q = waitq_new()
+      o.locked = src ~= nil and src.locked or false
+   end)
+   __type__.sync.RWMutex = syncType("RWMutex", RWMutex, function(o, src)
+      o.q = waitq_new()
+      o.writer = src ~= nil and src.writer or false
+      o.readers = src ~= nil and src.readers or 0
+      o.writersWaiting = 0
+   end)
+   __type__.sync.WaitGroup = syncType("WaitGroup", WaitGroup, function(o, src)
+      o.q = waitq_new()
+      o.n = src ~= nil and src.n or 0
+   end)
+   __type__.sync.Once = syncType("Once", Once, function(o, src)
+      o.m = __type__.sync.Mutex()
+      o.done = src ~= nil and src.done or false
+   end)
+   __type__.sync.Cond = syncType("Cond", Cond, function(o, src)
+      o.q = waitq_new()
+      o.L = src ~= nil and src.L or nil
+   end)
+   __packages["sync"] = {
+      NewCond = function(l)
+         local c = __type__.sync.Cond()
+         c.L = l
+         return c
+      end,
+   }
+end
+
+----------------------------------------------------------------------------
+-- import "sync/atomic". The pointers are gijit
+-- pointers, read and written with __get and __set.
+
+-- the 32-bit kinds wrap around as Go's do; the
+-- 64-bit cdata kinds wrap by themselves.
+local function wrapInt32(v) return int(int32(v)) end
+local function wrapUint32(v) return uint(uint32(v)) end
+local function same(v) return v end
+
+local function addFor(wrap)
+   return function(p, delta)
+      local v = wrap(p.__get() + delta)
+      p.__set(v)
+      return v
+   end
+end
+
+local function load(p)
+   return p.__get()
+end
+
+local function store(p, v)
+   p.__set(v)
+end
+
+local function swap(p, new)
+   local old = p.__get()
+   p.__set(new)
+   return old
+end
+
+local function compareAndSwap(p, old, new)
+   if p.__get() ~= old then
+      return false
+   end
+   p.__set(new)
+   return true
+end
+
+-- atomicType makes one of the Go 1.19 typed atomics,
+-- such as atomic.Int64, holding its value in v.
+local function atomicType(name, zero, wrap)
+   local methods = {
+      Load = function(self) return self.v end,
+      Store = function(self, v) self.v = v end,
+      Swap = function(self, new)
+         local old = self.v
+         self.v = new
+         return old
+      end,
+      CompareAndSwap = function(self, old, new)
+         if self.v ~= old then
+            return false
+         end
+         self.v = new
+         return true
+      end,
+   }
+   if wrap ~= nil then
+      methods.Add = function(self, delta)
+         self.v = wrap(self.v + delta)
+         return self.v
+      end
+   end
+   local typ = {
+      id = 0,
+      __name = "gijit_sync_type",
+      __str = "atomic."..name,
+      exported = true,
+   }
+   methods.__index = methods
+   typ.__call = function(t, src)
+      local v = zero
+      if src ~= nil then
+         v = src.v
+      end
+      return bind(setmetatable({v = v}, methods), methods)
+   end
+   return setmetatable(typ, typ)
+end
+
+function __gijit_installAtomic()
+   local atomic = {}
+   __packages["sync/atomic"] = atomic
+   local kinds = {
+      Int32 = wrapInt32, Int64 = same,
+      Uint32 = wrapUint32, Uint64 = same, Uintptr = same,
+   }
+   for kind, wrap in pairs(kinds) do
+      atomic["Add"..kind] = addFor(wrap)
+      atomic["Load"..kind] = load
+      atomic["Store"..kind] = store
+      atomic["Swap"..kind] = swap
+      atomic["CompareAndSwap"..kind] = compareAndSwap
+   end
+   atomic.LoadPointer = load
+   atomic.StorePointer = store
+   atomic.SwapPointer = swap
+   atomic.CompareAndSwapPointer = compareAndSwap
+
+   __type__["sync/atomic"] = {
+      Int32 = atomicType("Int32", 0LL, wrapInt32),
+      Int64 = atomicType("Int64", 0LL, same),
+      Uint32 = atomicType("Uint32", 0ULL, wrapUint32),
+      Uint64 = atomicType("Uint64", 0ULL, same),
+      Uintptr = atomicType("Uintptr", 0ULL, same),
+      Bool = atomicType("Bool", false, nil),
+      Pointer = atomicType("Pointer", nil, nil),
+      Value = atomicType("Value", nil, nil),
+   }
+end
@@ -576,8 +576,8 @@ end
 
 --
 __flushConsole = function() end;
-__throwRuntimeError = function(...) error(...) end
-__throwNilPointerError = function()  __throwRuntimeError("invalid memory address or nil pointer dereference"); end;
+-- __throwRuntimeError is under "runtime errors", below.
+__throwNilPointerError = function() __throwRuntimeError("invalid memory address or nil pointer dereference") end
 __call = function(fn, rcvr, args)  return fn(rcvr, args); end;
 __makeFunc = function(fn)
    return function()
@@ -695,11 +695,20 @@ end;
 -- low, high are 0-based slice [low,high). max is the
 --  maximum capacity of the slice.
 __subslice = function(slice, low, high, max)
-   if high == nil then
-      
-   end
-   if low < 0  or  (high ~= nil and high < low)  or  (max ~= nil and high ~= nil and max < high)  or  (high ~= nil and high > slice.__capacity)  or  (max ~= nil and max > slice.__capacity) then
-      __throwRuntimeError("slice bounds out of range");
+   local cap = slice.__capacity
+   local h = high or slice.__length
+   if max ~= nil then
+      if max > cap then
+         __throwRuntimeError("slice bounds out of range [::"..__itoa(max).."] with capacity "..__itoa(cap))
+      elseif h > max then
+         __throwRuntimeError("slice bounds out of range [:"..__itoa(h)..":"..__itoa(max).."]")
+      elseif low < 0 or low > h then
+         __throwRuntimeError("slice bounds out of range ["..__itoa(low)..":"..__itoa(h)..":]")
+      end
+   elseif h > cap then
+      __throwRuntimeError("slice bounds out of range [:"..__itoa(h).."] with capacity "..__itoa(cap))
+   elseif low < 0 or low > h then
+      __throwRuntimeError("slice bounds out of range ["..__itoa(low)..":"..__itoa(h).."]")
    end
    
    local s = slice.__constructor.tfun(slice.__array);
@@ -736,6 +745,13 @@ __copyArray = function(dst, src, dstOffset, srcOffset, n, elem)
       return;
    end
 
+   local dd, sd = rawget(dst, "__data"), rawget(src, "__data")
+   if dd ~= nil and sd ~= nil then
+      -- numeric arrays; memmove, as dst may be src.
+      __ffi.C.memmove(dd + dstOffset, sd + srcOffset, n * dst.__size)
+      return;
+   end
+
    local sw = elem.kind
    if sw == __kindArray or sw == __kindStruct then
       
@@ -775,6 +791,23 @@ __clone = function(src, typ)
    if typ.__name == "native_Go_struct_type_wrapper" then
       return typ(src) -- if src is nil, return zero value, else copy of src.
    end
+   if typ.kind == __kindStruct and typ.__ctype == nil and #typ.fields > 0 then
+      -- typ() would fill the fields from typ.zero(),
+      -- which for a struct is a pointer; construct
+      -- it from copies of src's fields instead.
+      local args = {}
+      for i, f in ipairs(typ.fields) do
+         local v = src[f.__prop]
+         local k = f.__typ.kind
+         if k == __kindStruct then
+            v = f.__typ.ptr(__clone(v, f.__typ))
+         elseif k == __kindArray then
+            v = __clone(v, f.__typ)
+         end
+         args[i] = v
+      end
+      return typ(unpack(args, 1, #typ.fields))
+   end
    local clone = typ()
    typ.copy(clone, src);
    return clone;
@@ -872,14 +905,8 @@ __internalAppend = function(slice, array, offset, length)
       end
       newCapacity = __max(newLength, tmpCap);
 
-      newArray = {}
-      local w = slice.__offset
-      for i = 0,slice.__length do
-         newArray[i] = slice.__array[i + w]
-      end
-      for i = #slice,newCapacity-1 do
-         newArray[i] = elem.zero();
-      end
+      newArray = __newAnyArrayValue(elem, newCapacity)
+      __copyArray(newArray, slice.__array, 0, slice.__offset, slice.__length, elem);
       
    end
 
@@ -899,8 +926,10 @@ end;
 --
 
 __substring = function(str, low, high)
-   if low < 0  or  high < low  or  high > #str then
-      __throwRuntimeError("string slice bounds out of range");
+   if high > #str then
+      __throwRuntimeError("slice bounds out of range [:"..__itoa(high).."] with length "..__itoa(#str))
+   elseif low < 0 or high < low then
+      __throwRuntimeError("slice bounds out of range ["..__itoa(low)..":"..__itoa(high).."]")
    end
    return string.sub(str, low+1, high); -- high is inclusive, so no +1 needed.
 end;
@@ -959,7 +988,7 @@ __valueSliceIpairs = function(t)
       if k >= off + slcLen then
          return
       end
-      return k, rawget(arr, off + k)
+      return k, arr[off + k]
    end       
    -- Return an iterator function, the table, starting point
    local arr = rawget(t, "__array")
@@ -1001,8 +1030,7 @@ __valueArrayMT = {
       end
       --__st(t.__val)
       if k < 0 or k >= #t then
-         print(debug.traceback())
-         error("read from array error: access out-of-bounds; "..tostring(k).." is outside [0, "  .. tostring(#t) .. ")")
+         __throwRuntimeError("index out of range ["..__itoa(k).."] with length "..__itoa(#t))
       end
       --print("array access bounds check ok.")
       --__st(t.__array, "t.__array")
@@ -1025,10 +1053,7 @@ __valueArrayMT = {
             return "<this.__val == this; avoid inf loop>"
          end
 
-         local len = #self.__val
-         if self.__val[0] ~= nil then
-            len=len+1
-         end
+         local len = __lenz(self.__val)
          local s = self.__constructor.__str.."{"
          local raw = self.__val
          local beg = 0
@@ -1090,8 +1115,7 @@ __valueSliceMT = {
       local w = t.__offset + k
       --print("index slice with w = "..type(w).." value: "..tostring(w))
       if k < 0 or k >= t.__capacity then
-         print(debug.traceback())
-         error("slice error: access out-of-bounds, k="..tostring(k).."; cap="..tostring(t.__capacity))
+         __throwRuntimeError("index out of range ["..__itoa(k).."] with length "..__itoa(t.__length))
       end
       --print("slice access bounds check ok: w = ", w)
       --__st(t.__array, "t.__array")
@@ -1246,7 +1270,142 @@ __valuePointerMT = {
 -- a.k.a. this is now called prototype
 
 
+-- numeric arrays: the elements of slices and arrays
+-- of these kinds live in memory from calloc, not in
+-- a Lua table. A []float64 of 50M elements is then
+-- 400MB that the LuaJIT GC never walks or counts, and
+-- that luar copies to a Go []float64 in one memmove, or,
+-- with luar.NumericSliceViews set, hands to Go as is;
+-- see copyGiTableToSlice. float32 is not among them:
+-- gijit's float32 values are unrounded doubles, which
+-- a float array would round. Nor are the small ints,
+-- whose gijit values are boxed cdata, not the
+-- numbers an FFI array of them would give back.
+__numericCtypes = {
+   [__kindInt] = "int64_t",
+   [__kindInt64] = "int64_t",
+   [__kindUint] = "uint64_t",
+   [__kindUint64] = "uint64_t",
+   [__kindUintptr] = "uint64_t",
+   [__kindFloat64] = "double",
+}
+
+__ffi.cdef[[
+void *calloc(size_t nmemb, size_t size);
+void free(void *ptr);
+void *memmove(void *dest, const void *src, size_t n);
+]]
+
+-- __ffiElemCtype is the ctype of the elements of a
+-- numeric array of elem, or nil if elem's values are
+-- not kept in one. Structs that are plain old data
+-- have theirs from __cstructType.
+function __ffiElemCtype(elem)
+   return __numericCtypes[elem.kind] or elem.__ctype
+end
+
+-- a reference into FFI memory, such as a struct
+-- element of an array, does not keep that memory
+-- alive; the references given out are keys here,
+-- with what holds the memory as their value.
+__cstructAnchors = setmetatable({}, {__mode = "k"})
+
+-- a numeric array is a table holding the pointer, as
+-- __newByteArray's do, so that the slice code can go
+-- on indexing __array, and keep __ptr in it.
+local numericArrayMT = {
+   __name = "__numericArrayMT",
+   __index = function(me, i)
+      if type(i) == "string" or i < 0 or i >= me.__sz then
+         return nil
+      end
+      if me.__structs then
+         local r = me.__data[i]
+         __cstructAnchors[r] = me
+         return r
+      end
+      return me.__data[i]
+   end,
+   __newindex = function(me, i, v)
+      if type(i) == "string" then
+         rawset(me, i, v)
+         return
+      end
+      if i < 0 or i >= me.__sz then
+         error("numeric array: write out of bounds")
+      end
+      if me.__structs then
+         v = __cstructValue(v)
+      end
+      me.__data[i] = v
+   end,
+   __len = function(me)
+      return me.__sz
+   end,
+}
+
+-- __newNumericArray returns n zeroed elements of the
+-- numeric kind of elem, with vals, a 0-based table,
+-- copied in if given.
+function __newNumericArray(elem, n, vals)
+   local ctype = __ffi.typeof(__ffiElemCtype(elem))
+   local size = __ffi.sizeof(ctype)
+   -- calloc(0) may give NULL, so at least one.
+   local p = __ffi.C.calloc(n > 0 and n or 1, size)
+   if p == nil then
+      error("out of memory allocating "..tostring(n).." "..tostring(ctype))
+   end
+   local data = __ffi.gc(__ffi.cast(__ffi.typeof("$*", ctype), p), __ffi.C.free)
+   local me = setmetatable({
+         __data = data,
+         __sz = n,
+         __size = size,
+         __elemCtype = __numericCtypes[elem.kind],
+         __name = "__valueNumericArray",
+         __structs = elem.__ctype ~= nil or nil,
+   }, numericArrayMT)
+   if vals ~= nil then
+      for i = 0, n-1 do
+         me[i] = vals[i]
+      end
+   end
+   return me
+end
+
+-- __numericArrayView gives the n elements of elem at
+-- ptr, memory that anchor holds, as a numeric array;
+-- an array field of a cstruct is one.
+function __numericArrayView(elem, n, ptr, anchor)
+   local ctype = __ffi.typeof(__ffiElemCtype(elem))
+   return setmetatable({
+         __data = __ffi.cast(__ffi.typeof("$*", ctype), ptr),
+         __sz = n,
+         __size = __ffi.sizeof(ctype),
+         __elemCtype = __numericCtypes[elem.kind],
+         __name = "__valueNumericArray",
+         __anchor = anchor,
+   }, numericArrayMT)
+end
+
+-- __asNumericArray gives the backing array for a slice
+-- or array of elem from a literal's table, or shares
+-- one that is already numeric, as a[:] does with the
+-- array value a.
+function __asNumericArray(elem, array)
+   if rawget(array, "__data") ~= nil then
+      return array
+   end
+   local val = rawget(array, "__val")
+   if val ~= nil and rawget(val, "__data") ~= nil then
+      return val
+   end
+   return __newNumericArray(elem, __lenz(array), array)
+end
+
 function __newAnyArrayValue(elem, len)
+   if __ffiElemCtype(elem) ~= nil then
+      return __newNumericArray(elem, len)
+   end
    local array = {}
    for i =0, len -1 do
       array[i]= elem.zero();
@@ -1460,6 +1619,9 @@ __newType = function(size, kind, str, named, pkg, exported, constructor)
       
       typ.tfun = function(array)
          local this={};
+         if typ.elem ~= nil and __ffiElemCtype(typ.elem) ~= nil then
+            array = __asNumericArray(typ.elem, array)
+         end
          --print(debug.traceback())
          --print("slice tfun for type '"..__addressof(typ).."' called with array = ")
          --__st(array)
@@ -1491,6 +1653,9 @@ __newType = function(size, kind, str, named, pkg, exported, constructor)
    elseif kind ==  __kindArray then
       typ.tfun = function(v)
          local this={};
+         if typ.elem ~= nil and __ffiElemCtype(typ.elem) ~= nil then
+            v = __asNumericArray(typ.elem, v)
+         end
          --print("in tfun ctor function for __kindArray, this="..tostring(this).." and v="..tostring(v))
          this.__val = v;
          this.__array = v; -- like slice, to reuse ipairs method.
@@ -1637,6 +1802,7 @@ __newType = function(size, kind, str, named, pkg, exported, constructor)
          return this;
                                 end;
       typ.wrapped = true;
+      typ.__nil = __mapNil;
       typ.init = function(key, elem)
          typ.key = key;
          typ.elem = elem;
@@ -1846,7 +2012,20 @@ __newType = function(size, kind, str, named, pkg, exported, constructor)
          for i,f in ipairs(fields) do
             properties[f.__prop] = { get= __throwNilPointerError, set= __throwNilPointerError };
          end;
-         typ.ptr.__nil = {} -- Object.create(constructor.prototype,s properties);
+         -- the fields of the nil pointer panic, as in Go.
+         typ.ptr.__nil = setmetatable({}, {
+               __index = function(t, k)
+                  if properties[k] ~= nil then
+                     properties[k].get()
+                  end
+               end,
+               __newindex = function(t, k, v)
+                  if properties[k] ~= nil then
+                     properties[k].set()
+                  end
+                  rawset(t, k, v)
+               end,
+         }) -- Object.create(constructor.prototype,s properties);
          --if constructor ~= nil then
          --   constructor(typ.ptr.__nil)
          --end
@@ -1891,7 +2070,7 @@ __newType = function(size, kind, str, named, pkg, exported, constructor)
       typ.zero = function() return false; end;
 
    elseif kind ==__kindMap then
-      typ.zero = function() return nil; end;
+      typ.zero = function() return __mapNil; end;
 
    elseif kind == __kindInt or
       kind ==  __kindInt8 or
@@ -1940,6 +2119,9 @@ __newType = function(size, kind, str, named, pkg, exported, constructor)
       typ.zero = function()
          --print("in zero() for array...")
 
+         if __ffiElemCtype(typ.elem) ~= nil then
+            return __newNumericArray(typ.elem, typ.len)
+         end
          local array = {}
          for i =0, typ.len -1 do
             array[i]= typ.elem();
@@ -2103,7 +2285,7 @@ __type__.float64 = __newType( 8, __kindFloat64,    "float64",  true, "", false,
 __type__.complex64  = __newType( 8, __kindComplex64,  "complex64",   true, "", false, nil);
 __type__.complex128 = __newType(16, __kindComplex128, "complex128",  true, "", false, nil);
 __type__.string  = __newType(16, __kindString,  "string",   true, "", false, nil);
---__type__.unsafePointer = __newType( 8, __kindUnsafePointer, "unsafe.Pointer", true, "", false, nil);
+__type__.UnsafePointer = __newType( 8, __kindUnsafePointer, "unsafe.Pointer", true, "", false, nil);
 
 __ptrType = function(elem)
    if elem == nil then
@@ -2259,7 +2441,7 @@ function __Chan(elem, capacity, elemReflectType)
 
    -- gopherJS stuff below
    if capacity < 0  or  capacity > 2147483647 then
-      __throwRuntimeError("makechan: size out of range");
+      __throwPlainError("makechan: size out of range");
    end
    this.elem = elem;
    this.__capacity = capacity;
@@ -2276,7 +2458,7 @@ end;
 function __Chan_GopherJS(elem, capacity)
    local this = {}
    if capacity < 0  or  capacity > 2147483647 then
-      __throwRuntimeError("makechan: size out of range");
+      __throwPlainError("makechan: size out of range");
    end
    this.elem = elem;
    this.__capacity = capacity;
@@ -2323,7 +2505,9 @@ __funcType = function(params, results, variadic)
    if typ == nil then
       local paramTypeNames = __mapArray(params, function(p) return p.__str; end);
       if variadic then
-         paramTypeNames[#paramTypeNames - 1] = "..." .. paramTypeNames[#paramTypeNames - 1].substr(2);
+         -- "[]T" becomes "...T"
+         local last = #paramTypeNames
+         paramTypeNames[last] = "..." .. string.sub(paramTypeNames[last], 3);
       end
       local str = "func(" .. table.concat(paramTypeNames, ", ") .. ")";
       
@@ -2389,6 +2573,8 @@ __type__.emptyInterface = __interfaceType({});
 __ifaceNil = {};
 __error = __newType(8, __kindInterface, "error", true, "", false, nil);
 __error.init({{__prop= "Error", __name= "Error", __pkg= "", __typ= __funcType({}, {__type__.string}, false) }});
+-- the translation names it __type__.error
+__type__.error = __error;
 
 __mapTypes = {};
 __mapType = function(key, elem, mType)
@@ -2682,6 +2868,30 @@ __makeMap = function(entries, keyType, elemType, mType)
    return m
 end;
 
+-- __mapNil is the nil map, of every map type. As in
+-- Go, reading it gets the zero value, ranging over it
+-- does nothing, and writing to it panics.
+__mapNil = setmetatable({}, {
+   __name = "__mapNil",
+   __newindex = function(t, k, v)
+      __throwPlainError("assignment to entry in nil map")
+   end,
+   __len = function(t)
+      return 0
+   end,
+   __pairs = function(t)
+      return function() return nil end, t, nil
+   end,
+   __call = function(t, oper, k, zeroVal)
+      if oper == "get" then
+         return zeroVal, false
+      end
+   end,
+   __tostring = function(t)
+      return "map[]"
+   end,
+})
+
 
 -- __basicValue2kind: identify type of basic value
 --   or return __kindUnknown if we don't recognize it.
@@ -2764,7 +2974,7 @@ __makeSlice = function(typ, length, capacity)
       __throwRuntimeError("makeslice: len out of range");
    end
    if capacity < 0  or  capacity < length  or  capacity > 9007199254740992 then
-      __throwRuntimeError("makeslice: cap out of range: "..tostring(capacity));
+      __throwRuntimeError("makeslice: cap out of range");
    end
    local array = __newAnyArrayValue(typ.elem, capacity)
    local slice = typ(array);
@@ -2819,6 +3029,154 @@ __structType = function(pkgPath, fields)
    return typ;
 end;
 
+-- cstructs: the values of a named struct type that
+-- is plain old data are cdata of an FFI struct, which
+-- the compiler declares with __cstructType once the
+-- fields are known; see ffistruct.go. Number and bool
+-- fields are the cstruct's own. Array and struct fields
+-- are declared as __gi_<name>; reading one gives a
+-- gijit array value over the struct's memory, or an
+-- anchored reference to the nested struct, and writing
+-- one copies into it.
+
+-- the ctype ids of cstructs, and of references
+-- to them, map to their gijit types.
+__cstructTypes = {}
+
+-- __cstructOf returns the gijit type of x if
+-- x is a cstruct, or nil.
+function __cstructOf(x)
+   if type(x) ~= "cdata" then
+      return nil
+   end
+   return __cstructTypes[tonumber(__ffi.typeof(x))]
+end
+
+-- __cstructValue is the struct value v is, or
+-- that v points to.
+function __cstructValue(v)
+   if type(v) == "table" then
+      return v.__val
+   end
+   return v
+end
+
+-- __cstructType declares typ's FFI struct from decl,
+-- in which each $ is the struct of the next of the
+-- nested types, and makes typ's values cdata of it. A
+-- nested type not itself a cstruct yet leaves typ a
+-- table, as it would be without the FFI.
+function __cstructType(typ, decl, ...)
+   local nested = {...}
+   for _, n in ipairs(nested) do
+      if n.__ctype == nil then
+         return
+      end
+   end
+   local i = 0
+   decl = string.gsub(decl, "%$", function()
+                         i = i + 1
+                         return "struct "..nested[i].__cname
+   end)
+   -- type ids are never reused, so a type redefined
+   -- at the REPL gets a new struct.
+   local cname = "gi_cstruct_"..typ.id
+   __ffi.cdef("struct "..cname.." { "..decl.." };")
+   local ct = __ffi.typeof("struct "..cname)
+
+   local arrays, structs = {}, {}
+   for _, f in ipairs(typ.fields) do
+      if f.__typ.kind == __kindArray then
+         arrays[f.__prop] = f.__typ
+      elseif f.__typ.kind == __kindStruct then
+         structs[f.__prop] = "__gi_"..f.__prop
+      end
+   end
+   local proto = typ.prototype
+
+   __ffi.metatype(ct, {
+      __index = function(me, k)
+         if k == "__val" then
+            return me
+         elseif k == "__typ" then
+            return typ
+         elseif k == "__name" then
+            return "__structValue"
+         end
+         local at = arrays[k]
+         if at ~= nil then
+            return at(__numericArrayView(at.elem, at.len, me["__gi_"..k], me))
+         end
+         local sf = structs[k]
+         if sf ~= nil then
+            local r = me[sf]
+            __cstructAnchors[r] = me
+            return r
+         end
+         return proto[k]
+      end,
+      __newindex = function(me, k, v)
+         local at = arrays[k]
+         if at ~= nil then
+            local dst = __numericArrayView(at.elem, at.len, me["__gi_"..k], me)
+            __copyArray(dst, rawget(v, "__val") or v, 0, 0, at.len, at.elem)
+            return
+         end
+         local sf = structs[k]
+         if sf ~= nil then
+            me[sf] = __cstructValue(v)
+            return
+         end
+         error(typ.__str.." has no field "..tostring(k), 2)
+      end,
+      __eq = function(a, b)
+         if __cstructOf(a) ~= typ or __cstructOf(b) ~= typ then
+            return false
+         end
+         return __equal(a, b, typ)
+      end,
+      __tostring = function(me)
+         return proto.__tostring(me)
+      end,
+   })
+
+   typ.__cname = cname
+   __cstructAdopt(typ, ct)
+end
+
+-- __cstructAdopt makes the values of struct type
+-- typ cdata of ct, whose metatype is already set.
+function __cstructAdopt(typ, ct)
+   typ.__ctype = ct
+   __cstructTypes[tonumber(ct)] = typ
+   __cstructTypes[tonumber(__ffi.typeof("$&", ct))] = typ
+
+   local size = __ffi.sizeof(ct)
+   typ.tfun = function(...)
+      local me = ct()
+      -- typ() calls us with typ.zero().
+      local v = ...
+      if select("#", ...) == 1 and __cstructOf(v) == typ then
+         __ffi.copy(me, v, size)
+         return me
+      end
+      local this = typ.__constructor(...)
+      for _, f in ipairs(typ.fields) do
+         local v = this[f.__prop]
+         if v ~= nil then
+            me[f.__prop] = v
+         end
+      end
+      return me
+   end
+   typ.copy = function(dst, src)
+      __ffi.copy(__cstructValue(dst), __cstructValue(src), size)
+   end
+   typ.zero = function()
+      return ct()
+   end
+end
+
 
 __equal = function(a, b, typ)
    if typ == nil then
@@ -2908,12 +3266,17 @@ __interfaceIsEqual = function(a, b)
 end;
 
 
-__assertType = function(value, typ, returnTuple)
+-- __assertType is x.(typ). returnTuple is 0 for x.(typ),
+-- which panics with a *runtime.TypeAssertionError on
+-- failure, and 1 or 2 for the v, ok forms. from, when
+-- given, is the static type of x, for the panic message.
+__assertType = function(value, typ, returnTuple, from)
 
+   local tuple = returnTuple ~= nil and returnTuple ~= 0 and returnTuple ~= false
    local isInterface = (typ.kind == __kindInterface)
    local ok = false
    local missingMethod = "";
-   if value == __ifaceNil then
+   if value == nil or value == __ifaceNil then
       ok = false;
    elseif  not isInterface then
       local knd = __basicValue2kind(value)
@@ -2922,9 +3285,25 @@ __assertType = function(value, typ, returnTuple)
          if typ.kind == knd then
             return value, true
          end
-      else
+      elseif type(value) == "table" or __cstructOf(value) ~= nil then
          ok = value.__typ == typ;
       end
+   elseif (type(value) ~= "table" and __cstructOf(value) == nil) or value.__typ == nil then
+      -- basic values have no methods; Go values from
+      -- luar, and Lua objects, have theirs by name.
+      ok = true
+      for _, tm in ipairs(typ.methods) do
+         if type(value) ~= "table" and type(value) ~= "userdata" then
+            ok = false
+         else
+            local found, m = pcall(function() return value[tm.__name] end)
+            ok = found and m ~= nil
+         end
+         if not ok then
+            missingMethod = tm.__name
+            break
+         end
+      end
    else
       local valueTypeString = value.__typ.__str;
 
@@ -2973,32 +3352,169 @@ __assertType = function(value, typ, returnTuple)
    --print("__assertType: after matching loop, ok = ", ok)
    
    if not ok then
-      if returnTuple then
+      if tuple then
          if isInterface then
             return nil, false
          end
          return typ.zero(), false
       end
-      local msg = ""
-      if value ~= __ifaceNil then
-         msg = value.__typ.__str
+      local iface = "interface"
+      if from ~= nil then
+         iface = from.__str
       end
-      --__panic(__packages["runtime"].TypeAssertionError.ptr("", msg, typ.__str, missingMethod));
-      error("type-assertion-error: could not '"..msg.."' -> '"..typ.__str.."', missing method '"..missingMethod.."'")
+      panic(__type__.__runtime.TypeAssertionError.ptrToNewlyConstructed(iface, __concreteTypeString(value), typ.__str, missingMethod))
    end
    
-   if not isInterface then
+   if not isInterface and typ.kind == __kindStruct and (type(value) == "table" or __cstructOf(value) ~= nil) then
+      -- a copy, so that the boxed value stays as it is.
+      value = __clone(value, typ)
+   elseif not isInterface and type(value) == "table" then
       value = value.__val;
    end
    if typ == __jsObjectPtr then
       value = value.object;
    end
-   if returnTuple then
+   if tuple then
       return value, true
    end
    return value
 end;
 
+-- runtime errors.
+--
+-- The runtime faults of Go code, such as an index out of
+-- range, panic with values that implement runtime.Error,
+-- with Go's messages, so that recover() returns what it
+-- would in Go. gijit's named types are structs, so Go's
+-- errorString and plainError are here structs holding
+-- the message. import "runtime" makes runtime.Error and
+-- runtime.TypeAssertionError these; see __gijit_installRuntime.
+
+-- __concreteTypeString is the Go type of value,
+-- as the runtime names it, or nil for nil.
+__concreteTypeString = function(value)
+   if value == nil or value == __ifaceNil then
+      return nil
+   end
+   if type(value) == "table" and value.__typ ~= nil then
+      return value.__typ.__str
+   end
+   local cst = __cstructOf(value)
+   if cst ~= nil then
+      return cst.__str
+   end
+   local knd = __basicValue2kind(value)
+   if knd ~= __kindUnknown then
+      return string.lower(string.sub(__kind2str[knd], 7))
+   end
+   if type(value) == "userdata" then
+      local ok, str = pcall(function() return reflect.TypeOf(value):String() end)
+      if ok then
+         return str
+      end
+   end
+   return type(value)
+end
+
+local function runtimeErrorType(name, fields, errorFn)
+   local typ = __newType(0, __kindStruct, "runtime."..name, true, "runtime", false, nil);
+   local runtimeError = {prop= "RuntimeError", __name= "RuntimeError", __pkg="", __typ= __funcType({}, {}, false), __ptrRecv= true}
+   local err = {prop= "Error", __name= "Error", __pkg="", __typ= __funcType({}, {__type__.string}, false), __ptrRecv= true}
+   typ.__methods_desc = {runtimeError, err}
+   __ptrType(typ)
+   typ.ptr.__methods_desc = {runtimeError, err}
+
+   local initFields = {}
+   for i, f in ipairs(fields) do
+      initFields[i] = {__prop= f, __name= f, __anonymous= false, __exported= false, __typ= __type__.string, __tag= ""}
+   end
+   typ.init("runtime", initFields)
+   typ.__constructor = function(...)
+      local self = {}
+      local args = {...}
+      for i, f in ipairs(fields) do
+         self[f] = args[i] or ""
+      end
+      return self
+   end
+
+   typ.ptr.prototype.RuntimeError = function(e) end
+   typ.prototype.RuntimeError = function(this) return typ.ptr.prototype.RuntimeError(this.__val) end
+   typ.__addToMethods(runtimeError)
+   typ.ptr.__addToMethods(runtimeError)
+   typ.ptr.prototype.Error = errorFn
+   typ.prototype.Error = function(this) return errorFn(this.__val) end
+   typ.__addToMethods(err)
+   typ.ptr.__addToMethods(err)
+   return typ
+end
+
+__type__.__runtime = {}
+
+__type__.__runtime.Error = __newType(8, __kindInterface, "runtime.Error", true, "runtime", true, nil);
+__type__.__runtime.Error.init({
+      {__prop= "Error", __name= "Error", __pkg= "", __typ= __funcType({}, {__type__.string}, false)},
+      {__prop= "RuntimeError", __name= "RuntimeError", __pkg= "", __typ= __funcType({}, {}, false)},
+});
+
+-- most faults are Go's errorString, as
+-- "runtime error: index out of range [3] with length 0".
+__type__.__runtime.errorString = runtimeErrorType("errorString", {"s"}, function(e)
+   return "runtime error: "..e.s
+end)
+
+-- a few, such as a write to a nil map, are Go's
+-- plainError, without the "runtime error: ".
+__type__.__runtime.plainError = runtimeErrorType("plainError", {"s"}, function(e)
+   return e.s
+end)
+
+-- concrete is nil when the value asserted was nil.
+__type__.__runtime.TypeAssertionError = runtimeErrorType("TypeAssertionError",
+   {"_interface", "concrete", "asserted", "missingMethod"}, function(e)
+      local inter = e._interface
+      if inter == "" then
+         inter = "interface"
+      end
+      if e.concrete == "" then
+         return "interface conversion: "..inter.." is nil, not "..e.asserted
+      end
+      if e.missingMethod == "" then
+         return "interface conversion: "..inter.." is "..e.concrete..", not "..e.asserted
+      end
+      return "interface conversion: "..e.concrete.." is not "..e.asserted..": missing method "..e.missingMethod
+end)
+
+__throwRuntimeError = function(msg)
+   panic(__type__.__runtime.errorString.ptrToNewlyConstructed(msg))
+end
+
+__throwPlainError = function(msg)
+   panic(__type__.__runtime.plainError.ptrToNewlyConstructed(msg))
+end
+
+-- __isRuntimeError reports whether v is one of the above.
+__isRuntimeError = function(v)
+   if type(v) ~= "table" then
+      return false
+   end
+   local t = v.__typ
+   return t == __type__.__runtime.errorString.ptr or
+      t == __type__.__runtime.plainError.ptr or
+      t == __type__.__runtime.TypeAssertionError.ptr
+end
+
+-- __itoa formats an int64 index as Go does, without the LL.
+__itoa = function(i)
+   return tostring(tonumber(i))
+end
+
+-- __gijit_installRuntime: import "runtime".
+function __gijit_installRuntime()
+   __type__.runtime.Error = __type__.__runtime.Error
+   __type__.runtime.TypeAssertionError = __type__.__runtime.TypeAssertionError
+end
+
 __stackDepthOffset = 0;
 __getStackDepth = function()
    local err = __error(); -- new
@@ -3266,14 +3782,17 @@ end
 -- The main eval procedure for the gijit REPL
 -- It only compiles and runs 'code', then exits.
 --
-__gijitMainEval = function(code)
+-- The optional chunkname, e.g. "=gi:12", is what LuaJIT
+-- reports for this code in tracebacks and profiles.
+--
+__gijitMainEval = function(code, chunkname)
    --print("top of __gijitMainEval")
    __lastEvalErr = ""
    
    local chunk, err, ok
    --print("top of main loop: while true...")
    -- compile chunk to bytecode
-   chunk, err = loadstring(code);
+   chunk, err = loadstring(code, chunkname);
    --print("back from loadstring of code '"..code.."'  we have err=",err," and chunk=", chunk)
    if err ~= nil then
       
@@ -3310,7 +3829,7 @@ end
 
 __eval_next_count = 1
 
-__eval = function(code)
+__eval = function(code, chunkname)
    local res = {pcall(function() 
                       --print("__eval called with code: '"..tostring(code).."'")
                       --__stacks()
@@ -3319,9 +3838,8 @@ __eval = function(code)
    -- need to start each new bit of code at the repl
    -- on its own coroutine.
 
-   __gijitEvalCoro = coroutine.create(function() __gijitMainEval(code) end)
-   table.insert(__all_coro, __gijitEvalCoro)
-   __coro2notes[__gijitEvalCoro]={__loc=#__all_coro, __name="co-eval-"..tostring(__eval_next_count)}
+   __gijitEvalCoro = coroutine.create(function() __gijitMainEval(code, chunkname) end)
+   __task_register(__gijitEvalCoro, "co-eval-"..tostring(__eval_next_count))
    __eval_next_count = __eval_next_count+1
 
    -- we need the scheduler to resume this goroutine,
@@ -3329,8 +3847,11 @@ __eval = function(code)
    -- eval coro yields, so that it can effect
    -- the actual receive during chan receive <- ops.
 
-   __task_ready(__gijitEvalCoro)
-   __task.resume_scheduler()
+   local fatal = __task.run_eval(__gijitEvalCoro)
+   if fatal ~= nil then
+      __lastEvalErr = fatal
+      print(fatal)
+   end
 
    __cleanupDeadCoro()   
    --print("end of __eval, returning")
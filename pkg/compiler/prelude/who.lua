@@ -0,0 +1,169 @@
+-- who.lua: the sizes and previews behind :who and :whos.
+--
+-- Bytes are an estimate of what the value would
+-- occupy in compiled Go on a 64-bit machine: the
+-- value itself, plus what it points to (backing
+-- arrays, string bytes, map entries, pointees),
+-- each counted once. Map overhead is a guess.
+
+local mapHeader = 48 -- runtime.hmap
+local mapEntry = 8   -- tophash and overflow, per entry
+
+local fixed = {
+   [__kindBool] = 1,
+   [__kindInt8] = 1,
+   [__kindUint8] = 1,
+   [__kindInt16] = 2,
+   [__kindUint16] = 2,
+   [__kindInt32] = 4,
+   [__kindUint32] = 4,
+   [__kindFloat32] = 4,
+   [__kindInt] = 8,
+   [__kindInt64] = 8,
+   [__kindUint] = 8,
+   [__kindUint64] = 8,
+   [__kindUintptr] = 8,
+   [__kindFloat64] = 8,
+   [__kindComplex64] = 8,
+   [__kindPtr] = 8,
+   [__kindMap] = 8,
+   [__kindChan] = 8,
+   [__kindFunc] = 8,
+   [__kindUnsafePointer] = 8,
+   [__kindComplex128] = 16,
+   [__kindString] = 16,
+   [__kindInterface] = 16,
+   [__kindSlice] = 24,
+}
+
+local function isNil(typ, x)
+   return x == nil or x == false or x == __ifaceNil or x == typ.__nil
+end
+
+-- sizeof is the size of a typ value itself,
+-- not counting what it points to.
+local function sizeof(typ)
+   local k = typ.kind
+   if k == __kindArray then
+      return typ.len * sizeof(typ.elem)
+   elseif k == __kindStruct then
+      local n = 0
+      for _, f in ipairs(typ.fields) do
+         n = n + sizeof(f.__typ)
+      end
+      return n
+   end
+   return fixed[k] or 8
+end
+
+-- beyond is what x, of type typ, points to,
+-- skipping what seen has already counted.
+local function beyond(typ, x, seen)
+   if x == nil then
+      return 0
+   end
+   local k = typ.kind
+   if k == __kindString then
+      return #x
+   elseif k == __kindSlice then
+      if isNil(typ, x) or seen[x] then
+         return 0
+      end
+      seen[x] = true
+      local n = (x.__capacity or #x) * sizeof(typ.elem)
+      for i = 0, #x - 1 do
+         n = n + beyond(typ.elem, __gi_GetRangeCheck(x, i), seen)
+      end
+      return n
+   elseif k == __kindArray then
+      local n = 0
+      for i = 0, typ.len - 1 do
+         n = n + beyond(typ.elem, x[i], seen)
+      end
+      return n
+   elseif k == __kindStruct then
+      local n = 0
+      for _, f in ipairs(typ.fields) do
+         n = n + beyond(f.__typ, x[f.__prop], seen)
+      end
+      return n
+   elseif k == __kindMap then
+      if isNil(typ, x) or seen[x] then
+         return 0
+      end
+      seen[x] = true
+      local n = mapHeader
+      local per = sizeof(typ.key) + sizeof(typ.elem) + mapEntry
+      for key, v in pairs(x) do
+         n = n + per + beyond(typ.key, key, seen) + beyond(typ.elem, v, seen)
+      end
+      return n
+   elseif k == __kindPtr then
+      if isNil(typ, x) or type(x) ~= "table" or seen[x] then
+         return 0
+      end
+      seen[x] = true
+      -- structs and arrays are their own pointers.
+      local ek = typ.elem.kind
+      if ek == __kindStruct or ek == __kindArray then
+         return sizeof(typ.elem) + beyond(typ.elem, x, seen)
+      end
+      if x.__get == nil then
+         return 0
+      end
+      return sizeof(typ.elem) + beyond(typ.elem, x.__get(), seen)
+   end
+   return 0
+end
+
+-- preview is the value as %v prints it, on one
+-- line of at most width bytes.
+local function preview(typ, x, width)
+   local ok, s = pcall(__gijit_fmtSprintf, "%v", {typ}, x)
+   if not ok then
+      s = tostring(x)
+   end
+   s = string.gsub(s, "\n", " ")
+   if #s > width then
+      s = string.sub(s, 1, width - 3) .. "..."
+   end
+   return s
+end
+
+-- __gijit_whos returns the length, capacity, and
+-- approximate bytes of x, a value of type typ, and a
+-- preview of it. Length and capacity are -1 where
+-- typ has none. typ may be nil, when the type has
+-- no runtime descriptor; then only the preview is
+-- given.
+function __gijit_whos(x, typ, width)
+   width = width or 40
+   if typ == nil then
+      return -1, -1, -1, preview(nil, x, width)
+   end
+   local n, c = -1, -1
+   local k = typ.kind
+   if k == __kindSlice then
+      if isNil(typ, x) then
+         n, c = 0, 0
+      else
+         n, c = #x, x.__capacity or #x
+      end
+   elseif k == __kindMap then
+      if isNil(typ, x) then
+         n = 0
+      else
+         n = #x
+      end
+   elseif k == __kindString then
+      n = #x
+   elseif k == __kindArray then
+      n, c = typ.len, typ.len
+   end
+   local ok, more = pcall(beyond, typ, x, {})
+   local bytes = sizeof(typ)
+   if ok then
+      bytes = bytes + more
+   end
+   return n, c, bytes, preview(typ, x, width)
+end
@@ -0,0 +1,49 @@
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2003StaticPreludeIsCurrent(t *testing.T) {
+
+	cv.Convey(`prelude_static.go, which gi loads its prelude from unless run with -d, holds each prelude/*.lua as it is; if not, rerun cmd/gen_static_prelude. A vm on the static prelude has the prelude's globals.`, t, func() {
+
+		files, err := filepath.Glob("prelude/*.lua")
+		panicOn(err)
+		cv.So(len(files), cv.ShouldBeGreaterThan, 0)
+		for _, fn := range files {
+			want, err := ioutil.ReadFile(fn)
+			panicOn(err)
+			f, err := preludeFiles.Open(filepath.Base(fn))
+			if err != nil {
+				t.Errorf("prelude_static.go has no %s", fn)
+				continue
+			}
+			got, err := ioutil.ReadAll(f)
+			panicOn(err)
+			f.Close()
+			if string(got) != string(want) {
+				t.Errorf("prelude_static.go has a stale %s", fn)
+			}
+		}
+
+		cfg := NewGIConfig()
+		cfg.IsTestMode = false
+		cfg.Quiet = true
+		vm, err := NewLuaVmWithPrelude(cfg)
+		panicOn(err)
+		defer vm.Close()
+
+		// some of the functions that the
+		// newer prelude files define.
+		for _, g := range []string{"__profile_start", "__gijit_proxyCall", "__gijit_funcCall", "__gijit_idle"} {
+			panicOn(LuaRun(vm, fmt.Sprintf(`__hasGlobal = type(%s) == "function"`, g), false))
+			LuaMustBool(vm, "__hasGlobal", true)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package compiler
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2000PositionMarkersDoNotChangeTranslation(t *testing.T) {
+
+	srcs := []string{
+		`a := 10`,
+		"func adder(a, b int) int {\n\treturn a + b\n}\nsum := adder(a, 5)",
+		`type Pt struct { X, Y int }; func (p *Pt) Len() int { return p.X + p.Y }`,
+		`pt := &Pt{X: 1, Y: 2}; n := pt.Len()`,
+		"s := []string{\"a\", \"b\\nc\"}\nfor i := range s {\n\tprintln(s[i])\n}",
+		`f := func(x int) int { if x > 2 { return x }; return -x }; m := f(3)`,
+		`if a > 0 { println("hi") }`,
+		`var ch = make(chan int, 1); ch <- 4; q := <-ch`,
+	}
+
+	plainVm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer plainVm.Close()
+	plain := NewIncrState(plainVm, nil)
+
+	posVm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer posVm.Close()
+	withPos := NewIncrState(posVm, nil)
+	withPos.TrackPos = true
+
+	cv.Convey("Given TrackPos, the Lua generated is unchanged once the position markers are removed, and each entry gets a ChunkPosMap named gi:N.", t, func() {
+		for i, src := range srcs {
+			by1, err := plain.Tr([]byte(src))
+			panicOn(err)
+			by2, err := withPos.Tr([]byte(src))
+			panicOn(err)
+			cv.So(string(by2), cv.ShouldEqual, string(by1))
+			cv.So(strings.Contains(string(by2), "\b"), cv.ShouldBeFalse)
+
+			m := withPos.LastPosMap
+			cv.So(m, cv.ShouldNotBeNil)
+			cv.So(m.Entry, cv.ShouldEqual, i+1)
+			cv.So(m.Name, cv.ShouldEqual, "gi:"+strconv.Itoa(i+1))
+
+			err = LuaRunChunk(posVm, string(by2), m.Name)
+			panicOn(err)
+		}
+		LuaMustInt64(posVm, "sum", 15)
+		LuaMustInt64(posVm, "n", 3)
+		LuaMustInt64(posVm, "m", 3)
+		LuaMustInt64(posVm, "q", 4)
+	})
+}
+
+func Test2001ChunkPosMapNamesGoFunctions(t *testing.T) {
+
+	vm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer vm.Close()
+	inc := NewIncrState(vm, nil)
+	inc.TrackPos = true
+
+	cv.Convey("A ChunkPosMap maps Lua lines back to Go lines and the Go function enclosing them.", t, func() {
+		src := `type T struct{}
+func (t *T) Get() int {
+	return 7
+}
+func twice(x int) int {
+	g := func(y int) int {
+		return y * 2
+	}
+	return g(x)
+}
+z := twice(4)
+`
+		by, err := inc.Tr([]byte(src))
+		panicOn(err)
+		m := inc.LastPosMap
+		cv.So(m.FuncName(3), cv.ShouldEqual, "main.(*T).Get")
+		cv.So(m.FuncName(9), cv.ShouldEqual, "main.twice")
+		cv.So(m.FuncName(7), cv.ShouldEqual, "main.twice.func1")
+		cv.So(m.FuncName(11), cv.ShouldEqual, "main.entry1")
+		cv.So(m.SrcLine(7), cv.ShouldEqual, "return y * 2")
+
+		// every Go line with code in it should be
+		// the target of some Lua line.
+		targets := make(map[int]bool)
+		for i := 1; i <= strings.Count(string(by), "\n")+1; i++ {
+			targets[m.GoLine(i)] = true
+		}
+		cv.So(targets[3], cv.ShouldBeTrue)
+		cv.So(targets[7], cv.ShouldBeTrue)
+		cv.So(targets[11], cv.ShouldBeTrue)
+
+		cv.So(LuaRunChunk(vm, string(by), m.Name), cv.ShouldBeNil)
+		LuaMustInt64(vm, "z", 8)
+
+		chunk, line, ok := parseChunkLine("gi:12:40")
+		cv.So(ok, cv.ShouldBeTrue)
+		cv.So(chunk, cv.ShouldEqual, "gi:12")
+		cv.So(line, cv.ShouldEqual, 40)
+	})
+}
+
+func Test2002ProfileWritesPprof(t *testing.T) {
+
+	vm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer vm.Close()
+	inc := NewIncrState(vm, nil)
+	inc.TrackPos = true
+	r := &Repl{lvm: vm, inc: inc, chunks: make(map[string]*ChunkPosMap)}
+
+	dir, err := ioutil.TempDir("", "gi-profile")
+	panicOn(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cpu.pprof")
+
+	cv.Convey(":profile start and stop sample the Go code run in between, and write a gzipped pprof profile naming the Go functions.", t, func() {
+		src := `
+func busy(n int) int {
+	tot := 0
+	for i := 0; i < n; i++ {
+		tot += i
+	}
+	return tot
+}`
+		by, err := inc.Tr([]byte(src))
+		panicOn(err)
+		m := inc.LastPosMap
+		r.chunks[m.Name] = m
+		panicOn(LuaRunChunk(vm, string(by), m.Name))
+
+		_, err = r.profileCmd([]string{"start", path})
+		panicOn(err)
+		cv.So(r.prof.running, cv.ShouldBeTrue)
+
+		by, err = inc.Tr([]byte(`b := busy(30000000)`))
+		panicOn(err)
+		m = inc.LastPosMap
+		r.chunks[m.Name] = m
+		panicOn(LuaRunChunk(vm, string(by), m.Name))
+
+		LuaMustInt64(vm, "b", 449999985000000)
+
+		_, err = r.profileCmd([]string{"stop"})
+		panicOn(err)
+		cv.So(r.prof.running, cv.ShouldBeFalse)
+		cv.So(r.prof.total, cv.ShouldBeGreaterThan, 0)
+
+		sum := r.prof.summary(5)
+		cv.So(sum, cv.ShouldContainSubstring, "main.busy")
+		cv.So(sum, cv.ShouldNotContainSubstring, "__gijitMainEval")
+
+		f, err := os.Open(path)
+		panicOn(err)
+		defer f.Close()
+		zr, err := gzip.NewReader(f)
+		cv.So(err, cv.ShouldBeNil)
+		raw, err := ioutil.ReadAll(zr)
+		cv.So(err, cv.ShouldBeNil)
+		cv.So(string(raw), cv.ShouldContainSubstring, "main.busy")
+		cv.So(string(raw), cv.ShouldContainSubstring, "nanoseconds")
+
+		_, err = r.profileCmd([]string{"stop"})
+		cv.So(err, cv.ShouldNotBeNil)
+	})
+}
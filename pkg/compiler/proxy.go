@@ -0,0 +1,239 @@
+package compiler
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+
+	golua "github.com/glycerine/golua/lua"
+	"github.com/glycerine/luar"
+
+	shadow_context "github.com/gijit/gi/pkg/compiler/shadow/context"
+	shadow_encoding "github.com/gijit/gi/pkg/compiler/shadow/encoding"
+	shadow_encoding_binary "github.com/gijit/gi/pkg/compiler/shadow/encoding/binary"
+	shadow_encoding_json "github.com/gijit/gi/pkg/compiler/shadow/encoding/json"
+	shadow_fmt "github.com/gijit/gi/pkg/compiler/shadow/fmt"
+	shadow_io "github.com/gijit/gi/pkg/compiler/shadow/io"
+	shadow_math_rand "github.com/gijit/gi/pkg/compiler/shadow/math/rand"
+	shadow_os "github.com/gijit/gi/pkg/compiler/shadow/os"
+	shadow_runtime "github.com/gijit/gi/pkg/compiler/shadow/runtime"
+	shadow_sync "github.com/gijit/gi/pkg/compiler/shadow/sync"
+
+	shadow_blas "github.com/gijit/gi/pkg/compiler/shadow/gonum.org/v1/gonum/blas"
+	shadow_graph "github.com/gijit/gi/pkg/compiler/shadow/gonum.org/v1/gonum/graph"
+	shadow_lapack "github.com/gijit/gi/pkg/compiler/shadow/gonum.org/v1/gonum/lapack"
+	shadow_mat "github.com/gijit/gi/pkg/compiler/shadow/gonum.org/v1/gonum/mat"
+	shadow_optimize "github.com/gijit/gi/pkg/compiler/shadow/gonum.org/v1/gonum/optimize"
+	shadow_unit "github.com/gijit/gi/pkg/compiler/shadow/gonum.org/v1/gonum/unit"
+)
+
+// Interface proxies: when a gijit value is passed to Go
+// where a Go interface is wanted, e.g. to io.Copy or
+// sort.Sort, luar asks gijitInterfaceProxy for a Go
+// value to stand in for it. The stand-in is a proxy: a
+// Go type implementing the interface, whose methods
+// call the gijit value's methods back in Lua; see
+// prelude/proxy.lua. Go cannot make new types with
+// methods at run time, so each interface supported
+// has a proxy type. cmd/gen-gijit-shadow-import makes
+// them for every interface of a shadowed package (see
+// proxyTemplate in genshadow.go), and they are all
+// registered below, with those for error and
+// sort.Interface. Other interfaces need a proxy type
+// registered with RegisterInterfaceProxy; passing a
+// gijit value for one that has none is an error.
+//
+// Go may keep a proxy, and call it later from any
+// goroutine. The proxy keeps only the gijit value's
+// registry ref: a call runs on the state Lua is
+// running, if Lua has called into Go, else on the
+// vm's main state, on a ticket, as the REPL's own
+// runs do; see (*Goro).onLuaThread. Once Go drops the
+// proxy, the gijit value is let go too.
+//
+// Where interface{} is wanted, as by fmt.Println, a
+// gijit value with an Error or String method is
+// passed as an error or fmt.Stringer, as Go would.
+
+// LuaProxy is the part common to all proxies: a gijit
+// value, held in the Lua registry of the vm that goro
+// runs, and the Go interface it stands in for.
+type LuaProxy struct {
+	goro  *Goro
+	ref   int
+	iface reflect.Type
+}
+
+var interfaceProxies = make(map[reflect.Type]func(p *LuaProxy) interface{})
+
+// RegisterInterfaceProxy makes mk the maker of proxies
+// for the interface type that ptrToIface points to,
+// e.g. (*io.Reader)(nil). The value mk returns must
+// implement that interface, with each method calling
+// p.Call.
+func RegisterInterfaceProxy(ptrToIface interface{}, mk func(p *LuaProxy) interface{}) {
+	t := reflect.TypeOf(ptrToIface).Elem()
+	if t.Kind() != reflect.Interface {
+		panic(fmt.Sprintf("RegisterInterfaceProxy: '%v' is not an interface", t))
+	}
+	interfaceProxies[t] = mk
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func init() {
+	luar.InterfaceProxyHook = gijitInterfaceProxy
+}
+
+// gijitInterfaceProxy is luar's InterfaceProxyHook.
+func gijitInterfaceProxy(L *golua.State, idx int, t reflect.Type) (reflect.Value, bool, error) {
+	if t.NumMethod() == 0 {
+		for _, t2 := range []reflect.Type{errorType, stringerType} {
+			if pv, ok, err := gijitInterfaceProxy(L, idx, t2); ok || err != nil {
+				return pv, ok, err
+			}
+		}
+		return reflect.Value{}, false, nil
+	}
+	if idx < 0 {
+		idx = L.GetTop() + idx + 1
+	}
+
+	// does the value have the methods?
+	top := L.GetTop()
+	L.GetGlobal("__gijit_proxyMissing")
+	L.PushValue(idx)
+	for i := 0; i < t.NumMethod(); i++ {
+		L.PushString(t.Method(i).Name)
+	}
+	err := L.Call(1+t.NumMethod(), 1)
+	missing := err != nil || !L.IsNil(-1)
+	L.SetTop(top)
+	if missing {
+		return reflect.Value{}, false, nil
+	}
+	mk, ok := interfaceProxies[t]
+	if !ok {
+		return reflect.Value{}, false, fmt.Errorf("gijit has no proxy for %v, "+
+			"to pass a gijit value as one; RegisterInterfaceProxy registers one", t)
+	}
+	r := goroFor(L)
+	if r == nil {
+		return reflect.Value{}, false, fmt.Errorf("gijit proxy for %v: the vm is closed", t)
+	}
+
+	// like a LuaObject, the proxy holds
+	// the value in the registry.
+	L.PushValue(idx)
+	p := &LuaProxy{goro: r, ref: L.Ref(golua.LUA_REGISTRYINDEX), iface: t}
+	runtime.SetFinalizer(p, func(p *LuaProxy) { p.goro.releaseRef(p.ref) })
+	return reflect.ValueOf(mk(p)), true, nil
+}
+
+// Call calls the gijit value's method name with args,
+// returning its results, typed as in the interface.
+// Slice arguments are copied back after the call, to
+// get whatever the method wrote into them. A Lua
+// error panics, as there is nowhere to return it.
+func (p *LuaProxy) Call(name string, args ...interface{}) (out []reflect.Value) {
+	m, ok := p.iface.MethodByName(name)
+	if !ok {
+		panic(fmt.Sprintf("%v has no method %s", p.iface, name))
+	}
+	p.goro.onLuaThread(func(L *golua.State) {
+		out = p.call(L, m, args)
+	})
+	return
+}
+
+func (p *LuaProxy) call(L *golua.State, m reflect.Method, args []interface{}) []reflect.Value {
+	name := m.Name
+	top := L.GetTop()
+	defer L.SetTop(top)
+
+	L.GetGlobal("__gijit_proxyCall")
+	L.RawGeti(golua.LUA_REGISTRYINDEX, p.ref)
+	L.PushString(name)
+	for _, a := range args {
+		luar.GoToLua(L, a)
+	}
+	err := L.Call(2+len(args), golua.LUA_MULTRET)
+	if err != nil {
+		panic(fmt.Sprintf("gijit proxy for %v, calling %s: %v", p.iface, name, err))
+	}
+
+	out := make([]reflect.Value, m.Type.NumOut())
+	k := top + 1
+	for i := range out {
+		pv := reflect.New(m.Type.Out(i))
+		_, err = luar.LuaToGo(L, k, pv.Interface())
+		if err != nil {
+			panic(fmt.Sprintf("gijit proxy for %v, result %d of %s: %v", p.iface, i, name, err))
+		}
+		out[i] = pv.Elem()
+		k++
+	}
+	for _, a := range args {
+		av := reflect.ValueOf(a)
+		if av.Kind() != reflect.Slice {
+			continue
+		}
+		if av.Len() > 0 {
+			pv := reflect.New(av.Type())
+			_, err = luar.LuaToGo(L, k, pv.Interface())
+			if err != nil {
+				panic(fmt.Sprintf("gijit proxy for %v, argument to %s: %v", p.iface, name, err))
+			}
+			reflect.Copy(av, pv.Elem())
+		}
+		k++
+	}
+	return out
+}
+
+// the proxies for error, which is no package's,
+// and sort.Interface, which gijit does not shadow.
+
+type errorProxy struct{ *LuaProxy }
+
+func (p errorProxy) Error() string { return p.Call("Error")[0].String() }
+
+type sortProxy struct{ *LuaProxy }
+
+func (p sortProxy) Len() int           { return int(p.Call("Len")[0].Int()) }
+func (p sortProxy) Less(i, j int) bool { return p.Call("Less", i, j)[0].Bool() }
+func (p sortProxy) Swap(i, j int)      { p.Call("Swap", i, j) }
+
+// shadowProxies are the proxies made for the
+// shadowed packages' interfaces, by interface.
+var shadowProxies = []map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{}{
+	shadow_context.Proxy,
+	shadow_encoding.Proxy,
+	shadow_encoding_binary.Proxy,
+	shadow_encoding_json.Proxy,
+	shadow_fmt.Proxy,
+	shadow_io.Proxy,
+	shadow_math_rand.Proxy,
+	shadow_os.Proxy,
+	shadow_runtime.Proxy,
+	shadow_sync.Proxy,
+	shadow_blas.Proxy,
+	shadow_graph.Proxy,
+	shadow_lapack.Proxy,
+	shadow_mat.Proxy,
+	shadow_optimize.Proxy,
+	shadow_unit.Proxy,
+}
+
+func init() {
+	for _, proxies := range shadowProxies {
+		for t, mk := range proxies {
+			mk := mk
+			interfaceProxies[t] = func(p *LuaProxy) interface{} { return mk(p.Call) }
+		}
+	}
+	RegisterInterfaceProxy((*error)(nil), func(p *LuaProxy) interface{} { return errorProxy{p} })
+	RegisterInterfaceProxy((*sort.Interface)(nil), func(p *LuaProxy) interface{} { return sortProxy{p} })
+}
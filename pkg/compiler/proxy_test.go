@@ -0,0 +1,250 @@
+package compiler
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2050GijitValuesSatisfyGoInterfaces(t *testing.T) {
+
+	cv.Convey("a gijit value passed to Go for an io.Reader, io.Writer, sort.Interface, error, or fmt.Stringer gets a Go proxy calling back into its methods.", t, func() {
+
+		src := `
+type reader interface { Read(p []byte) (int, error) }
+type writer interface { Write(p []byte) (int, error) }
+type sorter interface { Len() int; Less(i, j int) bool; Swap(i, j int) }
+
+type R struct { data string; off int }
+func (r *R) Read(p []byte) (int, error) {
+	if r.off >= len(r.data) { return 0, &E{"done"} }
+	n := copy(p, r.data[r.off:])
+	r.off += n
+	return n, nil
+}
+type E struct { msg string }
+func (e *E) Error() string { return "E:" + e.msg }
+type W struct { got string }
+func (w *W) Write(p []byte) (int, error) { w.got += string(p); return len(p), nil }
+type P struct { s []int }
+func (p *P) Len() int { return len(p.s) }
+func (p *P) Less(i, j int) bool { return p.s[i] < p.s[j] }
+func (p *P) Swap(i, j int) { p.s[i], p.s[j] = p.s[j], p.s[i] }
+type S struct{ x int }
+func (s *S) String() string { return "S!" }
+
+// stub definitions, to keep the type checker happy;
+// we replace them with Go functions below.
+func readAll(r reader) string { return "" }
+func readLine(r reader) string { return "" }
+func greet(w writer) {}
+func sortIt(x sorter) {}
+func sprint(x ...interface{}) string { return "" }
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		tk := vm.goro.newTicket("", false)
+		tk.regmap["readAll"] = func(r io.Reader) string {
+			b, err := ioutil.ReadAll(r)
+			return fmt.Sprintf("%q %v", b, err)
+		}
+		tk.regmap["readLine"] = func(r io.Reader) string {
+			s, err := bufio.NewReader(r).ReadString('\n')
+			return fmt.Sprintf("%q %v", s, err)
+		}
+		tk.regmap["greet"] = func(w io.Writer) { fmt.Fprintf(w, "hello %d", 42) }
+		tk.regmap["sortIt"] = func(x sort.Interface) { sort.Sort(x) }
+		tk.regmap["sprint"] = func(x ...interface{}) string { return fmt.Sprint(x...) }
+		panicOn(tk.Do())
+
+		src = `
+r := &R{data: "line one\nline two"}
+all := readAll(r)
+r.off = 0
+line := readLine(r)
+w := &W{}
+greet(w)
+got := w.got
+p := &P{s: []int{3, 1, 2}}
+sortIt(p)
+p0, p1, p2 := p.s[0], p.s[1], p.s[2]
+str := sprint(&S{}, " ", &E{"oops"})
+`
+		translation, err = inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		LuaMustString(vm, "all", `"line one\nline two" E:done`)
+		LuaMustString(vm, "line", `"line one\n" <nil>`)
+		LuaMustString(vm, "got", "hello 42")
+		LuaMustInt64(vm, "p0", 1)
+		LuaMustInt64(vm, "p1", 2)
+		LuaMustInt64(vm, "p2", 3)
+		LuaMustString(vm, "str", "S! E:oops")
+	})
+}
+
+func Test2051GoKeepsAndReleasesProxies(t *testing.T) {
+
+	cv.Convey("a proxy that Go keeps can be called later from other goroutines, each call waiting its turn on the vm; once Go drops it, the gijit value is released.", t, func() {
+
+		src := `
+type stringer interface { String() string }
+type S struct{ n int }
+func (s *S) String() string { s.n++; return "S!" }
+func keep(x stringer) {}
+s := &S{}
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		var kept fmt.Stringer
+		tk := vm.goro.newTicket("", false)
+		tk.regmap["keep"] = func(x fmt.Stringer) { kept = x }
+		panicOn(tk.Do())
+
+		translation, err = inc.Tr([]byte("if s != nil { keep(s) }"))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+		cv.So(kept, cv.ShouldNotBeNil)
+
+		// tickets from here on call s.String too.
+		LuaRunAndReport(vm, "function bump() s:String() end; s.n = 0LL")
+
+		var wg sync.WaitGroup
+		bad := make(chan string, 1)
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < 100; i++ {
+					if got := kept.String(); got != "S!" {
+						select {
+						case bad <- got:
+						default:
+						}
+					}
+				}
+			}()
+		}
+		for i := 0; i < 100; i++ {
+			panicOn(LuaRun(vm, "bump()", false))
+		}
+		wg.Wait()
+		close(bad)
+		cv.So(<-bad, cv.ShouldEqual, "")
+		LuaRunAndReport(vm, "n = s.n")
+		LuaMustInt64(vm, "n", 500)
+
+		kept = nil
+		pending := func() int {
+			vm.goro.mut.Lock()
+			defer vm.goro.mut.Unlock()
+			return len(vm.goro.unrefs)
+		}
+		for i := 0; i < 100 && pending() == 0; i++ {
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+		}
+		cv.So(pending(), cv.ShouldEqual, 1)
+		panicOn(LuaRun(vm, "", false))
+		cv.So(pending(), cv.ShouldEqual, 0)
+	})
+}
+
+type fooer interface{ Foo() int }
+
+func Test2052ProxiesForShadowedInterfacesAndOnTheRunningState(t *testing.T) {
+
+	cv.Convey("every interface of a shadowed package gets a proxy; an interface with none is an error; and a kept proxy calls back on the state Lua is running, not on the one it was made on.", t, func() {
+
+		src := `
+type seeker interface { Seek(offset int64, whence int) (int64, error) }
+type stringer interface { String() string }
+
+type K struct { off int64 }
+func (k *K) Seek(offset int64, whence int) (int64, error) {
+	if whence != 1 { return k.off, &E{"whence"} }
+	k.off += offset
+	return k.off, nil
+}
+type E struct { msg string }
+func (e *E) Error() string { return "E:" + e.msg }
+type S struct{ n int }
+func (s *S) String() string { s.n++; return "S!" }
+type F struct{}
+func (f *F) Foo() int { return 1 }
+
+func seekIt(s seeker) string { return "" }
+func keep(x stringer) {}
+func useKept() string { return "" }
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		var kept fmt.Stringer
+		tk := vm.goro.newTicket("", false)
+		tk.regmap["seekIt"] = func(s io.Seeker) string {
+			a, err1 := s.Seek(5, io.SeekCurrent)
+			b, err2 := s.Seek(0, io.SeekStart)
+			return fmt.Sprint(a, err1, b, err2)
+		}
+		tk.regmap["keep"] = func(x fmt.Stringer) { kept = x }
+		tk.regmap["useKept"] = func() string { return kept.String() }
+		panicOn(tk.Do())
+
+		src = `
+got := seekIt(&K{off: 2})
+s := &S{}
+done := make(chan bool)
+go func() { keep(s); done <- true }()
+<-done
+again := useKept()
+n := s.n
+`
+		translation, err = inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		LuaMustString(vm, "got", "7 <nil> 7 E:whence")
+		LuaMustString(vm, "again", "S!")
+		LuaMustInt64(vm, "n", 1)
+
+		translation, err = inc.Tr([]byte("f := &F{}"))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+		vm.vm.GetGlobal("f")
+		_, ok, err := gijitInterfaceProxy(vm.vm, -1, reflect.TypeOf((*fooer)(nil)).Elem())
+		vm.vm.Pop(1)
+		cv.So(ok, cv.ShouldBeFalse)
+		cv.So(err.Error(), cv.ShouldContainSubstring, "gijit has no proxy for compiler.fooer")
+	})
+}
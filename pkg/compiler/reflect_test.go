@@ -1,5 +1,11 @@
 package compiler
 
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
 /* come back to this
 import (
 	"fmt"
@@ -102,3 +108,86 @@ func Comparable(a Unit, b Unit) bool {
 	})
 }
 */
+
+func Test2070ReflectOnGijitValues(t *testing.T) {
+
+	cv.Convey("reflect.TypeOf and reflect.ValueOf on gijit-defined values report the Go types: kinds, names, fields with tags, method sets, and settable values.", t, func() {
+
+		src := `
+import "reflect"
+type Inner struct { Z int }
+type P struct {
+	Name string ` + "`json:\"name,omitempty\" db:\"nm\"`" + `
+	age int
+	Inner
+}
+func (p P) Hello() string { return "hi " + p.Name }
+func (p *P) SetName(s string) { p.Name = s }
+type Celsius int
+p := &P{Name: "ann", age: 3}
+p.Z = 7
+var c Celsius = 21
+
+t := reflect.TypeOf(p)
+ptrKind := t.Kind() == reflect.Ptr
+ptrString := t.String()
+ptrMethods := t.NumMethod()
+st := t.Elem()
+structName := st.Name()
+structKind := st.Kind() == reflect.Struct
+numField := st.NumField()
+valueMethods := st.NumMethod()
+firstMethod := st.Method(0).Name
+f := st.Field(0)
+jsonTag := f.Tag.Get("json")
+dbTag := f.Tag.Get("db")
+_, hasXML := f.Tag.Lookup("xml")
+agePkg := st.Field(1).PkgPath
+embedded := st.Field(2).Anonymous
+z, found := st.FieldByName("Z")
+zIndex := z.Index[1]
+celsiusName := reflect.TypeOf(c).Name()
+celsiusKind := reflect.TypeOf(c).Kind() == reflect.Int
+var e interface{} = p
+sameType := reflect.TypeOf(e) == t
+
+v := reflect.ValueOf(p)
+nameVal := v.Elem().Field(0).String()
+v.Elem().FieldByName("Name").SetString("bob")
+zVal := v.Elem().FieldByName("Z").Int()
+canSetDirect := reflect.ValueOf(c).CanSet()
+hello := v.MethodByName("Hello").Call(nil)[0].Interface().(string)
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		LoadAndRunTestHelper(t, vm, translation)
+
+		LuaMustBool(vm, "ptrKind", true)
+		LuaMustString(vm, "ptrString", "*main.P")
+		LuaMustInt64(vm, "ptrMethods", 2)
+		LuaMustString(vm, "structName", "P")
+		LuaMustBool(vm, "structKind", true)
+		LuaMustInt64(vm, "numField", 3)
+		LuaMustInt64(vm, "valueMethods", 1)
+		LuaMustString(vm, "firstMethod", "Hello")
+		LuaMustString(vm, "jsonTag", "name,omitempty")
+		LuaMustString(vm, "dbTag", "nm")
+		LuaMustBool(vm, "hasXML", false)
+		LuaMustString(vm, "agePkg", "main")
+		LuaMustBool(vm, "embedded", true)
+		LuaMustBool(vm, "found", true)
+		LuaMustInt64(vm, "zIndex", 0)
+		LuaMustString(vm, "celsiusName", "Celsius")
+		LuaMustBool(vm, "celsiusKind", true)
+		LuaMustBool(vm, "sameType", true)
+		LuaMustString(vm, "nameVal", "ann")
+		LuaMustInt64(vm, "zVal", 7)
+		LuaMustBool(vm, "canSetDirect", false)
+		LuaMustString(vm, "hello", "hi bob")
+	})
+}
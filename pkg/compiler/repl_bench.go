@@ -0,0 +1,513 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	goast "go/ast"
+	goparser "go/parser"
+	gotoken "go/token"
+	"io/ioutil"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gijit/gi/pkg/ast"
+	"github.com/gijit/gi/pkg/parser"
+	"github.com/gijit/gi/pkg/printer"
+	"github.com/gijit/gi/pkg/token"
+)
+
+// :bench [-count R] [-benchtime 1s] [-vs] <expr or stmt>
+//
+// Runs the snippet in a compiled closure, warming
+// up the JIT first, and reports ns/op, bytes
+// allocated per op, and the spread over R rounds,
+// after the manner of testing.B. With -vs, the same
+// snippet is also benchmarked by the Go toolchain,
+// when `go` is on the PATH.
+
+const benchFuncName = "__gijit_bench"
+
+type benchOpts struct {
+	rounds    int
+	benchtime time.Duration
+	vs        bool
+	snippet   string
+}
+
+// benchResult is one :bench run.
+type benchResult struct {
+	N          int64
+	NsPerOp    []float64 // one per round
+	BytesPerOp float64
+}
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "bench",
+		args: "[-vs] <code>",
+		help: "Benchmark a Go expression or statement.",
+		raw:  true,
+		run:  (*Repl).benchCmd,
+	})
+}
+
+func parseBenchArgs(line string) (o benchOpts, err error) {
+	o.rounds = 10
+	o.benchtime = time.Second
+	for {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "-") {
+			break
+		}
+		var flag string
+		if sp := strings.IndexAny(line, " \t"); sp >= 0 {
+			flag, line = line[:sp], line[sp+1:]
+		} else {
+			flag, line = line, ""
+		}
+		name, val := flag, ""
+		if eq := strings.Index(flag, "="); eq >= 0 {
+			name, val = flag[:eq], flag[eq+1:]
+		}
+		if name == "-vs" {
+			o.vs = true
+			continue
+		}
+		if val == "" {
+			line = strings.TrimSpace(line)
+			sp := strings.IndexAny(line, " \t")
+			if sp < 0 {
+				sp = len(line)
+			}
+			val, line = line[:sp], line[sp:]
+		}
+		switch name {
+		case "-count":
+			o.rounds, err = strconv.Atoi(val)
+			if err != nil || o.rounds < 1 {
+				return o, fmt.Errorf(":bench: bad -count '%s'", val)
+			}
+		case "-benchtime":
+			o.benchtime, err = time.ParseDuration(val)
+			if err != nil || o.benchtime <= 0 {
+				return o, fmt.Errorf(":bench: bad -benchtime '%s'", val)
+			}
+		default:
+			return o, fmt.Errorf(":bench: unknown flag '%s'", name)
+		}
+	}
+	o.snippet = line
+	if o.snippet == "" {
+		return o, fmt.Errorf(":bench: nothing to benchmark. Usage: :bench [-count 10] [-benchtime 1s] [-vs] <expr or stmt>")
+	}
+	return o, nil
+}
+
+// benchBody turns the snippet into the statement to
+// repeat: expressions other than calls are assigned
+// to the blank identifier, so that they compile.
+func benchBody(snippet string) string {
+	x, err := goparser.ParseExpr(snippet)
+	if err != nil {
+		return snippet
+	}
+	if _, isCall := x.(*goast.CallExpr); isCall {
+		return snippet
+	}
+	return "_ = " + snippet
+}
+
+func (r *Repl) benchCmd(args []string) (string, error) {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	o, err := parseBenchArgs(args[0])
+	if err != nil {
+		return "", err
+	}
+	body := benchBody(o.snippet)
+	res, err := r.bench(body, o.rounds, o.benchtime)
+	if err != nil {
+		return "", err
+	}
+	fmt.Print(res.String())
+	if o.vs {
+		out, err := r.goBench(body)
+		if err != nil {
+			return "", fmt.Errorf(":bench -vs: %v", err)
+		}
+		fmt.Printf("go:\n%s", out)
+	}
+	return "", nil
+}
+
+// bench compiles body into a closure and times it.
+func (r *Repl) bench(body string, rounds int, benchtime time.Duration) (*benchResult, error) {
+	src := fmt.Sprintf("%s := func() {\n%s\n}\n", benchFuncName, body)
+	translation, err := TranslateAndCatchPanic(r.inc, []byte(src))
+	if err != nil {
+		return nil, err
+	}
+	chunkName := ""
+	if m := r.inc.LastPosMap; m != nil {
+		r.chunks[m.Name] = m
+		chunkName = m.Name
+	}
+	if chunkName != "" {
+		err = LuaRunChunk(r.lvm, translation, chunkName)
+	} else {
+		err = LuaRun(r.lvm, translation, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the closure is ours, not the user's; only the
+	// type checker, which cannot forget it, keeps it.
+	defer LuaRun(r.lvm, benchFuncName+" = nil", true)
+
+	res := &benchResult{}
+	report := func(n int64, ns float64) {
+		res.N = n
+		res.NsPerOp = append(res.NsPerOp, ns/float64(n))
+	}
+	done := func(n int64, bytesPerOp float64) {
+		res.BytesPerOp = bytesPerOp
+	}
+	tk := r.lvm.goro.newTicket("", false)
+	tk.regmap["__bench_report"] = report
+	tk.regmap["__bench_done"] = done
+	err = tk.Do()
+	if err != nil {
+		return nil, err
+	}
+	roundNs := int64(benchtime) / int64(rounds)
+	err = LuaRun(r.lvm, fmt.Sprintf("__bench(%s, %d, %d, __bench_report, __bench_done)",
+		benchFuncName, roundNs, rounds), true)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.NsPerOp) != rounds {
+		return nil, fmt.Errorf(":bench: the benchmark did not finish; did it panic?")
+	}
+	return res, nil
+}
+
+func (b *benchResult) Mean() float64 {
+	var tot float64
+	for _, x := range b.NsPerOp {
+		tot += x
+	}
+	return tot / float64(len(b.NsPerOp))
+}
+
+func (b *benchResult) Median() float64 {
+	s := append([]float64(nil), b.NsPerOp...)
+	sort.Float64s(s)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
+
+func (b *benchResult) Stddev() float64 {
+	n := len(b.NsPerOp)
+	if n < 2 {
+		return 0
+	}
+	mean := b.Mean()
+	var ss float64
+	for _, x := range b.NsPerOp {
+		ss += (x - mean) * (x - mean)
+	}
+	return math.Sqrt(ss / float64(n-1))
+}
+
+// String formats b like `go test -bench -benchmem`,
+// followed by the statistics over the rounds.
+func (b *benchResult) String() string {
+	mean := b.Mean()
+	sd := b.Stddev()
+	pct := 0.0
+	if mean > 0 {
+		pct = 100 * sd / mean
+	}
+	return fmt.Sprintf("BenchmarkEntry\t%10d\t%10s ns/op\t%8.0f B/op\n"+
+		"%d rounds: mean %s ns/op, median %s, stddev %s (%.1f%%)\n",
+		b.N, fmtNs(mean), b.BytesPerOp,
+		len(b.NsPerOp), fmtNs(mean), fmtNs(b.Median()), fmtNs(sd), pct)
+}
+
+// fmtNs prints ns with the precision testing.B uses.
+func fmtNs(ns float64) string {
+	switch {
+	case ns >= 100:
+		return fmt.Sprintf("%.0f", ns)
+	case ns >= 10:
+		return fmt.Sprintf("%.1f", ns)
+	}
+	return fmt.Sprintf("%.2f", ns)
+}
+
+// goBench benchmarks body with the Go toolchain,
+// returning the benchmark lines of `go test`.
+func (r *Repl) goBench(body string) (string, error) {
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		return "", fmt.Errorf("the go toolchain was not found on PATH")
+	}
+	src := r.goBenchSource(body)
+	dir, err := ioutil.TempDir("", "gijit-bench")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module gibench\n"), 0644)
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "bench_test.go"), []byte(src), 0644)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.Command(goCmd, "test", "-run", "^$", "-bench", ".", "-benchmem")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=-mod=mod")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("%v\n%s", err, out)
+	}
+	var keep []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasPrefix(line, "Benchmark") {
+			keep = append(keep, line)
+		}
+	}
+	return strings.Join(keep, "\n") + "\n", nil
+}
+
+// goBenchSource writes a Go test file benchmarking body,
+// carrying over the declarations made at the REPL
+// so far. A later declaration of a name replaces an
+// earlier one, as at the REPL. As with :export, the
+// vars that `x := e` declares go to package level,
+// and the top-level statements, `x = e` for those,
+// run in order as the benchmark's setup, before
+// b.ResetTimer(), but for the bare expressions the
+// REPL only displays.
+func (r *Repl) goBenchSource(body string) string {
+	var entries []*ChunkPosMap
+	for _, m := range r.chunks {
+		entries = append(entries, m)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Entry < entries[j].Entry })
+
+	pkg := r.mainPkg()
+	fset := token.NewFileSet()
+	var decls, setup []string
+	declAt := make(map[string]int)
+	imports := make(map[string]string) // local name -> import path
+	add := func(src string, names ...string) {
+		// a declaration of several names together, as
+		// var a, b = f() is, goes once any of them is
+		// declared again.
+		for _, name := range names {
+			if i, ok := declAt[name]; ok {
+				decls[i] = ""
+			}
+		}
+		for _, name := range names {
+			declAt[name] = len(decls)
+		}
+		decls = append(decls, src)
+	}
+	for _, m := range entries {
+		if strings.Contains(m.GoSrc, benchFuncName) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, "", m.GoSrc, 0)
+		if err != nil {
+			continue
+		}
+		for _, n := range file.Nodes {
+			var buf bytes.Buffer
+			switch d := n.(type) {
+			case *ast.FuncDecl:
+				name := d.Name.Name
+				if d.Recv != nil && len(d.Recv.List) > 0 {
+					name = recvTypeString(d.Recv.List[0].Type) + "." + name
+				}
+				printer.Fprint(&buf, fset, d)
+				add(buf.String(), name)
+			case *ast.GenDecl:
+				if implicitConsts(d) {
+					// the specs repeat the ones before,
+					// so the group goes as one.
+					printer.Fprint(&buf, fset, d)
+					var names []string
+					for _, spec := range d.Specs {
+						for _, id := range spec.(*ast.ValueSpec).Names {
+							names = append(names, id.Name)
+						}
+					}
+					add(buf.String(), names...)
+					continue
+				}
+				for _, spec := range d.Specs {
+					buf.Reset()
+					switch s := spec.(type) {
+					case *ast.ImportSpec:
+						path, _ := strconv.Unquote(s.Path.Value)
+						local := path[strings.LastIndex(path, "/")+1:]
+						if s.Name != nil {
+							local = s.Name.Name
+						}
+						imports[local] = path
+					case *ast.TypeSpec:
+						printer.Fprint(&buf, fset, s)
+						add("type "+buf.String(), s.Name.Name)
+					case *ast.ValueSpec:
+						if len(s.Values) != 0 && len(s.Values) != len(s.Names) {
+							// var a, b = f(): declared together.
+							printer.Fprint(&buf, fset, s)
+							var names []string
+							for _, id := range s.Names {
+								names = append(names, id.Name)
+							}
+							add(d.Tok.String()+" "+buf.String(), names...)
+							continue
+						}
+						// one by one, so each can be replaced.
+						for i, id := range s.Names {
+							one := &ast.ValueSpec{Names: []*ast.Ident{id}, Type: s.Type}
+							if len(s.Values) > 0 {
+								one.Values = []ast.Expr{s.Values[i]}
+							}
+							buf.Reset()
+							printer.Fprint(&buf, fset, one)
+							add(d.Tok.String()+" "+buf.String(), id.Name)
+						}
+					}
+				}
+			case *ast.AssignStmt:
+				if isGijitAns(d) {
+					// keep the calls among the values
+					// displayed, for their effects.
+					for _, e := range d.Rhs[0].(*ast.CompositeLit).Elts {
+						if call, isCall := e.(*ast.CallExpr); isCall {
+							printer.Fprint(&buf, fset, call)
+							setup = append(setup, buf.String())
+							buf.Reset()
+						}
+					}
+					continue
+				}
+				if d.Tok == token.DEFINE {
+					var ids []*ast.Ident
+					for _, e := range d.Lhs {
+						if id, ok := e.(*ast.Ident); ok {
+							if id.Name != "_" {
+								add("var "+id.Name+" "+exportVarType(pkg, id.Name), id.Name)
+							}
+							ids = append(ids, id)
+						}
+					}
+					setup = append(setup, exportAssign(fset, ids, d.Rhs))
+					continue
+				}
+				printer.Fprint(&buf, fset, d)
+				setup = append(setup, buf.String())
+			case *ast.ExprStmt:
+				call, isCall := d.X.(*ast.CallExpr)
+				if !isCall {
+					continue
+				}
+				if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "__gijit_printQuoted" {
+					continue
+				}
+				printer.Fprint(&buf, fset, d)
+				setup = append(setup, buf.String())
+			case ast.Stmt:
+				printer.Fprint(&buf, fset, d)
+				setup = append(setup, buf.String())
+			}
+		}
+	}
+	var kept []string
+	for _, d := range decls {
+		if d != "" {
+			kept = append(kept, d)
+		}
+	}
+	code := strings.Join(kept, "\n\n") + "\n\n" +
+		"func BenchmarkEntry(b *testing.B) {\n"
+	if len(setup) > 0 {
+		for _, s := range setup {
+			code += "\t" + strings.Replace(s, "\n", "\n\t", -1) + "\n"
+		}
+		code += "\tb.ResetTimer()\n"
+	}
+	code += "\tfor i := 0; i < b.N; i++ {\n\t\t" + body + "\n\t}\n}\n"
+
+	// keep only the imports that are used.
+	used := goQualifiers("package gibench\n\n" + code)
+	var locals []string
+	for local := range imports {
+		if local == "_" || local == "." || used[local] {
+			locals = append(locals, local)
+		}
+	}
+	sort.Strings(locals)
+	head := "package gibench\n\nimport (\n\t\"testing\"\n"
+	for _, local := range locals {
+		path := imports[local]
+		if local == path[strings.LastIndex(path, "/")+1:] {
+			head += fmt.Sprintf("\t%q\n", path)
+		} else {
+			head += fmt.Sprintf("\t%s %q\n", local, path)
+		}
+	}
+	head += ")\n\n"
+	return head + code
+}
+
+// implicitConsts reports whether d is a group of
+// consts in which some spec, having no values,
+// repeats the one before it, as with iota.
+func implicitConsts(d *ast.GenDecl) bool {
+	if d.Tok != token.CONST {
+		return false
+	}
+	for _, spec := range d.Specs {
+		if len(spec.(*ast.ValueSpec).Values) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// goQualifiers returns the names that qualify a
+// selector x.Sel in the Go source src, which are
+// the package names it may use.
+func goQualifiers(src string) map[string]bool {
+	used := make(map[string]bool)
+	file, err := goparser.ParseFile(gotoken.NewFileSet(), "", src, 0)
+	if err != nil {
+		return used
+	}
+	goast.Inspect(file, func(n goast.Node) bool {
+		if sel, ok := n.(*goast.SelectorExpr); ok {
+			if id, ok := sel.X.(*goast.Ident); ok {
+				used[id.Name] = true
+			}
+		}
+		return true
+	})
+	return used
+}
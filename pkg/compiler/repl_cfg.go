@@ -19,6 +19,12 @@ type GIConfig struct {
 	NoLuar         bool
 
 	Dev bool // dev mode, don't use statically cached prelude
+
+	VerifyPath string // replay this transcript, under -verify
+
+	Preempt int // instruction budget of a goroutine before it is preempted; 0 for none
+
+	FFIViews bool // hand Go numeric slices as views of their FFI memory; see luar.NumericSliceViews
 }
 
 var defaultTestMode bool // set to true by init() for tests, in repl_test.go.
@@ -39,6 +45,9 @@ func (c *GIConfig) DefineFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.IsTestMode, "t", false, "load test mode functions and types")
 	fs.BoolVar(&c.NoLiner, "no-liner", false, "turn off liner, e.g. under emacs")
 	fs.BoolVar(&c.NoPrelude, "np", false, "no prelude; skip loading the prelude .lua files and Luar. implies -r raw mode too.")
+	fs.StringVar(&c.VerifyPath, "verify", "", "replay the REPL transcript in this file, checking that each input prints what was recorded. Exits non-zero on a mismatch.")
+	fs.IntVar(&c.Preempt, "preempt", 0, "preempt each goroutine after this many Lua instructions, so CPU-bound goroutines take turns. 0 (the default) preempts none, and keeps the JIT on.")
+	fs.BoolVar(&c.FFIViews, "ffi-views", false, "hand Go functions numeric slices as views of their FFI memory, not copies. Faster, but Go code must not keep them past the call. Same as ':ffi views on'.")
 	fs.BoolVar(&c.Dev, "d", false, "dev mode uses the pkg/compiler/prelude/*.lua files, skipping the statically cached pkg/compiler/prelude_static.go version.")
 }
 
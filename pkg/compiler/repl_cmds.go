@@ -0,0 +1,80 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// A replCommand is a colon command at the gi> prompt
+// that takes arguments, e.g. `:profile start cpu.pprof`.
+// The simple, argument-free commands are handled
+// directly in Repl.Read.
+type replCommand struct {
+	name string
+	args string // usage synopsis, for :help
+	help string
+
+	// raw commands get the rest of the line
+	// unsplit, as args[0], e.g. for Go source.
+	raw bool
+
+	// run does the work. Any src returned is
+	// handed to Eval as if typed at the prompt.
+	run func(r *Repl, args []string) (src string, err error)
+}
+
+var replCommands = make(map[string]*replCommand)
+
+func registerReplCommand(c *replCommand) {
+	if _, dup := replCommands[c.name]; dup {
+		panic(fmt.Sprintf("duplicate repl command ':%s'", c.name))
+	}
+	replCommands[c.name] = c
+}
+
+// lookupReplCommand splits line, e.g. ":profile stop x.pprof",
+// into a registered command and its arguments.
+// Arguments keep their case; file names need it.
+func lookupReplCommand(line string) (c *replCommand, args []string) {
+	if len(line) < 2 || line[0] != ':' {
+		return nil, nil
+	}
+	fields := strings.Fields(line[1:])
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	c = replCommands[strings.ToLower(fields[0])]
+	if c == nil {
+		return nil, nil
+	}
+	if c.raw {
+		rest := strings.TrimSpace(line[1:])
+		rest = strings.TrimSpace(rest[len(fields[0]):])
+		if rest == "" {
+			return c, nil
+		}
+		return c, []string{rest}
+	}
+	return c, fields[1:]
+}
+
+// replCommandsHelp formats the registered commands
+// for :help, in the style of the built-in list.
+func replCommandsHelp() string {
+	var names []string
+	for nm := range replCommands {
+		names = append(names, nm)
+	}
+	sort.Strings(names)
+	var s string
+	for _, nm := range names {
+		c := replCommands[nm]
+		use := ":" + c.name
+		if c.args != "" {
+			use += " " + c.args
+		}
+		s += fmt.Sprintf(" %-15s %s\n", use, c.help)
+	}
+	return s
+}
@@ -0,0 +1,101 @@
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// :edit [Name | T.Method]
+//
+// Opens the current definition of a func, method
+// or type in $EDITOR (vi by default), from the
+// source kept in Archive.FuncSrcCache. When the
+// editor exits, what was saved is evaluated as if
+// typed at the prompt, redefining it. With no name,
+// the last entry is edited instead.
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "edit",
+		args: "[name]",
+		help: "Edit a func, method or type (or the last entry) in $EDITOR, then re-evaluate it.",
+		run:  (*Repl).editCmd,
+	})
+}
+
+func (r *Repl) editCmd(args []string) (string, error) {
+	var src string
+	if len(args) == 0 {
+		src = r.lastSrc
+		if src == "" {
+			return "", fmt.Errorf(":edit: nothing entered yet to edit")
+		}
+	} else {
+		var err error
+		src, err = r.editSource(args[0])
+		if err != nil {
+			return "", err
+		}
+	}
+	edited, err := editInEditor(src)
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(edited) == strings.TrimSpace(src) {
+		fmt.Printf("no changes.\n")
+		return "", nil
+	}
+	return edited, nil
+}
+
+// editSource returns the source of name, which
+// may be a func, a type, or a method as T.Method
+// or (*T).Method.
+func (r *Repl) editSource(name string) (string, error) {
+	key := strings.NewReplacer("(", "", ")", "", "*", "").Replace(name)
+	var cache map[string]string
+	if r.inc != nil && r.inc.CurPkg != nil && r.inc.CurPkg.Arch != nil {
+		cache = r.inc.CurPkg.Arch.FuncSrcCache
+	}
+	src, ok := cache[key]
+	if !ok {
+		return "", fmt.Errorf(":edit: no func, method or type '%s' has been defined", name)
+	}
+	return src, nil
+}
+
+// editInEditor writes src to a temp file, runs
+// $EDITOR on it, and returns what was saved.
+func editInEditor(src string) (string, error) {
+	f, err := ioutil.TempFile("", "gi_edit_")
+	if err != nil {
+		return "", err
+	}
+	fn := f.Name()
+	defer os.Remove(fn)
+	_, err = f.WriteString(strings.TrimRight(src, "\n") + "\n")
+	f.Close()
+	if err != nil {
+		return "", err
+	}
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+	cmd := exec.Command(editor[0], append(editor[1:], fn)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf(":edit: editor '%s' failed: %v", strings.Join(editor, " "), err)
+	}
+	by, err := ioutil.ReadFile(fn)
+	if err != nil {
+		return "", err
+	}
+	return string(by), nil
+}
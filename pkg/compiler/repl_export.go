@@ -0,0 +1,421 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gijit/gi/pkg/ast"
+	"github.com/gijit/gi/pkg/format"
+	"github.com/gijit/gi/pkg/parser"
+	"github.com/gijit/gi/pkg/printer"
+	"github.com/gijit/gi/pkg/token"
+	"github.com/gijit/gi/pkg/types"
+)
+
+// :export dir/
+//
+// Writes the session as a main package that the
+// standard `go build` compiles. The types, funcs and
+// methods are those in Archive.Declarations that the
+// package still has, each in its latest source from
+// Archive.FuncSrcCache. The imports, consts and
+// vars come from the entries' Go source, the vars
+// declared at package level, as the REPL keeps them,
+// so that funcs can still refer to them. The
+// top-level statements are folded, in the order they
+// were entered, into func main(); `x := e` becomes
+// `x = e` there. Bare expressions, which the REPL
+// only displays, are left out, as is all of an entry
+// that failed when run, but for the vars it declared.
+
+// exportFile is the file that :export writes.
+const exportFile = "main.go"
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "export",
+		args: "<dir>",
+		help: "Write the session as a Go main package in dir.",
+		run:  (*Repl).exportCmd,
+	})
+}
+
+func (r *Repl) exportCmd(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf(":export needs a directory, e.g. ':export ./prog/'")
+	}
+	fn, err := r.export(args[0])
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("wrote %s\n", fn)
+	return "", nil
+}
+
+// export writes the session into dir, with a
+// go.mod if dir has none, and returns the path
+// of the Go file written.
+func (r *Repl) export(dir string) (string, error) {
+	src, err := r.exportSource()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	gomod := filepath.Join(dir, "go.mod")
+	if _, err := os.Stat(gomod); os.IsNotExist(err) {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return "", err
+		}
+		mod := fmt.Sprintf("module %s\n", filepath.Base(abs))
+		if err := ioutil.WriteFile(gomod, []byte(mod), 0644); err != nil {
+			return "", err
+		}
+	}
+	fn := filepath.Join(dir, exportFile)
+	return fn, ioutil.WriteFile(fn, src, 0644)
+}
+
+// exportSource returns the session as
+// the gofmt'd source of a main package.
+func (r *Repl) exportSource() ([]byte, error) {
+	var entries []*ChunkPosMap
+	for _, m := range r.chunks {
+		entries = append(entries, m)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Entry < entries[j].Entry })
+
+	var cache map[string]string
+	if r.inc != nil && r.inc.CurPkg != nil && r.inc.CurPkg.Arch != nil {
+		cache = r.inc.CurPkg.Arch.FuncSrcCache
+	}
+	pkg := r.mainPkg()
+
+	// the latest of each, in order of first appearance.
+	var typs, funcs []string
+	src := make(map[string]string)
+	note := func(list *[]string, name, s string) {
+		if _, seen := src[name]; !seen {
+			*list = append(*list, name)
+		}
+		src[name] = s
+	}
+	// consts in the order declared; a group declared
+	// together goes once any of its names is declared
+	// again.
+	var consts []string
+	constAt := make(map[string]int)
+	addConst := func(s string, names ...string) {
+		for _, name := range names {
+			if i, ok := constAt[name]; ok {
+				consts[i] = ""
+			}
+		}
+		for _, name := range names {
+			constAt[name] = len(consts)
+		}
+		consts = append(consts, s)
+	}
+	imports := make(map[string]string) // local name -> import path
+	var vars []string
+	varSeen := make(map[string]bool)
+	declare := func(id *ast.Ident) {
+		if id.Name != "_" && !varSeen[id.Name] {
+			varSeen[id.Name] = true
+			vars = append(vars, id.Name)
+		}
+	}
+	var body []string
+
+	fset := token.NewFileSet()
+	for _, m := range entries {
+		if strings.Contains(m.GoSrc, benchFuncName) {
+			continue
+		}
+		file, err := parser.ParseFile(fset, "", m.GoSrc, 0)
+		if err != nil {
+			continue
+		}
+		if m.Failed {
+			// later entries may still use its vars.
+			exportDeclaredVars(file, declare)
+			continue
+		}
+		for _, d := range m.decls {
+			key, isType := exportDeclKey(pkg, d)
+			if s, ok := cache[key]; ok {
+				if isType {
+					note(&typs, key, s)
+				} else {
+					note(&funcs, key, s)
+				}
+			}
+		}
+		for _, n := range file.Nodes {
+			var buf bytes.Buffer
+			switch d := n.(type) {
+			case *ast.FuncDecl:
+				// from the Declarations, above.
+			case *ast.GenDecl:
+				switch d.Tok {
+				case token.IMPORT:
+					for _, spec := range d.Specs {
+						s := spec.(*ast.ImportSpec)
+						path, _ := strconv.Unquote(s.Path.Value)
+						local := path[strings.LastIndex(path, "/")+1:]
+						if s.Name != nil {
+							local = s.Name.Name
+						}
+						imports[local] = path
+					}
+				case token.CONST:
+					if implicitConsts(d) {
+						// the specs repeat the ones before,
+						// as with iota, so the group goes as one.
+						printer.Fprint(&buf, fset, d)
+						var names []string
+						for _, spec := range d.Specs {
+							for _, id := range spec.(*ast.ValueSpec).Names {
+								names = append(names, id.Name)
+							}
+						}
+						addConst(buf.String(), names...)
+						continue
+					}
+					// one by one, so each can be replaced.
+					for _, spec := range d.Specs {
+						s := spec.(*ast.ValueSpec)
+						for i, id := range s.Names {
+							one := &ast.ValueSpec{Names: []*ast.Ident{id}, Type: s.Type, Values: []ast.Expr{s.Values[i]}}
+							buf.Reset()
+							printer.Fprint(&buf, fset, one)
+							addConst("const "+buf.String(), id.Name)
+						}
+					}
+				case token.VAR:
+					// declared at package level, assigned in main.
+					for _, spec := range d.Specs {
+						s := spec.(*ast.ValueSpec)
+						for _, id := range s.Names {
+							declare(id)
+						}
+						if len(s.Values) > 0 {
+							body = append(body, exportAssign(fset, s.Names, s.Values))
+						}
+					}
+				}
+			case *ast.AssignStmt:
+				if isGijitAns(d) {
+					// the REPL displays the values; keep
+					// the calls among them, for their effects.
+					for _, e := range d.Rhs[0].(*ast.CompositeLit).Elts {
+						if call, isCall := e.(*ast.CallExpr); isCall {
+							buf.Reset()
+							printer.Fprint(&buf, fset, call)
+							body = append(body, buf.String())
+						}
+					}
+					continue
+				}
+				if d.Tok == token.DEFINE {
+					var ids []*ast.Ident
+					for _, e := range d.Lhs {
+						if id, ok := e.(*ast.Ident); ok {
+							declare(id)
+							ids = append(ids, id)
+						}
+					}
+					body = append(body, exportAssign(fset, ids, d.Rhs))
+					continue
+				}
+				printer.Fprint(&buf, fset, d)
+				body = append(body, buf.String())
+			case *ast.ExprStmt:
+				call, isCall := d.X.(*ast.CallExpr)
+				if !isCall {
+					continue
+				}
+				if id, ok := call.Fun.(*ast.Ident); ok && id.Name == "__gijit_printQuoted" {
+					continue
+				}
+				printer.Fprint(&buf, fset, d)
+				body = append(body, buf.String())
+			case ast.Stmt:
+				printer.Fprint(&buf, fset, d)
+				body = append(body, buf.String())
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	emit := func(list []string) {
+		for _, name := range list {
+			b.WriteString(src[name] + "\n\n")
+		}
+	}
+	emit(typs)
+	for _, s := range consts {
+		if s != "" {
+			b.WriteString(s + "\n\n")
+		}
+	}
+	if len(vars) > 0 {
+		b.WriteString("var (\n")
+		for _, name := range vars {
+			fmt.Fprintf(&b, "\t%s %s\n", name, exportVarType(pkg, name))
+		}
+		b.WriteString(")\n\n")
+	}
+	emit(funcs)
+
+	entry := "main"
+	if _, ok := src["main"]; ok {
+		entry = "init"
+	}
+	fmt.Fprintf(&b, "func %s() {\n", entry)
+	for _, s := range body {
+		b.WriteString(s + "\n")
+	}
+	b.WriteString("}\n")
+	code := b.String()
+
+	// keep only the imports that are used: those
+	// whose names the code leaves unresolved.
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package main\n\n"+code, 0)
+	if err != nil {
+		return nil, fmt.Errorf(":export: %v", err)
+	}
+	used := make(map[string]bool)
+	for _, id := range file.Unresolved {
+		used[id.Name] = true
+	}
+	var locals []string
+	for local := range imports {
+		if local == "_" || local == "." || used[local] {
+			locals = append(locals, local)
+		}
+	}
+	sort.Strings(locals)
+	head := "package main\n\n"
+	if len(locals) > 0 {
+		head += "import (\n"
+		for _, local := range locals {
+			path := imports[local]
+			if local == path[strings.LastIndex(path, "/")+1:] {
+				head += fmt.Sprintf("\t%q\n", path)
+			} else {
+				head += fmt.Sprintf("\t%s %q\n", local, path)
+			}
+		}
+		head += ")\n\n"
+	}
+	out, err := format.Source([]byte(head + code))
+	if err != nil {
+		return nil, fmt.Errorf(":export: %v", err)
+	}
+	return out, nil
+}
+
+// exportDeclKey is d's key in the FuncSrcCache, if
+// d declares a type, func or method that pkg still
+// has, and whether it is a type.
+func exportDeclKey(pkg *types.Package, d *Decl) (key string, isType bool) {
+	if pkg == nil {
+		return "", false
+	}
+	name := d.FullName[strings.LastIndex(d.FullName, ".")+1:]
+	switch {
+	case strings.HasPrefix(d.FullName, "("):
+		// a method, (*main.T).M or (main.T).M.
+		return d.DceObjectFilter + "." + name, false
+	case d.FullName != "":
+		if _, ok := pkg.Scope().Lookup(name).(*types.Func); ok {
+			return name, false
+		}
+	case d.DceObjectFilter != "":
+		if _, ok := pkg.Scope().Lookup(d.DceObjectFilter).(*types.TypeName); ok {
+			return d.DceObjectFilter, true
+		}
+	}
+	return "", false
+}
+
+// exportDeclaredVars calls declare with
+// each var that file declares.
+func exportDeclaredVars(file *ast.File, declare func(id *ast.Ident)) {
+	for _, n := range file.Nodes {
+		switch d := n.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range d.Specs {
+				for _, id := range spec.(*ast.ValueSpec).Names {
+					declare(id)
+				}
+			}
+		case *ast.AssignStmt:
+			if d.Tok != token.DEFINE || isGijitAns(d) {
+				continue
+			}
+			for _, e := range d.Lhs {
+				if id, ok := e.(*ast.Ident); ok {
+					declare(id)
+				}
+			}
+		}
+	}
+}
+
+// isGijitAns reports whether as is the
+// `__gijit_ans := []interface{}{...}` that the REPL
+// wraps around an expression, to display it.
+func isGijitAns(as *ast.AssignStmt) bool {
+	if as.Tok != token.DEFINE || len(as.Lhs) != 1 || len(as.Rhs) != 1 {
+		return false
+	}
+	id, ok := as.Lhs[0].(*ast.Ident)
+	if !ok || id.Name != "__gijit_ans" {
+		return false
+	}
+	_, ok = as.Rhs[0].(*ast.CompositeLit)
+	return ok
+}
+
+// exportAssign turns the declaration of ids
+// from values into an assignment.
+func exportAssign(fset *token.FileSet, ids []*ast.Ident, values []ast.Expr) string {
+	as := &ast.AssignStmt{Tok: token.ASSIGN, Rhs: values}
+	for _, id := range ids {
+		as.Lhs = append(as.Lhs, id)
+	}
+	var buf bytes.Buffer
+	printer.Fprint(&buf, fset, as)
+	return buf.String()
+}
+
+// exportVarType is the Go type of the package-level
+// var name, as the type checker has it now.
+func exportVarType(pkg *types.Package, name string) string {
+	if pkg == nil {
+		return "interface{}"
+	}
+	obj, ok := pkg.Scope().Lookup(name).(*types.Var)
+	if !ok {
+		return "interface{}"
+	}
+	return types.TypeString(obj.Type(), func(p *types.Package) string {
+		if p == pkg {
+			return ""
+		}
+		return p.Name()
+	})
+}
@@ -0,0 +1,56 @@
+package compiler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glycerine/luar"
+)
+
+// :ffi views
+// :ffi views on|off
+//
+// The elements of numeric slices and arrays live
+// in FFI memory (see __numericCtypes in tsys.lua).
+// A Go function that takes one gets a copy, by
+// default. With views on, it gets a slice over that
+// memory instead: no copy, and its writes are seen
+// by gijit. But the memory is freed once the LuaJIT
+// GC collects the gijit slice, so views suit only Go
+// functions that do not keep the slice past the call;
+// see luar.NumericSliceViews. The -ffi-views flag
+// starts gi with views on.
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "ffi",
+		args: "views [on|off]",
+		help: "Hand Go numeric slices as views of their FFI memory, not copies.",
+		run:  (*Repl).ffiCmd,
+	})
+}
+
+func (r *Repl) ffiCmd(args []string) (string, error) {
+	if len(args) == 0 || strings.ToLower(args[0]) != "views" {
+		return "", fmt.Errorf(":ffi: use ':ffi views on' or ':ffi views off'.")
+	}
+	if len(args) > 1 {
+		switch strings.ToLower(args[1]) {
+		case "on":
+			luar.NumericSliceViews = true
+		case "off":
+			luar.NumericSliceViews = false
+		default:
+			return "", fmt.Errorf(":ffi views: use on or off, not '%s'.", args[1])
+		}
+	}
+	fmt.Print(ffiViewsStatus())
+	return "", nil
+}
+
+func ffiViewsStatus() string {
+	if luar.NumericSliceViews {
+		return "Go gets views of numeric slices' FFI memory; it must not keep them past the call.\n"
+	}
+	return "Go gets copies of numeric slices.\n"
+}
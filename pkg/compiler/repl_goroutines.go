@@ -0,0 +1,58 @@
+package compiler
+
+import (
+	"fmt"
+)
+
+// :goroutines
+//
+// Lists the gijit goroutines as the Go runtime's
+// traceback does: each with its id, its state
+// (running, runnable, chan receive, chan send,
+// select, sleep), and the Go source line(s) where it
+// is parked. Where :stacks dumps the raw Lua
+// coroutines, this is in Go terms. The scheduler in
+// prelude/chan.lua keeps the states, and prints the
+// same listing with Go's "all goroutines are asleep"
+// fatal error when an entry deadlocks.
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "goroutines",
+		help: "List the goroutines, their states, and where each is parked.",
+		run:  (*Repl).goroutinesCmd,
+	})
+}
+
+func (r *Repl) goroutinesCmd(args []string) (string, error) {
+	dump, err := r.goroutineDump()
+	if err != nil {
+		return "", err
+	}
+	if dump == "" {
+		dump = "no goroutines.\n"
+	}
+	fmt.Print(dump)
+	return "", nil
+}
+
+// goroutineDump returns the listing
+// from __task.goroutine_dump.
+func (r *Repl) goroutineDump() (string, error) {
+	tk := r.lvm.goro.newTicket("__gijit_goroutinesOut = __task.goroutine_dump()", false)
+	tk.varname["__gijit_goroutinesOut"] = nil
+	tk.gettyp = GetString
+	if err := tk.Do(); err != nil {
+		return "", err
+	}
+	return tk.varname["__gijit_goroutinesOut"].(string), nil
+}
+
+// registerGoLoc lets the runtime describe Lua
+// locations in the REPL's entries in Go terms,
+// with r.goLoc.
+func (r *Repl) registerGoLoc() error {
+	tk := r.lvm.goro.newTicket("", false)
+	tk.regmap["__gijit_goLoc"] = r.goLoc
+	return tk.Do()
+}
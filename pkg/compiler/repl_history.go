@@ -0,0 +1,183 @@
+package compiler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// History is kept by entry: whatever was submitted at
+// once, a five-line func as much as `a := 1`, is one
+// entry, replayed whole by `:n` and `:a-b`. Each entry
+// is stored as a line of JSON, so that newlines in the
+// source survive, along with when and where it was
+// entered and whether it ran without error. Lines of
+// the older, line-per-line format are read back as
+// entries of their own.
+//
+// Inside a project, recognized by a go.mod or .git in
+// the working directory or above it, history is kept
+// per project, under ~/.gijit/hist/; elsewhere, in
+// ~/.gijit.hist.
+
+// histEntry is one entry in the history.
+type histEntry struct {
+	Src  string    `json:"src"`
+	When time.Time `json:"when,omitempty"`
+	Dir  string    `json:"dir,omitempty"`
+	OK   bool      `json:"ok"`
+}
+
+// histFileFor returns the history file for
+// a session started in dir.
+func histFileFor(home, dir string) string {
+	root := projectRoot(dir)
+	if root == "" {
+		return filepath.Join(home, ".gijit.hist")
+	}
+	name := strings.Trim(filepath.ToSlash(root), "/")
+	name = strings.NewReplacer("/", "%", ":", "%").Replace(name)
+	return filepath.Join(home, ".gijit", "hist", name+".hist")
+}
+
+// projectRoot returns dir or its nearest parent
+// holding a go.mod or .git, or "" if none does.
+func projectRoot(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		for _, marker := range []string{"go.mod", ".git"} {
+			if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+				return dir
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func readHistory(histFn string) (history []*histEntry, err error) {
+	if !FileExists(histFn) {
+		return nil, nil
+	}
+	f, err := os.Open(histFn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		e := &histEntry{}
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), e) != nil {
+			// the old format: a line of source.
+			e = &histEntry{Src: line, OK: true}
+		}
+		history = append(history, e)
+	}
+	return history, sc.Err()
+}
+
+// writeHistory appends the entries to w,
+// a line of JSON each.
+func writeHistory(w *os.File, entries ...*histEntry) {
+	for _, e := range entries {
+		by, err := json.Marshal(e)
+		panicOn(err)
+		fmt.Fprintf(w, "%s\n", by)
+	}
+	w.Sync()
+}
+
+// openHistory opens histFn for appending,
+// making its directory as needed.
+func openHistory(histFn string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(histFn), 0700); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(histFn,
+		os.O_WRONLY|os.O_CREATE|os.O_APPEND|os.O_SYNC,
+		0600)
+}
+
+// recordHistory adds src, entered now,
+// to the history and its file.
+func (r *Repl) recordHistory(src string, ok bool) {
+	src = strings.TrimRight(src, "\n")
+	if strings.TrimSpace(src) == "" {
+		return
+	}
+	dir, _ := os.Getwd()
+	e := &histEntry{Src: src, When: time.Now(), Dir: dir, OK: ok}
+	r.history = append(r.history, e)
+	if r.histFile != nil {
+		writeHistory(r.histFile, e)
+	}
+}
+
+// historySrc returns the source of history
+// entries beg through end, numbered from 1.
+func historySrc(history []*histEntry, beg, end int) string {
+	var srcs []string
+	for _, e := range history[beg-1 : end] {
+		srcs = append(srcs, e.Src)
+	}
+	return strings.Join(srcs, "\n") + "\n"
+}
+
+// formatHistEntry formats entry i for :h, indenting
+// the lines after the first under it, and marking the
+// entries that failed. With long, the time and
+// directory of entry are given too.
+func formatHistEntry(i int, e *histEntry, long bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%03d: ", i)
+	if long && !e.When.IsZero() {
+		fmt.Fprintf(&b, "[%s %s] ", e.When.Format("2006-01-02 15:04:05"), e.Dir)
+	}
+	lines := strings.Split(e.Src, "\n")
+	for j, ln := range lines {
+		if j > 0 {
+			b.WriteString("\n     ")
+		}
+		b.WriteString(ln)
+	}
+	if !e.OK {
+		b.WriteString("   // failed")
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// searchHistory lists the entries matching
+// the regular expression re, for `:h /re`.
+func searchHistory(history []*histEntry, re string) (string, error) {
+	rx, err := regexp.Compile(re)
+	if err != nil {
+		return "", fmt.Errorf("bad history search: %v", err)
+	}
+	var b strings.Builder
+	for i, e := range history {
+		if rx.MatchString(e.Src) {
+			b.WriteString(formatHistEntry(i+1, e, true))
+		}
+	}
+	if b.Len() == 0 {
+		return fmt.Sprintf("no history matches /%s/\n", re), nil
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,431 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gijit/gi/pkg/ast"
+	"github.com/gijit/gi/pkg/doc"
+	"github.com/gijit/gi/pkg/gostd/build"
+	"github.com/gijit/gi/pkg/parser"
+	"github.com/gijit/gi/pkg/printer"
+	"github.com/gijit/gi/pkg/token"
+	"github.com/gijit/gi/pkg/types"
+)
+
+// :type <expr>
+// :methods <type>
+// :doc <pkg> | <pkg.Name> | <pkg.Type.Method>
+//
+// Introspection at the prompt. :type and :methods
+// evaluate their argument with the type checker, in
+// the REPL's package scope, and run nothing. :doc
+// reads the package's sources from GOROOT or GOPATH
+// and renders their doc comments with pkg/doc, in
+// the manner of `go doc`.
+
+// shadowPrefix is where gijit keeps its shadows of
+// the binary Go packages; :doc wants the originals.
+const shadowPrefix = "github.com/gijit/gi/pkg/compiler/shadow/"
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "type",
+		args: "<expr>",
+		help: "Show the Go type and method set of an expression, without running it.",
+		raw:  true,
+		run:  (*Repl).typeCmd,
+	})
+	registerReplCommand(&replCommand{
+		name: "methods",
+		args: "<type>",
+		help: "List the method set of a type, promoted methods included.",
+		raw:  true,
+		run:  (*Repl).methodsCmd,
+	})
+	registerReplCommand(&replCommand{
+		name: "doc",
+		args: "<pkg.Name>",
+		help: "Show the documentation for a package or one of its names.",
+		raw:  true,
+		run:  (*Repl).docCmd,
+	})
+}
+
+func (r *Repl) typeCmd(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf(":type needs an expression, e.g. ':type x + 1'")
+	}
+	s, err := r.typeOf(args[0])
+	if err != nil {
+		return "", err
+	}
+	fmt.Print(s)
+	return "", nil
+}
+
+func (r *Repl) methodsCmd(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf(":methods needs a type, e.g. ':methods *bytes.Buffer'")
+	}
+	s, err := r.methodsOf(args[0])
+	if err != nil {
+		return "", err
+	}
+	fmt.Print(s)
+	return "", nil
+}
+
+func (r *Repl) docCmd(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf(":doc needs a package or name, e.g. ':doc strings.Split'")
+	}
+	s, err := r.docOf(args[0])
+	if err != nil {
+		return "", err
+	}
+	fmt.Print(s)
+	return "", nil
+}
+
+// typeCheck type checks expr in the REPL's package
+// scope, or the universe if nothing has been
+// declared yet. Nothing is run.
+func (r *Repl) typeCheck(expr string) (tv types.TypeAndValue, pkg *types.Package, err error) {
+	pkg = r.mainPkg()
+	fset := token.NewFileSet()
+	if r.inc != nil && r.inc.CurPkg != nil {
+		fset = r.inc.CurPkg.fileSet
+	}
+	tv, err = types.Eval(fset, pkg, token.NoPos, expr)
+	return
+}
+
+// typeOf describes the type of expr, and
+// its method set, for :type.
+func (r *Repl) typeOf(expr string) (string, error) {
+	tv, pkg, err := r.typeCheck(expr)
+	if err != nil {
+		return "", err
+	}
+	qual := types.RelativeTo(pkg)
+	var b strings.Builder
+	switch {
+	case tv.IsVoid():
+		fmt.Fprintf(&b, "%s has no value\n", expr)
+		return b.String(), nil
+	case tv.IsBuiltin():
+		fmt.Fprintf(&b, "%s is a built-in function\n", expr)
+		return b.String(), nil
+	case tv.IsType():
+		fmt.Fprintf(&b, "type %s\n", types.TypeString(tv.Type, qual))
+		if _, named := tv.Type.(*types.Named); named {
+			fmt.Fprintf(&b, "    %s\n", types.TypeString(tv.Type.Underlying(), qual))
+		}
+	case tv.Value != nil:
+		fmt.Fprintf(&b, "%s = %s\n", types.TypeString(tv.Type, qual), tv.Value)
+	default:
+		fmt.Fprintf(&b, "%s\n", types.TypeString(tv.Type, qual))
+	}
+	b.WriteString(methodSetLines(tv.Type, qual))
+	return b.String(), nil
+}
+
+// methodSetLines lists the method set of t, then
+// what the method set of *t adds, for :type.
+func methodSetLines(t types.Type, qual types.Qualifier) string {
+	var b strings.Builder
+	ms := types.NewMethodSet(t)
+	if ms.Len() > 0 {
+		fmt.Fprintf(&b, "method set of %s:\n", types.TypeString(t, qual))
+		for i := 0; i < ms.Len(); i++ {
+			fmt.Fprintf(&b, "    %s\n", methodLine(t, ms.At(i), qual))
+		}
+	}
+	if !addressable(t) {
+		return b.String()
+	}
+	pt := types.NewPointer(t)
+	pms := types.NewMethodSet(pt)
+	if pms.Len() > ms.Len() {
+		fmt.Fprintf(&b, "method set of %s adds:\n", types.TypeString(pt, qual))
+		for i := 0; i < pms.Len(); i++ {
+			sel := pms.At(i)
+			if ms.Lookup(sel.Obj().Pkg(), sel.Obj().Name()) == nil {
+				fmt.Fprintf(&b, "    %s\n", methodLine(pt, sel, qual))
+			}
+		}
+	}
+	return b.String()
+}
+
+// addressable reports whether *t can have
+// methods that t does not.
+func addressable(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Interface, *types.Pointer:
+		return false
+	}
+	return true
+}
+
+// methodsOf lists the full method set of the type
+// named by expr, or of the type of the value that
+// expr is, for :methods. For a T that is not an
+// interface or pointer, that is the method set of
+// *T, the methods callable on an addressable T.
+func (r *Repl) methodsOf(expr string) (string, error) {
+	tv, pkg, err := r.typeCheck(expr)
+	if err != nil {
+		return "", err
+	}
+	if tv.IsVoid() || tv.IsBuiltin() || tv.Type == nil {
+		return "", fmt.Errorf(":methods: %s has no type", expr)
+	}
+	qual := types.RelativeTo(pkg)
+	t := tv.Type
+	if addressable(t) {
+		t = types.NewPointer(t)
+	}
+	ms := types.NewMethodSet(t)
+	if ms.Len() == 0 {
+		return fmt.Sprintf("%s has no methods\n", types.TypeString(tv.Type, qual)), nil
+	}
+	var b strings.Builder
+	for i := 0; i < ms.Len(); i++ {
+		fmt.Fprintf(&b, "%s\n", methodLine(t, ms.At(i), qual))
+	}
+	return b.String(), nil
+}
+
+// methodLine formats the method sel of t as it was
+// declared, noting the embedded fields that it was
+// promoted through, if any.
+func methodLine(t types.Type, sel *types.Selection, qual types.Qualifier) string {
+	f := sel.Obj().(*types.Func)
+	sig := f.Type().(*types.Signature)
+	s := "func "
+	if recv := sig.Recv(); recv != nil {
+		if _, iface := recv.Type().Underlying().(*types.Interface); !iface {
+			s += "(" + types.TypeString(recv.Type(), qual) + ") "
+		}
+	}
+	s += f.Name() + strings.TrimPrefix(types.TypeString(sig, qual), "func")
+	if idx := sel.Index(); len(idx) > 1 {
+		s += "  // promoted from " + embeddedPath(t, idx[:len(idx)-1])
+	}
+	return s
+}
+
+// embeddedPath names the chain of embedded
+// fields that idx indexes into t, as in "A.B".
+func embeddedPath(t types.Type, idx []int) string {
+	var names []string
+	for _, i := range idx {
+		if p, ok := t.Underlying().(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		st, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			break
+		}
+		f := st.Field(i)
+		names = append(names, f.Name())
+		t = f.Type()
+	}
+	return strings.Join(names, ".")
+}
+
+// docOf renders the documentation for what, which
+// is a package, pkg.Name, or pkg.Type.Method, for
+// :doc. The package may be named as imported at the
+// prompt, or by its import path.
+func (r *Repl) docOf(what string) (string, error) {
+	path, sym := splitDocArg(what)
+	if pkg := r.mainPkg(); pkg != nil {
+		if pn, ok := pkg.Scope().Lookup(path).(*types.PkgName); ok {
+			path = pn.Imported().Path()
+		}
+	}
+	path = strings.TrimPrefix(path, shadowPrefix)
+
+	dp, fset, err := loadDoc(path)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if sym == "" {
+		docPackage(&b, fset, dp)
+		return b.String(), nil
+	}
+	if !docSymbol(&b, fset, dp, sym) {
+		return "", fmt.Errorf(":doc: no symbol %s in package %s", sym, path)
+	}
+	return b.String(), nil
+}
+
+// splitDocArg splits "net/http.Client.Do" into
+// the package "net/http" and the "Client.Do" in it.
+func splitDocArg(what string) (path, sym string) {
+	slash := strings.LastIndex(what, "/")
+	dot := strings.Index(what[slash+1:], ".")
+	if dot < 0 {
+		return what, ""
+	}
+	dot += slash + 1
+	return what[:dot], what[dot+1:]
+}
+
+// loadDoc parses the Go files of the package at
+// path that build for this platform, comments and
+// all. Files that this parser cannot read, such as
+// those using newer syntax, are passed over.
+func loadDoc(path string) (*doc.Package, *token.FileSet, error) {
+	bp, err := build.Import(path, "", build.FindOnly)
+	if err != nil {
+		return nil, nil, fmt.Errorf(":doc: cannot find package %q: %v", path, err)
+	}
+	ents, err := os.ReadDir(bp.Dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	fset := token.NewFileSet()
+	pkgs := make(map[string]*ast.Package)
+	for _, ent := range ents {
+		nm := ent.Name()
+		if ent.IsDir() || !strings.HasSuffix(nm, ".go") || strings.HasSuffix(nm, "_test.go") {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(bp.Dir, nm); err != nil || !ok {
+			continue
+		}
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, nm), nil, parser.ParseComments)
+		if err != nil {
+			continue
+		}
+		ap := pkgs[f.Name.Name]
+		if ap == nil {
+			ap = &ast.Package{Name: f.Name.Name, Files: make(map[string]*ast.File)}
+			pkgs[f.Name.Name] = ap
+		}
+		ap.Files[nm] = f
+	}
+	var ap *ast.Package
+	for name, p := range pkgs {
+		// skip the package main of a generator, kept alongside.
+		if ap == nil || name != "main" {
+			ap = p
+		}
+	}
+	if ap == nil {
+		return nil, nil, fmt.Errorf(":doc: no Go files readable in %s", bp.Dir)
+	}
+	return doc.New(ap, path, 0), fset, nil
+}
+
+// docPackage writes the package doc, then a
+// line for each exported declaration.
+func docPackage(b *bytes.Buffer, fset *token.FileSet, dp *doc.Package) {
+	fmt.Fprintf(b, "package %s // import %q\n\n", dp.Name, dp.ImportPath)
+	doc.ToText(b, dp.Doc, "", "    ", 72)
+	b.WriteString("\n")
+	for _, v := range dp.Consts {
+		docDecl(b, fset, v.Decl)
+	}
+	for _, v := range dp.Vars {
+		docDecl(b, fset, v.Decl)
+	}
+	for _, f := range dp.Funcs {
+		docDecl(b, fset, f.Decl)
+	}
+	for _, t := range dp.Types {
+		fmt.Fprintf(b, "type %s\n", t.Name)
+		for _, f := range t.Funcs {
+			fmt.Fprintf(b, "    ")
+			docDecl(b, fset, f.Decl)
+		}
+	}
+}
+
+// docSymbol writes the declaration and doc of sym,
+// a name in dp or a Type.Method, and reports whether
+// it was found.
+func docSymbol(b *bytes.Buffer, fset *token.FileSet, dp *doc.Package, sym string) bool {
+	tname, meth := sym, ""
+	if i := strings.Index(sym, "."); i >= 0 {
+		tname, meth = sym[:i], sym[i+1:]
+	}
+	for _, t := range dp.Types {
+		if t.Name != tname {
+			continue
+		}
+		if meth != "" {
+			for _, m := range t.Methods {
+				if m.Name == meth {
+					docEntry(b, fset, m.Decl, m.Doc)
+					return true
+				}
+			}
+			return false
+		}
+		docEntry(b, fset, t.Decl, t.Doc)
+		for _, f := range t.Funcs {
+			docDecl(b, fset, f.Decl)
+		}
+		for _, m := range t.Methods {
+			docDecl(b, fset, m.Decl)
+		}
+		return true
+	}
+	if meth != "" {
+		return false
+	}
+	for _, f := range dp.Funcs {
+		if f.Name == sym {
+			docEntry(b, fset, f.Decl, f.Doc)
+			return true
+		}
+	}
+	for _, vs := range [][]*doc.Value{dp.Consts, dp.Vars} {
+		for _, v := range vs {
+			for _, nm := range v.Names {
+				if nm == sym {
+					docEntry(b, fset, v.Decl, v.Doc)
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// docEntry writes decl, then its doc indented.
+func docEntry(b *bytes.Buffer, fset *token.FileSet, decl ast.Decl, text string) {
+	docDecl(b, fset, decl)
+	if text != "" {
+		doc.ToText(b, text, "    ", "        ", 72)
+	}
+	b.WriteString("\n")
+}
+
+// docDecl writes decl on its own, without a
+// func body or the doc comment that we print
+// separately.
+func docDecl(b *bytes.Buffer, fset *token.FileSet, decl ast.Decl) {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		cp := *d
+		cp.Body = nil
+		cp.Doc = nil
+		decl = &cp
+	case *ast.GenDecl:
+		cp := *d
+		cp.Doc = nil
+		decl = &cp
+	}
+	printer.Fprint(b, fset, decl)
+	b.WriteString("\n")
+}
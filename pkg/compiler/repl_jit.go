@@ -0,0 +1,283 @@
+package compiler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// :jit on
+// :jit off
+// :jit report
+//
+// The trace inspector. LuaJIT only compiles the
+// loops it can trace; anything it cannot (NYI, or
+// "not yet implemented") sends a hot loop back to the
+// interpreter, silently. :jit on records the trace
+// events via jit.attach (see prelude/jitlog.lua) and
+// :jit report lists, by Go source location, what
+// compiled and why the rest aborted.
+
+// jitEvent is one trace event from jitlog.lua.
+type jitEvent struct {
+	What     string // "stop" (compiled), "abort", or "flush"
+	Trace    int
+	Parent   int // for side traces, the parent trace
+	Exit     int // and its exit number
+	Start    string
+	Loc      string
+	Reason   string
+	LinkType string
+	Link     int
+}
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "jit",
+		args: "on|off|report",
+		help: "Record LuaJIT traces; report which Go loops compiled.",
+		run:  (*Repl).jitCmd,
+	})
+}
+
+func (r *Repl) jitCmd(args []string) (string, error) {
+	sub := "report"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case "on":
+		err := LuaRun(r.lvm, "__jitlog_on()", false)
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("recording JIT traces. ':jit report' to see them.\n")
+	case "off":
+		err := LuaRun(r.lvm, "__jitlog_off()", false)
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("stopped recording JIT traces.\n")
+	case "report":
+		evs, err := r.jitEvents()
+		if err != nil {
+			return "", err
+		}
+		fmt.Print(r.jitReport(evs))
+	default:
+		return "", fmt.Errorf(":jit: unknown subcommand '%s'; use on, off, or report.", args[0])
+	}
+	return "", nil
+}
+
+// jitEvents fetches the events recorded since :jit on.
+func (r *Repl) jitEvents() (evs []jitEvent, err error) {
+	record := func(what string, tr, parent, exit int, start, loc, reason, linktype string, link int) {
+		evs = append(evs, jitEvent{
+			What:     what,
+			Trace:    tr,
+			Parent:   parent,
+			Exit:     exit,
+			Start:    start,
+			Loc:      loc,
+			Reason:   reason,
+			LinkType: linktype,
+			Link:     link,
+		})
+	}
+	tk := r.lvm.goro.newTicket("", false)
+	tk.regmap["__jitlog_record"] = record
+	err = tk.Do()
+	if err == nil {
+		err = LuaRun(r.lvm, "__jitlog_report(__jitlog_record)", false)
+	}
+	return
+}
+
+// jitReport formats the events: compiled traces
+// in order, then aborts, grouped by where they
+// started and why, most frequent first.
+func (r *Repl) jitReport(evs []jitEvent) string {
+	var b strings.Builder
+	var compiled, mine int
+	type abortKey struct{ start, loc, reason string }
+	aborts := make(map[abortKey]int)
+	var order []abortKey
+
+	for _, ev := range evs {
+		switch ev.What {
+		case "stop":
+			compiled++
+			if r.isUserLoc(ev.Start) {
+				mine++
+			}
+		case "abort":
+			k := abortKey{ev.Start, ev.Loc, ev.Reason}
+			if aborts[k] == 0 {
+				order = append(order, k)
+			}
+			aborts[k]++
+		}
+	}
+	fmt.Fprintf(&b, "%d traces compiled (%d in your code), %d aborted.\n",
+		compiled, mine, len(evs)-compiled-countFlushes(evs))
+
+	for _, ev := range evs {
+		switch ev.What {
+		case "stop":
+			fmt.Fprintf(&b, "TRACE %d", ev.Trace)
+			if ev.Parent != 0 {
+				fmt.Fprintf(&b, " (side trace of %d, exit %d)", ev.Parent, ev.Exit)
+			}
+			fmt.Fprintf(&b, " %s: %s\n", jitLinkDesc(ev), r.goLoc(ev.Start))
+			if why := jitLinkAdvice(ev.LinkType); why != "" {
+				fmt.Fprintf(&b, "    %s\n", why)
+			}
+		case "flush":
+			fmt.Fprintf(&b, "FLUSH all traces discarded.\n")
+		}
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return aborts[order[i]] > aborts[order[j]]
+	})
+	for _, k := range order {
+		fmt.Fprintf(&b, "ABORT")
+		if n := aborts[k]; n > 1 {
+			fmt.Fprintf(&b, " x%d", n)
+		}
+		fmt.Fprintf(&b, " %s\n    started at %s\n", k.reason, r.goLoc(k.start))
+		if k.loc != "" && k.loc != k.start {
+			fmt.Fprintf(&b, "    stopped at %s\n", r.goLoc(k.loc))
+		}
+		if why := jitAbortAdvice(k.reason); why != "" {
+			fmt.Fprintf(&b, "    %s\n", why)
+		}
+		if !r.isUserLoc(k.loc) && strings.HasSuffix(locChunk(k.loc), ".lua") {
+			fmt.Fprintf(&b, "    (in the gijit runtime: if the Go above is ordinary, the generated Lua may be at fault; see expressions.go.)\n")
+		}
+	}
+	return b.String()
+}
+
+func countFlushes(evs []jitEvent) (n int) {
+	for _, ev := range evs {
+		if ev.What == "flush" {
+			n++
+		}
+	}
+	return
+}
+
+func locChunk(loc string) string {
+	chunk, _, ok := parseChunkLine(loc)
+	if !ok {
+		return ""
+	}
+	return chunk
+}
+
+// isUserLoc reports if loc, chunk:line, is in
+// code typed at the REPL.
+func (r *Repl) isUserLoc(loc string) bool {
+	return r.chunks[locChunk(loc)] != nil
+}
+
+// goLoc describes loc in Go terms where we can,
+// e.g. `main.busy line 4: for i := 0; i < n; i++ {`
+func (r *Repl) goLoc(loc string) string {
+	chunk, line, ok := parseChunkLine(loc)
+	if !ok {
+		if loc == "" {
+			return "(unknown)"
+		}
+		return "builtin " + loc
+	}
+	m := r.chunks[chunk]
+	if m == nil {
+		if strings.HasSuffix(chunk, ".lua") {
+			return "gijit runtime " + loc
+		}
+		return loc
+	}
+	goLine := m.GoLine(line)
+	return fmt.Sprintf("%s line %d (%s): %s", m.FuncName(goLine), goLine, chunk, m.SrcLine(goLine))
+}
+
+func jitLinkDesc(ev jitEvent) string {
+	switch {
+	case ev.LinkType == "interpreter":
+		return "falls back to the interpreter"
+	case ev.LinkType == "stitch":
+		return "stitched"
+	case ev.Link == ev.Trace || ev.Link == 0:
+		return ev.LinkType
+	}
+	return fmt.Sprintf("%s -> %d", ev.LinkType, ev.Link)
+}
+
+func jitLinkAdvice(linktype string) string {
+	switch linktype {
+	case "stitch":
+		return "Ends at a call the JIT cannot follow (a builtin or a Go package function); the code after it is traced separately."
+	case "interpreter":
+		return "Compiled, but exits to the interpreter each time; usually a loop that was entered only a few times."
+	}
+	return ""
+}
+
+// jitAbortAdvice explains an abort reason in Go terms.
+// The reasons are LuaJIT's, from lj_traceerr.h.
+func jitAbortAdvice(reason string) string {
+	has := func(s ...string) bool {
+		for _, x := range s {
+			if strings.Contains(reason, x) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case has("bytecode FNEW"):
+		return "A func literal is created on every pass. Declare the closure once, outside the hot loop."
+	case has("bytecode UCLO"):
+		return "A closure (func literal, defer, or go statement) captures a variable declared inside the loop. Move the closure out of the loop, or pass the variable as an argument."
+	case has("bytecode ITERN", "bytecode ISNEXT", "FastFunc next", "FastFunc pairs"):
+		return "Ranging over a map is not compiled. In hot code, range over a slice of the keys instead."
+	case has("bytecode VARG", "FastFunc select", "FastFunc unpack"):
+		return "A variadic call (...) is not compiled here. Pass a slice explicitly in hot code."
+	case has("pcall", "xpcall"):
+		return "defer and recover run under pcall, which ends the trace. Keep defer out of hot loops."
+	case has("FastFunc coroutine."):
+		return "Channel operations, select, and goroutine switches yield a coroutine, which is never compiled. Batch channel traffic outside hot loops."
+	case has("FastFunc error"):
+		return "A panic ends the trace."
+	case has("FastFunc tostring", "FastFunc string.format", "FastFunc print", "FastFunc io."):
+		return "String formatting and printing (fmt, println, string conversions) are not compiled. Keep them out of hot loops."
+	case has("NYI: bytecode"):
+		return "The generated Lua uses a construct the JIT cannot compile yet."
+	case has("NYI: unsupported C function type", "NYI: unsupported C type conversion"):
+		return "A call into a Go package, or a conversion between Go and Lua values, cannot be compiled. Hoist it out of the hot loop."
+	case has("loop unroll limit reached"):
+		return "A branch in the loop goes both ways unpredictably (an unbiased branch), so no single path could be compiled. Make the common case branch-free, or split the loop."
+	case has("leaving loop in root trace"):
+		return "The loop exits, by break, return, or its condition, before a whole iteration was traced."
+	case has("inner loop in root trace"):
+		return "An inner loop got hot first; it is compiled on its own. Usually harmless."
+	case has("blacklisted"):
+		return "This code failed to compile too many times, and now always runs in the interpreter. Fix the earlier abort, then ':jit on' again to retry."
+	case has("call unroll limit reached", "down-recursion"):
+		return "Recursion is only partly traced. An iterative version of the function will compile better."
+	case has("NYI: return to lower frame"):
+		return "The trace returned into a caller that began before it did; typically a function called from an interpreted loop. Usually fine once the caller compiles."
+	case has("trace too long", "too many snapshots", "too many spill slots", "machine code too long", "trace too deep"):
+		return "The loop body is too big to compile as one trace. Move parts of it into separate functions."
+	case has("bad argument type", "persistent type instability", "guard would always fail"):
+		return "A value changes type between iterations, e.g. an int64 mixed with a float64, or a nil. Give it one consistent type."
+	case has("NYI: mixed sparse/dense table", "store with nil or NaN key"):
+		return "A slice or map is being indexed with a hole, nil, or NaN key."
+	case has("error thrown or hook called during recording"):
+		return "A panic or a debug hook fired while the trace was being recorded."
+	}
+	return ""
+}
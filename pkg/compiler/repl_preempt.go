@@ -0,0 +1,106 @@
+package compiler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// :preempt
+// :preempt <budget>|on|off
+// :preempt eval on|off
+//
+// Goroutines are coroutines, and switch only at
+// channel operations, so a CPU-bound goroutine
+// starves the rest. With a budget, a count hook
+// marks each goroutine due after that many Lua
+// instructions, and the goroutine yields to the
+// chan.lua scheduler at its next loop iteration, much
+// as Go's preemption would. The REPL's own entry is exempt
+// unless ':preempt eval on'. Compiled traces do not
+// count instructions, so the JIT is off meanwhile.
+// Only loops entered while a budget is set check
+// for it; those from earlier entries run on.
+
+// defaultPreemptBudget is the budget for ':preempt on'.
+const defaultPreemptBudget = 100000
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "preempt",
+		args: "[budget|on|off|eval on|off]",
+		help: "Preempt CPU-bound goroutines after a budget of Lua instructions.",
+		run:  (*Repl).preemptCmd,
+	})
+}
+
+func (r *Repl) preemptCmd(args []string) (string, error) {
+	budget, eval, err := r.preemptState()
+	if err != nil {
+		return "", err
+	}
+	if len(args) == 0 {
+		fmt.Print(preemptStatus(budget, eval))
+		return "", nil
+	}
+	switch sub := strings.ToLower(args[0]); sub {
+	case "on":
+		budget = defaultPreemptBudget
+	case "off":
+		budget = 0
+	case "eval":
+		if len(args) != 2 {
+			return "", fmt.Errorf(":preempt eval: use on or off.")
+		}
+		switch strings.ToLower(args[1]) {
+		case "on":
+			eval = true
+		case "off":
+			eval = false
+		default:
+			return "", fmt.Errorf(":preempt eval: use on or off, not '%s'.", args[1])
+		}
+	default:
+		n, err := strconv.Atoi(sub)
+		if err != nil || n < 0 {
+			return "", fmt.Errorf(":preempt: '%s' is not a budget; use a count of instructions, on, off, or eval.", args[0])
+		}
+		budget = n
+	}
+	if err := r.setPreempt(budget, eval); err != nil {
+		return "", err
+	}
+	fmt.Print(preemptStatus(budget, eval))
+	return "", nil
+}
+
+func preemptStatus(budget int, eval bool) string {
+	if budget <= 0 {
+		return "preemption off.\n"
+	}
+	who := "goroutines"
+	if eval {
+		who = "goroutines and REPL entries"
+	}
+	return fmt.Sprintf("preempting %s every %d instructions; the JIT is off.\n", who, budget)
+}
+
+// setPreempt sets the budget, 0 for none, and
+// whether REPL entries are preempted too.
+func (r *Repl) setPreempt(budget int, eval bool) error {
+	r.inc.Preempt = budget > 0
+	return LuaRun(r.lvm, fmt.Sprintf("__task.set_preempt(%d, %v)", budget, eval), false)
+}
+
+// preemptState returns what setPreempt last set.
+func (r *Repl) preemptState() (budget int, eval bool, err error) {
+	tk := r.lvm.goro.newTicket(`local b, e = __task.preempt_state(); __gijit_preemptOut = tostring(b).." "..tostring(e)`, false)
+	tk.varname["__gijit_preemptOut"] = nil
+	tk.gettyp = GetString
+	if err = tk.Do(); err != nil {
+		return
+	}
+	var ev string
+	_, err = fmt.Sscan(tk.varname["__gijit_preemptOut"].(string), &budget, &ev)
+	return budget, ev == "true", err
+}
@@ -0,0 +1,255 @@
+package compiler
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// :profile start [file]
+// :profile stop [file]
+// :profile top [N]
+//
+// A sampling profiler for code run at the REPL,
+// built on LuaJIT's jit.profile; see prelude/profile.lua.
+// Samples are taken in Lua, and their chunk:line
+// locations are mapped back to the Go that was
+// typed, using the ChunkPosMap kept for each entry.
+// The result is written in pprof format, so
+// `go tool pprof` can read it.
+
+const defaultProfileFile = "gijit.pprof"
+
+// replProfiler holds the state of :profile
+// between start and stop, and the last result
+// for :profile top.
+type replProfiler struct {
+	running  bool
+	path     string
+	interval time.Duration
+	begin    time.Time
+	end      time.Time
+
+	total    int64
+	samples  []pprofSample
+	vmstates map[string]int64
+}
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "profile",
+		args: "start|stop|top",
+		help: "CPU profile: ':profile stop cpu.pprof' writes pprof output.",
+		run:  (*Repl).profileCmd,
+	})
+}
+
+func (r *Repl) profileCmd(args []string) (string, error) {
+	if r.prof == nil {
+		r.prof = &replProfiler{interval: time.Millisecond}
+	}
+	pr := r.prof
+	sub := "start"
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	switch sub {
+	case "start":
+		if len(args) > 1 {
+			pr.path = args[1]
+		}
+		if pr.path == "" {
+			pr.path = defaultProfileFile
+		}
+		ms := int(pr.interval / time.Millisecond)
+		err := LuaRun(r.lvm, fmt.Sprintf("__profile_start(%d)", ms), false)
+		if err != nil {
+			return "", err
+		}
+		pr.running = true
+		pr.begin = time.Now()
+		fmt.Printf("profiling every %v; ':profile stop' to write '%s'.\n", pr.interval, pr.path)
+
+	case "stop":
+		if !pr.running {
+			return "", fmt.Errorf(":profile stop: no profile running. Use ':profile start' first.")
+		}
+		if len(args) > 1 {
+			pr.path = args[1]
+		}
+		err := r.stopProfile()
+		if err != nil {
+			return "", err
+		}
+		f, err := os.Create(pr.path)
+		if err != nil {
+			return "", err
+		}
+		err = writePprof(f, pr.samples, pr.interval, pr.begin, pr.end.Sub(pr.begin))
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return "", err
+		}
+		fmt.Printf("wrote %d samples to '%s'. View with: go tool pprof %s\n", pr.total, pr.path, pr.path)
+		fmt.Print(pr.summary(10))
+
+	case "top":
+		if pr.running {
+			return "", fmt.Errorf(":profile top: stop the profile first.")
+		}
+		n := 10
+		if len(args) > 1 {
+			var err error
+			n, err = strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return "", fmt.Errorf(":profile top: bad count '%s'", args[1])
+			}
+		}
+		fmt.Print(pr.summary(n))
+
+	default:
+		return "", fmt.Errorf(":profile: unknown subcommand '%s'; use start, stop, or top.", args[0])
+	}
+	return "", nil
+}
+
+// stopProfile collects the samples from the Lua side.
+func (r *Repl) stopProfile() error {
+	pr := r.prof
+	pr.samples = pr.samples[:0]
+	pr.vmstates = make(map[string]int64)
+	pr.total = 0
+
+	record := func(count int64, vmstate, stack string) {
+		pr.vmstates[vmstate] += count
+		pr.total += count
+		pr.samples = append(pr.samples, pprofSample{
+			Stack: r.resolveLuaStack(stack),
+			Count: count,
+		})
+	}
+	tk := r.lvm.goro.newTicket("", false)
+	tk.regmap["__profile_record"] = record
+	err := tk.Do()
+	if err == nil {
+		err = LuaRun(r.lvm, "__profile_stop(__profile_record)", false)
+	}
+	pr.running = false
+	pr.end = time.Now()
+	return err
+}
+
+// resolveLuaStack turns a stack from profile.lua,
+// "fn|chunk:line;fn|chunk:line;...", innermost first,
+// into frames, naming Go functions and lines where
+// the chunk came from a REPL entry. The frames of
+// __eval that run each entry are dropped.
+func (r *Repl) resolveLuaStack(stack string) (frames []pprofFrame) {
+	if stack == "" {
+		return []pprofFrame{{Func: "[lua]"}}
+	}
+	outermost := -1
+	for _, s := range strings.Split(stack, ";") {
+		fn, loc := s, s
+		if bar := strings.Index(s, "|"); bar >= 0 {
+			fn, loc = s[:bar], s[bar+1:]
+		}
+		chunk, line, ok := parseChunkLine(loc)
+		if !ok {
+			// a builtin or C function.
+			frames = append(frames, pprofFrame{Func: fn, File: loc})
+			continue
+		}
+		if m := r.chunks[chunk]; m != nil {
+			goLine := m.GoLine(line)
+			frames = append(frames, pprofFrame{
+				Func: m.FuncName(goLine),
+				File: chunk,
+				Line: goLine,
+			})
+			outermost = len(frames)
+			continue
+		}
+		frames = append(frames, pprofFrame{Func: fn, File: chunk, Line: line})
+	}
+	if outermost > 0 {
+		frames = frames[:outermost]
+	}
+	return
+}
+
+var vmstateNames = map[string]string{
+	"N": "compiled",
+	"I": "interpreted",
+	"C": "C code",
+	"G": "garbage collector",
+	"J": "JIT compiler",
+}
+
+// summary reports the top n functions by flat
+// (innermost frame) samples, with their cumulative
+// samples, in the manner of pprof's top.
+func (pr *replProfiler) summary(n int) string {
+	if pr.total == 0 {
+		return "no samples.\n"
+	}
+	flat := make(map[string]int64)
+	cum := make(map[string]int64)
+	for _, s := range pr.samples {
+		if len(s.Stack) == 0 {
+			continue
+		}
+		flat[s.Stack[0].Func] += s.Count
+		seen := make(map[string]bool)
+		for _, f := range s.Stack {
+			if !seen[f.Func] {
+				seen[f.Func] = true
+				cum[f.Func] += s.Count
+			}
+		}
+	}
+	var names []string
+	for nm := range cum {
+		names = append(names, nm)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := names[i], names[j]
+		if flat[a] != flat[b] {
+			return flat[a] > flat[b]
+		}
+		if cum[a] != cum[b] {
+			return cum[a] > cum[b]
+		}
+		return a < b
+	})
+	if len(names) > n {
+		names = names[:n]
+	}
+
+	pct := func(x int64) float64 { return 100 * float64(x) / float64(pr.total) }
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d samples in %v.", pr.total, pr.end.Sub(pr.begin))
+	var states []string
+	for st := range pr.vmstates {
+		states = append(states, st)
+	}
+	sort.Strings(states)
+	for _, st := range states {
+		nm := vmstateNames[st]
+		if nm == "" {
+			nm = st
+		}
+		fmt.Fprintf(&b, " %s %.1f%%", nm, pct(pr.vmstates[st]))
+	}
+	fmt.Fprintf(&b, "\n %8s %6s %8s %6s\n", "flat", "flat%", "cum", "cum%")
+	for _, nm := range names {
+		fmt.Fprintf(&b, " %8d %5.1f%% %8d %5.1f%%  %s\n",
+			flat[nm], pct(flat[nm]), cum[nm], pct(cum[nm]), nm)
+	}
+	return b.String()
+}
@@ -50,26 +50,7 @@ func TranslateAndCatchPanic(inc *IncrState, src []byte) (translation string, err
 	return translation, err
 }
 
-func readHistory(histFn string) (history []string, err error) {
-	if !FileExists(histFn) {
-		return nil, nil
-	}
-	by, err := ioutil.ReadFile(histFn)
-	if err != nil {
-		return nil, err
-	}
-	splt := strings.Split(string(by), "\n")
-	n := len(splt)
-
-	// avoid returning an extra blank history line
-	// at the end of the history file.
-	if n > 0 && strings.TrimSpace(splt[n-1]) == "" {
-		return splt[:n-1], nil
-	}
-	return splt, nil
-}
-
-func removeCommands(history []string, histFn string, histFile *os.File, rms string) (history2 []string, histFile2 *os.File, beg int, end int, err error) {
+func removeCommands(history []*histEntry, histFn string, histFile *os.File, rms string) (history2 []*histEntry, histFile2 *os.File, beg int, end int, err error) {
 
 	beg = -1
 	end = -1
@@ -102,18 +83,14 @@ func removeCommands(history []string, histFn string, histFile *os.File, rms stri
 
 	histFile.Close()
 	os.Remove(histFn)
-	histFile2, err = os.OpenFile(histFn,
-		os.O_WRONLY|os.O_CREATE|os.O_APPEND|os.O_SYNC,
-		0600)
+	histFile2, err = openHistory(histFn)
 	panicOn(err)
 	// print new history to file
-	for i := range history2 {
-		fmt.Fprintf(histFile2, "%s\n", history2[i])
-	}
+	writeHistory(histFile2, history2...)
 	return
 }
 
-func getHistoryRange(lows string, history []string) (slc []int, err error) {
+func getHistoryRange(lows string, history []*histEntry) (slc []int, err error) {
 	parts := strings.Split(lows, "-")
 	if len(parts) > 2 {
 		return nil, fmt.Errorf("bad history range request, more than one '-' found.")
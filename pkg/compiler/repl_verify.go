@@ -0,0 +1,294 @@
+package compiler
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// gi -verify session.txt [more.txt ...]
+//
+// Replays a transcript of a REPL session, checking
+// that each input still prints what was recorded,
+// so that transcripts kept as documentation can be
+// run in CI. In the transcript, each line of input,
+// continuation lines included, follows a "gi> "
+// prompt; the lines up to the next prompt are the
+// output that input printed. Lines before the first
+// prompt are commentary, and are skipped.
+//
+// In recorded output, "..." matches any text within
+// a line, so timing can be written as
+//
+//     elapsed: ...
+//
+// and a line that is only "..." matches any number
+// of lines. A line written /regexp/ must match the
+// regexp in full.
+
+const verifyPrompt = "gi> "
+
+// transcriptEntry is one input and its recorded output.
+type transcriptEntry struct {
+	line  int // of the input, 1-based
+	input string
+	want  []string
+}
+
+// parseTranscript splits a transcript into its entries.
+func parseTranscript(text string) (entries []*transcriptEntry) {
+	var cur *transcriptEntry
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if strings.HasPrefix(line+" ", verifyPrompt) {
+			cur = &transcriptEntry{line: i + 1}
+			if len(line) > len(verifyPrompt) {
+				cur.input = line[len(verifyPrompt):]
+			}
+			entries = append(entries, cur)
+			continue
+		}
+		if cur != nil {
+			cur.want = append(cur.want, line)
+		}
+	}
+	for _, e := range entries {
+		e.want = trimBlankTail(e.want)
+	}
+	return
+}
+
+func trimBlankTail(lines []string) []string {
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchOutput reports whether got matches the
+// recorded want, with its placeholders.
+func matchOutput(want, got []string) bool {
+	if len(want) == 0 {
+		return len(got) == 0
+	}
+	if want[0] == "..." {
+		for skip := 0; skip <= len(got); skip++ {
+			if matchOutput(want[1:], got[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(got) == 0 || !matchLine(want[0], got[0]) {
+		return false
+	}
+	return matchOutput(want[1:], got[1:])
+}
+
+func matchLine(want, got string) bool {
+	if want == got {
+		return true
+	}
+	if len(want) > 2 && want[0] == '/' && want[len(want)-1] == '/' {
+		re, err := regexp.Compile("^(?:" + want[1:len(want)-1] + ")$")
+		return err == nil && re.MatchString(got)
+	}
+	if !strings.Contains(want, "...") {
+		return false
+	}
+	parts := strings.Split(want, "...")
+	for i := range parts {
+		parts[i] = regexp.QuoteMeta(parts[i])
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$").MatchString(got)
+}
+
+// diffContext is how many lines firstDifference
+// shows on either side of where output diverged.
+const diffContext = 2
+
+// firstDifference describes where got stops
+// matching want: the output line number, the
+// lines that matched just before it, then the
+// next few lines of each, want marked - and
+// got marked +.
+func firstDifference(want, got []string) string {
+	wi, gi := longestMatch(want, got)
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- want\n+++ got\n@@ output line %d @@\n", gi+1)
+	from := gi - diffContext
+	if from < 0 {
+		from = 0
+	}
+	for i := from; i < gi; i++ {
+		fmt.Fprintf(&b, " %s\n", got[i])
+	}
+	side := func(mark string, lines []string) {
+		if len(lines) == 0 {
+			fmt.Fprintf(&b, "%s(end of output)\n", mark)
+			return
+		}
+		for i, line := range lines {
+			if i > diffContext {
+				fmt.Fprintf(&b, "%s...\n", mark)
+				break
+			}
+			fmt.Fprintf(&b, "%s%s\n", mark, line)
+		}
+	}
+	side("-", want[wi:])
+	side("+", got[gi:])
+	return b.String()
+}
+
+// longestMatch finds the longest prefix of want
+// that matches a prefix of got, returning where
+// each prefix ends. When placeholders leave a
+// choice, the one consuming the most of got wins.
+func longestMatch(want, got []string) (wi, gi int) {
+	seen := make(map[[2]int]bool)
+	var walk func(w, g int)
+	walk = func(w, g int) {
+		if seen[[2]int{w, g}] {
+			return
+		}
+		seen[[2]int{w, g}] = true
+		if w > wi || (w == wi && g > gi) {
+			wi, gi = w, g
+		}
+		switch {
+		case w == len(want):
+		case want[w] == "...":
+			for skip := 0; g+skip <= len(got); skip++ {
+				walk(w+1, g+skip)
+			}
+		case g < len(got) && matchLine(want[w], got[g]):
+			walk(w+1, g+1)
+		}
+	}
+	walk(0, 0)
+	return
+}
+
+// VerifyTranscripts replays each transcript file in
+// its own fresh REPL, printing a diff for each
+// mismatch. It returns the exit code: 0 if all
+// matched, 1 if any did not, 2 if a file could
+// not be read; a file that cannot be read does
+// not stop the others being checked.
+func VerifyTranscripts(cfg *GIConfig, paths []string) int {
+	status := 0
+	for _, path := range paths {
+		by, err := ioutil.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gi -verify: %v\n", err)
+			status = 2
+			continue
+		}
+		entries := parseTranscript(string(by))
+		bad, err := verifyTranscript(cfg, path, entries)
+		switch {
+		case err != nil:
+			fmt.Printf("FAIL\t%s\n%v\n", path, err)
+			if status < 1 {
+				status = 1
+			}
+		case bad > 0:
+			fmt.Printf("FAIL\t%s\t%d of %d inputs differ\n", path, bad, len(entries))
+			if status < 1 {
+				status = 1
+			}
+		default:
+			fmt.Printf("ok  \t%s\t%d inputs\n", path, len(entries))
+		}
+	}
+	return status
+}
+
+// verifyTranscript replays entries, returning
+// how many printed other than what was recorded.
+func verifyTranscript(cfg *GIConfig, path string, entries []*transcriptEntry) (bad int, err error) {
+	c := *cfg
+	c.NoLiner = true
+	c.Quiet = true
+	r := NewRepl(&c)
+	defer r.lvm.Close()
+
+	// a replay is not something typed; keep
+	// it out of the history file.
+	if r.histFile != nil {
+		r.histFile.Close()
+		r.histFile = nil
+	}
+	return r.replay(path, entries)
+}
+
+// replay evaluates entries in r, printing where
+// each one's output differs from the recording.
+func (r *Repl) replay(path string, entries []*transcriptEntry) (bad int, err error) {
+
+	// Lua's print writes to C's stdout, which
+	// we cannot capture; while each entry runs,
+	// print goes through os.Stdout instead,
+	// formatted the same. The session's own
+	// print is put back after every entry,
+	// unless the entry defined one itself.
+	tk := r.lvm.goro.newTicket("", false)
+	tk.regmap["__gijit_verify_write"] = func(s string) {
+		os.Stdout.WriteString(s)
+	}
+	err = tk.Do()
+	if err != nil {
+		return 0, err
+	}
+	err = LuaRun(r.lvm, `__gijit_verify_print = function(...)
+   local n = select("#", ...)
+   local t = {}
+   for i = 1, n do
+      t[i] = tostring((select(i, ...)))
+   end
+   __gijit_verify_write(table.concat(t, "\t").."\n")
+end`, false)
+	if err != nil {
+		return 0, err
+	}
+	defer LuaRun(r.lvm, `__gijit_verify_print = nil; __gijit_verify_write = nil`, false)
+
+	for _, e := range entries {
+		out, _ := captureStdout(func() error {
+			err := LuaRun(r.lvm, `__gijit_verify_saved = print; print = __gijit_verify_print`, false)
+			if err != nil {
+				return err
+			}
+			defer LuaRun(r.lvm, `if print == __gijit_verify_print then print = __gijit_verify_saved end; __gijit_verify_saved = nil`, false)
+			return r.eval(e.input)
+		})
+		got := trimBlankTail(strings.Split(strings.TrimRight(out, " \t\r\n"), "\n"))
+		for i := range got {
+			got[i] = strings.TrimRight(got[i], " \t\r")
+		}
+		if matchOutput(e.want, got) {
+			continue
+		}
+		bad++
+		fmt.Printf("%s:%d: %s%s\n", path, e.line, verifyPrompt, e.input)
+		fmt.Print(firstDifference(e.want, got))
+	}
+	return bad, nil
+}
+
+// eval runs one line of input as if typed
+// at the prompt, colon commands included.
+func (r *Repl) eval(input string) error {
+	if c, args := lookupReplCommand(strings.TrimSpace(input)); c != nil {
+		src, err := c.run(r, args)
+		if err != nil {
+			fmt.Printf("%s\n", err.Error())
+			return nil
+		}
+		input = src
+	}
+	return r.Eval(input)
+}
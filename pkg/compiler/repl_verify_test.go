@@ -0,0 +1,109 @@
+package compiler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2040VerifyReplaysTranscripts(t *testing.T) {
+
+	cv.Convey("gi -verify replays a REPL transcript, matching ... and /regexp/ placeholders, and reports each mismatch as a diff.", t, func() {
+		cv.So(matchLine("elapsed: ...", "elapsed: '46.1µs'"), cv.ShouldBeTrue)
+		cv.So(matchLine("elapsed: ...", "elapsed"), cv.ShouldBeFalse)
+		cv.So(matchLine("/[0-9]+LL/", "42LL"), cv.ShouldBeTrue)
+		cv.So(matchLine("/[0-9]+LL/", "x42LL"), cv.ShouldBeFalse)
+		cv.So(matchOutput([]string{"a", "...", "d"}, []string{"a", "b", "c", "d"}), cv.ShouldBeTrue)
+		cv.So(matchOutput([]string{"a", "...", "d"}, []string{"a", "d"}), cv.ShouldBeTrue)
+		cv.So(matchOutput([]string{"a", "...", "d"}, []string{"a", "b"}), cv.ShouldBeFalse)
+
+		dir, err := ioutil.TempDir("", "gijit-verify")
+		panicOn(err)
+		defer os.RemoveAll(dir)
+
+		session := `Adding, at the REPL.
+gi> x := 3
+
+elapsed: ...
+gi> func add(a, b int) int {
+gi>     return a + b
+gi> }
+
+elapsed: ...
+gi> add(x, 1)
+/[0-9]+LL/
+
+elapsed: ...
+gi> println("sum", add(x, x))
+...
+elapsed: ...
+gi> `
+		good := filepath.Join(dir, "good.txt")
+		panicOn(ioutil.WriteFile(good, []byte(session), 0644))
+
+		cfg := NewGIConfig()
+		rc := 0
+		out, err := captureStdout(func() error {
+			rc = VerifyTranscripts(cfg, []string{good})
+			return nil
+		})
+		panicOn(err)
+		cv.So(rc, cv.ShouldEqual, 0)
+		cv.So(out, cv.ShouldContainSubstring, "ok  \t"+good+"\t7 inputs\n")
+
+		bad := filepath.Join(dir, "bad.txt")
+		panicOn(ioutil.WriteFile(bad, []byte(strings.Replace(session, "/[0-9]+LL/", "5LL", 1)), 0644))
+		out, err = captureStdout(func() error {
+			rc = VerifyTranscripts(cfg, []string{bad})
+			return nil
+		})
+		panicOn(err)
+		cv.So(rc, cv.ShouldEqual, 1)
+		cv.So(out, cv.ShouldContainSubstring, bad+":10: gi> add(x, 1)\n--- want\n+++ got\n@@ output line 1 @@\n-5LL\n-\n-elapsed: ...\n+4LL\n+\n+elapsed: ")
+		cv.So(out, cv.ShouldContainSubstring, "FAIL\t"+bad+"\t1 of 7 inputs differ\n")
+
+		// only where output diverges is shown, after the
+		// lines that matched just before it.
+		cv.So(firstDifference(
+			[]string{"a", "...", "d", "e", "f", "g", "h", "i"},
+			[]string{"a", "b", "c", "d", "e", "F", "g"}),
+			cv.ShouldEqual, "--- want\n+++ got\n@@ output line 6 @@\n d\n e\n-f\n-g\n-h\n-...\n+F\n+g\n")
+		cv.So(firstDifference([]string{"a", "b"}, []string{"a"}),
+			cv.ShouldEqual, "--- want\n+++ got\n@@ output line 2 @@\n a\n-b\n+(end of output)\n")
+
+		// the session's own print is only swapped out
+		// while an entry runs, and is put back after.
+		c := *cfg
+		c.NoLiner = true
+		c.Quiet = true
+		r := NewRepl(&c)
+		defer r.lvm.Close()
+		LuaRunAndReport(r.lvm, `mine = print`)
+		n := 0
+		out, err = captureStdout(func() (err error) {
+			n, err = r.replay(good, parseTranscript(session))
+			return
+		})
+		panicOn(err)
+		cv.So(n, cv.ShouldEqual, 0)
+		cv.So(out, cv.ShouldEqual, "")
+		LuaRunAndReport(r.lvm, `same = print == mine; gone = __gijit_verify_print == nil`)
+		LuaMustBool(r.lvm, "same", true)
+		LuaMustBool(r.lvm, "gone", true)
+
+		// a file that cannot be read is reported, and the
+		// rest are still checked; the worst status wins.
+		out, err = captureStdout(func() error {
+			rc = VerifyTranscripts(cfg, []string{filepath.Join(dir, "missing.txt"), bad, good})
+			return nil
+		})
+		panicOn(err)
+		cv.So(rc, cv.ShouldEqual, 2)
+		cv.So(out, cv.ShouldContainSubstring, "FAIL\t"+bad+"\t1 of 7 inputs differ\n")
+		cv.So(out, cv.ShouldContainSubstring, "ok  \t"+good+"\t7 inputs\n")
+	})
+}
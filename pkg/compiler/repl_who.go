@@ -0,0 +1,295 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gijit/gi/pkg/types"
+)
+
+// :who  [var|func|type|const] [pattern ...]
+// :whos [var|func|type|const] [pattern ...]
+//
+// The workspace browser, after Matlab's. Where :ls
+// dumps the Lua globals, :who lists what you declared
+// at the prompt, by its Go name, from the type
+// checker's package scope. :whos adds each one's kind
+// and Go type; for vars, the length and capacity, an
+// estimate of the bytes held, and a one-line preview
+// of the value (see prelude/who.lua). Patterns are
+// shell globs on the name, e.g. `:whos var a*`.
+
+// whoEntry is one row of :whos.
+type whoEntry struct {
+	Name  string
+	Kind  string // "var", "func", "type", or "const"
+	Type  string
+	Len   int // -1 if not applicable
+	Cap   int // -1 if not applicable
+	Bytes int // -1 if unknown
+	Value string
+}
+
+// whoPreviewWidth bounds the :whos value column.
+const whoPreviewWidth = 40
+
+func init() {
+	registerReplCommand(&replCommand{
+		name: "who",
+		args: "[kind] [pattern]",
+		help: "List your declarations by name.",
+		run:  (*Repl).whoCmd,
+	})
+	registerReplCommand(&replCommand{
+		name: "whos",
+		args: "[kind] [pattern]",
+		help: "List your declarations with Go types, sizes, and values.",
+		run:  (*Repl).whosCmd,
+	})
+}
+
+func (r *Repl) whoCmd(args []string) (string, error) {
+	ents, err := r.who(args, false)
+	if err != nil {
+		return "", err
+	}
+	fmt.Print(whoList(ents))
+	return "", nil
+}
+
+func (r *Repl) whosCmd(args []string) (string, error) {
+	ents, err := r.who(args, true)
+	if err != nil {
+		return "", err
+	}
+	fmt.Print(whosTable(ents))
+	return "", nil
+}
+
+// whoKinds maps the :who kind filters to
+// the kinds they select.
+var whoKinds = map[string]string{
+	"var":    "var",
+	"vars":   "var",
+	"func":   "func",
+	"funcs":  "func",
+	"type":   "type",
+	"types":  "type",
+	"const":  "const",
+	"consts": "const",
+}
+
+// who returns the package-level declarations made
+// at the prompt, filtered by args, sorted by name.
+// With values, vars get their sizes and previews.
+func (r *Repl) who(args []string, values bool) (ents []*whoEntry, err error) {
+	kinds := make(map[string]bool)
+	var pats []string
+	for _, a := range args {
+		if k, ok := whoKinds[strings.ToLower(a)]; ok {
+			kinds[k] = true
+			continue
+		}
+		if _, err := path.Match(a, ""); err != nil {
+			return nil, fmt.Errorf(":who: bad pattern '%s': %v", a, err)
+		}
+		pats = append(pats, a)
+	}
+
+	pkg := r.mainPkg()
+	if pkg == nil {
+		return nil, nil
+	}
+	qual := types.RelativeTo(pkg)
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		obj := scope.Lookup(name)
+		// the prelude's __ helpers have no position.
+		if strings.HasPrefix(name, "_") || !obj.Pos().IsValid() {
+			continue
+		}
+		if !whoMatch(name, pats) {
+			continue
+		}
+		e := &whoEntry{Name: name, Len: -1, Cap: -1, Bytes: -1}
+		switch o := obj.(type) {
+		case *types.Var:
+			e.Kind = "var"
+			e.Type = types.TypeString(o.Type(), qual)
+		case *types.Func:
+			e.Kind = "func"
+			e.Type = types.TypeString(o.Type(), qual)
+		case *types.TypeName:
+			e.Kind = "type"
+			e.Type = types.TypeString(o.Type().Underlying(), qual)
+		case *types.Const:
+			e.Kind = "const"
+			e.Type = types.TypeString(o.Type(), qual)
+			e.Value = o.Val().String()
+		default:
+			continue
+		}
+		if len(kinds) > 0 && !kinds[e.Kind] {
+			continue
+		}
+		ents = append(ents, e)
+	}
+	sort.Slice(ents, func(i, j int) bool { return ents[i].Name < ents[j].Name })
+
+	if values {
+		err = r.whoValues(pkg, ents)
+	}
+	return
+}
+
+func (r *Repl) mainPkg() *types.Package {
+	if r.inc == nil {
+		return nil
+	}
+	ps := r.inc.pkgMap["main"]
+	if ps == nil || ps.Arch == nil {
+		return nil
+	}
+	return ps.Arch.Pkg
+}
+
+func whoMatch(name string, pats []string) bool {
+	if len(pats) == 0 {
+		return true
+	}
+	for _, p := range pats {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// whoValues fills in the sizes and previews of
+// the vars among ents, from the Lua globals.
+func (r *Repl) whoValues(pkg *types.Package, ents []*whoEntry) error {
+	byName := make(map[string]*whoEntry)
+	var src bytes.Buffer
+	for _, e := range ents {
+		if e.Kind != "var" {
+			continue
+		}
+		byName[e.Name] = e
+		t := pkg.Scope().Lookup(e.Name).Type()
+		fmt.Fprintf(&src, "__gijit_whoRecord(%q, __gijit_whos(_G[%q], %s, %d))\n",
+			e.Name, e.Name, luaTypeExpr(t, pkg), whoPreviewWidth)
+	}
+	if len(byName) == 0 {
+		return nil
+	}
+	record := func(name string, n, c, bytes int, preview string) {
+		if e := byName[name]; e != nil {
+			e.Len, e.Cap, e.Bytes, e.Value = n, c, bytes, preview
+		}
+	}
+	tk := r.lvm.goro.newTicket("", false)
+	tk.regmap["__gijit_whoRecord"] = record
+	err := tk.Do()
+	if err != nil {
+		return err
+	}
+	return LuaRun(r.lvm, src.String(), false)
+}
+
+// luaTypeExpr returns the Lua expression for the
+// runtime descriptor of t, or "nil" if t has none
+// that can be named from the prompt.
+func luaTypeExpr(t types.Type, pkg *types.Package) string {
+	switch t := t.(type) {
+	case *types.Basic:
+		if t.Info()&types.IsUntyped != 0 || t.Kind() == types.UnsafePointer {
+			return "nil"
+		}
+		switch t.Kind() {
+		case types.Byte:
+			return "__type__.uint8"
+		case types.Rune:
+			return "__type__.int32"
+		}
+		return "__type__." + t.Name()
+	case *types.Named:
+		obj := t.Obj()
+		if obj.Pkg() == pkg || (obj.Pkg() == nil && obj.Name() == "error") {
+			return "__type__." + obj.Name()
+		}
+	case *types.Interface:
+		if t.Empty() {
+			return "__type__.emptyInterface"
+		}
+	case *types.Pointer:
+		if e := luaTypeExpr(t.Elem(), pkg); e != "nil" {
+			return "__ptrType(" + e + ")"
+		}
+	case *types.Slice:
+		if e := luaTypeExpr(t.Elem(), pkg); e != "nil" {
+			return "__sliceType(" + e + ")"
+		}
+	case *types.Array:
+		if e := luaTypeExpr(t.Elem(), pkg); e != "nil" {
+			return "__arrayType(" + e + ", " + strconv.FormatInt(t.Len(), 10) + ")"
+		}
+	case *types.Map:
+		k := luaTypeExpr(t.Key(), pkg)
+		e := luaTypeExpr(t.Elem(), pkg)
+		if k != "nil" && e != "nil" {
+			return "__mapType(" + k + ", " + e + ")"
+		}
+	}
+	return "nil"
+}
+
+// whoList formats the names for :who,
+// several to the line.
+func whoList(ents []*whoEntry) string {
+	if len(ents) == 0 {
+		return "no declarations.\n"
+	}
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	for i, e := range ents {
+		fmt.Fprint(w, e.Name)
+		if i%5 == 4 || i == len(ents)-1 {
+			fmt.Fprint(w, "\n")
+		} else {
+			fmt.Fprint(w, "\t")
+		}
+	}
+	w.Flush()
+	return b.String()
+}
+
+// whosTable formats the entries for :whos.
+func whosTable(ents []*whoEntry) string {
+	if len(ents) == 0 {
+		return "no declarations.\n"
+	}
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Name\tKind\tType\tLen/Cap\tBytes\tValue\n")
+	for _, e := range ents {
+		size := ""
+		switch {
+		case e.Cap >= 0 && e.Cap != e.Len:
+			size = fmt.Sprintf("%d/%d", e.Len, e.Cap)
+		case e.Len >= 0:
+			size = strconv.Itoa(e.Len)
+		}
+		by := ""
+		if e.Bytes >= 0 {
+			by = strconv.Itoa(e.Bytes)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Name, e.Kind, e.Type, size, by, e.Value)
+	}
+	w.Flush()
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2200RuntimeFaultsPanicWithRuntimeErrors(t *testing.T) {
+
+	cv.Convey("runtime faults panic with values implementing runtime.Error, with Go's messages, and a failed x.(T) with a *runtime.TypeAssertionError", t, func() {
+
+		code := `
+func msg(r interface{}) string {
+	if e, ok := r.(error); ok {
+		return e.Error()
+	}
+	return "not an error"
+}
+zero := 0
+var m map[string]int
+var p *int
+type T struct{ x int }
+var tp *T
+var i interface{} = 3
+var nothing interface{}
+var err error
+
+func div() (r interface{}) {
+	defer func() { r = recover() }()
+	_ = 5 / zero
+	return nil
+}
+func nilMap() (r interface{}) {
+	defer func() { r = recover() }()
+	m["a"] = 1
+	return nil
+}
+func index() (r interface{}) {
+	defer func() { r = recover() }()
+	var s []int
+	s[3] = 1
+	return nil
+}
+func deref() (r interface{}) {
+	defer func() { r = recover() }()
+	*p = 1
+	return nil
+}
+func field() (r interface{}) {
+	defer func() { r = recover() }()
+	tp.x = 2
+	return nil
+}
+func bounds() (r interface{}) {
+	defer func() { r = recover() }()
+	s := []int{1, 2, 3}
+	n := 5
+	_ = s[1:n]
+	return nil
+}
+func notString() (r interface{}) {
+	defer func() { r = recover() }()
+	_ = i.(string)
+	return nil
+}
+func notError() (r interface{}) {
+	defer func() { r = recover() }()
+	err = i.(error)
+	return nil
+}
+func isNil() (r interface{}) {
+	defer func() { r = recover() }()
+	err = nothing.(error)
+	return nil
+}
+m1, m2, m3, m4, m5 := msg(div()), msg(nilMap()), msg(index()), msg(deref()), msg(field())
+m6, m7, m8, m9 := msg(bounds()), msg(notString()), msg(notError()), msg(isNil())
+read := m["q"]
+n := len(m)
+type H struct{ m map[int]int }
+var h H
+hNil := h.m == nil
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation, err := inc.Tr([]byte(code))
+		panicOn(err)
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustString(vm, "m1", "runtime error: integer divide by zero")
+		LuaMustString(vm, "m2", "assignment to entry in nil map")
+		LuaMustString(vm, "m3", "runtime error: index out of range [3] with length 0")
+		LuaMustString(vm, "m4", "runtime error: invalid memory address or nil pointer dereference")
+		LuaMustString(vm, "m5", "runtime error: invalid memory address or nil pointer dereference")
+		LuaMustString(vm, "m6", "runtime error: slice bounds out of range [:5] with capacity 3")
+		LuaMustString(vm, "m7", "interface conversion: interface {} is int, not string")
+		LuaMustString(vm, "m8", "interface conversion: int is not error: missing method Error")
+		LuaMustString(vm, "m9", "interface conversion: interface {} is nil, not error")
+
+		// reading a nil map gets the zero value; a
+		// map field starts as the same nil map.
+		LuaMustInt64(vm, "read", 0)
+		LuaMustInt64(vm, "n", 0)
+		LuaMustBool(vm, "hNil", true)
+
+		// all are runtime.Error, which import "runtime" names.
+		panicOn(LuaRun(vm, `
+__allRuntimeErrors = true
+for _, e in ipairs({
+      __type__.__runtime.errorString.ptrToNewlyConstructed("x"),
+      __type__.__runtime.plainError.ptrToNewlyConstructed("x"),
+      __type__.__runtime.TypeAssertionError.ptrToNewlyConstructed("", "", "int", ""),
+}) do
+   local _, ok = __assertType(e, __type__.__runtime.Error, 2)
+   __allRuntimeErrors = __allRuntimeErrors and ok
+end
+`, false))
+		LuaMustBool(vm, "__allRuntimeErrors", true)
+		cv.So(true, cv.ShouldBeTrue)
+	})
+}
@@ -81,13 +81,13 @@ func GijitShadow_NewStruct_Reader(src *bytes.Reader) *bytes.Reader {
 
  func InitLua() string {
   return `
-__type__.bytes ={};
+__type__["bytes"] ={};
 
 -----------------
 -- struct Buffer
 -----------------
 
-__type__.bytes.Buffer = {
+__type__["bytes"].Buffer = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Buffer",
@@ -97,14 +97,14 @@ __type__.bytes.Buffer = {
    return __ctor__bytes.Buffer(src)
  end,
 };
-setmetatable(__type__.bytes.Buffer, __type__.bytes.Buffer);
+setmetatable(__type__["bytes"].Buffer, __type__["bytes"].Buffer);
 
 
 -----------------
 -- struct Reader
 -----------------
 
-__type__.bytes.Reader = {
+__type__["bytes"].Reader = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Reader",
@@ -114,7 +114,7 @@ __type__.bytes.Reader = {
    return __ctor__bytes.Reader(src)
  end,
 };
-setmetatable(__type__.bytes.Reader, __type__.bytes.Reader);
+setmetatable(__type__["bytes"].Reader, __type__["bytes"].Reader);
 
 
 `}
\ No newline at end of file
@@ -0,0 +1,79 @@
+package shadow_context
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+var Pkg = make(map[string]interface{})
+var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
+
+func init() {
+    Pkg["AfterFunc"] = context.AfterFunc
+    Pkg["Background"] = context.Background
+    Pkg["Canceled"] = context.Canceled
+    Pkg["Cause"] = context.Cause
+    Pkg["Context"] = GijitShadow_InterfaceConvertTo2_Context
+    Proxy[reflect.TypeOf((*context.Context)(nil)).Elem()] = GijitShadow_NewProxy_Context
+    Pkg["DeadlineExceeded"] = context.DeadlineExceeded
+    Pkg["TODO"] = context.TODO
+    Pkg["WithCancel"] = context.WithCancel
+    Pkg["WithCancelCause"] = context.WithCancelCause
+    Pkg["WithDeadline"] = context.WithDeadline
+    Pkg["WithDeadlineCause"] = context.WithDeadlineCause
+    Pkg["WithTimeout"] = context.WithTimeout
+    Pkg["WithTimeoutCause"] = context.WithTimeoutCause
+    Pkg["WithValue"] = context.WithValue
+    Pkg["WithoutCancel"] = context.WithoutCancel
+
+}
+func GijitShadow_InterfaceConvertTo2_Context(x interface{}) (y context.Context, b bool) {
+	y, b = x.(context.Context)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Context(x interface{}) context.Context {
+	return x.(context.Context)
+}
+
+
+type GijitShadow_Proxy_Context struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Context(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Context{call: call}
+}
+
+func (x *GijitShadow_Proxy_Context) Deadline() (time.Time, bool) {
+	out := x.call("Deadline")
+	r0, _ := out[0].Interface().(time.Time)
+	r1, _ := out[1].Interface().(bool)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Context) Done() <-chan struct{} {
+	out := x.call("Done")
+	r0, _ := out[0].Interface().(<-chan struct{})
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Context) Err() error {
+	out := x.call("Err")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Context) Value(a0 interface{}) interface{} {
+	out := x.call("Value", a0)
+	r0, _ := out[0].Interface().(interface{})
+	return r0
+}
+
+ func InitLua() string {
+  return `
+__type__["context"] ={};
+
+`}
\ No newline at end of file
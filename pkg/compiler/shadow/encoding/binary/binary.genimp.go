@@ -1,13 +1,18 @@
 package shadow_binary
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Pkg["BigEndian"] = binary.BigEndian
     Pkg["ByteOrder"] = GijitShadow_InterfaceConvertTo2_ByteOrder
+    Proxy[reflect.TypeOf((*binary.ByteOrder)(nil)).Elem()] = GijitShadow_NewProxy_ByteOrder
     Pkg["LittleEndian"] = binary.LittleEndian
     Pkg["MaxVarintLen16"] = binary.MaxVarintLen16
     Pkg["MaxVarintLen32"] = binary.MaxVarintLen32
@@ -33,16 +38,61 @@ func GijitShadow_InterfaceConvertTo1_ByteOrder(x interface{}) binary.ByteOrder {
 }
 
 
+type GijitShadow_Proxy_ByteOrder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ByteOrder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ByteOrder{call: call}
+}
+
+func (x *GijitShadow_Proxy_ByteOrder) PutUint16(a0 []byte, a1 uint16)  {
+	x.call("PutUint16", a0, a1)
+}
+
+func (x *GijitShadow_Proxy_ByteOrder) PutUint32(a0 []byte, a1 uint32)  {
+	x.call("PutUint32", a0, a1)
+}
+
+func (x *GijitShadow_Proxy_ByteOrder) PutUint64(a0 []byte, a1 uint64)  {
+	x.call("PutUint64", a0, a1)
+}
+
+func (x *GijitShadow_Proxy_ByteOrder) String() string {
+	out := x.call("String")
+	r0, _ := out[0].Interface().(string)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_ByteOrder) Uint16(a0 []byte) uint16 {
+	out := x.call("Uint16", a0)
+	r0, _ := out[0].Interface().(uint16)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_ByteOrder) Uint32(a0 []byte) uint32 {
+	out := x.call("Uint32", a0)
+	r0, _ := out[0].Interface().(uint32)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_ByteOrder) Uint64(a0 []byte) uint64 {
+	out := x.call("Uint64", a0)
+	r0, _ := out[0].Interface().(uint64)
+	return r0
+}
+
+
 
  func InitLua() string {
   return `
-__type__.binary ={};
+__type__["encoding/binary"] ={};
 
 -----------------
 -- struct BigEndian
 -----------------
 
-__type__.binary.BigEndian = {
+__type__["encoding/binary"].BigEndian = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "BigEndian",
@@ -52,14 +102,14 @@ __type__.binary.BigEndian = {
    return __ctor__binary.BigEndian(src)
  end,
 };
-setmetatable(__type__.binary.BigEndian, __type__.binary.BigEndian);
+setmetatable(__type__["encoding/binary"].BigEndian, __type__["encoding/binary"].BigEndian);
 
 
 -----------------
 -- struct LittleEndian
 -----------------
 
-__type__.binary.LittleEndian = {
+__type__["encoding/binary"].LittleEndian = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "LittleEndian",
@@ -69,7 +119,7 @@ __type__.binary.LittleEndian = {
    return __ctor__binary.LittleEndian(src)
  end,
 };
-setmetatable(__type__.binary.LittleEndian, __type__.binary.LittleEndian);
+setmetatable(__type__["encoding/binary"].LittleEndian, __type__["encoding/binary"].LittleEndian);
 
 
 `}
\ No newline at end of file
@@ -1,15 +1,23 @@
 package shadow_encoding
 
-import "encoding"
+import (
+	"encoding"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Pkg["BinaryMarshaler"] = GijitShadow_InterfaceConvertTo2_BinaryMarshaler
+    Proxy[reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem()] = GijitShadow_NewProxy_BinaryMarshaler
     Pkg["BinaryUnmarshaler"] = GijitShadow_InterfaceConvertTo2_BinaryUnmarshaler
+    Proxy[reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem()] = GijitShadow_NewProxy_BinaryUnmarshaler
     Pkg["TextMarshaler"] = GijitShadow_InterfaceConvertTo2_TextMarshaler
+    Proxy[reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()] = GijitShadow_NewProxy_TextMarshaler
     Pkg["TextUnmarshaler"] = GijitShadow_InterfaceConvertTo2_TextUnmarshaler
+    Proxy[reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()] = GijitShadow_NewProxy_TextUnmarshaler
 
 }
 func GijitShadow_InterfaceConvertTo2_BinaryMarshaler(x interface{}) (y encoding.BinaryMarshaler, b bool) {
@@ -22,6 +30,22 @@ func GijitShadow_InterfaceConvertTo1_BinaryMarshaler(x interface{}) encoding.Bin
 }
 
 
+type GijitShadow_Proxy_BinaryMarshaler struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_BinaryMarshaler(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_BinaryMarshaler{call: call}
+}
+
+func (x *GijitShadow_Proxy_BinaryMarshaler) MarshalBinary() ([]byte, error) {
+	out := x.call("MarshalBinary")
+	r0, _ := out[0].Interface().([]byte)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_BinaryUnmarshaler(x interface{}) (y encoding.BinaryUnmarshaler, b bool) {
 	y, b = x.(encoding.BinaryUnmarshaler)
 	return
@@ -32,6 +56,21 @@ func GijitShadow_InterfaceConvertTo1_BinaryUnmarshaler(x interface{}) encoding.B
 }
 
 
+type GijitShadow_Proxy_BinaryUnmarshaler struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_BinaryUnmarshaler(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_BinaryUnmarshaler{call: call}
+}
+
+func (x *GijitShadow_Proxy_BinaryUnmarshaler) UnmarshalBinary(a0 []byte) error {
+	out := x.call("UnmarshalBinary", a0)
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+
 func GijitShadow_InterfaceConvertTo2_TextMarshaler(x interface{}) (y encoding.TextMarshaler, b bool) {
 	y, b = x.(encoding.TextMarshaler)
 	return
@@ -42,6 +81,22 @@ func GijitShadow_InterfaceConvertTo1_TextMarshaler(x interface{}) encoding.TextM
 }
 
 
+type GijitShadow_Proxy_TextMarshaler struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_TextMarshaler(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_TextMarshaler{call: call}
+}
+
+func (x *GijitShadow_Proxy_TextMarshaler) MarshalText() ([]byte, error) {
+	out := x.call("MarshalText")
+	r0, _ := out[0].Interface().([]byte)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_TextUnmarshaler(x interface{}) (y encoding.TextUnmarshaler, b bool) {
 	y, b = x.(encoding.TextUnmarshaler)
 	return
@@ -52,9 +107,24 @@ func GijitShadow_InterfaceConvertTo1_TextUnmarshaler(x interface{}) encoding.Tex
 }
 
 
+type GijitShadow_Proxy_TextUnmarshaler struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_TextUnmarshaler(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_TextUnmarshaler{call: call}
+}
+
+func (x *GijitShadow_Proxy_TextUnmarshaler) UnmarshalText(a0 []byte) error {
+	out := x.call("UnmarshalText", a0)
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+
 
  func InitLua() string {
   return `
-__type__.encoding ={};
+__type__["encoding"] ={};
 
 `}
\ No newline at end of file
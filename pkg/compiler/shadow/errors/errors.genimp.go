@@ -12,6 +12,6 @@ func init() {
 
  func InitLua() string {
   return `
-__type__.errors ={};
+__type__["errors"] ={};
 
 `}
\ No newline at end of file
@@ -1,13 +1,18 @@
 package shadow_fmt
 
-import "fmt"
+import (
+	"fmt"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Pkg["Errorf"] = fmt.Errorf
     Pkg["Formatter"] = GijitShadow_InterfaceConvertTo2_Formatter
+    Proxy[reflect.TypeOf((*fmt.Formatter)(nil)).Elem()] = GijitShadow_NewProxy_Formatter
     Pkg["Fprint"] = fmt.Fprint
     Pkg["Fprintf"] = fmt.Fprintf
     Pkg["Fprintln"] = fmt.Fprintln
@@ -15,14 +20,17 @@ func init() {
     Pkg["Fscanf"] = fmt.Fscanf
     Pkg["Fscanln"] = fmt.Fscanln
     Pkg["GoStringer"] = GijitShadow_InterfaceConvertTo2_GoStringer
+    Proxy[reflect.TypeOf((*fmt.GoStringer)(nil)).Elem()] = GijitShadow_NewProxy_GoStringer
     Pkg["Print"] = fmt.Print
     Pkg["Printf"] = fmt.Printf
     Pkg["Println"] = fmt.Println
     Pkg["Scan"] = fmt.Scan
     Pkg["ScanState"] = GijitShadow_InterfaceConvertTo2_ScanState
+    Proxy[reflect.TypeOf((*fmt.ScanState)(nil)).Elem()] = GijitShadow_NewProxy_ScanState
     Pkg["Scanf"] = fmt.Scanf
     Pkg["Scanln"] = fmt.Scanln
     Pkg["Scanner"] = GijitShadow_InterfaceConvertTo2_Scanner
+    Proxy[reflect.TypeOf((*fmt.Scanner)(nil)).Elem()] = GijitShadow_NewProxy_Scanner
     Pkg["Sprint"] = fmt.Sprint
     Pkg["Sprintf"] = fmt.Sprintf
     Pkg["Sprintln"] = fmt.Sprintln
@@ -30,7 +38,9 @@ func init() {
     Pkg["Sscanf"] = fmt.Sscanf
     Pkg["Sscanln"] = fmt.Sscanln
     Pkg["State"] = GijitShadow_InterfaceConvertTo2_State
+    Proxy[reflect.TypeOf((*fmt.State)(nil)).Elem()] = GijitShadow_NewProxy_State
     Pkg["Stringer"] = GijitShadow_InterfaceConvertTo2_Stringer
+    Proxy[reflect.TypeOf((*fmt.Stringer)(nil)).Elem()] = GijitShadow_NewProxy_Stringer
 
 }
 func GijitShadow_InterfaceConvertTo2_Formatter(x interface{}) (y fmt.Formatter, b bool) {
@@ -43,6 +53,19 @@ func GijitShadow_InterfaceConvertTo1_Formatter(x interface{}) fmt.Formatter {
 }
 
 
+type GijitShadow_Proxy_Formatter struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Formatter(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Formatter{call: call}
+}
+
+func (x *GijitShadow_Proxy_Formatter) Format(a0 fmt.State, a1 rune)  {
+	x.call("Format", a0, a1)
+}
+
+
 func GijitShadow_InterfaceConvertTo2_GoStringer(x interface{}) (y fmt.GoStringer, b bool) {
 	y, b = x.(fmt.GoStringer)
 	return
@@ -53,6 +76,21 @@ func GijitShadow_InterfaceConvertTo1_GoStringer(x interface{}) fmt.GoStringer {
 }
 
 
+type GijitShadow_Proxy_GoStringer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_GoStringer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_GoStringer{call: call}
+}
+
+func (x *GijitShadow_Proxy_GoStringer) GoString() string {
+	out := x.call("GoString")
+	r0, _ := out[0].Interface().(string)
+	return r0
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ScanState(x interface{}) (y fmt.ScanState, b bool) {
 	y, b = x.(fmt.ScanState)
 	return
@@ -63,6 +101,54 @@ func GijitShadow_InterfaceConvertTo1_ScanState(x interface{}) fmt.ScanState {
 }
 
 
+type GijitShadow_Proxy_ScanState struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ScanState(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ScanState{call: call}
+}
+
+func (x *GijitShadow_Proxy_ScanState) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ScanState) ReadRune() (rune, int, error) {
+	out := x.call("ReadRune")
+	r0, _ := out[0].Interface().(rune)
+	r1, _ := out[1].Interface().(int)
+	r2, _ := out[2].Interface().(error)
+	return r0, r1, r2
+}
+
+func (x *GijitShadow_Proxy_ScanState) SkipSpace()  {
+	x.call("SkipSpace")
+}
+
+func (x *GijitShadow_Proxy_ScanState) Token(a0 bool, a1 func(rune) bool) ([]byte, error) {
+	out := x.call("Token", a0, a1)
+	r0, _ := out[0].Interface().([]byte)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ScanState) UnreadRune() error {
+	out := x.call("UnreadRune")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_ScanState) Width() (int, bool) {
+	out := x.call("Width")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(bool)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_Scanner(x interface{}) (y fmt.Scanner, b bool) {
 	y, b = x.(fmt.Scanner)
 	return
@@ -73,6 +159,21 @@ func GijitShadow_InterfaceConvertTo1_Scanner(x interface{}) fmt.Scanner {
 }
 
 
+type GijitShadow_Proxy_Scanner struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Scanner(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Scanner{call: call}
+}
+
+func (x *GijitShadow_Proxy_Scanner) Scan(a0 fmt.ScanState, a1 rune) error {
+	out := x.call("Scan", a0, a1)
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+
 func GijitShadow_InterfaceConvertTo2_State(x interface{}) (y fmt.State, b bool) {
 	y, b = x.(fmt.State)
 	return
@@ -83,6 +184,42 @@ func GijitShadow_InterfaceConvertTo1_State(x interface{}) fmt.State {
 }
 
 
+type GijitShadow_Proxy_State struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_State(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_State{call: call}
+}
+
+func (x *GijitShadow_Proxy_State) Flag(a0 int) bool {
+	out := x.call("Flag", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_State) Precision() (int, bool) {
+	out := x.call("Precision")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(bool)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_State) Width() (int, bool) {
+	out := x.call("Width")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(bool)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_State) Write(a0 []byte) (int, error) {
+	out := x.call("Write", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_Stringer(x interface{}) (y fmt.Stringer, b bool) {
 	y, b = x.(fmt.Stringer)
 	return
@@ -93,9 +230,24 @@ func GijitShadow_InterfaceConvertTo1_Stringer(x interface{}) fmt.Stringer {
 }
 
 
+type GijitShadow_Proxy_Stringer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Stringer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Stringer{call: call}
+}
+
+func (x *GijitShadow_Proxy_Stringer) String() string {
+	out := x.call("String")
+	r0, _ := out[0].Interface().(string)
+	return r0
+}
+
+
 
  func InitLua() string {
   return `
-__type__.fmt ={};
+__type__["fmt"] ={};
 
 `}
\ No newline at end of file
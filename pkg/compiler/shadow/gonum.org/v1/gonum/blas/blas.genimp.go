@@ -1,26 +1,46 @@
 package shadow_blas
 
-import "gonum.org/v1/gonum/blas"
+import (
+	"gonum.org/v1/gonum/blas"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
 	Pkg["Complex128"] = GijitShadow_InterfaceConvertTo2_Complex128
+	Proxy[reflect.TypeOf((*blas.Complex128)(nil)).Elem()] = GijitShadow_NewProxy_Complex128
 	Pkg["Complex128Level1"] = GijitShadow_InterfaceConvertTo2_Complex128Level1
+	Proxy[reflect.TypeOf((*blas.Complex128Level1)(nil)).Elem()] = GijitShadow_NewProxy_Complex128Level1
 	Pkg["Complex128Level2"] = GijitShadow_InterfaceConvertTo2_Complex128Level2
+	Proxy[reflect.TypeOf((*blas.Complex128Level2)(nil)).Elem()] = GijitShadow_NewProxy_Complex128Level2
 	Pkg["Complex128Level3"] = GijitShadow_InterfaceConvertTo2_Complex128Level3
+	Proxy[reflect.TypeOf((*blas.Complex128Level3)(nil)).Elem()] = GijitShadow_NewProxy_Complex128Level3
 	Pkg["Complex64"] = GijitShadow_InterfaceConvertTo2_Complex64
+	Proxy[reflect.TypeOf((*blas.Complex64)(nil)).Elem()] = GijitShadow_NewProxy_Complex64
 	Pkg["Complex64Level1"] = GijitShadow_InterfaceConvertTo2_Complex64Level1
+	Proxy[reflect.TypeOf((*blas.Complex64Level1)(nil)).Elem()] = GijitShadow_NewProxy_Complex64Level1
 	Pkg["Complex64Level2"] = GijitShadow_InterfaceConvertTo2_Complex64Level2
+	Proxy[reflect.TypeOf((*blas.Complex64Level2)(nil)).Elem()] = GijitShadow_NewProxy_Complex64Level2
 	Pkg["Complex64Level3"] = GijitShadow_InterfaceConvertTo2_Complex64Level3
+	Proxy[reflect.TypeOf((*blas.Complex64Level3)(nil)).Elem()] = GijitShadow_NewProxy_Complex64Level3
 	Pkg["Float32"] = GijitShadow_InterfaceConvertTo2_Float32
+	Proxy[reflect.TypeOf((*blas.Float32)(nil)).Elem()] = GijitShadow_NewProxy_Float32
 	Pkg["Float32Level1"] = GijitShadow_InterfaceConvertTo2_Float32Level1
+	Proxy[reflect.TypeOf((*blas.Float32Level1)(nil)).Elem()] = GijitShadow_NewProxy_Float32Level1
 	Pkg["Float32Level2"] = GijitShadow_InterfaceConvertTo2_Float32Level2
+	Proxy[reflect.TypeOf((*blas.Float32Level2)(nil)).Elem()] = GijitShadow_NewProxy_Float32Level2
 	Pkg["Float32Level3"] = GijitShadow_InterfaceConvertTo2_Float32Level3
+	Proxy[reflect.TypeOf((*blas.Float32Level3)(nil)).Elem()] = GijitShadow_NewProxy_Float32Level3
 	Pkg["Float64"] = GijitShadow_InterfaceConvertTo2_Float64
+	Proxy[reflect.TypeOf((*blas.Float64)(nil)).Elem()] = GijitShadow_NewProxy_Float64
 	Pkg["Float64Level1"] = GijitShadow_InterfaceConvertTo2_Float64Level1
+	Proxy[reflect.TypeOf((*blas.Float64Level1)(nil)).Elem()] = GijitShadow_NewProxy_Float64Level1
 	Pkg["Float64Level2"] = GijitShadow_InterfaceConvertTo2_Float64Level2
+	Proxy[reflect.TypeOf((*blas.Float64Level2)(nil)).Elem()] = GijitShadow_NewProxy_Float64Level2
 	Pkg["Float64Level3"] = GijitShadow_InterfaceConvertTo2_Float64Level3
+	Proxy[reflect.TypeOf((*blas.Float64Level3)(nil)).Elem()] = GijitShadow_NewProxy_Float64Level3
 
 }
 func GijitShadow_InterfaceConvertTo2_Complex128(x interface{}) (y blas.Complex128, b bool) {
@@ -32,89 +52,1029 @@ func GijitShadow_InterfaceConvertTo1_Complex128(x interface{}) blas.Complex128 {
 	return x.(blas.Complex128)
 }
 
+type GijitShadow_Proxy_Complex128 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex128(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex128{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex128) Dzasum(a0 int, a1 []complex128, a2 int) float64 {
+	out := x.call("Dzasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128) Dznrm2(a0 int, a1 []complex128, a2 int) float64 {
+	out := x.call("Dznrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128) Izamax(a0 int, a1 []complex128, a2 int) int {
+	out := x.call("Izamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zaxpy(a0 int, a1 complex128, a2 []complex128, a3 int, a4 []complex128, a5 int) {
+	x.call("Zaxpy", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zcopy(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) {
+	x.call("Zcopy", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zdotc(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) complex128 {
+	out := x.call("Zdotc", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex128)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zdotu(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) complex128 {
+	out := x.call("Zdotu", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex128)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zdscal(a0 int, a1 float64, a2 []complex128, a3 int) {
+	x.call("Zdscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 complex128, a6 []complex128, a7 int, a8 []complex128, a9 int, a10 complex128, a11 []complex128, a12 int) {
+	x.call("Zgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 complex128, a6 []complex128, a7 int, a8 []complex128, a9 int, a10 complex128, a11 []complex128, a12 int) {
+	x.call("Zgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zgemv(a0 blas.Transpose, a1 int, a2 int, a3 complex128, a4 []complex128, a5 int, a6 []complex128, a7 int, a8 complex128, a9 []complex128, a10 int) {
+	x.call("Zgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zgerc(a0 int, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Zgerc", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zgeru(a0 int, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Zgeru", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zhbmv(a0 blas.Uplo, a1 int, a2 int, a3 complex128, a4 []complex128, a5 int, a6 []complex128, a7 int, a8 complex128, a9 []complex128, a10 int) {
+	x.call("Zhbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zhemm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 complex128, a10 []complex128, a11 int) {
+	x.call("Zhemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zhemv(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 complex128, a8 []complex128, a9 int) {
+	x.call("Zhemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zher(a0 blas.Uplo, a1 int, a2 float64, a3 []complex128, a4 int, a5 []complex128, a6 int) {
+	x.call("Zher", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zher2(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Zher2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zher2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 float64, a10 []complex128, a11 int) {
+	x.call("Zher2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zherk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float64, a5 []complex128, a6 int, a7 float64, a8 []complex128, a9 int) {
+	x.call("Zherk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zhpmv(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 []complex128, a5 int, a6 complex128, a7 []complex128, a8 int) {
+	x.call("Zhpmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zhpr(a0 blas.Uplo, a1 int, a2 float64, a3 []complex128, a4 int, a5 []complex128) {
+	x.call("Zhpr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zhpr2(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128) {
+	x.call("Zhpr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zscal(a0 int, a1 complex128, a2 []complex128, a3 int) {
+	x.call("Zscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zswap(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) {
+	x.call("Zswap", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zsymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 complex128, a10 []complex128, a11 int) {
+	x.call("Zsymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zsyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 complex128, a10 []complex128, a11 int) {
+	x.call("Zsyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Zsyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 complex128, a8 []complex128, a9 int) {
+	x.call("Zsyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Ztbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Ztbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 []complex128, a6 int) {
+	x.call("Ztpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 []complex128, a6 int) {
+	x.call("Ztpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztrmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex128, a7 []complex128, a8 int, a9 []complex128, a10 int) {
+	x.call("Ztrmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztrmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 int, a6 []complex128, a7 int) {
+	x.call("Ztrmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztrsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex128, a7 []complex128, a8 int, a9 []complex128, a10 int) {
+	x.call("Ztrsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex128) Ztrsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 int, a6 []complex128, a7 int) {
+	x.call("Ztrsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
 func GijitShadow_InterfaceConvertTo2_Complex128Level1(x interface{}) (y blas.Complex128Level1, b bool) {
 	y, b = x.(blas.Complex128Level1)
 	return
 }
 
-func GijitShadow_InterfaceConvertTo1_Complex128Level1(x interface{}) blas.Complex128Level1 {
-	return x.(blas.Complex128Level1)
+func GijitShadow_InterfaceConvertTo1_Complex128Level1(x interface{}) blas.Complex128Level1 {
+	return x.(blas.Complex128Level1)
+}
+
+type GijitShadow_Proxy_Complex128Level1 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex128Level1(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex128Level1{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Dzasum(a0 int, a1 []complex128, a2 int) float64 {
+	out := x.call("Dzasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Dznrm2(a0 int, a1 []complex128, a2 int) float64 {
+	out := x.call("Dznrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Izamax(a0 int, a1 []complex128, a2 int) int {
+	out := x.call("Izamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Zaxpy(a0 int, a1 complex128, a2 []complex128, a3 int, a4 []complex128, a5 int) {
+	x.call("Zaxpy", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Zcopy(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) {
+	x.call("Zcopy", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Zdotc(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) complex128 {
+	out := x.call("Zdotc", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex128)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Zdotu(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) complex128 {
+	out := x.call("Zdotu", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex128)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Zdscal(a0 int, a1 float64, a2 []complex128, a3 int) {
+	x.call("Zdscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Zscal(a0 int, a1 complex128, a2 []complex128, a3 int) {
+	x.call("Zscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level1) Zswap(a0 int, a1 []complex128, a2 int, a3 []complex128, a4 int) {
+	x.call("Zswap", a0, a1, a2, a3, a4)
+}
+
+func GijitShadow_InterfaceConvertTo2_Complex128Level2(x interface{}) (y blas.Complex128Level2, b bool) {
+	y, b = x.(blas.Complex128Level2)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Complex128Level2(x interface{}) blas.Complex128Level2 {
+	return x.(blas.Complex128Level2)
+}
+
+type GijitShadow_Proxy_Complex128Level2 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex128Level2(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex128Level2{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 complex128, a6 []complex128, a7 int, a8 []complex128, a9 int, a10 complex128, a11 []complex128, a12 int) {
+	x.call("Zgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zgemv(a0 blas.Transpose, a1 int, a2 int, a3 complex128, a4 []complex128, a5 int, a6 []complex128, a7 int, a8 complex128, a9 []complex128, a10 int) {
+	x.call("Zgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zgerc(a0 int, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Zgerc", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zgeru(a0 int, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Zgeru", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zhbmv(a0 blas.Uplo, a1 int, a2 int, a3 complex128, a4 []complex128, a5 int, a6 []complex128, a7 int, a8 complex128, a9 []complex128, a10 int) {
+	x.call("Zhbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zhemv(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 complex128, a8 []complex128, a9 int) {
+	x.call("Zhemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zher(a0 blas.Uplo, a1 int, a2 float64, a3 []complex128, a4 int, a5 []complex128, a6 int) {
+	x.call("Zher", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zher2(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Zher2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zhpmv(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 []complex128, a5 int, a6 complex128, a7 []complex128, a8 int) {
+	x.call("Zhpmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zhpr(a0 blas.Uplo, a1 int, a2 float64, a3 []complex128, a4 int, a5 []complex128) {
+	x.call("Zhpr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Zhpr2(a0 blas.Uplo, a1 int, a2 complex128, a3 []complex128, a4 int, a5 []complex128, a6 int, a7 []complex128) {
+	x.call("Zhpr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Ztbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Ztbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Ztbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex128, a6 int, a7 []complex128, a8 int) {
+	x.call("Ztbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Ztpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 []complex128, a6 int) {
+	x.call("Ztpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Ztpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 []complex128, a6 int) {
+	x.call("Ztpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Ztrmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 int, a6 []complex128, a7 int) {
+	x.call("Ztrmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level2) Ztrsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex128, a5 int, a6 []complex128, a7 int) {
+	x.call("Ztrsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func GijitShadow_InterfaceConvertTo2_Complex128Level3(x interface{}) (y blas.Complex128Level3, b bool) {
+	y, b = x.(blas.Complex128Level3)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Complex128Level3(x interface{}) blas.Complex128Level3 {
+	return x.(blas.Complex128Level3)
+}
+
+type GijitShadow_Proxy_Complex128Level3 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex128Level3(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex128Level3{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Zgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 complex128, a6 []complex128, a7 int, a8 []complex128, a9 int, a10 complex128, a11 []complex128, a12 int) {
+	x.call("Zgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Zhemm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 complex128, a10 []complex128, a11 int) {
+	x.call("Zhemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Zher2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 float64, a10 []complex128, a11 int) {
+	x.call("Zher2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Zherk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float64, a5 []complex128, a6 int, a7 float64, a8 []complex128, a9 int) {
+	x.call("Zherk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Zsymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 complex128, a10 []complex128, a11 int) {
+	x.call("Zsymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Zsyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 []complex128, a8 int, a9 complex128, a10 []complex128, a11 int) {
+	x.call("Zsyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Zsyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex128, a5 []complex128, a6 int, a7 complex128, a8 []complex128, a9 int) {
+	x.call("Zsyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Ztrmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex128, a7 []complex128, a8 int, a9 []complex128, a10 int) {
+	x.call("Ztrmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex128Level3) Ztrsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex128, a7 []complex128, a8 int, a9 []complex128, a10 int) {
+	x.call("Ztrsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func GijitShadow_InterfaceConvertTo2_Complex64(x interface{}) (y blas.Complex64, b bool) {
+	y, b = x.(blas.Complex64)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Complex64(x interface{}) blas.Complex64 {
+	return x.(blas.Complex64)
+}
+
+type GijitShadow_Proxy_Complex64 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex64(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex64{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex64) Caxpy(a0 int, a1 complex64, a2 []complex64, a3 int, a4 []complex64, a5 int) {
+	x.call("Caxpy", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ccopy(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) {
+	x.call("Ccopy", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cdotc(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) complex64 {
+	out := x.call("Cdotc", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cdotu(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) complex64 {
+	out := x.call("Cdotu", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 complex64, a6 []complex64, a7 int, a8 []complex64, a9 int, a10 complex64, a11 []complex64, a12 int) {
+	x.call("Cgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 complex64, a6 []complex64, a7 int, a8 []complex64, a9 int, a10 complex64, a11 []complex64, a12 int) {
+	x.call("Cgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cgemv(a0 blas.Transpose, a1 int, a2 int, a3 complex64, a4 []complex64, a5 int, a6 []complex64, a7 int, a8 complex64, a9 []complex64, a10 int) {
+	x.call("Cgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cgerc(a0 int, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Cgerc", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cgeru(a0 int, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Cgeru", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Chbmv(a0 blas.Uplo, a1 int, a2 int, a3 complex64, a4 []complex64, a5 int, a6 []complex64, a7 int, a8 complex64, a9 []complex64, a10 int) {
+	x.call("Chbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Chemm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 complex64, a10 []complex64, a11 int) {
+	x.call("Chemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Chemv(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 complex64, a8 []complex64, a9 int) {
+	x.call("Chemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cher(a0 blas.Uplo, a1 int, a2 float32, a3 []complex64, a4 int, a5 []complex64, a6 int) {
+	x.call("Cher", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cher2(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Cher2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cher2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 float32, a10 []complex64, a11 int) {
+	x.call("Cher2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cherk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float32, a5 []complex64, a6 int, a7 float32, a8 []complex64, a9 int) {
+	x.call("Cherk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Chpmv(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 []complex64, a5 int, a6 complex64, a7 []complex64, a8 int) {
+	x.call("Chpmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Chpr(a0 blas.Uplo, a1 int, a2 float32, a3 []complex64, a4 int, a5 []complex64) {
+	x.call("Chpr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Chpr2(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64) {
+	x.call("Chpr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cscal(a0 int, a1 complex64, a2 []complex64, a3 int) {
+	x.call("Cscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Csscal(a0 int, a1 float32, a2 []complex64, a3 int) {
+	x.call("Csscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Cswap(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) {
+	x.call("Cswap", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Csymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 complex64, a10 []complex64, a11 int) {
+	x.call("Csymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Csyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 complex64, a10 []complex64, a11 int) {
+	x.call("Csyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Csyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 complex64, a8 []complex64, a9 int) {
+	x.call("Csyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Ctbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Ctbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 []complex64, a6 int) {
+	x.call("Ctpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 []complex64, a6 int) {
+	x.call("Ctpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctrmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex64, a7 []complex64, a8 int, a9 []complex64, a10 int) {
+	x.call("Ctrmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctrmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 int, a6 []complex64, a7 int) {
+	x.call("Ctrmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctrsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex64, a7 []complex64, a8 int, a9 []complex64, a10 int) {
+	x.call("Ctrsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Ctrsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 int, a6 []complex64, a7 int) {
+	x.call("Ctrsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex64) Icamax(a0 int, a1 []complex64, a2 int) int {
+	out := x.call("Icamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64) Scasum(a0 int, a1 []complex64, a2 int) float32 {
+	out := x.call("Scasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64) Scnrm2(a0 int, a1 []complex64, a2 int) float32 {
+	out := x.call("Scnrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_Complex64Level1(x interface{}) (y blas.Complex64Level1, b bool) {
+	y, b = x.(blas.Complex64Level1)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Complex64Level1(x interface{}) blas.Complex64Level1 {
+	return x.(blas.Complex64Level1)
+}
+
+type GijitShadow_Proxy_Complex64Level1 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex64Level1(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex64Level1{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Caxpy(a0 int, a1 complex64, a2 []complex64, a3 int, a4 []complex64, a5 int) {
+	x.call("Caxpy", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Ccopy(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) {
+	x.call("Ccopy", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Cdotc(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) complex64 {
+	out := x.call("Cdotc", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Cdotu(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) complex64 {
+	out := x.call("Cdotu", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(complex64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Cscal(a0 int, a1 complex64, a2 []complex64, a3 int) {
+	x.call("Cscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Csscal(a0 int, a1 float32, a2 []complex64, a3 int) {
+	x.call("Csscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Cswap(a0 int, a1 []complex64, a2 int, a3 []complex64, a4 int) {
+	x.call("Cswap", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Icamax(a0 int, a1 []complex64, a2 int) int {
+	out := x.call("Icamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Scasum(a0 int, a1 []complex64, a2 int) float32 {
+	out := x.call("Scasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Complex64Level1) Scnrm2(a0 int, a1 []complex64, a2 int) float32 {
+	out := x.call("Scnrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_Complex64Level2(x interface{}) (y blas.Complex64Level2, b bool) {
+	y, b = x.(blas.Complex64Level2)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Complex64Level2(x interface{}) blas.Complex64Level2 {
+	return x.(blas.Complex64Level2)
+}
+
+type GijitShadow_Proxy_Complex64Level2 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex64Level2(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex64Level2{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Cgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 complex64, a6 []complex64, a7 int, a8 []complex64, a9 int, a10 complex64, a11 []complex64, a12 int) {
+	x.call("Cgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Cgemv(a0 blas.Transpose, a1 int, a2 int, a3 complex64, a4 []complex64, a5 int, a6 []complex64, a7 int, a8 complex64, a9 []complex64, a10 int) {
+	x.call("Cgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Cgerc(a0 int, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Cgerc", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Cgeru(a0 int, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Cgeru", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Chbmv(a0 blas.Uplo, a1 int, a2 int, a3 complex64, a4 []complex64, a5 int, a6 []complex64, a7 int, a8 complex64, a9 []complex64, a10 int) {
+	x.call("Chbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Chemv(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 complex64, a8 []complex64, a9 int) {
+	x.call("Chemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Cher(a0 blas.Uplo, a1 int, a2 float32, a3 []complex64, a4 int, a5 []complex64, a6 int) {
+	x.call("Cher", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Cher2(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Cher2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Chpmv(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 []complex64, a5 int, a6 complex64, a7 []complex64, a8 int) {
+	x.call("Chpmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Chpr(a0 blas.Uplo, a1 int, a2 float32, a3 []complex64, a4 int, a5 []complex64) {
+	x.call("Chpr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Chpr2(a0 blas.Uplo, a1 int, a2 complex64, a3 []complex64, a4 int, a5 []complex64, a6 int, a7 []complex64) {
+	x.call("Chpr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Ctbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Ctbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Ctbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []complex64, a6 int, a7 []complex64, a8 int) {
+	x.call("Ctbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Ctpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 []complex64, a6 int) {
+	x.call("Ctpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Ctpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 []complex64, a6 int) {
+	x.call("Ctpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Ctrmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 int, a6 []complex64, a7 int) {
+	x.call("Ctrmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level2) Ctrsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []complex64, a5 int, a6 []complex64, a7 int) {
+	x.call("Ctrsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func GijitShadow_InterfaceConvertTo2_Complex64Level3(x interface{}) (y blas.Complex64Level3, b bool) {
+	y, b = x.(blas.Complex64Level3)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Complex64Level3(x interface{}) blas.Complex64Level3 {
+	return x.(blas.Complex64Level3)
+}
+
+type GijitShadow_Proxy_Complex64Level3 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex64Level3(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex64Level3{call: call}
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Cgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 complex64, a6 []complex64, a7 int, a8 []complex64, a9 int, a10 complex64, a11 []complex64, a12 int) {
+	x.call("Cgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Chemm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 complex64, a10 []complex64, a11 int) {
+	x.call("Chemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Cher2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 float32, a10 []complex64, a11 int) {
+	x.call("Cher2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Cherk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float32, a5 []complex64, a6 int, a7 float32, a8 []complex64, a9 int) {
+	x.call("Cherk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Csymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 complex64, a10 []complex64, a11 int) {
+	x.call("Csymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Csyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 []complex64, a8 int, a9 complex64, a10 []complex64, a11 int) {
+	x.call("Csyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Csyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 complex64, a5 []complex64, a6 int, a7 complex64, a8 []complex64, a9 int) {
+	x.call("Csyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Ctrmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex64, a7 []complex64, a8 int, a9 []complex64, a10 int) {
+	x.call("Ctrmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Complex64Level3) Ctrsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 complex64, a7 []complex64, a8 int, a9 []complex64, a10 int) {
+	x.call("Ctrsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func GijitShadow_NewStruct_DrotmParams() *blas.DrotmParams {
+	return &blas.DrotmParams{}
+}
+
+func GijitShadow_InterfaceConvertTo2_Float32(x interface{}) (y blas.Float32, b bool) {
+	y, b = x.(blas.Float32)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Float32(x interface{}) blas.Float32 {
+	return x.(blas.Float32)
+}
+
+type GijitShadow_Proxy_Float32 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float32(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float32{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float32) Dsdot(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) float64 {
+	out := x.call("Dsdot", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float32) Isamax(a0 int, a1 []float32, a2 int) int {
+	out := x.call("Isamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float32) Sasum(a0 int, a1 []float32, a2 int) float32 {
+	out := x.call("Sasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float32) Saxpy(a0 int, a1 float32, a2 []float32, a3 int, a4 []float32, a5 int) {
+	x.call("Saxpy", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float32) Scopy(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) {
+	x.call("Scopy", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sdot(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) float32 {
+	out := x.call("Sdot", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float32) Sdsdot(a0 int, a1 float32, a2 []float32, a3 int, a4 []float32, a5 int) float32 {
+	out := x.call("Sdsdot", a0, a1, a2, a3, a4, a5)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float32) Sgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 float32, a6 []float32, a7 int, a8 []float32, a9 int, a10 float32, a11 []float32, a12 int) {
+	x.call("Sgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 float32, a6 []float32, a7 int, a8 []float32, a9 int, a10 float32, a11 []float32, a12 int) {
+	x.call("Sgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sgemv(a0 blas.Transpose, a1 int, a2 int, a3 float32, a4 []float32, a5 int, a6 []float32, a7 int, a8 float32, a9 []float32, a10 int) {
+	x.call("Sgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sger(a0 int, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Sger", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32) Snrm2(a0 int, a1 []float32, a2 int) float32 {
+	out := x.call("Snrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float32) Srot(a0 int, a1 []float32, a2 int, a3 []float32, a4 int, a5 float32, a6 float32) {
+	x.call("Srot", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float32) Srotg(a0 float32, a1 float32) (float32, float32, float32, float32) {
+	out := x.call("Srotg", a0, a1)
+	r0, _ := out[0].Interface().(float32)
+	r1, _ := out[1].Interface().(float32)
+	r2, _ := out[2].Interface().(float32)
+	r3, _ := out[3].Interface().(float32)
+	return r0, r1, r2, r3
+}
+
+func (x *GijitShadow_Proxy_Float32) Srotm(a0 int, a1 []float32, a2 int, a3 []float32, a4 int, a5 blas.SrotmParams) {
+	x.call("Srotm", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float32) Srotmg(a0 float32, a1 float32, a2 float32, a3 float32) (blas.SrotmParams, float32, float32, float32) {
+	out := x.call("Srotmg", a0, a1, a2, a3)
+	r0, _ := out[0].Interface().(blas.SrotmParams)
+	r1, _ := out[1].Interface().(float32)
+	r2, _ := out[2].Interface().(float32)
+	r3, _ := out[3].Interface().(float32)
+	return r0, r1, r2, r3
+}
+
+func (x *GijitShadow_Proxy_Float32) Ssbmv(a0 blas.Uplo, a1 int, a2 int, a3 float32, a4 []float32, a5 int, a6 []float32, a7 int, a8 float32, a9 []float32, a10 int) {
+	x.call("Ssbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sscal(a0 int, a1 float32, a2 []float32, a3 int) {
+	x.call("Sscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sspmv(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 []float32, a5 int, a6 float32, a7 []float32, a8 int) {
+	x.call("Sspmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sspr(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32) {
+	x.call("Sspr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sspr2(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 []float32) {
+	x.call("Sspr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float32) Sswap(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) {
+	x.call("Sswap", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Float32) Ssymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 float32, a5 []float32, a6 int, a7 []float32, a8 int, a9 float32, a10 []float32, a11 int) {
+	x.call("Ssymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float32) Ssymv(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 float32, a8 []float32, a9 int) {
+	x.call("Ssymv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float32) Ssyr(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int) {
+	x.call("Ssyr", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float32) Ssyr2(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Ssyr2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32) Ssyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float32, a5 []float32, a6 int, a7 []float32, a8 int, a9 float32, a10 []float32, a11 int) {
+	x.call("Ssyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float32) Ssyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float32, a5 []float32, a6 int, a7 float32, a8 []float32, a9 int) {
+	x.call("Ssyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float32) Stbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Stbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32) Stbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Stbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32) Stpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 []float32, a6 int) {
+	x.call("Stpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float32) Stpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 []float32, a6 int) {
+	x.call("Stpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float32) Strmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float32, a7 []float32, a8 int, a9 []float32, a10 int) {
+	x.call("Strmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float32) Strmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 int, a6 []float32, a7 int) {
+	x.call("Strmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float32) Strsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float32, a7 []float32, a8 int, a9 []float32, a10 int) {
+	x.call("Strsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
 }
 
-func GijitShadow_InterfaceConvertTo2_Complex128Level2(x interface{}) (y blas.Complex128Level2, b bool) {
-	y, b = x.(blas.Complex128Level2)
+func (x *GijitShadow_Proxy_Float32) Strsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 int, a6 []float32, a7 int) {
+	x.call("Strsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func GijitShadow_InterfaceConvertTo2_Float32Level1(x interface{}) (y blas.Float32Level1, b bool) {
+	y, b = x.(blas.Float32Level1)
 	return
 }
 
-func GijitShadow_InterfaceConvertTo1_Complex128Level2(x interface{}) blas.Complex128Level2 {
-	return x.(blas.Complex128Level2)
+func GijitShadow_InterfaceConvertTo1_Float32Level1(x interface{}) blas.Float32Level1 {
+	return x.(blas.Float32Level1)
 }
 
-func GijitShadow_InterfaceConvertTo2_Complex128Level3(x interface{}) (y blas.Complex128Level3, b bool) {
-	y, b = x.(blas.Complex128Level3)
-	return
+type GijitShadow_Proxy_Float32Level1 struct {
+	call func(string, ...interface{}) []reflect.Value
 }
 
-func GijitShadow_InterfaceConvertTo1_Complex128Level3(x interface{}) blas.Complex128Level3 {
-	return x.(blas.Complex128Level3)
+func GijitShadow_NewProxy_Float32Level1(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float32Level1{call: call}
 }
 
-func GijitShadow_InterfaceConvertTo2_Complex64(x interface{}) (y blas.Complex64, b bool) {
-	y, b = x.(blas.Complex64)
-	return
+func (x *GijitShadow_Proxy_Float32Level1) Dsdot(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) float64 {
+	out := x.call("Dsdot", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(float64)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_Complex64(x interface{}) blas.Complex64 {
-	return x.(blas.Complex64)
+func (x *GijitShadow_Proxy_Float32Level1) Isamax(a0 int, a1 []float32, a2 int) int {
+	out := x.call("Isamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_Complex64Level1(x interface{}) (y blas.Complex64Level1, b bool) {
-	y, b = x.(blas.Complex64Level1)
-	return
+func (x *GijitShadow_Proxy_Float32Level1) Sasum(a0 int, a1 []float32, a2 int) float32 {
+	out := x.call("Sasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_Complex64Level1(x interface{}) blas.Complex64Level1 {
-	return x.(blas.Complex64Level1)
+func (x *GijitShadow_Proxy_Float32Level1) Saxpy(a0 int, a1 float32, a2 []float32, a3 int, a4 []float32, a5 int) {
+	x.call("Saxpy", a0, a1, a2, a3, a4, a5)
 }
 
-func GijitShadow_InterfaceConvertTo2_Complex64Level2(x interface{}) (y blas.Complex64Level2, b bool) {
-	y, b = x.(blas.Complex64Level2)
-	return
+func (x *GijitShadow_Proxy_Float32Level1) Scopy(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) {
+	x.call("Scopy", a0, a1, a2, a3, a4)
 }
 
-func GijitShadow_InterfaceConvertTo1_Complex64Level2(x interface{}) blas.Complex64Level2 {
-	return x.(blas.Complex64Level2)
+func (x *GijitShadow_Proxy_Float32Level1) Sdot(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) float32 {
+	out := x.call("Sdot", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(float32)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_Complex64Level3(x interface{}) (y blas.Complex64Level3, b bool) {
-	y, b = x.(blas.Complex64Level3)
-	return
+func (x *GijitShadow_Proxy_Float32Level1) Sdsdot(a0 int, a1 float32, a2 []float32, a3 int, a4 []float32, a5 int) float32 {
+	out := x.call("Sdsdot", a0, a1, a2, a3, a4, a5)
+	r0, _ := out[0].Interface().(float32)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_Complex64Level3(x interface{}) blas.Complex64Level3 {
-	return x.(blas.Complex64Level3)
+func (x *GijitShadow_Proxy_Float32Level1) Snrm2(a0 int, a1 []float32, a2 int) float32 {
+	out := x.call("Snrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float32)
+	return r0
 }
 
-func GijitShadow_NewStruct_DrotmParams() *blas.DrotmParams {
-	return &blas.DrotmParams{}
+func (x *GijitShadow_Proxy_Float32Level1) Srot(a0 int, a1 []float32, a2 int, a3 []float32, a4 int, a5 float32, a6 float32) {
+	x.call("Srot", a0, a1, a2, a3, a4, a5, a6)
 }
 
-func GijitShadow_InterfaceConvertTo2_Float32(x interface{}) (y blas.Float32, b bool) {
-	y, b = x.(blas.Float32)
-	return
+func (x *GijitShadow_Proxy_Float32Level1) Srotg(a0 float32, a1 float32) (float32, float32, float32, float32) {
+	out := x.call("Srotg", a0, a1)
+	r0, _ := out[0].Interface().(float32)
+	r1, _ := out[1].Interface().(float32)
+	r2, _ := out[2].Interface().(float32)
+	r3, _ := out[3].Interface().(float32)
+	return r0, r1, r2, r3
 }
 
-func GijitShadow_InterfaceConvertTo1_Float32(x interface{}) blas.Float32 {
-	return x.(blas.Float32)
+func (x *GijitShadow_Proxy_Float32Level1) Srotm(a0 int, a1 []float32, a2 int, a3 []float32, a4 int, a5 blas.SrotmParams) {
+	x.call("Srotm", a0, a1, a2, a3, a4, a5)
 }
 
-func GijitShadow_InterfaceConvertTo2_Float32Level1(x interface{}) (y blas.Float32Level1, b bool) {
-	y, b = x.(blas.Float32Level1)
-	return
+func (x *GijitShadow_Proxy_Float32Level1) Srotmg(a0 float32, a1 float32, a2 float32, a3 float32) (blas.SrotmParams, float32, float32, float32) {
+	out := x.call("Srotmg", a0, a1, a2, a3)
+	r0, _ := out[0].Interface().(blas.SrotmParams)
+	r1, _ := out[1].Interface().(float32)
+	r2, _ := out[2].Interface().(float32)
+	r3, _ := out[3].Interface().(float32)
+	return r0, r1, r2, r3
 }
 
-func GijitShadow_InterfaceConvertTo1_Float32Level1(x interface{}) blas.Float32Level1 {
-	return x.(blas.Float32Level1)
+func (x *GijitShadow_Proxy_Float32Level1) Sscal(a0 int, a1 float32, a2 []float32, a3 int) {
+	x.call("Sscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Float32Level1) Sswap(a0 int, a1 []float32, a2 int, a3 []float32, a4 int) {
+	x.call("Sswap", a0, a1, a2, a3, a4)
 }
 
 func GijitShadow_InterfaceConvertTo2_Float32Level2(x interface{}) (y blas.Float32Level2, b bool) {
@@ -126,6 +1086,78 @@ func GijitShadow_InterfaceConvertTo1_Float32Level2(x interface{}) blas.Float32Le
 	return x.(blas.Float32Level2)
 }
 
+type GijitShadow_Proxy_Float32Level2 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float32Level2(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float32Level2{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Sgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 float32, a6 []float32, a7 int, a8 []float32, a9 int, a10 float32, a11 []float32, a12 int) {
+	x.call("Sgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Sgemv(a0 blas.Transpose, a1 int, a2 int, a3 float32, a4 []float32, a5 int, a6 []float32, a7 int, a8 float32, a9 []float32, a10 int) {
+	x.call("Sgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Sger(a0 int, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Sger", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Ssbmv(a0 blas.Uplo, a1 int, a2 int, a3 float32, a4 []float32, a5 int, a6 []float32, a7 int, a8 float32, a9 []float32, a10 int) {
+	x.call("Ssbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Sspmv(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 []float32, a5 int, a6 float32, a7 []float32, a8 int) {
+	x.call("Sspmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Sspr(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32) {
+	x.call("Sspr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Sspr2(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 []float32) {
+	x.call("Sspr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Ssymv(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 float32, a8 []float32, a9 int) {
+	x.call("Ssymv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Ssyr(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int) {
+	x.call("Ssyr", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Ssyr2(a0 blas.Uplo, a1 int, a2 float32, a3 []float32, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Ssyr2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Stbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Stbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Stbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float32, a6 int, a7 []float32, a8 int) {
+	x.call("Stbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Stpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 []float32, a6 int) {
+	x.call("Stpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Stpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 []float32, a6 int) {
+	x.call("Stpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Strmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 int, a6 []float32, a7 int) {
+	x.call("Strmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float32Level2) Strsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float32, a5 int, a6 []float32, a7 int) {
+	x.call("Strsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
 func GijitShadow_InterfaceConvertTo2_Float32Level3(x interface{}) (y blas.Float32Level3, b bool) {
 	y, b = x.(blas.Float32Level3)
 	return
@@ -135,6 +1167,38 @@ func GijitShadow_InterfaceConvertTo1_Float32Level3(x interface{}) blas.Float32Le
 	return x.(blas.Float32Level3)
 }
 
+type GijitShadow_Proxy_Float32Level3 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float32Level3(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float32Level3{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float32Level3) Sgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 float32, a6 []float32, a7 int, a8 []float32, a9 int, a10 float32, a11 []float32, a12 int) {
+	x.call("Sgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float32Level3) Ssymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 float32, a5 []float32, a6 int, a7 []float32, a8 int, a9 float32, a10 []float32, a11 int) {
+	x.call("Ssymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float32Level3) Ssyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float32, a5 []float32, a6 int, a7 []float32, a8 int, a9 float32, a10 []float32, a11 int) {
+	x.call("Ssyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float32Level3) Ssyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float32, a5 []float32, a6 int, a7 float32, a8 []float32, a9 int) {
+	x.call("Ssyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float32Level3) Strmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float32, a7 []float32, a8 int, a9 []float32, a10 int) {
+	x.call("Strmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float32Level3) Strsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float32, a7 []float32, a8 int, a9 []float32, a10 int) {
+	x.call("Strsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
 func GijitShadow_InterfaceConvertTo2_Float64(x interface{}) (y blas.Float64, b bool) {
 	y, b = x.(blas.Float64)
 	return
@@ -144,6 +1208,168 @@ func GijitShadow_InterfaceConvertTo1_Float64(x interface{}) blas.Float64 {
 	return x.(blas.Float64)
 }
 
+type GijitShadow_Proxy_Float64 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float64(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float64{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float64) Dasum(a0 int, a1 []float64, a2 int) float64 {
+	out := x.call("Dasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Daxpy(a0 int, a1 float64, a2 []float64, a3 int, a4 []float64, a5 int) {
+	x.call("Daxpy", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dcopy(a0 int, a1 []float64, a2 int, a3 []float64, a4 int) {
+	x.call("Dcopy", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Float64) Ddot(a0 int, a1 []float64, a2 int, a3 []float64, a4 int) float64 {
+	out := x.call("Ddot", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 float64, a6 []float64, a7 int, a8 []float64, a9 int, a10 float64, a11 []float64, a12 int) {
+	x.call("Dgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 float64, a6 []float64, a7 int, a8 []float64, a9 int, a10 float64, a11 []float64, a12 int) {
+	x.call("Dgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgemv(a0 blas.Transpose, a1 int, a2 int, a3 float64, a4 []float64, a5 int, a6 []float64, a7 int, a8 float64, a9 []float64, a10 int) {
+	x.call("Dgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dger(a0 int, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dger", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dnrm2(a0 int, a1 []float64, a2 int) float64 {
+	out := x.call("Dnrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Drot(a0 int, a1 []float64, a2 int, a3 []float64, a4 int, a5 float64, a6 float64) {
+	x.call("Drot", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64) Drotg(a0 float64, a1 float64) (float64, float64, float64, float64) {
+	out := x.call("Drotg", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	r1, _ := out[1].Interface().(float64)
+	r2, _ := out[2].Interface().(float64)
+	r3, _ := out[3].Interface().(float64)
+	return r0, r1, r2, r3
+}
+
+func (x *GijitShadow_Proxy_Float64) Drotm(a0 int, a1 []float64, a2 int, a3 []float64, a4 int, a5 blas.DrotmParams) {
+	x.call("Drotm", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float64) Drotmg(a0 float64, a1 float64, a2 float64, a3 float64) (blas.DrotmParams, float64, float64, float64) {
+	out := x.call("Drotmg", a0, a1, a2, a3)
+	r0, _ := out[0].Interface().(blas.DrotmParams)
+	r1, _ := out[1].Interface().(float64)
+	r2, _ := out[2].Interface().(float64)
+	r3, _ := out[3].Interface().(float64)
+	return r0, r1, r2, r3
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsbmv(a0 blas.Uplo, a1 int, a2 int, a3 float64, a4 []float64, a5 int, a6 []float64, a7 int, a8 float64, a9 []float64, a10 int) {
+	x.call("Dsbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dscal(a0 int, a1 float64, a2 []float64, a3 int) {
+	x.call("Dscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dspmv(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 []float64, a5 int, a6 float64, a7 []float64, a8 int) {
+	x.call("Dspmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dspr(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64) {
+	x.call("Dspr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dspr2(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 []float64) {
+	x.call("Dspr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dswap(a0 int, a1 []float64, a2 int, a3 []float64, a4 int) {
+	x.call("Dswap", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 float64, a5 []float64, a6 int, a7 []float64, a8 int, a9 float64, a10 []float64, a11 int) {
+	x.call("Dsymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsymv(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 float64, a8 []float64, a9 int) {
+	x.call("Dsymv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsyr(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int) {
+	x.call("Dsyr", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsyr2(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dsyr2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float64, a5 []float64, a6 int, a7 []float64, a8 int, a9 float64, a10 []float64, a11 int) {
+	x.call("Dsyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float64, a5 []float64, a6 int, a7 float64, a8 []float64, a9 int) {
+	x.call("Dsyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dtbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dtbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 []float64, a6 int) {
+	x.call("Dtpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 []float64, a6 int) {
+	x.call("Dtpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtrmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float64, a7 []float64, a8 int, a9 []float64, a10 int) {
+	x.call("Dtrmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtrmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 int, a6 []float64, a7 int) {
+	x.call("Dtrmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtrsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float64, a7 []float64, a8 int, a9 []float64, a10 int) {
+	x.call("Dtrsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtrsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 int, a6 []float64, a7 int) {
+	x.call("Dtrsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float64) Idamax(a0 int, a1 []float64, a2 int) int {
+	out := x.call("Idamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_Float64Level1(x interface{}) (y blas.Float64Level1, b bool) {
 	y, b = x.(blas.Float64Level1)
 	return
@@ -153,6 +1379,80 @@ func GijitShadow_InterfaceConvertTo1_Float64Level1(x interface{}) blas.Float64Le
 	return x.(blas.Float64Level1)
 }
 
+type GijitShadow_Proxy_Float64Level1 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float64Level1(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float64Level1{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Dasum(a0 int, a1 []float64, a2 int) float64 {
+	out := x.call("Dasum", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Daxpy(a0 int, a1 float64, a2 []float64, a3 int, a4 []float64, a5 int) {
+	x.call("Daxpy", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Dcopy(a0 int, a1 []float64, a2 int, a3 []float64, a4 int) {
+	x.call("Dcopy", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Ddot(a0 int, a1 []float64, a2 int, a3 []float64, a4 int) float64 {
+	out := x.call("Ddot", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Dnrm2(a0 int, a1 []float64, a2 int) float64 {
+	out := x.call("Dnrm2", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Drot(a0 int, a1 []float64, a2 int, a3 []float64, a4 int, a5 float64, a6 float64) {
+	x.call("Drot", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Drotg(a0 float64, a1 float64) (float64, float64, float64, float64) {
+	out := x.call("Drotg", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	r1, _ := out[1].Interface().(float64)
+	r2, _ := out[2].Interface().(float64)
+	r3, _ := out[3].Interface().(float64)
+	return r0, r1, r2, r3
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Drotm(a0 int, a1 []float64, a2 int, a3 []float64, a4 int, a5 blas.DrotmParams) {
+	x.call("Drotm", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Drotmg(a0 float64, a1 float64, a2 float64, a3 float64) (blas.DrotmParams, float64, float64, float64) {
+	out := x.call("Drotmg", a0, a1, a2, a3)
+	r0, _ := out[0].Interface().(blas.DrotmParams)
+	r1, _ := out[1].Interface().(float64)
+	r2, _ := out[2].Interface().(float64)
+	r3, _ := out[3].Interface().(float64)
+	return r0, r1, r2, r3
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Dscal(a0 int, a1 float64, a2 []float64, a3 int) {
+	x.call("Dscal", a0, a1, a2, a3)
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Dswap(a0 int, a1 []float64, a2 int, a3 []float64, a4 int) {
+	x.call("Dswap", a0, a1, a2, a3, a4)
+}
+
+func (x *GijitShadow_Proxy_Float64Level1) Idamax(a0 int, a1 []float64, a2 int) int {
+	out := x.call("Idamax", a0, a1, a2)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_Float64Level2(x interface{}) (y blas.Float64Level2, b bool) {
 	y, b = x.(blas.Float64Level2)
 	return
@@ -162,6 +1462,78 @@ func GijitShadow_InterfaceConvertTo1_Float64Level2(x interface{}) blas.Float64Le
 	return x.(blas.Float64Level2)
 }
 
+type GijitShadow_Proxy_Float64Level2 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float64Level2(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float64Level2{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dgbmv(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 int, a5 float64, a6 []float64, a7 int, a8 []float64, a9 int, a10 float64, a11 []float64, a12 int) {
+	x.call("Dgbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dgemv(a0 blas.Transpose, a1 int, a2 int, a3 float64, a4 []float64, a5 int, a6 []float64, a7 int, a8 float64, a9 []float64, a10 int) {
+	x.call("Dgemv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dger(a0 int, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dger", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dsbmv(a0 blas.Uplo, a1 int, a2 int, a3 float64, a4 []float64, a5 int, a6 []float64, a7 int, a8 float64, a9 []float64, a10 int) {
+	x.call("Dsbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dspmv(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 []float64, a5 int, a6 float64, a7 []float64, a8 int) {
+	x.call("Dspmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dspr(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64) {
+	x.call("Dspr", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dspr2(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 []float64) {
+	x.call("Dspr2", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dsymv(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 float64, a8 []float64, a9 int) {
+	x.call("Dsymv", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dsyr(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int) {
+	x.call("Dsyr", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dsyr2(a0 blas.Uplo, a1 int, a2 float64, a3 []float64, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dsyr2", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dtbmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dtbmv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dtbsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) {
+	x.call("Dtbsv", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dtpmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 []float64, a6 int) {
+	x.call("Dtpmv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dtpsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 []float64, a6 int) {
+	x.call("Dtpsv", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dtrmv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 int, a6 []float64, a7 int) {
+	x.call("Dtrmv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float64Level2) Dtrsv(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 []float64, a5 int, a6 []float64, a7 int) {
+	x.call("Dtrsv", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
 func GijitShadow_InterfaceConvertTo2_Float64Level3(x interface{}) (y blas.Float64Level3, b bool) {
 	y, b = x.(blas.Float64Level3)
 	return
@@ -171,6 +1543,38 @@ func GijitShadow_InterfaceConvertTo1_Float64Level3(x interface{}) blas.Float64Le
 	return x.(blas.Float64Level3)
 }
 
+type GijitShadow_Proxy_Float64Level3 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float64Level3(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float64Level3{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float64Level3) Dgemm(a0 blas.Transpose, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 float64, a6 []float64, a7 int, a8 []float64, a9 int, a10 float64, a11 []float64, a12 int) {
+	x.call("Dgemm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+}
+
+func (x *GijitShadow_Proxy_Float64Level3) Dsymm(a0 blas.Side, a1 blas.Uplo, a2 int, a3 int, a4 float64, a5 []float64, a6 int, a7 []float64, a8 int, a9 float64, a10 []float64, a11 int) {
+	x.call("Dsymm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float64Level3) Dsyr2k(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float64, a5 []float64, a6 int, a7 []float64, a8 int, a9 float64, a10 []float64, a11 int) {
+	x.call("Dsyr2k", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float64Level3) Dsyrk(a0 blas.Uplo, a1 blas.Transpose, a2 int, a3 int, a4 float64, a5 []float64, a6 int, a7 float64, a8 []float64, a9 int) {
+	x.call("Dsyrk", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+}
+
+func (x *GijitShadow_Proxy_Float64Level3) Dtrmm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float64, a7 []float64, a8 int, a9 []float64, a10 int) {
+	x.call("Dtrmm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
+func (x *GijitShadow_Proxy_Float64Level3) Dtrsm(a0 blas.Side, a1 blas.Uplo, a2 blas.Transpose, a3 blas.Diag, a4 int, a5 int, a6 float64, a7 []float64, a8 int, a9 []float64, a10 int) {
+	x.call("Dtrsm", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10)
+}
+
 func GijitShadow_NewStruct_SrotmParams() *blas.SrotmParams {
 	return &blas.SrotmParams{}
 }
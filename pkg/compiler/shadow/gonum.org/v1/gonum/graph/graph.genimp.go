@@ -1,49 +1,90 @@
 package shadow_graph
 
-import "gonum.org/v1/gonum/graph"
+import (
+	"gonum.org/v1/gonum/graph"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
 	Pkg["Builder"] = GijitShadow_InterfaceConvertTo2_Builder
+	Proxy[reflect.TypeOf((*graph.Builder)(nil)).Elem()] = GijitShadow_NewProxy_Builder
 	Pkg["Copy"] = graph.Copy
 	Pkg["CopyWeighted"] = graph.CopyWeighted
 	Pkg["Directed"] = GijitShadow_InterfaceConvertTo2_Directed
+	Proxy[reflect.TypeOf((*graph.Directed)(nil)).Elem()] = GijitShadow_NewProxy_Directed
 	Pkg["DirectedBuilder"] = GijitShadow_InterfaceConvertTo2_DirectedBuilder
+	Proxy[reflect.TypeOf((*graph.DirectedBuilder)(nil)).Elem()] = GijitShadow_NewProxy_DirectedBuilder
 	Pkg["DirectedMultigraph"] = GijitShadow_InterfaceConvertTo2_DirectedMultigraph
+	Proxy[reflect.TypeOf((*graph.DirectedMultigraph)(nil)).Elem()] = GijitShadow_NewProxy_DirectedMultigraph
 	Pkg["DirectedMultigraphBuilder"] = GijitShadow_InterfaceConvertTo2_DirectedMultigraphBuilder
+	Proxy[reflect.TypeOf((*graph.DirectedMultigraphBuilder)(nil)).Elem()] = GijitShadow_NewProxy_DirectedMultigraphBuilder
 	Pkg["DirectedWeightedBuilder"] = GijitShadow_InterfaceConvertTo2_DirectedWeightedBuilder
+	Proxy[reflect.TypeOf((*graph.DirectedWeightedBuilder)(nil)).Elem()] = GijitShadow_NewProxy_DirectedWeightedBuilder
 	Pkg["DirectedWeightedMultigraphBuilder"] = GijitShadow_InterfaceConvertTo2_DirectedWeightedMultigraphBuilder
+	Proxy[reflect.TypeOf((*graph.DirectedWeightedMultigraphBuilder)(nil)).Elem()] = GijitShadow_NewProxy_DirectedWeightedMultigraphBuilder
 	Pkg["Edge"] = GijitShadow_InterfaceConvertTo2_Edge
+	Proxy[reflect.TypeOf((*graph.Edge)(nil)).Elem()] = GijitShadow_NewProxy_Edge
 	Pkg["EdgeAdder"] = GijitShadow_InterfaceConvertTo2_EdgeAdder
+	Proxy[reflect.TypeOf((*graph.EdgeAdder)(nil)).Elem()] = GijitShadow_NewProxy_EdgeAdder
 	Pkg["EdgeRemover"] = GijitShadow_InterfaceConvertTo2_EdgeRemover
+	Proxy[reflect.TypeOf((*graph.EdgeRemover)(nil)).Elem()] = GijitShadow_NewProxy_EdgeRemover
 	Pkg["Graph"] = GijitShadow_InterfaceConvertTo2_Graph
+	Proxy[reflect.TypeOf((*graph.Graph)(nil)).Elem()] = GijitShadow_NewProxy_Graph
 	Pkg["Line"] = GijitShadow_InterfaceConvertTo2_Line
+	Proxy[reflect.TypeOf((*graph.Line)(nil)).Elem()] = GijitShadow_NewProxy_Line
 	Pkg["LineAdder"] = GijitShadow_InterfaceConvertTo2_LineAdder
+	Proxy[reflect.TypeOf((*graph.LineAdder)(nil)).Elem()] = GijitShadow_NewProxy_LineAdder
 	Pkg["LineRemover"] = GijitShadow_InterfaceConvertTo2_LineRemover
+	Proxy[reflect.TypeOf((*graph.LineRemover)(nil)).Elem()] = GijitShadow_NewProxy_LineRemover
 	Pkg["Multigraph"] = GijitShadow_InterfaceConvertTo2_Multigraph
+	Proxy[reflect.TypeOf((*graph.Multigraph)(nil)).Elem()] = GijitShadow_NewProxy_Multigraph
 	Pkg["MultigraphBuilder"] = GijitShadow_InterfaceConvertTo2_MultigraphBuilder
+	Proxy[reflect.TypeOf((*graph.MultigraphBuilder)(nil)).Elem()] = GijitShadow_NewProxy_MultigraphBuilder
 	Pkg["Node"] = GijitShadow_InterfaceConvertTo2_Node
+	Proxy[reflect.TypeOf((*graph.Node)(nil)).Elem()] = GijitShadow_NewProxy_Node
 	Pkg["NodeAdder"] = GijitShadow_InterfaceConvertTo2_NodeAdder
+	Proxy[reflect.TypeOf((*graph.NodeAdder)(nil)).Elem()] = GijitShadow_NewProxy_NodeAdder
 	Pkg["NodeRemover"] = GijitShadow_InterfaceConvertTo2_NodeRemover
+	Proxy[reflect.TypeOf((*graph.NodeRemover)(nil)).Elem()] = GijitShadow_NewProxy_NodeRemover
 	Pkg["Undirected"] = GijitShadow_InterfaceConvertTo2_Undirected
+	Proxy[reflect.TypeOf((*graph.Undirected)(nil)).Elem()] = GijitShadow_NewProxy_Undirected
 	Pkg["UndirectedBuilder"] = GijitShadow_InterfaceConvertTo2_UndirectedBuilder
+	Proxy[reflect.TypeOf((*graph.UndirectedBuilder)(nil)).Elem()] = GijitShadow_NewProxy_UndirectedBuilder
 	Pkg["UndirectedMultigraph"] = GijitShadow_InterfaceConvertTo2_UndirectedMultigraph
+	Proxy[reflect.TypeOf((*graph.UndirectedMultigraph)(nil)).Elem()] = GijitShadow_NewProxy_UndirectedMultigraph
 	Pkg["UndirectedMultigraphBuilder"] = GijitShadow_InterfaceConvertTo2_UndirectedMultigraphBuilder
+	Proxy[reflect.TypeOf((*graph.UndirectedMultigraphBuilder)(nil)).Elem()] = GijitShadow_NewProxy_UndirectedMultigraphBuilder
 	Pkg["UndirectedWeightedBuilder"] = GijitShadow_InterfaceConvertTo2_UndirectedWeightedBuilder
+	Proxy[reflect.TypeOf((*graph.UndirectedWeightedBuilder)(nil)).Elem()] = GijitShadow_NewProxy_UndirectedWeightedBuilder
 	Pkg["UndirectedWeightedMultigraphBuilder"] = GijitShadow_InterfaceConvertTo2_UndirectedWeightedMultigraphBuilder
+	Proxy[reflect.TypeOf((*graph.UndirectedWeightedMultigraphBuilder)(nil)).Elem()] = GijitShadow_NewProxy_UndirectedWeightedMultigraphBuilder
 	Pkg["Weighted"] = GijitShadow_InterfaceConvertTo2_Weighted
+	Proxy[reflect.TypeOf((*graph.Weighted)(nil)).Elem()] = GijitShadow_NewProxy_Weighted
 	Pkg["WeightedBuilder"] = GijitShadow_InterfaceConvertTo2_WeightedBuilder
+	Proxy[reflect.TypeOf((*graph.WeightedBuilder)(nil)).Elem()] = GijitShadow_NewProxy_WeightedBuilder
 	Pkg["WeightedDirected"] = GijitShadow_InterfaceConvertTo2_WeightedDirected
+	Proxy[reflect.TypeOf((*graph.WeightedDirected)(nil)).Elem()] = GijitShadow_NewProxy_WeightedDirected
 	Pkg["WeightedDirectedMultigraph"] = GijitShadow_InterfaceConvertTo2_WeightedDirectedMultigraph
+	Proxy[reflect.TypeOf((*graph.WeightedDirectedMultigraph)(nil)).Elem()] = GijitShadow_NewProxy_WeightedDirectedMultigraph
 	Pkg["WeightedEdge"] = GijitShadow_InterfaceConvertTo2_WeightedEdge
+	Proxy[reflect.TypeOf((*graph.WeightedEdge)(nil)).Elem()] = GijitShadow_NewProxy_WeightedEdge
 	Pkg["WeightedEdgeAdder"] = GijitShadow_InterfaceConvertTo2_WeightedEdgeAdder
+	Proxy[reflect.TypeOf((*graph.WeightedEdgeAdder)(nil)).Elem()] = GijitShadow_NewProxy_WeightedEdgeAdder
 	Pkg["WeightedLine"] = GijitShadow_InterfaceConvertTo2_WeightedLine
+	Proxy[reflect.TypeOf((*graph.WeightedLine)(nil)).Elem()] = GijitShadow_NewProxy_WeightedLine
 	Pkg["WeightedLineAdder"] = GijitShadow_InterfaceConvertTo2_WeightedLineAdder
+	Proxy[reflect.TypeOf((*graph.WeightedLineAdder)(nil)).Elem()] = GijitShadow_NewProxy_WeightedLineAdder
 	Pkg["WeightedMultigraph"] = GijitShadow_InterfaceConvertTo2_WeightedMultigraph
+	Proxy[reflect.TypeOf((*graph.WeightedMultigraph)(nil)).Elem()] = GijitShadow_NewProxy_WeightedMultigraph
 	Pkg["WeightedMultigraphBuilder"] = GijitShadow_InterfaceConvertTo2_WeightedMultigraphBuilder
+	Proxy[reflect.TypeOf((*graph.WeightedMultigraphBuilder)(nil)).Elem()] = GijitShadow_NewProxy_WeightedMultigraphBuilder
 	Pkg["WeightedUndirected"] = GijitShadow_InterfaceConvertTo2_WeightedUndirected
+	Proxy[reflect.TypeOf((*graph.WeightedUndirected)(nil)).Elem()] = GijitShadow_NewProxy_WeightedUndirected
 	Pkg["WeightedUndirectedMultigraph"] = GijitShadow_InterfaceConvertTo2_WeightedUndirectedMultigraph
+	Proxy[reflect.TypeOf((*graph.WeightedUndirectedMultigraph)(nil)).Elem()] = GijitShadow_NewProxy_WeightedUndirectedMultigraph
 
 }
 func GijitShadow_InterfaceConvertTo2_Builder(x interface{}) (y graph.Builder, b bool) {
@@ -55,6 +96,34 @@ func GijitShadow_InterfaceConvertTo1_Builder(x interface{}) graph.Builder {
 	return x.(graph.Builder)
 }
 
+type GijitShadow_Proxy_Builder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Builder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Builder{call: call}
+}
+
+func (x *GijitShadow_Proxy_Builder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_Builder) NewEdge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("NewEdge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Builder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Builder) SetEdge(a0 graph.Edge) {
+	x.call("SetEdge", a0)
+}
+
 func GijitShadow_InterfaceConvertTo2_Directed(x interface{}) (y graph.Directed, b bool) {
 	y, b = x.(graph.Directed)
 	return
@@ -64,255 +133,1431 @@ func GijitShadow_InterfaceConvertTo1_Directed(x interface{}) graph.Directed {
 	return x.(graph.Directed)
 }
 
+type GijitShadow_Proxy_Directed struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Directed(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Directed{call: call}
+}
+
+func (x *GijitShadow_Proxy_Directed) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Directed) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Directed) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Directed) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Directed) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Directed) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Directed) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_DirectedBuilder(x interface{}) (y graph.DirectedBuilder, b bool) {
 	y, b = x.(graph.DirectedBuilder)
 	return
 }
 
-func GijitShadow_InterfaceConvertTo1_DirectedBuilder(x interface{}) graph.DirectedBuilder {
-	return x.(graph.DirectedBuilder)
+func GijitShadow_InterfaceConvertTo1_DirectedBuilder(x interface{}) graph.DirectedBuilder {
+	return x.(graph.DirectedBuilder)
+}
+
+type GijitShadow_Proxy_DirectedBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_DirectedBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_DirectedBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) NewEdge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("NewEdge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) SetEdge(a0 graph.Edge) {
+	x.call("SetEdge", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedBuilder) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_DirectedMultigraph(x interface{}) (y graph.DirectedMultigraph, b bool) {
+	y, b = x.(graph.DirectedMultigraph)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_DirectedMultigraph(x interface{}) graph.DirectedMultigraph {
+	return x.(graph.DirectedMultigraph)
+}
+
+type GijitShadow_Proxy_DirectedMultigraph struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_DirectedMultigraph(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_DirectedMultigraph{call: call}
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraph) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraph) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraph) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraph) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraph) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraph) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraph) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_DirectedMultigraphBuilder(x interface{}) (y graph.DirectedMultigraphBuilder, b bool) {
+	y, b = x.(graph.DirectedMultigraphBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_DirectedMultigraphBuilder(x interface{}) graph.DirectedMultigraphBuilder {
+	return x.(graph.DirectedMultigraphBuilder)
+}
+
+type GijitShadow_Proxy_DirectedMultigraphBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_DirectedMultigraphBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_DirectedMultigraphBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) NewLine(a0 graph.Node, a1 graph.Node) graph.Line {
+	out := x.call("NewLine", a0, a1)
+	r0, _ := out[0].Interface().(graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) SetLine(a0 graph.Line) {
+	x.call("SetLine", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedMultigraphBuilder) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_DirectedWeightedBuilder(x interface{}) (y graph.DirectedWeightedBuilder, b bool) {
+	y, b = x.(graph.DirectedWeightedBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_DirectedWeightedBuilder(x interface{}) graph.DirectedWeightedBuilder {
+	return x.(graph.DirectedWeightedBuilder)
+}
+
+type GijitShadow_Proxy_DirectedWeightedBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_DirectedWeightedBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_DirectedWeightedBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) NewWeightedEdge(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedEdge {
+	out := x.call("NewWeightedEdge", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) SetWeightedEdge(a0 graph.WeightedEdge) {
+	x.call("SetWeightedEdge", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedBuilder) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_DirectedWeightedMultigraphBuilder(x interface{}) (y graph.DirectedWeightedMultigraphBuilder, b bool) {
+	y, b = x.(graph.DirectedWeightedMultigraphBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_DirectedWeightedMultigraphBuilder(x interface{}) graph.DirectedWeightedMultigraphBuilder {
+	return x.(graph.DirectedWeightedMultigraphBuilder)
+}
+
+type GijitShadow_Proxy_DirectedWeightedMultigraphBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_DirectedWeightedMultigraphBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_DirectedWeightedMultigraphBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) NewWeightedLine(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedLine {
+	out := x.call("NewWeightedLine", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedLine)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) SetWeightedLine(a0 graph.WeightedLine) {
+	x.call("SetWeightedLine", a0)
+}
+
+func (x *GijitShadow_Proxy_DirectedWeightedMultigraphBuilder) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_Edge(x interface{}) (y graph.Edge, b bool) {
+	y, b = x.(graph.Edge)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Edge(x interface{}) graph.Edge {
+	return x.(graph.Edge)
+}
+
+type GijitShadow_Proxy_Edge struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Edge(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Edge{call: call}
+}
+
+func (x *GijitShadow_Proxy_Edge) From() graph.Node {
+	out := x.call("From")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Edge) To() graph.Node {
+	out := x.call("To")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_EdgeAdder(x interface{}) (y graph.EdgeAdder, b bool) {
+	y, b = x.(graph.EdgeAdder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_EdgeAdder(x interface{}) graph.EdgeAdder {
+	return x.(graph.EdgeAdder)
+}
+
+type GijitShadow_Proxy_EdgeAdder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_EdgeAdder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_EdgeAdder{call: call}
+}
+
+func (x *GijitShadow_Proxy_EdgeAdder) NewEdge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("NewEdge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_EdgeAdder) SetEdge(a0 graph.Edge) {
+	x.call("SetEdge", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_EdgeRemover(x interface{}) (y graph.EdgeRemover, b bool) {
+	y, b = x.(graph.EdgeRemover)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_EdgeRemover(x interface{}) graph.EdgeRemover {
+	return x.(graph.EdgeRemover)
+}
+
+type GijitShadow_Proxy_EdgeRemover struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_EdgeRemover(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_EdgeRemover{call: call}
+}
+
+func (x *GijitShadow_Proxy_EdgeRemover) RemoveEdge(a0 graph.Edge) {
+	x.call("RemoveEdge", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_Graph(x interface{}) (y graph.Graph, b bool) {
+	y, b = x.(graph.Graph)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Graph(x interface{}) graph.Graph {
+	return x.(graph.Graph)
+}
+
+type GijitShadow_Proxy_Graph struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Graph(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Graph{call: call}
+}
+
+func (x *GijitShadow_Proxy_Graph) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Graph) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Graph) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Graph) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Graph) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_Line(x interface{}) (y graph.Line, b bool) {
+	y, b = x.(graph.Line)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Line(x interface{}) graph.Line {
+	return x.(graph.Line)
+}
+
+type GijitShadow_Proxy_Line struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Line(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Line{call: call}
+}
+
+func (x *GijitShadow_Proxy_Line) From() graph.Node {
+	out := x.call("From")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Line) ID() int64 {
+	out := x.call("ID")
+	r0, _ := out[0].Interface().(int64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Line) To() graph.Node {
+	out := x.call("To")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_LineAdder(x interface{}) (y graph.LineAdder, b bool) {
+	y, b = x.(graph.LineAdder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_LineAdder(x interface{}) graph.LineAdder {
+	return x.(graph.LineAdder)
+}
+
+type GijitShadow_Proxy_LineAdder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_LineAdder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_LineAdder{call: call}
+}
+
+func (x *GijitShadow_Proxy_LineAdder) NewLine(a0 graph.Node, a1 graph.Node) graph.Line {
+	out := x.call("NewLine", a0, a1)
+	r0, _ := out[0].Interface().(graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_LineAdder) SetLine(a0 graph.Line) {
+	x.call("SetLine", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_LineRemover(x interface{}) (y graph.LineRemover, b bool) {
+	y, b = x.(graph.LineRemover)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_LineRemover(x interface{}) graph.LineRemover {
+	return x.(graph.LineRemover)
+}
+
+type GijitShadow_Proxy_LineRemover struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_LineRemover(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_LineRemover{call: call}
+}
+
+func (x *GijitShadow_Proxy_LineRemover) RemoveLine(a0 graph.Line) {
+	x.call("RemoveLine", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_Multigraph(x interface{}) (y graph.Multigraph, b bool) {
+	y, b = x.(graph.Multigraph)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Multigraph(x interface{}) graph.Multigraph {
+	return x.(graph.Multigraph)
+}
+
+type GijitShadow_Proxy_Multigraph struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Multigraph(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Multigraph{call: call}
+}
+
+func (x *GijitShadow_Proxy_Multigraph) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Multigraph) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Multigraph) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Multigraph) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Multigraph) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_MultigraphBuilder(x interface{}) (y graph.MultigraphBuilder, b bool) {
+	y, b = x.(graph.MultigraphBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_MultigraphBuilder(x interface{}) graph.MultigraphBuilder {
+	return x.(graph.MultigraphBuilder)
+}
+
+type GijitShadow_Proxy_MultigraphBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_MultigraphBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_MultigraphBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_MultigraphBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_MultigraphBuilder) NewLine(a0 graph.Node, a1 graph.Node) graph.Line {
+	out := x.call("NewLine", a0, a1)
+	r0, _ := out[0].Interface().(graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MultigraphBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MultigraphBuilder) SetLine(a0 graph.Line) {
+	x.call("SetLine", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_Node(x interface{}) (y graph.Node, b bool) {
+	y, b = x.(graph.Node)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Node(x interface{}) graph.Node {
+	return x.(graph.Node)
+}
+
+type GijitShadow_Proxy_Node struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Node(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Node{call: call}
+}
+
+func (x *GijitShadow_Proxy_Node) ID() int64 {
+	out := x.call("ID")
+	r0, _ := out[0].Interface().(int64)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_NodeAdder(x interface{}) (y graph.NodeAdder, b bool) {
+	y, b = x.(graph.NodeAdder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_NodeAdder(x interface{}) graph.NodeAdder {
+	return x.(graph.NodeAdder)
+}
+
+type GijitShadow_Proxy_NodeAdder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_NodeAdder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_NodeAdder{call: call}
+}
+
+func (x *GijitShadow_Proxy_NodeAdder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_NodeAdder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_NodeRemover(x interface{}) (y graph.NodeRemover, b bool) {
+	y, b = x.(graph.NodeRemover)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_NodeRemover(x interface{}) graph.NodeRemover {
+	return x.(graph.NodeRemover)
+}
+
+type GijitShadow_Proxy_NodeRemover struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_NodeRemover(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_NodeRemover{call: call}
+}
+
+func (x *GijitShadow_Proxy_NodeRemover) RemoveNode(a0 graph.Node) {
+	x.call("RemoveNode", a0)
+}
+
+func GijitShadow_NewStruct_Undirect() *graph.Undirect {
+	return &graph.Undirect{}
+}
+
+func GijitShadow_NewStruct_UndirectWeighted() *graph.UndirectWeighted {
+	return &graph.UndirectWeighted{}
+}
+
+func GijitShadow_InterfaceConvertTo2_Undirected(x interface{}) (y graph.Undirected, b bool) {
+	y, b = x.(graph.Undirected)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_Undirected(x interface{}) graph.Undirected {
+	return x.(graph.Undirected)
+}
+
+type GijitShadow_Proxy_Undirected struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Undirected(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Undirected{call: call}
+}
+
+func (x *GijitShadow_Proxy_Undirected) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Undirected) EdgeBetween(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("EdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Undirected) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Undirected) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Undirected) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Undirected) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_UndirectedBuilder(x interface{}) (y graph.UndirectedBuilder, b bool) {
+	y, b = x.(graph.UndirectedBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_UndirectedBuilder(x interface{}) graph.UndirectedBuilder {
+	return x.(graph.UndirectedBuilder)
+}
+
+type GijitShadow_Proxy_UndirectedBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_UndirectedBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_UndirectedBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) EdgeBetween(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("EdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) NewEdge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("NewEdge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedBuilder) SetEdge(a0 graph.Edge) {
+	x.call("SetEdge", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_UndirectedMultigraph(x interface{}) (y graph.UndirectedMultigraph, b bool) {
+	y, b = x.(graph.UndirectedMultigraph)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_UndirectedMultigraph(x interface{}) graph.UndirectedMultigraph {
+	return x.(graph.UndirectedMultigraph)
+}
+
+type GijitShadow_Proxy_UndirectedMultigraph struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_UndirectedMultigraph(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_UndirectedMultigraph{call: call}
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraph) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraph) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraph) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraph) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraph) LinesBetween(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("LinesBetween", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraph) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_UndirectedMultigraphBuilder(x interface{}) (y graph.UndirectedMultigraphBuilder, b bool) {
+	y, b = x.(graph.UndirectedMultigraphBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_UndirectedMultigraphBuilder(x interface{}) graph.UndirectedMultigraphBuilder {
+	return x.(graph.UndirectedMultigraphBuilder)
+}
+
+type GijitShadow_Proxy_UndirectedMultigraphBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_UndirectedMultigraphBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_UndirectedMultigraphBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) LinesBetween(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("LinesBetween", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) NewLine(a0 graph.Node, a1 graph.Node) graph.Line {
+	out := x.call("NewLine", a0, a1)
+	r0, _ := out[0].Interface().(graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedMultigraphBuilder) SetLine(a0 graph.Line) {
+	x.call("SetLine", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_UndirectedWeightedBuilder(x interface{}) (y graph.UndirectedWeightedBuilder, b bool) {
+	y, b = x.(graph.UndirectedWeightedBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_UndirectedWeightedBuilder(x interface{}) graph.UndirectedWeightedBuilder {
+	return x.(graph.UndirectedWeightedBuilder)
+}
+
+type GijitShadow_Proxy_UndirectedWeightedBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_UndirectedWeightedBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_UndirectedWeightedBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) EdgeBetween(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("EdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) NewWeightedEdge(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedEdge {
+	out := x.call("NewWeightedEdge", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_UndirectedWeightedBuilder) SetWeightedEdge(a0 graph.WeightedEdge) {
+	x.call("SetWeightedEdge", a0)
+}
+
+func GijitShadow_InterfaceConvertTo2_UndirectedWeightedMultigraphBuilder(x interface{}) (y graph.UndirectedWeightedMultigraphBuilder, b bool) {
+	y, b = x.(graph.UndirectedWeightedMultigraphBuilder)
+	return
+}
+
+func GijitShadow_InterfaceConvertTo1_UndirectedWeightedMultigraphBuilder(x interface{}) graph.UndirectedWeightedMultigraphBuilder {
+	return x.(graph.UndirectedWeightedMultigraphBuilder)
+}
+
+type GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
 }
 
-func GijitShadow_InterfaceConvertTo2_DirectedMultigraph(x interface{}) (y graph.DirectedMultigraph, b bool) {
-	y, b = x.(graph.DirectedMultigraph)
-	return
+func GijitShadow_NewProxy_UndirectedWeightedMultigraphBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder{call: call}
 }
 
-func GijitShadow_InterfaceConvertTo1_DirectedMultigraph(x interface{}) graph.DirectedMultigraph {
-	return x.(graph.DirectedMultigraph)
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
 }
 
-func GijitShadow_InterfaceConvertTo2_DirectedMultigraphBuilder(x interface{}) (y graph.DirectedMultigraphBuilder, b bool) {
-	y, b = x.(graph.DirectedMultigraphBuilder)
-	return
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_DirectedMultigraphBuilder(x interface{}) graph.DirectedMultigraphBuilder {
-	return x.(graph.DirectedMultigraphBuilder)
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_DirectedWeightedBuilder(x interface{}) (y graph.DirectedWeightedBuilder, b bool) {
-	y, b = x.(graph.DirectedWeightedBuilder)
-	return
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_DirectedWeightedBuilder(x interface{}) graph.DirectedWeightedBuilder {
-	return x.(graph.DirectedWeightedBuilder)
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_DirectedWeightedMultigraphBuilder(x interface{}) (y graph.DirectedWeightedMultigraphBuilder, b bool) {
-	y, b = x.(graph.DirectedWeightedMultigraphBuilder)
-	return
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) LinesBetween(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("LinesBetween", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_DirectedWeightedMultigraphBuilder(x interface{}) graph.DirectedWeightedMultigraphBuilder {
-	return x.(graph.DirectedWeightedMultigraphBuilder)
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_Edge(x interface{}) (y graph.Edge, b bool) {
-	y, b = x.(graph.Edge)
-	return
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) NewWeightedLine(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedLine {
+	out := x.call("NewWeightedLine", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedLine)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_Edge(x interface{}) graph.Edge {
-	return x.(graph.Edge)
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_EdgeAdder(x interface{}) (y graph.EdgeAdder, b bool) {
-	y, b = x.(graph.EdgeAdder)
-	return
+func (x *GijitShadow_Proxy_UndirectedWeightedMultigraphBuilder) SetWeightedLine(a0 graph.WeightedLine) {
+	x.call("SetWeightedLine", a0)
 }
 
-func GijitShadow_InterfaceConvertTo1_EdgeAdder(x interface{}) graph.EdgeAdder {
-	return x.(graph.EdgeAdder)
+func GijitShadow_InterfaceConvertTo2_Weighted(x interface{}) (y graph.Weighted, b bool) {
+	y, b = x.(graph.Weighted)
+	return
 }
 
-func GijitShadow_InterfaceConvertTo2_EdgeRemover(x interface{}) (y graph.EdgeRemover, b bool) {
-	y, b = x.(graph.EdgeRemover)
-	return
+func GijitShadow_InterfaceConvertTo1_Weighted(x interface{}) graph.Weighted {
+	return x.(graph.Weighted)
 }
 
-func GijitShadow_InterfaceConvertTo1_EdgeRemover(x interface{}) graph.EdgeRemover {
-	return x.(graph.EdgeRemover)
+type GijitShadow_Proxy_Weighted struct {
+	call func(string, ...interface{}) []reflect.Value
 }
 
-func GijitShadow_InterfaceConvertTo2_Graph(x interface{}) (y graph.Graph, b bool) {
-	y, b = x.(graph.Graph)
-	return
+func GijitShadow_NewProxy_Weighted(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Weighted{call: call}
 }
 
-func GijitShadow_InterfaceConvertTo1_Graph(x interface{}) graph.Graph {
-	return x.(graph.Graph)
+func (x *GijitShadow_Proxy_Weighted) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_Line(x interface{}) (y graph.Line, b bool) {
-	y, b = x.(graph.Line)
-	return
+func (x *GijitShadow_Proxy_Weighted) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_Line(x interface{}) graph.Line {
-	return x.(graph.Line)
+func (x *GijitShadow_Proxy_Weighted) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_LineAdder(x interface{}) (y graph.LineAdder, b bool) {
-	y, b = x.(graph.LineAdder)
-	return
+func (x *GijitShadow_Proxy_Weighted) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_LineAdder(x interface{}) graph.LineAdder {
-	return x.(graph.LineAdder)
+func (x *GijitShadow_Proxy_Weighted) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_LineRemover(x interface{}) (y graph.LineRemover, b bool) {
-	y, b = x.(graph.LineRemover)
-	return
+func (x *GijitShadow_Proxy_Weighted) Weight(a0 graph.Node, a1 graph.Node) (float64, bool) {
+	out := x.call("Weight", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	r1, _ := out[1].Interface().(bool)
+	return r0, r1
 }
 
-func GijitShadow_InterfaceConvertTo1_LineRemover(x interface{}) graph.LineRemover {
-	return x.(graph.LineRemover)
+func (x *GijitShadow_Proxy_Weighted) WeightedEdge(a0 graph.Node, a1 graph.Node) graph.WeightedEdge {
+	out := x.call("WeightedEdge", a0, a1)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_Multigraph(x interface{}) (y graph.Multigraph, b bool) {
-	y, b = x.(graph.Multigraph)
+func GijitShadow_InterfaceConvertTo2_WeightedBuilder(x interface{}) (y graph.WeightedBuilder, b bool) {
+	y, b = x.(graph.WeightedBuilder)
 	return
 }
 
-func GijitShadow_InterfaceConvertTo1_Multigraph(x interface{}) graph.Multigraph {
-	return x.(graph.Multigraph)
+func GijitShadow_InterfaceConvertTo1_WeightedBuilder(x interface{}) graph.WeightedBuilder {
+	return x.(graph.WeightedBuilder)
 }
 
-func GijitShadow_InterfaceConvertTo2_MultigraphBuilder(x interface{}) (y graph.MultigraphBuilder, b bool) {
-	y, b = x.(graph.MultigraphBuilder)
-	return
+type GijitShadow_Proxy_WeightedBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
 }
 
-func GijitShadow_InterfaceConvertTo1_MultigraphBuilder(x interface{}) graph.MultigraphBuilder {
-	return x.(graph.MultigraphBuilder)
+func GijitShadow_NewProxy_WeightedBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedBuilder{call: call}
 }
 
-func GijitShadow_InterfaceConvertTo2_Node(x interface{}) (y graph.Node, b bool) {
-	y, b = x.(graph.Node)
-	return
+func (x *GijitShadow_Proxy_WeightedBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
 }
 
-func GijitShadow_InterfaceConvertTo1_Node(x interface{}) graph.Node {
-	return x.(graph.Node)
+func (x *GijitShadow_Proxy_WeightedBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_NodeAdder(x interface{}) (y graph.NodeAdder, b bool) {
-	y, b = x.(graph.NodeAdder)
-	return
+func (x *GijitShadow_Proxy_WeightedBuilder) NewWeightedEdge(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedEdge {
+	out := x.call("NewWeightedEdge", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_NodeAdder(x interface{}) graph.NodeAdder {
-	return x.(graph.NodeAdder)
+func (x *GijitShadow_Proxy_WeightedBuilder) SetWeightedEdge(a0 graph.WeightedEdge) {
+	x.call("SetWeightedEdge", a0)
 }
 
-func GijitShadow_InterfaceConvertTo2_NodeRemover(x interface{}) (y graph.NodeRemover, b bool) {
-	y, b = x.(graph.NodeRemover)
+func GijitShadow_InterfaceConvertTo2_WeightedDirected(x interface{}) (y graph.WeightedDirected, b bool) {
+	y, b = x.(graph.WeightedDirected)
 	return
 }
 
-func GijitShadow_InterfaceConvertTo1_NodeRemover(x interface{}) graph.NodeRemover {
-	return x.(graph.NodeRemover)
+func GijitShadow_InterfaceConvertTo1_WeightedDirected(x interface{}) graph.WeightedDirected {
+	return x.(graph.WeightedDirected)
 }
 
-func GijitShadow_NewStruct_Undirect() *graph.Undirect {
-	return &graph.Undirect{}
+type GijitShadow_Proxy_WeightedDirected struct {
+	call func(string, ...interface{}) []reflect.Value
 }
 
-func GijitShadow_NewStruct_UndirectWeighted() *graph.UndirectWeighted {
-	return &graph.UndirectWeighted{}
+func GijitShadow_NewProxy_WeightedDirected(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedDirected{call: call}
 }
 
-func GijitShadow_InterfaceConvertTo2_Undirected(x interface{}) (y graph.Undirected, b bool) {
-	y, b = x.(graph.Undirected)
-	return
+func (x *GijitShadow_Proxy_WeightedDirected) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_Undirected(x interface{}) graph.Undirected {
-	return x.(graph.Undirected)
+func (x *GijitShadow_Proxy_WeightedDirected) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_UndirectedBuilder(x interface{}) (y graph.UndirectedBuilder, b bool) {
-	y, b = x.(graph.UndirectedBuilder)
-	return
+func (x *GijitShadow_Proxy_WeightedDirected) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_UndirectedBuilder(x interface{}) graph.UndirectedBuilder {
-	return x.(graph.UndirectedBuilder)
+func (x *GijitShadow_Proxy_WeightedDirected) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_UndirectedMultigraph(x interface{}) (y graph.UndirectedMultigraph, b bool) {
-	y, b = x.(graph.UndirectedMultigraph)
-	return
+func (x *GijitShadow_Proxy_WeightedDirected) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_UndirectedMultigraph(x interface{}) graph.UndirectedMultigraph {
-	return x.(graph.UndirectedMultigraph)
+func (x *GijitShadow_Proxy_WeightedDirected) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_UndirectedMultigraphBuilder(x interface{}) (y graph.UndirectedMultigraphBuilder, b bool) {
-	y, b = x.(graph.UndirectedMultigraphBuilder)
-	return
+func (x *GijitShadow_Proxy_WeightedDirected) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_UndirectedMultigraphBuilder(x interface{}) graph.UndirectedMultigraphBuilder {
-	return x.(graph.UndirectedMultigraphBuilder)
+func (x *GijitShadow_Proxy_WeightedDirected) Weight(a0 graph.Node, a1 graph.Node) (float64, bool) {
+	out := x.call("Weight", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	r1, _ := out[1].Interface().(bool)
+	return r0, r1
 }
 
-func GijitShadow_InterfaceConvertTo2_UndirectedWeightedBuilder(x interface{}) (y graph.UndirectedWeightedBuilder, b bool) {
-	y, b = x.(graph.UndirectedWeightedBuilder)
+func (x *GijitShadow_Proxy_WeightedDirected) WeightedEdge(a0 graph.Node, a1 graph.Node) graph.WeightedEdge {
+	out := x.call("WeightedEdge", a0, a1)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
+}
+
+func GijitShadow_InterfaceConvertTo2_WeightedDirectedMultigraph(x interface{}) (y graph.WeightedDirectedMultigraph, b bool) {
+	y, b = x.(graph.WeightedDirectedMultigraph)
 	return
 }
 
-func GijitShadow_InterfaceConvertTo1_UndirectedWeightedBuilder(x interface{}) graph.UndirectedWeightedBuilder {
-	return x.(graph.UndirectedWeightedBuilder)
+func GijitShadow_InterfaceConvertTo1_WeightedDirectedMultigraph(x interface{}) graph.WeightedDirectedMultigraph {
+	return x.(graph.WeightedDirectedMultigraph)
 }
 
-func GijitShadow_InterfaceConvertTo2_UndirectedWeightedMultigraphBuilder(x interface{}) (y graph.UndirectedWeightedMultigraphBuilder, b bool) {
-	y, b = x.(graph.UndirectedWeightedMultigraphBuilder)
-	return
+type GijitShadow_Proxy_WeightedDirectedMultigraph struct {
+	call func(string, ...interface{}) []reflect.Value
 }
 
-func GijitShadow_InterfaceConvertTo1_UndirectedWeightedMultigraphBuilder(x interface{}) graph.UndirectedWeightedMultigraphBuilder {
-	return x.(graph.UndirectedWeightedMultigraphBuilder)
+func GijitShadow_NewProxy_WeightedDirectedMultigraph(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedDirectedMultigraph{call: call}
 }
 
-func GijitShadow_InterfaceConvertTo2_Weighted(x interface{}) (y graph.Weighted, b bool) {
-	y, b = x.(graph.Weighted)
-	return
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_Weighted(x interface{}) graph.Weighted {
-	return x.(graph.Weighted)
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_WeightedBuilder(x interface{}) (y graph.WeightedBuilder, b bool) {
-	y, b = x.(graph.WeightedBuilder)
-	return
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_WeightedBuilder(x interface{}) graph.WeightedBuilder {
-	return x.(graph.WeightedBuilder)
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) HasEdgeFromTo(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeFromTo", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_WeightedDirected(x interface{}) (y graph.WeightedDirected, b bool) {
-	y, b = x.(graph.WeightedDirected)
-	return
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_WeightedDirected(x interface{}) graph.WeightedDirected {
-	return x.(graph.WeightedDirected)
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo2_WeightedDirectedMultigraph(x interface{}) (y graph.WeightedDirectedMultigraph, b bool) {
-	y, b = x.(graph.WeightedDirectedMultigraph)
-	return
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) To(a0 graph.Node) []graph.Node {
+	out := x.call("To", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
 }
 
-func GijitShadow_InterfaceConvertTo1_WeightedDirectedMultigraph(x interface{}) graph.WeightedDirectedMultigraph {
-	return x.(graph.WeightedDirectedMultigraph)
+func (x *GijitShadow_Proxy_WeightedDirectedMultigraph) WeightedLines(a0 graph.Node, a1 graph.Node) []graph.WeightedLine {
+	out := x.call("WeightedLines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.WeightedLine)
+	return r0
 }
 
 func GijitShadow_InterfaceConvertTo2_WeightedEdge(x interface{}) (y graph.WeightedEdge, b bool) {
@@ -324,6 +1569,32 @@ func GijitShadow_InterfaceConvertTo1_WeightedEdge(x interface{}) graph.WeightedE
 	return x.(graph.WeightedEdge)
 }
 
+type GijitShadow_Proxy_WeightedEdge struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedEdge(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedEdge{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedEdge) From() graph.Node {
+	out := x.call("From")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedEdge) To() graph.Node {
+	out := x.call("To")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedEdge) Weight() float64 {
+	out := x.call("Weight")
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_WeightedEdgeAdder(x interface{}) (y graph.WeightedEdgeAdder, b bool) {
 	y, b = x.(graph.WeightedEdgeAdder)
 	return
@@ -333,6 +1604,24 @@ func GijitShadow_InterfaceConvertTo1_WeightedEdgeAdder(x interface{}) graph.Weig
 	return x.(graph.WeightedEdgeAdder)
 }
 
+type GijitShadow_Proxy_WeightedEdgeAdder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedEdgeAdder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedEdgeAdder{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedEdgeAdder) NewWeightedEdge(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedEdge {
+	out := x.call("NewWeightedEdge", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedEdgeAdder) SetWeightedEdge(a0 graph.WeightedEdge) {
+	x.call("SetWeightedEdge", a0)
+}
+
 func GijitShadow_NewStruct_WeightedEdgePair() *graph.WeightedEdgePair {
 	return &graph.WeightedEdgePair{}
 }
@@ -346,6 +1635,38 @@ func GijitShadow_InterfaceConvertTo1_WeightedLine(x interface{}) graph.WeightedL
 	return x.(graph.WeightedLine)
 }
 
+type GijitShadow_Proxy_WeightedLine struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedLine(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedLine{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedLine) From() graph.Node {
+	out := x.call("From")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedLine) ID() int64 {
+	out := x.call("ID")
+	r0, _ := out[0].Interface().(int64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedLine) To() graph.Node {
+	out := x.call("To")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedLine) Weight() float64 {
+	out := x.call("Weight")
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_WeightedLineAdder(x interface{}) (y graph.WeightedLineAdder, b bool) {
 	y, b = x.(graph.WeightedLineAdder)
 	return
@@ -355,6 +1676,24 @@ func GijitShadow_InterfaceConvertTo1_WeightedLineAdder(x interface{}) graph.Weig
 	return x.(graph.WeightedLineAdder)
 }
 
+type GijitShadow_Proxy_WeightedLineAdder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedLineAdder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedLineAdder{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedLineAdder) NewWeightedLine(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedLine {
+	out := x.call("NewWeightedLine", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedLine)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedLineAdder) SetWeightedLine(a0 graph.WeightedLine) {
+	x.call("SetWeightedLine", a0)
+}
+
 func GijitShadow_InterfaceConvertTo2_WeightedMultigraph(x interface{}) (y graph.WeightedMultigraph, b bool) {
 	y, b = x.(graph.WeightedMultigraph)
 	return
@@ -364,6 +1703,50 @@ func GijitShadow_InterfaceConvertTo1_WeightedMultigraph(x interface{}) graph.Wei
 	return x.(graph.WeightedMultigraph)
 }
 
+type GijitShadow_Proxy_WeightedMultigraph struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedMultigraph(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedMultigraph{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraph) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraph) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraph) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraph) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraph) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraph) WeightedLines(a0 graph.Node, a1 graph.Node) []graph.WeightedLine {
+	out := x.call("WeightedLines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.WeightedLine)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_WeightedMultigraphBuilder(x interface{}) (y graph.WeightedMultigraphBuilder, b bool) {
 	y, b = x.(graph.WeightedMultigraphBuilder)
 	return
@@ -373,6 +1756,34 @@ func GijitShadow_InterfaceConvertTo1_WeightedMultigraphBuilder(x interface{}) gr
 	return x.(graph.WeightedMultigraphBuilder)
 }
 
+type GijitShadow_Proxy_WeightedMultigraphBuilder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedMultigraphBuilder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedMultigraphBuilder{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraphBuilder) AddNode(a0 graph.Node) {
+	x.call("AddNode", a0)
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraphBuilder) NewNode() graph.Node {
+	out := x.call("NewNode")
+	r0, _ := out[0].Interface().(graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraphBuilder) NewWeightedLine(a0 graph.Node, a1 graph.Node, a2 float64) graph.WeightedLine {
+	out := x.call("NewWeightedLine", a0, a1, a2)
+	r0, _ := out[0].Interface().(graph.WeightedLine)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedMultigraphBuilder) SetWeightedLine(a0 graph.WeightedLine) {
+	x.call("SetWeightedLine", a0)
+}
+
 func GijitShadow_InterfaceConvertTo2_WeightedUndirected(x interface{}) (y graph.WeightedUndirected, b bool) {
 	y, b = x.(graph.WeightedUndirected)
 	return
@@ -382,6 +1793,63 @@ func GijitShadow_InterfaceConvertTo1_WeightedUndirected(x interface{}) graph.Wei
 	return x.(graph.WeightedUndirected)
 }
 
+type GijitShadow_Proxy_WeightedUndirected struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedUndirected(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedUndirected{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) Edge(a0 graph.Node, a1 graph.Node) graph.Edge {
+	out := x.call("Edge", a0, a1)
+	r0, _ := out[0].Interface().(graph.Edge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) Weight(a0 graph.Node, a1 graph.Node) (float64, bool) {
+	out := x.call("Weight", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	r1, _ := out[1].Interface().(bool)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) WeightedEdge(a0 graph.Node, a1 graph.Node) graph.WeightedEdge {
+	out := x.call("WeightedEdge", a0, a1)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirected) WeightedEdgeBetween(a0 graph.Node, a1 graph.Node) graph.WeightedEdge {
+	out := x.call("WeightedEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(graph.WeightedEdge)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_WeightedUndirectedMultigraph(x interface{}) (y graph.WeightedUndirectedMultigraph, b bool) {
 	y, b = x.(graph.WeightedUndirectedMultigraph)
 	return
@@ -390,3 +1858,53 @@ func GijitShadow_InterfaceConvertTo2_WeightedUndirectedMultigraph(x interface{})
 func GijitShadow_InterfaceConvertTo1_WeightedUndirectedMultigraph(x interface{}) graph.WeightedUndirectedMultigraph {
 	return x.(graph.WeightedUndirectedMultigraph)
 }
+
+type GijitShadow_Proxy_WeightedUndirectedMultigraph struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WeightedUndirectedMultigraph(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WeightedUndirectedMultigraph{call: call}
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirectedMultigraph) From(a0 graph.Node) []graph.Node {
+	out := x.call("From", a0)
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirectedMultigraph) Has(a0 graph.Node) bool {
+	out := x.call("Has", a0)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirectedMultigraph) HasEdgeBetween(a0 graph.Node, a1 graph.Node) bool {
+	out := x.call("HasEdgeBetween", a0, a1)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirectedMultigraph) Lines(a0 graph.Node, a1 graph.Node) []graph.Line {
+	out := x.call("Lines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.Line)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirectedMultigraph) Nodes() []graph.Node {
+	out := x.call("Nodes")
+	r0, _ := out[0].Interface().([]graph.Node)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirectedMultigraph) WeightedLines(a0 graph.Node, a1 graph.Node) []graph.WeightedLine {
+	out := x.call("WeightedLines", a0, a1)
+	r0, _ := out[0].Interface().([]graph.WeightedLine)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WeightedUndirectedMultigraph) WeightedLinesBetween(a0 graph.Node, a1 graph.Node) []graph.WeightedLine {
+	out := x.call("WeightedLinesBetween", a0, a1)
+	r0, _ := out[0].Interface().([]graph.WeightedLine)
+	return r0
+}
@@ -1,12 +1,19 @@
 package shadow_lapack
 
-import "gonum.org/v1/gonum/lapack"
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
 	Pkg["Complex128"] = GijitShadow_InterfaceConvertTo2_Complex128
+	Proxy[reflect.TypeOf((*lapack.Complex128)(nil)).Elem()] = GijitShadow_NewProxy_Complex128
 	Pkg["Float64"] = GijitShadow_InterfaceConvertTo2_Float64
+	Proxy[reflect.TypeOf((*lapack.Float64)(nil)).Elem()] = GijitShadow_NewProxy_Float64
 	Pkg["None"] = lapack.None
 
 }
@@ -19,6 +26,14 @@ func GijitShadow_InterfaceConvertTo1_Complex128(x interface{}) lapack.Complex128
 	return x.(lapack.Complex128)
 }
 
+type GijitShadow_Proxy_Complex128 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Complex128(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Complex128{call: call}
+}
+
 func GijitShadow_InterfaceConvertTo2_Float64(x interface{}) (y lapack.Float64, b bool) {
 	y, b = x.(lapack.Float64)
 	return
@@ -27,3 +42,133 @@ func GijitShadow_InterfaceConvertTo2_Float64(x interface{}) (y lapack.Float64, b
 func GijitShadow_InterfaceConvertTo1_Float64(x interface{}) lapack.Float64 {
 	return x.(lapack.Float64)
 }
+
+type GijitShadow_Proxy_Float64 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Float64(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Float64{call: call}
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgecon(a0 lapack.MatrixNorm, a1 int, a2 []float64, a3 int, a4 float64, a5 []float64, a6 []int) float64 {
+	out := x.call("Dgecon", a0, a1, a2, a3, a4, a5, a6)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgeev(a0 lapack.LeftEVJob, a1 lapack.RightEVJob, a2 int, a3 []float64, a4 int, a5 []float64, a6 []float64, a7 []float64, a8 int, a9 []float64, a10 int, a11 []float64, a12 int) int {
+	out := x.call("Dgeev", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgelqf(a0 int, a1 int, a2 []float64, a3 int, a4 []float64, a5 []float64, a6 int) {
+	x.call("Dgelqf", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgels(a0 blas.Transpose, a1 int, a2 int, a3 int, a4 []float64, a5 int, a6 []float64, a7 int, a8 []float64, a9 int) bool {
+	out := x.call("Dgels", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgeqrf(a0 int, a1 int, a2 []float64, a3 int, a4 []float64, a5 []float64, a6 int) {
+	x.call("Dgeqrf", a0, a1, a2, a3, a4, a5, a6)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgesvd(a0 lapack.SVDJob, a1 lapack.SVDJob, a2 int, a3 int, a4 []float64, a5 int, a6 []float64, a7 []float64, a8 int, a9 []float64, a10 int, a11 []float64, a12 int) bool {
+	out := x.call("Dgesvd", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgetrf(a0 int, a1 int, a2 []float64, a3 int, a4 []int) bool {
+	out := x.call("Dgetrf", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgetri(a0 int, a1 []float64, a2 int, a3 []int, a4 []float64, a5 int) bool {
+	out := x.call("Dgetri", a0, a1, a2, a3, a4, a5)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dgetrs(a0 blas.Transpose, a1 int, a2 int, a3 []float64, a4 int, a5 []int, a6 []float64, a7 int) {
+	x.call("Dgetrs", a0, a1, a2, a3, a4, a5, a6, a7)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dggsvd3(a0 lapack.GSVDJob, a1 lapack.GSVDJob, a2 lapack.GSVDJob, a3 int, a4 int, a5 int, a6 []float64, a7 int, a8 []float64, a9 int, a10 []float64, a11 []float64, a12 []float64, a13 int, a14 []float64, a15 int, a16 []float64, a17 int, a18 []float64, a19 int, a20 []int) (int, int, bool) {
+	out := x.call("Dggsvd3", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11, a12, a13, a14, a15, a16, a17, a18, a19, a20)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	r2, _ := out[2].Interface().(bool)
+	return r0, r1, r2
+}
+
+func (x *GijitShadow_Proxy_Float64) Dlange(a0 lapack.MatrixNorm, a1 int, a2 int, a3 []float64, a4 int, a5 []float64) float64 {
+	out := x.call("Dlange", a0, a1, a2, a3, a4, a5)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dlansy(a0 lapack.MatrixNorm, a1 blas.Uplo, a2 int, a3 []float64, a4 int, a5 []float64) float64 {
+	out := x.call("Dlansy", a0, a1, a2, a3, a4, a5)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dlantr(a0 lapack.MatrixNorm, a1 blas.Uplo, a2 blas.Diag, a3 int, a4 int, a5 []float64, a6 int, a7 []float64) float64 {
+	out := x.call("Dlantr", a0, a1, a2, a3, a4, a5, a6, a7)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dlapmt(a0 bool, a1 int, a2 int, a3 []float64, a4 int, a5 []int) {
+	x.call("Dlapmt", a0, a1, a2, a3, a4, a5)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dormlq(a0 blas.Side, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 []float64, a6 int, a7 []float64, a8 []float64, a9 int, a10 []float64, a11 int) {
+	x.call("Dormlq", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dormqr(a0 blas.Side, a1 blas.Transpose, a2 int, a3 int, a4 int, a5 []float64, a6 int, a7 []float64, a8 []float64, a9 int, a10 []float64, a11 int) {
+	x.call("Dormqr", a0, a1, a2, a3, a4, a5, a6, a7, a8, a9, a10, a11)
+}
+
+func (x *GijitShadow_Proxy_Float64) Dpocon(a0 blas.Uplo, a1 int, a2 []float64, a3 int, a4 float64, a5 []float64, a6 []int) float64 {
+	out := x.call("Dpocon", a0, a1, a2, a3, a4, a5, a6)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dpotrf(a0 blas.Uplo, a1 int, a2 []float64, a3 int) bool {
+	out := x.call("Dpotrf", a0, a1, a2, a3)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dsyev(a0 lapack.EVJob, a1 blas.Uplo, a2 int, a3 []float64, a4 int, a5 []float64, a6 []float64, a7 int) bool {
+	out := x.call("Dsyev", a0, a1, a2, a3, a4, a5, a6, a7)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtrcon(a0 lapack.MatrixNorm, a1 blas.Uplo, a2 blas.Diag, a3 int, a4 []float64, a5 int, a6 []float64, a7 []int) float64 {
+	out := x.call("Dtrcon", a0, a1, a2, a3, a4, a5, a6, a7)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtrtri(a0 blas.Uplo, a1 blas.Diag, a2 int, a3 []float64, a4 int) bool {
+	out := x.call("Dtrtri", a0, a1, a2, a3, a4)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Float64) Dtrtrs(a0 blas.Uplo, a1 blas.Transpose, a2 blas.Diag, a3 int, a4 int, a5 []float64, a6 int, a7 []float64, a8 int) bool {
+	out := x.call("Dtrtrs", a0, a1, a2, a3, a4, a5, a6, a7, a8)
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
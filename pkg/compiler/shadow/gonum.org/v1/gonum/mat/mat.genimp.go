@@ -1,20 +1,32 @@
 package shadow_mat
 
-import "gonum.org/v1/gonum/mat"
+import (
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/mat"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
 	Pkg["BandWidther"] = GijitShadow_InterfaceConvertTo2_BandWidther
+	Proxy[reflect.TypeOf((*mat.BandWidther)(nil)).Elem()] = GijitShadow_NewProxy_BandWidther
 	Pkg["Banded"] = GijitShadow_InterfaceConvertTo2_Banded
+	Proxy[reflect.TypeOf((*mat.Banded)(nil)).Elem()] = GijitShadow_NewProxy_Banded
 	Pkg["CMatrix"] = GijitShadow_InterfaceConvertTo2_CMatrix
+	Proxy[reflect.TypeOf((*mat.CMatrix)(nil)).Elem()] = GijitShadow_NewProxy_CMatrix
 	Pkg["Cloner"] = GijitShadow_InterfaceConvertTo2_Cloner
+	Proxy[reflect.TypeOf((*mat.Cloner)(nil)).Elem()] = GijitShadow_NewProxy_Cloner
 	Pkg["Col"] = mat.Col
 	Pkg["ColNonZeroDoer"] = GijitShadow_InterfaceConvertTo2_ColNonZeroDoer
+	Proxy[reflect.TypeOf((*mat.ColNonZeroDoer)(nil)).Elem()] = GijitShadow_NewProxy_ColNonZeroDoer
 	Pkg["ColViewer"] = GijitShadow_InterfaceConvertTo2_ColViewer
+	Proxy[reflect.TypeOf((*mat.ColViewer)(nil)).Elem()] = GijitShadow_NewProxy_ColViewer
 	Pkg["Cond"] = mat.Cond
 	Pkg["ConditionTolerance"] = mat.ConditionTolerance
 	Pkg["Copier"] = GijitShadow_InterfaceConvertTo2_Copier
+	Proxy[reflect.TypeOf((*mat.Copier)(nil)).Elem()] = GijitShadow_NewProxy_Copier
 	Pkg["DenseCopyOf"] = mat.DenseCopyOf
 	Pkg["Det"] = mat.Det
 	Pkg["Dot"] = mat.Dot
@@ -43,19 +55,26 @@ func init() {
 	Pkg["Excerpt"] = mat.Excerpt
 	Pkg["Formatted"] = mat.Formatted
 	Pkg["Grower"] = GijitShadow_InterfaceConvertTo2_Grower
+	Proxy[reflect.TypeOf((*mat.Grower)(nil)).Elem()] = GijitShadow_NewProxy_Grower
 	Pkg["Inner"] = mat.Inner
 	Pkg["LogDet"] = mat.LogDet
 	Pkg["Matrix"] = GijitShadow_InterfaceConvertTo2_Matrix
+	Proxy[reflect.TypeOf((*mat.Matrix)(nil)).Elem()] = GijitShadow_NewProxy_Matrix
 	Pkg["Max"] = mat.Max
 	Pkg["Maybe"] = mat.Maybe
 	Pkg["MaybeComplex"] = mat.MaybeComplex
 	Pkg["MaybeFloat"] = mat.MaybeFloat
 	Pkg["Min"] = mat.Min
 	Pkg["Mutable"] = GijitShadow_InterfaceConvertTo2_Mutable
+	Proxy[reflect.TypeOf((*mat.Mutable)(nil)).Elem()] = GijitShadow_NewProxy_Mutable
 	Pkg["MutableBanded"] = GijitShadow_InterfaceConvertTo2_MutableBanded
+	Proxy[reflect.TypeOf((*mat.MutableBanded)(nil)).Elem()] = GijitShadow_NewProxy_MutableBanded
 	Pkg["MutableSymBanded"] = GijitShadow_InterfaceConvertTo2_MutableSymBanded
+	Proxy[reflect.TypeOf((*mat.MutableSymBanded)(nil)).Elem()] = GijitShadow_NewProxy_MutableSymBanded
 	Pkg["MutableSymmetric"] = GijitShadow_InterfaceConvertTo2_MutableSymmetric
+	Proxy[reflect.TypeOf((*mat.MutableSymmetric)(nil)).Elem()] = GijitShadow_NewProxy_MutableSymmetric
 	Pkg["MutableTriangular"] = GijitShadow_InterfaceConvertTo2_MutableTriangular
+	Proxy[reflect.TypeOf((*mat.MutableTriangular)(nil)).Elem()] = GijitShadow_NewProxy_MutableTriangular
 	Pkg["NewBandDense"] = mat.NewBandDense
 	Pkg["NewDense"] = mat.NewDense
 	Pkg["NewDiagonal"] = mat.NewDiagonal
@@ -65,32 +84,52 @@ func init() {
 	Pkg["NewTriDense"] = mat.NewTriDense
 	Pkg["NewVecDense"] = mat.NewVecDense
 	Pkg["NonZeroDoer"] = GijitShadow_InterfaceConvertTo2_NonZeroDoer
+	Proxy[reflect.TypeOf((*mat.NonZeroDoer)(nil)).Elem()] = GijitShadow_NewProxy_NonZeroDoer
 	Pkg["Norm"] = mat.Norm
 	Pkg["Prefix"] = mat.Prefix
 	Pkg["RawBander"] = GijitShadow_InterfaceConvertTo2_RawBander
+	Proxy[reflect.TypeOf((*mat.RawBander)(nil)).Elem()] = GijitShadow_NewProxy_RawBander
 	Pkg["RawColViewer"] = GijitShadow_InterfaceConvertTo2_RawColViewer
+	Proxy[reflect.TypeOf((*mat.RawColViewer)(nil)).Elem()] = GijitShadow_NewProxy_RawColViewer
 	Pkg["RawMatrixSetter"] = GijitShadow_InterfaceConvertTo2_RawMatrixSetter
+	Proxy[reflect.TypeOf((*mat.RawMatrixSetter)(nil)).Elem()] = GijitShadow_NewProxy_RawMatrixSetter
 	Pkg["RawMatrixer"] = GijitShadow_InterfaceConvertTo2_RawMatrixer
+	Proxy[reflect.TypeOf((*mat.RawMatrixer)(nil)).Elem()] = GijitShadow_NewProxy_RawMatrixer
 	Pkg["RawRowViewer"] = GijitShadow_InterfaceConvertTo2_RawRowViewer
+	Proxy[reflect.TypeOf((*mat.RawRowViewer)(nil)).Elem()] = GijitShadow_NewProxy_RawRowViewer
 	Pkg["RawSymBander"] = GijitShadow_InterfaceConvertTo2_RawSymBander
+	Proxy[reflect.TypeOf((*mat.RawSymBander)(nil)).Elem()] = GijitShadow_NewProxy_RawSymBander
 	Pkg["RawSymmetricer"] = GijitShadow_InterfaceConvertTo2_RawSymmetricer
+	Proxy[reflect.TypeOf((*mat.RawSymmetricer)(nil)).Elem()] = GijitShadow_NewProxy_RawSymmetricer
 	Pkg["RawTriangular"] = GijitShadow_InterfaceConvertTo2_RawTriangular
+	Proxy[reflect.TypeOf((*mat.RawTriangular)(nil)).Elem()] = GijitShadow_NewProxy_RawTriangular
 	Pkg["RawVectorer"] = GijitShadow_InterfaceConvertTo2_RawVectorer
+	Proxy[reflect.TypeOf((*mat.RawVectorer)(nil)).Elem()] = GijitShadow_NewProxy_RawVectorer
 	Pkg["Reseter"] = GijitShadow_InterfaceConvertTo2_Reseter
+	Proxy[reflect.TypeOf((*mat.Reseter)(nil)).Elem()] = GijitShadow_NewProxy_Reseter
 	Pkg["Row"] = mat.Row
 	Pkg["RowNonZeroDoer"] = GijitShadow_InterfaceConvertTo2_RowNonZeroDoer
+	Proxy[reflect.TypeOf((*mat.RowNonZeroDoer)(nil)).Elem()] = GijitShadow_NewProxy_RowNonZeroDoer
 	Pkg["RowViewer"] = GijitShadow_InterfaceConvertTo2_RowViewer
+	Proxy[reflect.TypeOf((*mat.RowViewer)(nil)).Elem()] = GijitShadow_NewProxy_RowViewer
 	Pkg["Squeeze"] = mat.Squeeze
 	Pkg["Sum"] = mat.Sum
 	Pkg["Symmetric"] = GijitShadow_InterfaceConvertTo2_Symmetric
+	Proxy[reflect.TypeOf((*mat.Symmetric)(nil)).Elem()] = GijitShadow_NewProxy_Symmetric
 	Pkg["Trace"] = mat.Trace
 	Pkg["Triangular"] = GijitShadow_InterfaceConvertTo2_Triangular
+	Proxy[reflect.TypeOf((*mat.Triangular)(nil)).Elem()] = GijitShadow_NewProxy_Triangular
 	Pkg["Unconjugator"] = GijitShadow_InterfaceConvertTo2_Unconjugator
+	Proxy[reflect.TypeOf((*mat.Unconjugator)(nil)).Elem()] = GijitShadow_NewProxy_Unconjugator
 	Pkg["UntransposeBander"] = GijitShadow_InterfaceConvertTo2_UntransposeBander
+	Proxy[reflect.TypeOf((*mat.UntransposeBander)(nil)).Elem()] = GijitShadow_NewProxy_UntransposeBander
 	Pkg["UntransposeTrier"] = GijitShadow_InterfaceConvertTo2_UntransposeTrier
+	Proxy[reflect.TypeOf((*mat.UntransposeTrier)(nil)).Elem()] = GijitShadow_NewProxy_UntransposeTrier
 	Pkg["Untransposer"] = GijitShadow_InterfaceConvertTo2_Untransposer
+	Proxy[reflect.TypeOf((*mat.Untransposer)(nil)).Elem()] = GijitShadow_NewProxy_Untransposer
 	Pkg["VecDenseCopyOf"] = mat.VecDenseCopyOf
 	Pkg["Vector"] = GijitShadow_InterfaceConvertTo2_Vector
+	Proxy[reflect.TypeOf((*mat.Vector)(nil)).Elem()] = GijitShadow_NewProxy_Vector
 
 }
 func GijitShadow_NewStruct_BandDense() *mat.BandDense {
@@ -106,6 +145,21 @@ func GijitShadow_InterfaceConvertTo1_BandWidther(x interface{}) mat.BandWidther
 	return x.(mat.BandWidther)
 }
 
+type GijitShadow_Proxy_BandWidther struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_BandWidther(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_BandWidther{call: call}
+}
+
+func (x *GijitShadow_Proxy_BandWidther) BandWidth() (int, int) {
+	out := x.call("BandWidth")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
 func GijitShadow_InterfaceConvertTo2_Banded(x interface{}) (y mat.Banded, b bool) {
 	y, b = x.(mat.Banded)
 	return
@@ -115,6 +169,46 @@ func GijitShadow_InterfaceConvertTo1_Banded(x interface{}) mat.Banded {
 	return x.(mat.Banded)
 }
 
+type GijitShadow_Proxy_Banded struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Banded(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Banded{call: call}
+}
+
+func (x *GijitShadow_Proxy_Banded) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Banded) Bandwidth() (int, int) {
+	out := x.call("Bandwidth")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Banded) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Banded) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Banded) TBand() mat.Banded {
+	out := x.call("TBand")
+	r0, _ := out[0].Interface().(mat.Banded)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_CMatrix(x interface{}) (y mat.CMatrix, b bool) {
 	y, b = x.(mat.CMatrix)
 	return
@@ -124,6 +218,33 @@ func GijitShadow_InterfaceConvertTo1_CMatrix(x interface{}) mat.CMatrix {
 	return x.(mat.CMatrix)
 }
 
+type GijitShadow_Proxy_CMatrix struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_CMatrix(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_CMatrix{call: call}
+}
+
+func (x *GijitShadow_Proxy_CMatrix) At(a0 int, a1 int) complex128 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(complex128)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_CMatrix) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_CMatrix) H() mat.CMatrix {
+	out := x.call("H")
+	r0, _ := out[0].Interface().(mat.CMatrix)
+	return r0
+}
+
 func GijitShadow_NewStruct_Cholesky() *mat.Cholesky {
 	return &mat.Cholesky{}
 }
@@ -137,6 +258,18 @@ func GijitShadow_InterfaceConvertTo1_Cloner(x interface{}) mat.Cloner {
 	return x.(mat.Cloner)
 }
 
+type GijitShadow_Proxy_Cloner struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Cloner(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Cloner{call: call}
+}
+
+func (x *GijitShadow_Proxy_Cloner) Clone(a0 mat.Matrix) {
+	x.call("Clone", a0)
+}
+
 func GijitShadow_InterfaceConvertTo2_ColNonZeroDoer(x interface{}) (y mat.ColNonZeroDoer, b bool) {
 	y, b = x.(mat.ColNonZeroDoer)
 	return
@@ -146,6 +279,18 @@ func GijitShadow_InterfaceConvertTo1_ColNonZeroDoer(x interface{}) mat.ColNonZer
 	return x.(mat.ColNonZeroDoer)
 }
 
+type GijitShadow_Proxy_ColNonZeroDoer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ColNonZeroDoer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ColNonZeroDoer{call: call}
+}
+
+func (x *GijitShadow_Proxy_ColNonZeroDoer) DoColNonZero(a0 int, a1 func(i int, j int, v float64)) {
+	x.call("DoColNonZero", a0, a1)
+}
+
 func GijitShadow_InterfaceConvertTo2_ColViewer(x interface{}) (y mat.ColViewer, b bool) {
 	y, b = x.(mat.ColViewer)
 	return
@@ -155,6 +300,20 @@ func GijitShadow_InterfaceConvertTo1_ColViewer(x interface{}) mat.ColViewer {
 	return x.(mat.ColViewer)
 }
 
+type GijitShadow_Proxy_ColViewer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ColViewer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ColViewer{call: call}
+}
+
+func (x *GijitShadow_Proxy_ColViewer) ColView(a0 int) mat.Vector {
+	out := x.call("ColView", a0)
+	r0, _ := out[0].Interface().(mat.Vector)
+	return r0
+}
+
 func GijitShadow_NewStruct_Conjugate() *mat.Conjugate {
 	return &mat.Conjugate{}
 }
@@ -168,6 +327,21 @@ func GijitShadow_InterfaceConvertTo1_Copier(x interface{}) mat.Copier {
 	return x.(mat.Copier)
 }
 
+type GijitShadow_Proxy_Copier struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Copier(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Copier{call: call}
+}
+
+func (x *GijitShadow_Proxy_Copier) Copy(a0 mat.Matrix) (int, int) {
+	out := x.call("Copy", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
 func GijitShadow_NewStruct_Dense() *mat.Dense {
 	return &mat.Dense{}
 }
@@ -201,6 +375,27 @@ func GijitShadow_InterfaceConvertTo1_Grower(x interface{}) mat.Grower {
 	return x.(mat.Grower)
 }
 
+type GijitShadow_Proxy_Grower struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Grower(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Grower{call: call}
+}
+
+func (x *GijitShadow_Proxy_Grower) Caps() (int, int) {
+	out := x.call("Caps")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Grower) Grow(a0 int, a1 int) mat.Matrix {
+	out := x.call("Grow", a0, a1)
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
 func GijitShadow_NewStruct_HOGSVD() *mat.HOGSVD {
 	return &mat.HOGSVD{}
 }
@@ -222,6 +417,33 @@ func GijitShadow_InterfaceConvertTo1_Matrix(x interface{}) mat.Matrix {
 	return x.(mat.Matrix)
 }
 
+type GijitShadow_Proxy_Matrix struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Matrix(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Matrix{call: call}
+}
+
+func (x *GijitShadow_Proxy_Matrix) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Matrix) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Matrix) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_Mutable(x interface{}) (y mat.Mutable, b bool) {
 	y, b = x.(mat.Mutable)
 	return
@@ -231,6 +453,37 @@ func GijitShadow_InterfaceConvertTo1_Mutable(x interface{}) mat.Mutable {
 	return x.(mat.Mutable)
 }
 
+type GijitShadow_Proxy_Mutable struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Mutable(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Mutable{call: call}
+}
+
+func (x *GijitShadow_Proxy_Mutable) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Mutable) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Mutable) Set(a0 int, a1 int, a2 float64) {
+	x.call("Set", a0, a1, a2)
+}
+
+func (x *GijitShadow_Proxy_Mutable) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_MutableBanded(x interface{}) (y mat.MutableBanded, b bool) {
 	y, b = x.(mat.MutableBanded)
 	return
@@ -240,6 +493,50 @@ func GijitShadow_InterfaceConvertTo1_MutableBanded(x interface{}) mat.MutableBan
 	return x.(mat.MutableBanded)
 }
 
+type GijitShadow_Proxy_MutableBanded struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_MutableBanded(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_MutableBanded{call: call}
+}
+
+func (x *GijitShadow_Proxy_MutableBanded) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableBanded) Bandwidth() (int, int) {
+	out := x.call("Bandwidth")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_MutableBanded) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_MutableBanded) SetBand(a0 int, a1 int, a2 float64) {
+	x.call("SetBand", a0, a1, a2)
+}
+
+func (x *GijitShadow_Proxy_MutableBanded) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableBanded) TBand() mat.Banded {
+	out := x.call("TBand")
+	r0, _ := out[0].Interface().(mat.Banded)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_MutableSymBanded(x interface{}) (y mat.MutableSymBanded, b bool) {
 	y, b = x.(mat.MutableSymBanded)
 	return
@@ -249,6 +546,50 @@ func GijitShadow_InterfaceConvertTo1_MutableSymBanded(x interface{}) mat.Mutable
 	return x.(mat.MutableSymBanded)
 }
 
+type GijitShadow_Proxy_MutableSymBanded struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_MutableSymBanded(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_MutableSymBanded{call: call}
+}
+
+func (x *GijitShadow_Proxy_MutableSymBanded) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableSymBanded) Bandwidth() (int, int) {
+	out := x.call("Bandwidth")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_MutableSymBanded) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_MutableSymBanded) SetSymBand(a0 int, a1 int, a2 float64) {
+	x.call("SetSymBand", a0, a1, a2)
+}
+
+func (x *GijitShadow_Proxy_MutableSymBanded) Symmetric() int {
+	out := x.call("Symmetric")
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableSymBanded) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_MutableSymmetric(x interface{}) (y mat.MutableSymmetric, b bool) {
 	y, b = x.(mat.MutableSymmetric)
 	return
@@ -258,6 +599,43 @@ func GijitShadow_InterfaceConvertTo1_MutableSymmetric(x interface{}) mat.Mutable
 	return x.(mat.MutableSymmetric)
 }
 
+type GijitShadow_Proxy_MutableSymmetric struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_MutableSymmetric(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_MutableSymmetric{call: call}
+}
+
+func (x *GijitShadow_Proxy_MutableSymmetric) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableSymmetric) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_MutableSymmetric) SetSym(a0 int, a1 int, a2 float64) {
+	x.call("SetSym", a0, a1, a2)
+}
+
+func (x *GijitShadow_Proxy_MutableSymmetric) Symmetric() int {
+	out := x.call("Symmetric")
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableSymmetric) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_MutableTriangular(x interface{}) (y mat.MutableTriangular, b bool) {
 	y, b = x.(mat.MutableTriangular)
 	return
@@ -267,6 +645,50 @@ func GijitShadow_InterfaceConvertTo1_MutableTriangular(x interface{}) mat.Mutabl
 	return x.(mat.MutableTriangular)
 }
 
+type GijitShadow_Proxy_MutableTriangular struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_MutableTriangular(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_MutableTriangular{call: call}
+}
+
+func (x *GijitShadow_Proxy_MutableTriangular) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableTriangular) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_MutableTriangular) SetTri(a0 int, a1 int, a2 float64) {
+	x.call("SetTri", a0, a1, a2)
+}
+
+func (x *GijitShadow_Proxy_MutableTriangular) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableTriangular) TTri() mat.Triangular {
+	out := x.call("TTri")
+	r0, _ := out[0].Interface().(mat.Triangular)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_MutableTriangular) Triangle() (int, mat.TriKind) {
+	out := x.call("Triangle")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(mat.TriKind)
+	return r0, r1
+}
+
 func GijitShadow_InterfaceConvertTo2_NonZeroDoer(x interface{}) (y mat.NonZeroDoer, b bool) {
 	y, b = x.(mat.NonZeroDoer)
 	return
@@ -276,6 +698,18 @@ func GijitShadow_InterfaceConvertTo1_NonZeroDoer(x interface{}) mat.NonZeroDoer
 	return x.(mat.NonZeroDoer)
 }
 
+type GijitShadow_Proxy_NonZeroDoer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_NonZeroDoer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_NonZeroDoer{call: call}
+}
+
+func (x *GijitShadow_Proxy_NonZeroDoer) DoNonZero(a0 func(i int, j int, v float64)) {
+	x.call("DoNonZero", a0)
+}
+
 func GijitShadow_NewStruct_QR() *mat.QR {
 	return &mat.QR{}
 }
@@ -289,6 +723,20 @@ func GijitShadow_InterfaceConvertTo1_RawBander(x interface{}) mat.RawBander {
 	return x.(mat.RawBander)
 }
 
+type GijitShadow_Proxy_RawBander struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawBander(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawBander{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawBander) RawBand() blas64.Band {
+	out := x.call("RawBand")
+	r0, _ := out[0].Interface().(blas64.Band)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_RawColViewer(x interface{}) (y mat.RawColViewer, b bool) {
 	y, b = x.(mat.RawColViewer)
 	return
@@ -298,6 +746,20 @@ func GijitShadow_InterfaceConvertTo1_RawColViewer(x interface{}) mat.RawColViewe
 	return x.(mat.RawColViewer)
 }
 
+type GijitShadow_Proxy_RawColViewer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawColViewer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawColViewer{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawColViewer) RawColView(a0 int) []float64 {
+	out := x.call("RawColView", a0)
+	r0, _ := out[0].Interface().([]float64)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_RawMatrixSetter(x interface{}) (y mat.RawMatrixSetter, b bool) {
 	y, b = x.(mat.RawMatrixSetter)
 	return
@@ -307,6 +769,18 @@ func GijitShadow_InterfaceConvertTo1_RawMatrixSetter(x interface{}) mat.RawMatri
 	return x.(mat.RawMatrixSetter)
 }
 
+type GijitShadow_Proxy_RawMatrixSetter struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawMatrixSetter(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawMatrixSetter{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawMatrixSetter) SetRawMatrix(a0 blas64.General) {
+	x.call("SetRawMatrix", a0)
+}
+
 func GijitShadow_InterfaceConvertTo2_RawMatrixer(x interface{}) (y mat.RawMatrixer, b bool) {
 	y, b = x.(mat.RawMatrixer)
 	return
@@ -316,6 +790,20 @@ func GijitShadow_InterfaceConvertTo1_RawMatrixer(x interface{}) mat.RawMatrixer
 	return x.(mat.RawMatrixer)
 }
 
+type GijitShadow_Proxy_RawMatrixer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawMatrixer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawMatrixer{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawMatrixer) RawMatrix() blas64.General {
+	out := x.call("RawMatrix")
+	r0, _ := out[0].Interface().(blas64.General)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_RawRowViewer(x interface{}) (y mat.RawRowViewer, b bool) {
 	y, b = x.(mat.RawRowViewer)
 	return
@@ -325,6 +813,20 @@ func GijitShadow_InterfaceConvertTo1_RawRowViewer(x interface{}) mat.RawRowViewe
 	return x.(mat.RawRowViewer)
 }
 
+type GijitShadow_Proxy_RawRowViewer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawRowViewer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawRowViewer{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawRowViewer) RawRowView(a0 int) []float64 {
+	out := x.call("RawRowView", a0)
+	r0, _ := out[0].Interface().([]float64)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_RawSymBander(x interface{}) (y mat.RawSymBander, b bool) {
 	y, b = x.(mat.RawSymBander)
 	return
@@ -334,6 +836,20 @@ func GijitShadow_InterfaceConvertTo1_RawSymBander(x interface{}) mat.RawSymBande
 	return x.(mat.RawSymBander)
 }
 
+type GijitShadow_Proxy_RawSymBander struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawSymBander(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawSymBander{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawSymBander) RawSymBand() blas64.SymmetricBand {
+	out := x.call("RawSymBand")
+	r0, _ := out[0].Interface().(blas64.SymmetricBand)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_RawSymmetricer(x interface{}) (y mat.RawSymmetricer, b bool) {
 	y, b = x.(mat.RawSymmetricer)
 	return
@@ -343,6 +859,20 @@ func GijitShadow_InterfaceConvertTo1_RawSymmetricer(x interface{}) mat.RawSymmet
 	return x.(mat.RawSymmetricer)
 }
 
+type GijitShadow_Proxy_RawSymmetricer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawSymmetricer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawSymmetricer{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawSymmetricer) RawSymmetric() blas64.Symmetric {
+	out := x.call("RawSymmetric")
+	r0, _ := out[0].Interface().(blas64.Symmetric)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_RawTriangular(x interface{}) (y mat.RawTriangular, b bool) {
 	y, b = x.(mat.RawTriangular)
 	return
@@ -352,6 +882,20 @@ func GijitShadow_InterfaceConvertTo1_RawTriangular(x interface{}) mat.RawTriangu
 	return x.(mat.RawTriangular)
 }
 
+type GijitShadow_Proxy_RawTriangular struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawTriangular(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawTriangular{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawTriangular) RawTriangular() blas64.Triangular {
+	out := x.call("RawTriangular")
+	r0, _ := out[0].Interface().(blas64.Triangular)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_RawVectorer(x interface{}) (y mat.RawVectorer, b bool) {
 	y, b = x.(mat.RawVectorer)
 	return
@@ -361,6 +905,20 @@ func GijitShadow_InterfaceConvertTo1_RawVectorer(x interface{}) mat.RawVectorer
 	return x.(mat.RawVectorer)
 }
 
+type GijitShadow_Proxy_RawVectorer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RawVectorer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RawVectorer{call: call}
+}
+
+func (x *GijitShadow_Proxy_RawVectorer) RawVector() blas64.Vector {
+	out := x.call("RawVector")
+	r0, _ := out[0].Interface().(blas64.Vector)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_Reseter(x interface{}) (y mat.Reseter, b bool) {
 	y, b = x.(mat.Reseter)
 	return
@@ -370,6 +928,18 @@ func GijitShadow_InterfaceConvertTo1_Reseter(x interface{}) mat.Reseter {
 	return x.(mat.Reseter)
 }
 
+type GijitShadow_Proxy_Reseter struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Reseter(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Reseter{call: call}
+}
+
+func (x *GijitShadow_Proxy_Reseter) Reset() {
+	x.call("Reset")
+}
+
 func GijitShadow_InterfaceConvertTo2_RowNonZeroDoer(x interface{}) (y mat.RowNonZeroDoer, b bool) {
 	y, b = x.(mat.RowNonZeroDoer)
 	return
@@ -379,6 +949,18 @@ func GijitShadow_InterfaceConvertTo1_RowNonZeroDoer(x interface{}) mat.RowNonZer
 	return x.(mat.RowNonZeroDoer)
 }
 
+type GijitShadow_Proxy_RowNonZeroDoer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RowNonZeroDoer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RowNonZeroDoer{call: call}
+}
+
+func (x *GijitShadow_Proxy_RowNonZeroDoer) DoRowNonZero(a0 int, a1 func(i int, j int, v float64)) {
+	x.call("DoRowNonZero", a0, a1)
+}
+
 func GijitShadow_InterfaceConvertTo2_RowViewer(x interface{}) (y mat.RowViewer, b bool) {
 	y, b = x.(mat.RowViewer)
 	return
@@ -388,6 +970,20 @@ func GijitShadow_InterfaceConvertTo1_RowViewer(x interface{}) mat.RowViewer {
 	return x.(mat.RowViewer)
 }
 
+type GijitShadow_Proxy_RowViewer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RowViewer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RowViewer{call: call}
+}
+
+func (x *GijitShadow_Proxy_RowViewer) RowView(a0 int) mat.Vector {
+	out := x.call("RowView", a0)
+	r0, _ := out[0].Interface().(mat.Vector)
+	return r0
+}
+
 func GijitShadow_NewStruct_SVD() *mat.SVD {
 	return &mat.SVD{}
 }
@@ -409,6 +1005,39 @@ func GijitShadow_InterfaceConvertTo1_Symmetric(x interface{}) mat.Symmetric {
 	return x.(mat.Symmetric)
 }
 
+type GijitShadow_Proxy_Symmetric struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Symmetric(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Symmetric{call: call}
+}
+
+func (x *GijitShadow_Proxy_Symmetric) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Symmetric) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Symmetric) Symmetric() int {
+	out := x.call("Symmetric")
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Symmetric) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
 func GijitShadow_NewStruct_Transpose() *mat.Transpose {
 	return &mat.Transpose{}
 }
@@ -438,6 +1067,46 @@ func GijitShadow_InterfaceConvertTo1_Triangular(x interface{}) mat.Triangular {
 	return x.(mat.Triangular)
 }
 
+type GijitShadow_Proxy_Triangular struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Triangular(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Triangular{call: call}
+}
+
+func (x *GijitShadow_Proxy_Triangular) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Triangular) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Triangular) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Triangular) TTri() mat.Triangular {
+	out := x.call("TTri")
+	r0, _ := out[0].Interface().(mat.Triangular)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Triangular) Triangle() (int, mat.TriKind) {
+	out := x.call("Triangle")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(mat.TriKind)
+	return r0, r1
+}
+
 func GijitShadow_InterfaceConvertTo2_Unconjugator(x interface{}) (y mat.Unconjugator, b bool) {
 	y, b = x.(mat.Unconjugator)
 	return
@@ -447,6 +1116,20 @@ func GijitShadow_InterfaceConvertTo1_Unconjugator(x interface{}) mat.Unconjugato
 	return x.(mat.Unconjugator)
 }
 
+type GijitShadow_Proxy_Unconjugator struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Unconjugator(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Unconjugator{call: call}
+}
+
+func (x *GijitShadow_Proxy_Unconjugator) Unconjugate() mat.CMatrix {
+	out := x.call("Unconjugate")
+	r0, _ := out[0].Interface().(mat.CMatrix)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_UntransposeBander(x interface{}) (y mat.UntransposeBander, b bool) {
 	y, b = x.(mat.UntransposeBander)
 	return
@@ -456,6 +1139,20 @@ func GijitShadow_InterfaceConvertTo1_UntransposeBander(x interface{}) mat.Untran
 	return x.(mat.UntransposeBander)
 }
 
+type GijitShadow_Proxy_UntransposeBander struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_UntransposeBander(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_UntransposeBander{call: call}
+}
+
+func (x *GijitShadow_Proxy_UntransposeBander) UntransposeBand() mat.Banded {
+	out := x.call("UntransposeBand")
+	r0, _ := out[0].Interface().(mat.Banded)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_UntransposeTrier(x interface{}) (y mat.UntransposeTrier, b bool) {
 	y, b = x.(mat.UntransposeTrier)
 	return
@@ -465,6 +1162,20 @@ func GijitShadow_InterfaceConvertTo1_UntransposeTrier(x interface{}) mat.Untrans
 	return x.(mat.UntransposeTrier)
 }
 
+type GijitShadow_Proxy_UntransposeTrier struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_UntransposeTrier(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_UntransposeTrier{call: call}
+}
+
+func (x *GijitShadow_Proxy_UntransposeTrier) UntransposeTri() mat.Triangular {
+	out := x.call("UntransposeTri")
+	r0, _ := out[0].Interface().(mat.Triangular)
+	return r0
+}
+
 func GijitShadow_InterfaceConvertTo2_Untransposer(x interface{}) (y mat.Untransposer, b bool) {
 	y, b = x.(mat.Untransposer)
 	return
@@ -474,6 +1185,20 @@ func GijitShadow_InterfaceConvertTo1_Untransposer(x interface{}) mat.Untranspose
 	return x.(mat.Untransposer)
 }
 
+type GijitShadow_Proxy_Untransposer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Untransposer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Untransposer{call: call}
+}
+
+func (x *GijitShadow_Proxy_Untransposer) Untranspose() mat.Matrix {
+	out := x.call("Untranspose")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
+
 func GijitShadow_NewStruct_VecDense() *mat.VecDense {
 	return &mat.VecDense{}
 }
@@ -486,3 +1211,42 @@ func GijitShadow_InterfaceConvertTo2_Vector(x interface{}) (y mat.Vector, b bool
 func GijitShadow_InterfaceConvertTo1_Vector(x interface{}) mat.Vector {
 	return x.(mat.Vector)
 }
+
+type GijitShadow_Proxy_Vector struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Vector(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Vector{call: call}
+}
+
+func (x *GijitShadow_Proxy_Vector) At(a0 int, a1 int) float64 {
+	out := x.call("At", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Vector) AtVec(a0 int) float64 {
+	out := x.call("AtVec", a0)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Vector) Dims() (int, int) {
+	out := x.call("Dims")
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(int)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Vector) Len() int {
+	out := x.call("Len")
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Vector) T() mat.Matrix {
+	out := x.call("T")
+	r0, _ := out[0].Interface().(mat.Matrix)
+	return r0
+}
@@ -1,12 +1,17 @@
 package shadow_optimize
 
-import "gonum.org/v1/gonum/optimize"
+import (
+	"gonum.org/v1/gonum/optimize"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
 	Pkg["ArmijoConditionMet"] = optimize.ArmijoConditionMet
 	Pkg["CGVariant"] = GijitShadow_InterfaceConvertTo2_CGVariant
+	Proxy[reflect.TypeOf((*optimize.CGVariant)(nil)).Elem()] = GijitShadow_NewProxy_CGVariant
 	Pkg["DefaultSettings"] = optimize.DefaultSettings
 	Pkg["DefaultSettingsGlobal"] = optimize.DefaultSettingsGlobal
 	Pkg["ErrLinesearcherBound"] = optimize.ErrLinesearcherBound
@@ -16,16 +21,24 @@ func init() {
 	Pkg["ErrZeroDimensional"] = optimize.ErrZeroDimensional
 	Pkg["Global"] = optimize.Global
 	Pkg["GlobalMethod"] = GijitShadow_InterfaceConvertTo2_GlobalMethod
+	Proxy[reflect.TypeOf((*optimize.GlobalMethod)(nil)).Elem()] = GijitShadow_NewProxy_GlobalMethod
 	Pkg["Linesearcher"] = GijitShadow_InterfaceConvertTo2_Linesearcher
+	Proxy[reflect.TypeOf((*optimize.Linesearcher)(nil)).Elem()] = GijitShadow_NewProxy_Linesearcher
 	Pkg["Local"] = optimize.Local
 	Pkg["Method"] = GijitShadow_InterfaceConvertTo2_Method
+	Proxy[reflect.TypeOf((*optimize.Method)(nil)).Elem()] = GijitShadow_NewProxy_Method
 	Pkg["Needser"] = GijitShadow_InterfaceConvertTo2_Needser
+	Proxy[reflect.TypeOf((*optimize.Needser)(nil)).Elem()] = GijitShadow_NewProxy_Needser
 	Pkg["NewPrinter"] = optimize.NewPrinter
 	Pkg["NewStatus"] = optimize.NewStatus
 	Pkg["NextDirectioner"] = GijitShadow_InterfaceConvertTo2_NextDirectioner
+	Proxy[reflect.TypeOf((*optimize.NextDirectioner)(nil)).Elem()] = GijitShadow_NewProxy_NextDirectioner
 	Pkg["Recorder"] = GijitShadow_InterfaceConvertTo2_Recorder
+	Proxy[reflect.TypeOf((*optimize.Recorder)(nil)).Elem()] = GijitShadow_NewProxy_Recorder
 	Pkg["Statuser"] = GijitShadow_InterfaceConvertTo2_Statuser
+	Proxy[reflect.TypeOf((*optimize.Statuser)(nil)).Elem()] = GijitShadow_NewProxy_Statuser
 	Pkg["StepSizer"] = GijitShadow_InterfaceConvertTo2_StepSizer
+	Proxy[reflect.TypeOf((*optimize.StepSizer)(nil)).Elem()] = GijitShadow_NewProxy_StepSizer
 	Pkg["StrongWolfeConditionsMet"] = optimize.StrongWolfeConditionsMet
 	Pkg["WeakWolfeConditionsMet"] = optimize.WeakWolfeConditionsMet
 
@@ -55,6 +68,24 @@ func GijitShadow_InterfaceConvertTo1_CGVariant(x interface{}) optimize.CGVariant
 	return x.(optimize.CGVariant)
 }
 
+type GijitShadow_Proxy_CGVariant struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_CGVariant(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_CGVariant{call: call}
+}
+
+func (x *GijitShadow_Proxy_CGVariant) Beta(a0 []float64, a1 []float64, a2 []float64) float64 {
+	out := x.call("Beta", a0, a1, a2)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_CGVariant) Init(a0 *optimize.Location) {
+	x.call("Init", a0)
+}
+
 func GijitShadow_NewStruct_CmaEsChol() *optimize.CmaEsChol {
 	return &optimize.CmaEsChol{}
 }
@@ -92,6 +123,36 @@ func GijitShadow_InterfaceConvertTo1_GlobalMethod(x interface{}) optimize.Global
 	return x.(optimize.GlobalMethod)
 }
 
+type GijitShadow_Proxy_GlobalMethod struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_GlobalMethod(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_GlobalMethod{call: call}
+}
+
+func (x *GijitShadow_Proxy_GlobalMethod) InitGlobal(a0 int, a1 int) int {
+	out := x.call("InitGlobal", a0, a1)
+	r0, _ := out[0].Interface().(int)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_GlobalMethod) Needs() struct {
+	Gradient bool
+	Hessian  bool
+} {
+	out := x.call("Needs")
+	r0, _ := out[0].Interface().(struct {
+		Gradient bool
+		Hessian  bool
+	})
+	return r0
+}
+
+func (x *GijitShadow_Proxy_GlobalMethod) RunGlobal(a0 chan<- optimize.GlobalTask, a1 <-chan optimize.GlobalTask, a2 []optimize.GlobalTask) {
+	x.call("RunGlobal", a0, a1, a2)
+}
+
 func GijitShadow_NewStruct_GlobalTask() *optimize.GlobalTask {
 	return &optimize.GlobalTask{}
 }
@@ -129,6 +190,28 @@ func GijitShadow_InterfaceConvertTo1_Linesearcher(x interface{}) optimize.Linese
 	return x.(optimize.Linesearcher)
 }
 
+type GijitShadow_Proxy_Linesearcher struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Linesearcher(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Linesearcher{call: call}
+}
+
+func (x *GijitShadow_Proxy_Linesearcher) Init(a0 float64, a1 float64, a2 float64) optimize.Operation {
+	out := x.call("Init", a0, a1, a2)
+	r0, _ := out[0].Interface().(optimize.Operation)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Linesearcher) Iterate(a0 float64, a1 float64) (optimize.Operation, float64, error) {
+	out := x.call("Iterate", a0, a1)
+	r0, _ := out[0].Interface().(optimize.Operation)
+	r1, _ := out[1].Interface().(float64)
+	r2, _ := out[2].Interface().(error)
+	return r0, r1, r2
+}
+
 func GijitShadow_NewStruct_Location() *optimize.Location {
 	return &optimize.Location{}
 }
@@ -142,6 +225,40 @@ func GijitShadow_InterfaceConvertTo1_Method(x interface{}) optimize.Method {
 	return x.(optimize.Method)
 }
 
+type GijitShadow_Proxy_Method struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Method(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Method{call: call}
+}
+
+func (x *GijitShadow_Proxy_Method) Init(a0 *optimize.Location) (optimize.Operation, error) {
+	out := x.call("Init", a0)
+	r0, _ := out[0].Interface().(optimize.Operation)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Method) Iterate(a0 *optimize.Location) (optimize.Operation, error) {
+	out := x.call("Iterate", a0)
+	r0, _ := out[0].Interface().(optimize.Operation)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_Method) Needs() struct {
+	Gradient bool
+	Hessian  bool
+} {
+	out := x.call("Needs")
+	r0, _ := out[0].Interface().(struct {
+		Gradient bool
+		Hessian  bool
+	})
+	return r0
+}
+
 func GijitShadow_NewStruct_MoreThuente() *optimize.MoreThuente {
 	return &optimize.MoreThuente{}
 }
@@ -155,6 +272,26 @@ func GijitShadow_InterfaceConvertTo1_Needser(x interface{}) optimize.Needser {
 	return x.(optimize.Needser)
 }
 
+type GijitShadow_Proxy_Needser struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Needser(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Needser{call: call}
+}
+
+func (x *GijitShadow_Proxy_Needser) Needs() struct {
+	Gradient bool
+	Hessian  bool
+} {
+	out := x.call("Needs")
+	r0, _ := out[0].Interface().(struct {
+		Gradient bool
+		Hessian  bool
+	})
+	return r0
+}
+
 func GijitShadow_NewStruct_NelderMead() *optimize.NelderMead {
 	return &optimize.NelderMead{}
 }
@@ -172,6 +309,26 @@ func GijitShadow_InterfaceConvertTo1_NextDirectioner(x interface{}) optimize.Nex
 	return x.(optimize.NextDirectioner)
 }
 
+type GijitShadow_Proxy_NextDirectioner struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_NextDirectioner(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_NextDirectioner{call: call}
+}
+
+func (x *GijitShadow_Proxy_NextDirectioner) InitDirection(a0 *optimize.Location, a1 []float64) float64 {
+	out := x.call("InitDirection", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_NextDirectioner) NextDirection(a0 *optimize.Location, a1 []float64) float64 {
+	out := x.call("NextDirection", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
 func GijitShadow_NewStruct_PolakRibierePolyak() *optimize.PolakRibierePolyak {
 	return &optimize.PolakRibierePolyak{}
 }
@@ -197,6 +354,26 @@ func GijitShadow_InterfaceConvertTo1_Recorder(x interface{}) optimize.Recorder {
 	return x.(optimize.Recorder)
 }
 
+type GijitShadow_Proxy_Recorder struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Recorder(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Recorder{call: call}
+}
+
+func (x *GijitShadow_Proxy_Recorder) Init() error {
+	out := x.call("Init")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Recorder) Record(a0 *optimize.Location, a1 optimize.Operation, a2 *optimize.Stats) error {
+	out := x.call("Record", a0, a1, a2)
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
 func GijitShadow_NewStruct_Result() *optimize.Result {
 	return &optimize.Result{}
 }
@@ -218,6 +395,21 @@ func GijitShadow_InterfaceConvertTo1_Statuser(x interface{}) optimize.Statuser {
 	return x.(optimize.Statuser)
 }
 
+type GijitShadow_Proxy_Statuser struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Statuser(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Statuser{call: call}
+}
+
+func (x *GijitShadow_Proxy_Statuser) Status() (optimize.Status, error) {
+	out := x.call("Status")
+	r0, _ := out[0].Interface().(optimize.Status)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
 func GijitShadow_InterfaceConvertTo2_StepSizer(x interface{}) (y optimize.StepSizer, b bool) {
 	y, b = x.(optimize.StepSizer)
 	return
@@ -226,3 +418,23 @@ func GijitShadow_InterfaceConvertTo2_StepSizer(x interface{}) (y optimize.StepSi
 func GijitShadow_InterfaceConvertTo1_StepSizer(x interface{}) optimize.StepSizer {
 	return x.(optimize.StepSizer)
 }
+
+type GijitShadow_Proxy_StepSizer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_StepSizer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_StepSizer{call: call}
+}
+
+func (x *GijitShadow_Proxy_StepSizer) Init(a0 *optimize.Location, a1 []float64) float64 {
+	out := x.call("Init", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_StepSizer) StepSize(a0 *optimize.Location, a1 []float64) float64 {
+	out := x.call("StepSize", a0, a1)
+	r0, _ := out[0].Interface().(float64)
+	return r0
+}
@@ -1,8 +1,12 @@
 package shadow_unit
 
-import "gonum.org/v1/gonum/unit"
+import (
+	"gonum.org/v1/gonum/unit"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
 	Pkg["Atto"] = unit.Atto
@@ -26,6 +30,7 @@ func init() {
 	Pkg["SymbolExists"] = unit.SymbolExists
 	Pkg["Tera"] = unit.Tera
 	Pkg["Uniter"] = GijitShadow_InterfaceConvertTo2_Uniter
+	Proxy[reflect.TypeOf((*unit.Uniter)(nil)).Elem()] = GijitShadow_NewProxy_Uniter
 	Pkg["Yocto"] = unit.Yocto
 	Pkg["Yotta"] = unit.Yotta
 	Pkg["Zepto"] = unit.Zepto
@@ -44,3 +49,17 @@ func GijitShadow_InterfaceConvertTo2_Uniter(x interface{}) (y unit.Uniter, b boo
 func GijitShadow_InterfaceConvertTo1_Uniter(x interface{}) unit.Uniter {
 	return x.(unit.Uniter)
 }
+
+type GijitShadow_Proxy_Uniter struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Uniter(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Uniter{call: call}
+}
+
+func (x *GijitShadow_Proxy_Uniter) Unit() *unit.Unit {
+	out := x.call("Unit")
+	r0, _ := out[0].Interface().(*unit.Unit)
+	return r0
+}
@@ -1,15 +1,23 @@
 package shadow_io
 
-import "io"
+import (
+	"io"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Pkg["ByteReader"] = GijitShadow_InterfaceConvertTo2_ByteReader
+    Proxy[reflect.TypeOf((*io.ByteReader)(nil)).Elem()] = GijitShadow_NewProxy_ByteReader
     Pkg["ByteScanner"] = GijitShadow_InterfaceConvertTo2_ByteScanner
+    Proxy[reflect.TypeOf((*io.ByteScanner)(nil)).Elem()] = GijitShadow_NewProxy_ByteScanner
     Pkg["ByteWriter"] = GijitShadow_InterfaceConvertTo2_ByteWriter
+    Proxy[reflect.TypeOf((*io.ByteWriter)(nil)).Elem()] = GijitShadow_NewProxy_ByteWriter
     Pkg["Closer"] = GijitShadow_InterfaceConvertTo2_Closer
+    Proxy[reflect.TypeOf((*io.Closer)(nil)).Elem()] = GijitShadow_NewProxy_Closer
     Pkg["Copy"] = io.Copy
     Pkg["CopyBuffer"] = io.CopyBuffer
     Pkg["CopyN"] = io.CopyN
@@ -29,28 +37,44 @@ func init() {
     Ctor["PipeWriter"] = GijitShadow_NewStruct_PipeWriter
     Pkg["ReadAtLeast"] = io.ReadAtLeast
     Pkg["ReadCloser"] = GijitShadow_InterfaceConvertTo2_ReadCloser
+    Proxy[reflect.TypeOf((*io.ReadCloser)(nil)).Elem()] = GijitShadow_NewProxy_ReadCloser
     Pkg["ReadFull"] = io.ReadFull
     Pkg["ReadSeeker"] = GijitShadow_InterfaceConvertTo2_ReadSeeker
+    Proxy[reflect.TypeOf((*io.ReadSeeker)(nil)).Elem()] = GijitShadow_NewProxy_ReadSeeker
     Pkg["ReadWriteCloser"] = GijitShadow_InterfaceConvertTo2_ReadWriteCloser
+    Proxy[reflect.TypeOf((*io.ReadWriteCloser)(nil)).Elem()] = GijitShadow_NewProxy_ReadWriteCloser
     Pkg["ReadWriteSeeker"] = GijitShadow_InterfaceConvertTo2_ReadWriteSeeker
+    Proxy[reflect.TypeOf((*io.ReadWriteSeeker)(nil)).Elem()] = GijitShadow_NewProxy_ReadWriteSeeker
     Pkg["ReadWriter"] = GijitShadow_InterfaceConvertTo2_ReadWriter
+    Proxy[reflect.TypeOf((*io.ReadWriter)(nil)).Elem()] = GijitShadow_NewProxy_ReadWriter
     Pkg["Reader"] = GijitShadow_InterfaceConvertTo2_Reader
+    Proxy[reflect.TypeOf((*io.Reader)(nil)).Elem()] = GijitShadow_NewProxy_Reader
     Pkg["ReaderAt"] = GijitShadow_InterfaceConvertTo2_ReaderAt
+    Proxy[reflect.TypeOf((*io.ReaderAt)(nil)).Elem()] = GijitShadow_NewProxy_ReaderAt
     Pkg["ReaderFrom"] = GijitShadow_InterfaceConvertTo2_ReaderFrom
+    Proxy[reflect.TypeOf((*io.ReaderFrom)(nil)).Elem()] = GijitShadow_NewProxy_ReaderFrom
     Pkg["RuneReader"] = GijitShadow_InterfaceConvertTo2_RuneReader
+    Proxy[reflect.TypeOf((*io.RuneReader)(nil)).Elem()] = GijitShadow_NewProxy_RuneReader
     Pkg["RuneScanner"] = GijitShadow_InterfaceConvertTo2_RuneScanner
+    Proxy[reflect.TypeOf((*io.RuneScanner)(nil)).Elem()] = GijitShadow_NewProxy_RuneScanner
     Ctor["SectionReader"] = GijitShadow_NewStruct_SectionReader
     Pkg["SeekCurrent"] = io.SeekCurrent
     Pkg["SeekEnd"] = io.SeekEnd
     Pkg["SeekStart"] = io.SeekStart
     Pkg["Seeker"] = GijitShadow_InterfaceConvertTo2_Seeker
+    Proxy[reflect.TypeOf((*io.Seeker)(nil)).Elem()] = GijitShadow_NewProxy_Seeker
     Pkg["TeeReader"] = io.TeeReader
     Pkg["WriteCloser"] = GijitShadow_InterfaceConvertTo2_WriteCloser
+    Proxy[reflect.TypeOf((*io.WriteCloser)(nil)).Elem()] = GijitShadow_NewProxy_WriteCloser
     Pkg["WriteSeeker"] = GijitShadow_InterfaceConvertTo2_WriteSeeker
+    Proxy[reflect.TypeOf((*io.WriteSeeker)(nil)).Elem()] = GijitShadow_NewProxy_WriteSeeker
     Pkg["WriteString"] = io.WriteString
     Pkg["Writer"] = GijitShadow_InterfaceConvertTo2_Writer
+    Proxy[reflect.TypeOf((*io.Writer)(nil)).Elem()] = GijitShadow_NewProxy_Writer
     Pkg["WriterAt"] = GijitShadow_InterfaceConvertTo2_WriterAt
+    Proxy[reflect.TypeOf((*io.WriterAt)(nil)).Elem()] = GijitShadow_NewProxy_WriterAt
     Pkg["WriterTo"] = GijitShadow_InterfaceConvertTo2_WriterTo
+    Proxy[reflect.TypeOf((*io.WriterTo)(nil)).Elem()] = GijitShadow_NewProxy_WriterTo
 
 }
 func GijitShadow_InterfaceConvertTo2_ByteReader(x interface{}) (y io.ByteReader, b bool) {
@@ -63,6 +87,22 @@ func GijitShadow_InterfaceConvertTo1_ByteReader(x interface{}) io.ByteReader {
 }
 
 
+type GijitShadow_Proxy_ByteReader struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ByteReader(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ByteReader{call: call}
+}
+
+func (x *GijitShadow_Proxy_ByteReader) ReadByte() (byte, error) {
+	out := x.call("ReadByte")
+	r0, _ := out[0].Interface().(byte)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ByteScanner(x interface{}) (y io.ByteScanner, b bool) {
 	y, b = x.(io.ByteScanner)
 	return
@@ -73,6 +113,28 @@ func GijitShadow_InterfaceConvertTo1_ByteScanner(x interface{}) io.ByteScanner {
 }
 
 
+type GijitShadow_Proxy_ByteScanner struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ByteScanner(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ByteScanner{call: call}
+}
+
+func (x *GijitShadow_Proxy_ByteScanner) ReadByte() (byte, error) {
+	out := x.call("ReadByte")
+	r0, _ := out[0].Interface().(byte)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ByteScanner) UnreadByte() error {
+	out := x.call("UnreadByte")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ByteWriter(x interface{}) (y io.ByteWriter, b bool) {
 	y, b = x.(io.ByteWriter)
 	return
@@ -83,6 +145,21 @@ func GijitShadow_InterfaceConvertTo1_ByteWriter(x interface{}) io.ByteWriter {
 }
 
 
+type GijitShadow_Proxy_ByteWriter struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ByteWriter(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ByteWriter{call: call}
+}
+
+func (x *GijitShadow_Proxy_ByteWriter) WriteByte(a0 byte) error {
+	out := x.call("WriteByte", a0)
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+
 func GijitShadow_InterfaceConvertTo2_Closer(x interface{}) (y io.Closer, b bool) {
 	y, b = x.(io.Closer)
 	return
@@ -93,6 +170,21 @@ func GijitShadow_InterfaceConvertTo1_Closer(x interface{}) io.Closer {
 }
 
 
+type GijitShadow_Proxy_Closer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Closer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Closer{call: call}
+}
+
+func (x *GijitShadow_Proxy_Closer) Close() error {
+	out := x.call("Close")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+
 func GijitShadow_NewStruct_LimitedReader(src *io.LimitedReader) *io.LimitedReader {
     if src == nil {
 	   return &io.LimitedReader{}
@@ -130,6 +222,28 @@ func GijitShadow_InterfaceConvertTo1_ReadCloser(x interface{}) io.ReadCloser {
 }
 
 
+type GijitShadow_Proxy_ReadCloser struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ReadCloser(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ReadCloser{call: call}
+}
+
+func (x *GijitShadow_Proxy_ReadCloser) Close() error {
+	out := x.call("Close")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_ReadCloser) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ReadSeeker(x interface{}) (y io.ReadSeeker, b bool) {
 	y, b = x.(io.ReadSeeker)
 	return
@@ -140,6 +254,29 @@ func GijitShadow_InterfaceConvertTo1_ReadSeeker(x interface{}) io.ReadSeeker {
 }
 
 
+type GijitShadow_Proxy_ReadSeeker struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ReadSeeker(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ReadSeeker{call: call}
+}
+
+func (x *GijitShadow_Proxy_ReadSeeker) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ReadSeeker) Seek(a0 int64, a1 int) (int64, error) {
+	out := x.call("Seek", a0, a1)
+	r0, _ := out[0].Interface().(int64)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ReadWriteCloser(x interface{}) (y io.ReadWriteCloser, b bool) {
 	y, b = x.(io.ReadWriteCloser)
 	return
@@ -150,6 +287,35 @@ func GijitShadow_InterfaceConvertTo1_ReadWriteCloser(x interface{}) io.ReadWrite
 }
 
 
+type GijitShadow_Proxy_ReadWriteCloser struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ReadWriteCloser(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ReadWriteCloser{call: call}
+}
+
+func (x *GijitShadow_Proxy_ReadWriteCloser) Close() error {
+	out := x.call("Close")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_ReadWriteCloser) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ReadWriteCloser) Write(a0 []byte) (int, error) {
+	out := x.call("Write", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ReadWriteSeeker(x interface{}) (y io.ReadWriteSeeker, b bool) {
 	y, b = x.(io.ReadWriteSeeker)
 	return
@@ -160,6 +326,36 @@ func GijitShadow_InterfaceConvertTo1_ReadWriteSeeker(x interface{}) io.ReadWrite
 }
 
 
+type GijitShadow_Proxy_ReadWriteSeeker struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ReadWriteSeeker(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ReadWriteSeeker{call: call}
+}
+
+func (x *GijitShadow_Proxy_ReadWriteSeeker) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ReadWriteSeeker) Seek(a0 int64, a1 int) (int64, error) {
+	out := x.call("Seek", a0, a1)
+	r0, _ := out[0].Interface().(int64)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ReadWriteSeeker) Write(a0 []byte) (int, error) {
+	out := x.call("Write", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ReadWriter(x interface{}) (y io.ReadWriter, b bool) {
 	y, b = x.(io.ReadWriter)
 	return
@@ -170,6 +366,29 @@ func GijitShadow_InterfaceConvertTo1_ReadWriter(x interface{}) io.ReadWriter {
 }
 
 
+type GijitShadow_Proxy_ReadWriter struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ReadWriter(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ReadWriter{call: call}
+}
+
+func (x *GijitShadow_Proxy_ReadWriter) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_ReadWriter) Write(a0 []byte) (int, error) {
+	out := x.call("Write", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_Reader(x interface{}) (y io.Reader, b bool) {
 	y, b = x.(io.Reader)
 	return
@@ -180,6 +399,22 @@ func GijitShadow_InterfaceConvertTo1_Reader(x interface{}) io.Reader {
 }
 
 
+type GijitShadow_Proxy_Reader struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Reader(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Reader{call: call}
+}
+
+func (x *GijitShadow_Proxy_Reader) Read(a0 []byte) (int, error) {
+	out := x.call("Read", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ReaderAt(x interface{}) (y io.ReaderAt, b bool) {
 	y, b = x.(io.ReaderAt)
 	return
@@ -190,6 +425,22 @@ func GijitShadow_InterfaceConvertTo1_ReaderAt(x interface{}) io.ReaderAt {
 }
 
 
+type GijitShadow_Proxy_ReaderAt struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ReaderAt(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ReaderAt{call: call}
+}
+
+func (x *GijitShadow_Proxy_ReaderAt) ReadAt(a0 []byte, a1 int64) (int, error) {
+	out := x.call("ReadAt", a0, a1)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_ReaderFrom(x interface{}) (y io.ReaderFrom, b bool) {
 	y, b = x.(io.ReaderFrom)
 	return
@@ -200,6 +451,22 @@ func GijitShadow_InterfaceConvertTo1_ReaderFrom(x interface{}) io.ReaderFrom {
 }
 
 
+type GijitShadow_Proxy_ReaderFrom struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_ReaderFrom(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_ReaderFrom{call: call}
+}
+
+func (x *GijitShadow_Proxy_ReaderFrom) ReadFrom(a0 io.Reader) (int64, error) {
+	out := x.call("ReadFrom", a0)
+	r0, _ := out[0].Interface().(int64)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_RuneReader(x interface{}) (y io.RuneReader, b bool) {
 	y, b = x.(io.RuneReader)
 	return
@@ -210,6 +477,23 @@ func GijitShadow_InterfaceConvertTo1_RuneReader(x interface{}) io.RuneReader {
 }
 
 
+type GijitShadow_Proxy_RuneReader struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RuneReader(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RuneReader{call: call}
+}
+
+func (x *GijitShadow_Proxy_RuneReader) ReadRune() (rune, int, error) {
+	out := x.call("ReadRune")
+	r0, _ := out[0].Interface().(rune)
+	r1, _ := out[1].Interface().(int)
+	r2, _ := out[2].Interface().(error)
+	return r0, r1, r2
+}
+
+
 func GijitShadow_InterfaceConvertTo2_RuneScanner(x interface{}) (y io.RuneScanner, b bool) {
 	y, b = x.(io.RuneScanner)
 	return
@@ -220,6 +504,29 @@ func GijitShadow_InterfaceConvertTo1_RuneScanner(x interface{}) io.RuneScanner {
 }
 
 
+type GijitShadow_Proxy_RuneScanner struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_RuneScanner(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_RuneScanner{call: call}
+}
+
+func (x *GijitShadow_Proxy_RuneScanner) ReadRune() (rune, int, error) {
+	out := x.call("ReadRune")
+	r0, _ := out[0].Interface().(rune)
+	r1, _ := out[1].Interface().(int)
+	r2, _ := out[2].Interface().(error)
+	return r0, r1, r2
+}
+
+func (x *GijitShadow_Proxy_RuneScanner) UnreadRune() error {
+	out := x.call("UnreadRune")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+
 func GijitShadow_NewStruct_SectionReader(src *io.SectionReader) *io.SectionReader {
     if src == nil {
 	   return &io.SectionReader{}
@@ -239,6 +546,22 @@ func GijitShadow_InterfaceConvertTo1_Seeker(x interface{}) io.Seeker {
 }
 
 
+type GijitShadow_Proxy_Seeker struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Seeker(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Seeker{call: call}
+}
+
+func (x *GijitShadow_Proxy_Seeker) Seek(a0 int64, a1 int) (int64, error) {
+	out := x.call("Seek", a0, a1)
+	r0, _ := out[0].Interface().(int64)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_WriteCloser(x interface{}) (y io.WriteCloser, b bool) {
 	y, b = x.(io.WriteCloser)
 	return
@@ -249,6 +572,28 @@ func GijitShadow_InterfaceConvertTo1_WriteCloser(x interface{}) io.WriteCloser {
 }
 
 
+type GijitShadow_Proxy_WriteCloser struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WriteCloser(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WriteCloser{call: call}
+}
+
+func (x *GijitShadow_Proxy_WriteCloser) Close() error {
+	out := x.call("Close")
+	r0, _ := out[0].Interface().(error)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_WriteCloser) Write(a0 []byte) (int, error) {
+	out := x.call("Write", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_WriteSeeker(x interface{}) (y io.WriteSeeker, b bool) {
 	y, b = x.(io.WriteSeeker)
 	return
@@ -259,6 +604,29 @@ func GijitShadow_InterfaceConvertTo1_WriteSeeker(x interface{}) io.WriteSeeker {
 }
 
 
+type GijitShadow_Proxy_WriteSeeker struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WriteSeeker(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WriteSeeker{call: call}
+}
+
+func (x *GijitShadow_Proxy_WriteSeeker) Seek(a0 int64, a1 int) (int64, error) {
+	out := x.call("Seek", a0, a1)
+	r0, _ := out[0].Interface().(int64)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+func (x *GijitShadow_Proxy_WriteSeeker) Write(a0 []byte) (int, error) {
+	out := x.call("Write", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_Writer(x interface{}) (y io.Writer, b bool) {
 	y, b = x.(io.Writer)
 	return
@@ -269,6 +637,22 @@ func GijitShadow_InterfaceConvertTo1_Writer(x interface{}) io.Writer {
 }
 
 
+type GijitShadow_Proxy_Writer struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Writer(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Writer{call: call}
+}
+
+func (x *GijitShadow_Proxy_Writer) Write(a0 []byte) (int, error) {
+	out := x.call("Write", a0)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_WriterAt(x interface{}) (y io.WriterAt, b bool) {
 	y, b = x.(io.WriterAt)
 	return
@@ -279,6 +663,22 @@ func GijitShadow_InterfaceConvertTo1_WriterAt(x interface{}) io.WriterAt {
 }
 
 
+type GijitShadow_Proxy_WriterAt struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WriterAt(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WriterAt{call: call}
+}
+
+func (x *GijitShadow_Proxy_WriterAt) WriteAt(a0 []byte, a1 int64) (int, error) {
+	out := x.call("WriteAt", a0, a1)
+	r0, _ := out[0].Interface().(int)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 func GijitShadow_InterfaceConvertTo2_WriterTo(x interface{}) (y io.WriterTo, b bool) {
 	y, b = x.(io.WriterTo)
 	return
@@ -289,16 +689,32 @@ func GijitShadow_InterfaceConvertTo1_WriterTo(x interface{}) io.WriterTo {
 }
 
 
+type GijitShadow_Proxy_WriterTo struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_WriterTo(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_WriterTo{call: call}
+}
+
+func (x *GijitShadow_Proxy_WriterTo) WriteTo(a0 io.Writer) (int64, error) {
+	out := x.call("WriteTo", a0)
+	r0, _ := out[0].Interface().(int64)
+	r1, _ := out[1].Interface().(error)
+	return r0, r1
+}
+
+
 
  func InitLua() string {
   return `
-__type__.io ={};
+__type__["io"] ={};
 
 -----------------
 -- struct LimitedReader
 -----------------
 
-__type__.io.LimitedReader = {
+__type__["io"].LimitedReader = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "LimitedReader",
@@ -308,14 +724,14 @@ __type__.io.LimitedReader = {
    return __ctor__io.LimitedReader(src)
  end,
 };
-setmetatable(__type__.io.LimitedReader, __type__.io.LimitedReader);
+setmetatable(__type__["io"].LimitedReader, __type__["io"].LimitedReader);
 
 
 -----------------
 -- struct PipeReader
 -----------------
 
-__type__.io.PipeReader = {
+__type__["io"].PipeReader = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "PipeReader",
@@ -325,14 +741,14 @@ __type__.io.PipeReader = {
    return __ctor__io.PipeReader(src)
  end,
 };
-setmetatable(__type__.io.PipeReader, __type__.io.PipeReader);
+setmetatable(__type__["io"].PipeReader, __type__["io"].PipeReader);
 
 
 -----------------
 -- struct PipeWriter
 -----------------
 
-__type__.io.PipeWriter = {
+__type__["io"].PipeWriter = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "PipeWriter",
@@ -342,14 +758,14 @@ __type__.io.PipeWriter = {
    return __ctor__io.PipeWriter(src)
  end,
 };
-setmetatable(__type__.io.PipeWriter, __type__.io.PipeWriter);
+setmetatable(__type__["io"].PipeWriter, __type__["io"].PipeWriter);
 
 
 -----------------
 -- struct SectionReader
 -----------------
 
-__type__.io.SectionReader = {
+__type__["io"].SectionReader = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "SectionReader",
@@ -359,7 +775,7 @@ __type__.io.SectionReader = {
    return __ctor__io.SectionReader(src)
  end,
 };
-setmetatable(__type__.io.SectionReader, __type__.io.SectionReader);
+setmetatable(__type__["io"].SectionReader, __type__["io"].SectionReader);
 
 
 `}
\ No newline at end of file
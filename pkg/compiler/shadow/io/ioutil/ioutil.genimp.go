@@ -19,6 +19,6 @@ func init() {
 
  func InitLua() string {
   return `
-__type__.ioutil ={};
+__type__["io/ioutil"] ={};
 
 `}
\ No newline at end of file
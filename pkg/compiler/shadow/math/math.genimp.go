@@ -104,7 +104,7 @@ func init() {
 
 func InitLua() string {
 	return `
-__type__.math ={};
+__type__["math"] ={};
 
 `
 }
@@ -1,9 +1,13 @@
 package shadow_rand
 
-import "math/rand"
+import (
+	"math/rand"
+	"reflect"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Pkg["ExpFloat64"] = rand.ExpFloat64
@@ -25,7 +29,9 @@ func init() {
     Pkg["Seed"] = rand.Seed
     Pkg["Shuffle"] = rand.Shuffle
     Pkg["Source"] = GijitShadow_InterfaceConvertTo2_Source
+    Proxy[reflect.TypeOf((*rand.Source)(nil)).Elem()] = GijitShadow_NewProxy_Source
     Pkg["Source64"] = GijitShadow_InterfaceConvertTo2_Source64
+    Proxy[reflect.TypeOf((*rand.Source64)(nil)).Elem()] = GijitShadow_NewProxy_Source64
     Pkg["Uint32"] = rand.Uint32
     Pkg["Uint64"] = rand.Uint64
     Ctor["Zipf"] = GijitShadow_NewStruct_Zipf
@@ -50,6 +56,25 @@ func GijitShadow_InterfaceConvertTo1_Source(x interface{}) rand.Source {
 }
 
 
+type GijitShadow_Proxy_Source struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Source(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Source{call: call}
+}
+
+func (x *GijitShadow_Proxy_Source) Int63() int64 {
+	out := x.call("Int63")
+	r0, _ := out[0].Interface().(int64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Source) Seed(a0 int64)  {
+	x.call("Seed", a0)
+}
+
+
 func GijitShadow_InterfaceConvertTo2_Source64(x interface{}) (y rand.Source64, b bool) {
 	y, b = x.(rand.Source64)
 	return
@@ -60,6 +85,31 @@ func GijitShadow_InterfaceConvertTo1_Source64(x interface{}) rand.Source64 {
 }
 
 
+type GijitShadow_Proxy_Source64 struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Source64(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Source64{call: call}
+}
+
+func (x *GijitShadow_Proxy_Source64) Int63() int64 {
+	out := x.call("Int63")
+	r0, _ := out[0].Interface().(int64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Source64) Seed(a0 int64)  {
+	x.call("Seed", a0)
+}
+
+func (x *GijitShadow_Proxy_Source64) Uint64() uint64 {
+	out := x.call("Uint64")
+	r0, _ := out[0].Interface().(uint64)
+	return r0
+}
+
+
 func GijitShadow_NewStruct_Zipf(src *rand.Zipf) *rand.Zipf {
     if src == nil {
 	   return &rand.Zipf{}
@@ -72,13 +122,13 @@ func GijitShadow_NewStruct_Zipf(src *rand.Zipf) *rand.Zipf {
 
  func InitLua() string {
   return `
-__type__.rand ={};
+__type__["math/rand"] ={};
 
 -----------------
 -- struct Rand
 -----------------
 
-__type__.rand.Rand = {
+__type__["math/rand"].Rand = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Rand",
@@ -88,14 +138,14 @@ __type__.rand.Rand = {
    return __ctor__rand.Rand(src)
  end,
 };
-setmetatable(__type__.rand.Rand, __type__.rand.Rand);
+setmetatable(__type__["math/rand"].Rand, __type__["math/rand"].Rand);
 
 
 -----------------
 -- struct Zipf
 -----------------
 
-__type__.rand.Zipf = {
+__type__["math/rand"].Zipf = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Zipf",
@@ -105,7 +155,7 @@ __type__.rand.Zipf = {
    return __ctor__rand.Zipf(src)
  end,
 };
-setmetatable(__type__.rand.Zipf, __type__.rand.Zipf);
+setmetatable(__type__["math/rand"].Zipf, __type__["math/rand"].Zipf);
 
 
 `}
\ No newline at end of file
@@ -1,9 +1,15 @@
 package shadow_os
 
-import "os"
+import (
+	"io/fs"
+	"os"
+	"reflect"
+	"time"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Pkg["Args"] = os.Args
@@ -27,6 +33,7 @@ func init() {
     Pkg["ExpandEnv"] = os.ExpandEnv
     Ctor["File"] = GijitShadow_NewStruct_File
     Pkg["FileInfo"] = GijitShadow_InterfaceConvertTo2_FileInfo
+    Proxy[reflect.TypeOf((*os.FileInfo)(nil)).Elem()] = GijitShadow_NewProxy_FileInfo
     Pkg["FindProcess"] = os.FindProcess
     Pkg["Getegid"] = os.Getegid
     Pkg["Getenv"] = os.Getenv
@@ -82,6 +89,7 @@ func init() {
     Pkg["SameFile"] = os.SameFile
     Pkg["Setenv"] = os.Setenv
     Pkg["Signal"] = GijitShadow_InterfaceConvertTo2_Signal
+    Proxy[reflect.TypeOf((*os.Signal)(nil)).Elem()] = GijitShadow_NewProxy_Signal
     Pkg["StartProcess"] = os.StartProcess
     Pkg["Stat"] = os.Stat
     Pkg["Stderr"] = os.Stderr
@@ -113,6 +121,51 @@ func GijitShadow_InterfaceConvertTo1_FileInfo(x interface{}) os.FileInfo {
 }
 
 
+type GijitShadow_Proxy_FileInfo struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_FileInfo(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_FileInfo{call: call}
+}
+
+func (x *GijitShadow_Proxy_FileInfo) IsDir() bool {
+	out := x.call("IsDir")
+	r0, _ := out[0].Interface().(bool)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_FileInfo) ModTime() time.Time {
+	out := x.call("ModTime")
+	r0, _ := out[0].Interface().(time.Time)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_FileInfo) Mode() fs.FileMode {
+	out := x.call("Mode")
+	r0, _ := out[0].Interface().(fs.FileMode)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_FileInfo) Name() string {
+	out := x.call("Name")
+	r0, _ := out[0].Interface().(string)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_FileInfo) Size() int64 {
+	out := x.call("Size")
+	r0, _ := out[0].Interface().(int64)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_FileInfo) Sys() interface{} {
+	out := x.call("Sys")
+	r0, _ := out[0].Interface().(interface{})
+	return r0
+}
+
+
 func GijitShadow_NewStruct_LinkError(src *os.LinkError) *os.LinkError {
     if src == nil {
 	   return &os.LinkError{}
@@ -168,6 +221,25 @@ func GijitShadow_InterfaceConvertTo1_Signal(x interface{}) os.Signal {
 }
 
 
+type GijitShadow_Proxy_Signal struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Signal(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Signal{call: call}
+}
+
+func (x *GijitShadow_Proxy_Signal) Signal()  {
+	x.call("Signal")
+}
+
+func (x *GijitShadow_Proxy_Signal) String() string {
+	out := x.call("String")
+	r0, _ := out[0].Interface().(string)
+	return r0
+}
+
+
 func GijitShadow_NewStruct_SyscallError(src *os.SyscallError) *os.SyscallError {
     if src == nil {
 	   return &os.SyscallError{}
@@ -180,13 +252,13 @@ func GijitShadow_NewStruct_SyscallError(src *os.SyscallError) *os.SyscallError {
 
  func InitLua() string {
   return `
-__type__.os ={};
+__type__["os"] ={};
 
 -----------------
 -- struct File
 -----------------
 
-__type__.os.File = {
+__type__["os"].File = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "File",
@@ -196,14 +268,14 @@ __type__.os.File = {
    return __ctor__os.File(src)
  end,
 };
-setmetatable(__type__.os.File, __type__.os.File);
+setmetatable(__type__["os"].File, __type__["os"].File);
 
 
 -----------------
 -- struct LinkError
 -----------------
 
-__type__.os.LinkError = {
+__type__["os"].LinkError = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "LinkError",
@@ -213,14 +285,14 @@ __type__.os.LinkError = {
    return __ctor__os.LinkError(src)
  end,
 };
-setmetatable(__type__.os.LinkError, __type__.os.LinkError);
+setmetatable(__type__["os"].LinkError, __type__["os"].LinkError);
 
 
 -----------------
 -- struct PathError
 -----------------
 
-__type__.os.PathError = {
+__type__["os"].PathError = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "PathError",
@@ -230,14 +302,14 @@ __type__.os.PathError = {
    return __ctor__os.PathError(src)
  end,
 };
-setmetatable(__type__.os.PathError, __type__.os.PathError);
+setmetatable(__type__["os"].PathError, __type__["os"].PathError);
 
 
 -----------------
 -- struct ProcAttr
 -----------------
 
-__type__.os.ProcAttr = {
+__type__["os"].ProcAttr = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "ProcAttr",
@@ -247,14 +319,14 @@ __type__.os.ProcAttr = {
    return __ctor__os.ProcAttr(src)
  end,
 };
-setmetatable(__type__.os.ProcAttr, __type__.os.ProcAttr);
+setmetatable(__type__["os"].ProcAttr, __type__["os"].ProcAttr);
 
 
 -----------------
 -- struct Process
 -----------------
 
-__type__.os.Process = {
+__type__["os"].Process = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Process",
@@ -264,14 +336,14 @@ __type__.os.Process = {
    return __ctor__os.Process(src)
  end,
 };
-setmetatable(__type__.os.Process, __type__.os.Process);
+setmetatable(__type__["os"].Process, __type__["os"].Process);
 
 
 -----------------
 -- struct ProcessState
 -----------------
 
-__type__.os.ProcessState = {
+__type__["os"].ProcessState = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "ProcessState",
@@ -281,14 +353,14 @@ __type__.os.ProcessState = {
    return __ctor__os.ProcessState(src)
  end,
 };
-setmetatable(__type__.os.ProcessState, __type__.os.ProcessState);
+setmetatable(__type__["os"].ProcessState, __type__["os"].ProcessState);
 
 
 -----------------
 -- struct SyscallError
 -----------------
 
-__type__.os.SyscallError = {
+__type__["os"].SyscallError = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "SyscallError",
@@ -298,7 +370,7 @@ __type__.os.SyscallError = {
    return __ctor__os.SyscallError(src)
  end,
 };
-setmetatable(__type__.os.SyscallError, __type__.os.SyscallError);
+setmetatable(__type__["os"].SyscallError, __type__["os"].SyscallError);
 
 
 `}
\ No newline at end of file
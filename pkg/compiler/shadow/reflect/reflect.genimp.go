@@ -116,13 +116,13 @@ func GijitShadow_NewStruct_ValueError(src *reflect.ValueError) *reflect.ValueErr
 
  func InitLua() string {
   return `
-__type__.reflect ={};
+__type__["reflect"] ={};
 
 -----------------
 -- struct Method
 -----------------
 
-__type__.reflect.Method = {
+__type__["reflect"].Method = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Method",
@@ -132,14 +132,14 @@ __type__.reflect.Method = {
    return __ctor__reflect.Method(src)
  end,
 };
-setmetatable(__type__.reflect.Method, __type__.reflect.Method);
+setmetatable(__type__["reflect"].Method, __type__["reflect"].Method);
 
 
 -----------------
 -- struct SelectCase
 -----------------
 
-__type__.reflect.SelectCase = {
+__type__["reflect"].SelectCase = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "SelectCase",
@@ -149,14 +149,14 @@ __type__.reflect.SelectCase = {
    return __ctor__reflect.SelectCase(src)
  end,
 };
-setmetatable(__type__.reflect.SelectCase, __type__.reflect.SelectCase);
+setmetatable(__type__["reflect"].SelectCase, __type__["reflect"].SelectCase);
 
 
 -----------------
 -- struct SliceHeader
 -----------------
 
-__type__.reflect.SliceHeader = {
+__type__["reflect"].SliceHeader = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "SliceHeader",
@@ -166,14 +166,14 @@ __type__.reflect.SliceHeader = {
    return __ctor__reflect.SliceHeader(src)
  end,
 };
-setmetatable(__type__.reflect.SliceHeader, __type__.reflect.SliceHeader);
+setmetatable(__type__["reflect"].SliceHeader, __type__["reflect"].SliceHeader);
 
 
 -----------------
 -- struct StringHeader
 -----------------
 
-__type__.reflect.StringHeader = {
+__type__["reflect"].StringHeader = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "StringHeader",
@@ -183,14 +183,14 @@ __type__.reflect.StringHeader = {
    return __ctor__reflect.StringHeader(src)
  end,
 };
-setmetatable(__type__.reflect.StringHeader, __type__.reflect.StringHeader);
+setmetatable(__type__["reflect"].StringHeader, __type__["reflect"].StringHeader);
 
 
 -----------------
 -- struct StructField
 -----------------
 
-__type__.reflect.StructField = {
+__type__["reflect"].StructField = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "StructField",
@@ -200,14 +200,14 @@ __type__.reflect.StructField = {
    return __ctor__reflect.StructField(src)
  end,
 };
-setmetatable(__type__.reflect.StructField, __type__.reflect.StructField);
+setmetatable(__type__["reflect"].StructField, __type__["reflect"].StructField);
 
 
 -----------------
 -- struct Value
 -----------------
 
-__type__.reflect.Value = {
+__type__["reflect"].Value = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Value",
@@ -217,14 +217,14 @@ __type__.reflect.Value = {
    return __ctor__reflect.Value(src)
  end,
 };
-setmetatable(__type__.reflect.Value, __type__.reflect.Value);
+setmetatable(__type__["reflect"].Value, __type__["reflect"].Value);
 
 
 -----------------
 -- struct ValueError
 -----------------
 
-__type__.reflect.ValueError = {
+__type__["reflect"].ValueError = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "ValueError",
@@ -234,7 +234,7 @@ __type__.reflect.ValueError = {
    return __ctor__reflect.ValueError(src)
  end,
 };
-setmetatable(__type__.reflect.ValueError, __type__.reflect.ValueError);
+setmetatable(__type__["reflect"].ValueError, __type__["reflect"].ValueError);
 
 
 `}
\ No newline at end of file
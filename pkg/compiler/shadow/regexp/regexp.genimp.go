@@ -29,13 +29,13 @@ func GijitShadow_NewStruct_Regexp(src *regexp.Regexp) *regexp.Regexp {
 
  func InitLua() string {
   return `
-__type__.regexp ={};
+__type__["regexp"] ={};
 
 -----------------
 -- struct Regexp
 -----------------
 
-__type__.regexp.Regexp = {
+__type__["regexp"].Regexp = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Regexp",
@@ -45,7 +45,7 @@ __type__.regexp.Regexp = {
    return __ctor__regexp.Regexp(src)
  end,
 };
-setmetatable(__type__.regexp.Regexp, __type__.regexp.Regexp);
+setmetatable(__type__["regexp"].Regexp, __type__["regexp"].Regexp);
 
 
 `}
\ No newline at end of file
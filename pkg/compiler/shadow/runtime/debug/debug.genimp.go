@@ -31,13 +31,13 @@ func GijitShadow_NewStruct_GCStats(src *debug.GCStats) *debug.GCStats {
 
  func InitLua() string {
   return `
-__type__.debug ={};
+__type__["runtime/debug"] ={};
 
 -----------------
 -- struct GCStats
 -----------------
 
-__type__.debug.GCStats = {
+__type__["runtime/debug"].GCStats = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "GCStats",
@@ -47,7 +47,7 @@ __type__.debug.GCStats = {
    return __ctor__debug.GCStats(src)
  end,
 };
-setmetatable(__type__.debug.GCStats, __type__.debug.GCStats);
+setmetatable(__type__["runtime/debug"].GCStats, __type__["runtime/debug"].GCStats);
 
 
 `}
\ No newline at end of file
@@ -1,9 +1,13 @@
 package shadow_runtime
 
-import "runtime"
+import (
+	"reflect"
+	"runtime"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Pkg["BlockProfile"] = runtime.BlockProfile
@@ -15,6 +19,7 @@ func init() {
     Pkg["CallersFrames"] = runtime.CallersFrames
     Pkg["Compiler"] = runtime.Compiler
     Pkg["Error"] = GijitShadow_InterfaceConvertTo2_Error
+    Proxy[reflect.TypeOf((*runtime.Error)(nil)).Elem()] = GijitShadow_NewProxy_Error
     Ctor["Frame"] = GijitShadow_NewStruct_Frame
     Ctor["Frames"] = GijitShadow_NewStruct_Frames
     Ctor["Func"] = GijitShadow_NewStruct_Func
@@ -73,6 +78,25 @@ func GijitShadow_InterfaceConvertTo1_Error(x interface{}) runtime.Error {
 }
 
 
+type GijitShadow_Proxy_Error struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Error(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Error{call: call}
+}
+
+func (x *GijitShadow_Proxy_Error) Error() string {
+	out := x.call("Error")
+	r0, _ := out[0].Interface().(string)
+	return r0
+}
+
+func (x *GijitShadow_Proxy_Error) RuntimeError()  {
+	x.call("RuntimeError")
+}
+
+
 func GijitShadow_NewStruct_Frame(src *runtime.Frame) *runtime.Frame {
     if src == nil {
 	   return &runtime.Frame{}
@@ -139,13 +163,13 @@ func GijitShadow_NewStruct_TypeAssertionError(src *runtime.TypeAssertionError) *
 
  func InitLua() string {
   return `
-__type__.runtime ={};
+__type__["runtime"] ={};
 
 -----------------
 -- struct BlockProfileRecord
 -----------------
 
-__type__.runtime.BlockProfileRecord = {
+__type__["runtime"].BlockProfileRecord = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "BlockProfileRecord",
@@ -155,14 +179,14 @@ __type__.runtime.BlockProfileRecord = {
    return __ctor__runtime.BlockProfileRecord(src)
  end,
 };
-setmetatable(__type__.runtime.BlockProfileRecord, __type__.runtime.BlockProfileRecord);
+setmetatable(__type__["runtime"].BlockProfileRecord, __type__["runtime"].BlockProfileRecord);
 
 
 -----------------
 -- struct Frame
 -----------------
 
-__type__.runtime.Frame = {
+__type__["runtime"].Frame = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Frame",
@@ -172,14 +196,14 @@ __type__.runtime.Frame = {
    return __ctor__runtime.Frame(src)
  end,
 };
-setmetatable(__type__.runtime.Frame, __type__.runtime.Frame);
+setmetatable(__type__["runtime"].Frame, __type__["runtime"].Frame);
 
 
 -----------------
 -- struct Frames
 -----------------
 
-__type__.runtime.Frames = {
+__type__["runtime"].Frames = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Frames",
@@ -189,14 +213,14 @@ __type__.runtime.Frames = {
    return __ctor__runtime.Frames(src)
  end,
 };
-setmetatable(__type__.runtime.Frames, __type__.runtime.Frames);
+setmetatable(__type__["runtime"].Frames, __type__["runtime"].Frames);
 
 
 -----------------
 -- struct Func
 -----------------
 
-__type__.runtime.Func = {
+__type__["runtime"].Func = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Func",
@@ -206,14 +230,14 @@ __type__.runtime.Func = {
    return __ctor__runtime.Func(src)
  end,
 };
-setmetatable(__type__.runtime.Func, __type__.runtime.Func);
+setmetatable(__type__["runtime"].Func, __type__["runtime"].Func);
 
 
 -----------------
 -- struct MemProfileRecord
 -----------------
 
-__type__.runtime.MemProfileRecord = {
+__type__["runtime"].MemProfileRecord = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "MemProfileRecord",
@@ -223,14 +247,14 @@ __type__.runtime.MemProfileRecord = {
    return __ctor__runtime.MemProfileRecord(src)
  end,
 };
-setmetatable(__type__.runtime.MemProfileRecord, __type__.runtime.MemProfileRecord);
+setmetatable(__type__["runtime"].MemProfileRecord, __type__["runtime"].MemProfileRecord);
 
 
 -----------------
 -- struct MemStats
 -----------------
 
-__type__.runtime.MemStats = {
+__type__["runtime"].MemStats = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "MemStats",
@@ -240,14 +264,14 @@ __type__.runtime.MemStats = {
    return __ctor__runtime.MemStats(src)
  end,
 };
-setmetatable(__type__.runtime.MemStats, __type__.runtime.MemStats);
+setmetatable(__type__["runtime"].MemStats, __type__["runtime"].MemStats);
 
 
 -----------------
 -- struct StackRecord
 -----------------
 
-__type__.runtime.StackRecord = {
+__type__["runtime"].StackRecord = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "StackRecord",
@@ -257,14 +281,14 @@ __type__.runtime.StackRecord = {
    return __ctor__runtime.StackRecord(src)
  end,
 };
-setmetatable(__type__.runtime.StackRecord, __type__.runtime.StackRecord);
+setmetatable(__type__["runtime"].StackRecord, __type__["runtime"].StackRecord);
 
 
 -----------------
 -- struct TypeAssertionError
 -----------------
 
-__type__.runtime.TypeAssertionError = {
+__type__["runtime"].TypeAssertionError = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "TypeAssertionError",
@@ -274,7 +298,7 @@ __type__.runtime.TypeAssertionError = {
    return __ctor__runtime.TypeAssertionError(src)
  end,
 };
-setmetatable(__type__.runtime.TypeAssertionError, __type__.runtime.TypeAssertionError);
+setmetatable(__type__["runtime"].TypeAssertionError, __type__["runtime"].TypeAssertionError);
 
 
 `}
\ No newline at end of file
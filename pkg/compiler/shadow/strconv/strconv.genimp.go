@@ -55,13 +55,13 @@ func GijitShadow_NewStruct_NumError(src *strconv.NumError) *strconv.NumError {
 
  func InitLua() string {
   return `
-__type__.strconv ={};
+__type__["strconv"] ={};
 
 -----------------
 -- struct NumError
 -----------------
 
-__type__.strconv.NumError = {
+__type__["strconv"].NumError = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "NumError",
@@ -71,7 +71,7 @@ __type__.strconv.NumError = {
    return __ctor__strconv.NumError(src)
  end,
 };
-setmetatable(__type__.strconv.NumError, __type__.strconv.NumError);
+setmetatable(__type__["strconv"].NumError, __type__["strconv"].NumError);
 
 
 `}
\ No newline at end of file
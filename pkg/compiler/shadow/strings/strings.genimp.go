@@ -86,13 +86,13 @@ func GijitShadow_NewStruct_Replacer(src *strings.Replacer) *strings.Replacer {
 
  func InitLua() string {
   return `
-__type__.strings ={};
+__type__["strings"] ={};
 
 -----------------
 -- struct Builder
 -----------------
 
-__type__.strings.Builder = {
+__type__["strings"].Builder = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Builder",
@@ -102,14 +102,14 @@ __type__.strings.Builder = {
    return __ctor__strings.Builder(src)
  end,
 };
-setmetatable(__type__.strings.Builder, __type__.strings.Builder);
+setmetatable(__type__["strings"].Builder, __type__["strings"].Builder);
 
 
 -----------------
 -- struct Reader
 -----------------
 
-__type__.strings.Reader = {
+__type__["strings"].Reader = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Reader",
@@ -119,14 +119,14 @@ __type__.strings.Reader = {
    return __ctor__strings.Reader(src)
  end,
 };
-setmetatable(__type__.strings.Reader, __type__.strings.Reader);
+setmetatable(__type__["strings"].Reader, __type__["strings"].Reader);
 
 
 -----------------
 -- struct Replacer
 -----------------
 
-__type__.strings.Replacer = {
+__type__["strings"].Replacer = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Replacer",
@@ -136,7 +136,7 @@ __type__.strings.Replacer = {
    return __ctor__strings.Replacer(src)
  end,
 };
-setmetatable(__type__.strings.Replacer, __type__.strings.Replacer);
+setmetatable(__type__["strings"].Replacer, __type__["strings"].Replacer);
 
 
 `}
\ No newline at end of file
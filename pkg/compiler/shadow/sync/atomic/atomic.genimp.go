@@ -50,13 +50,13 @@ func GijitShadow_NewStruct_Value(src *atomic.Value) *atomic.Value {
 
  func InitLua() string {
   return `
-__type__.atomic ={};
+__type__["sync/atomic"] ={};
 
 -----------------
 -- struct Value
 -----------------
 
-__type__.atomic.Value = {
+__type__["sync/atomic"].Value = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Value",
@@ -66,7 +66,7 @@ __type__.atomic.Value = {
    return __ctor__atomic.Value(src)
  end,
 };
-setmetatable(__type__.atomic.Value, __type__.atomic.Value);
+setmetatable(__type__["sync/atomic"].Value, __type__["sync/atomic"].Value);
 
 
 `}
\ No newline at end of file
@@ -1,13 +1,18 @@
 package shadow_sync
 
-import "sync"
+import (
+	"reflect"
+	"sync"
+)
 
 var Pkg = make(map[string]interface{})
 var Ctor = make(map[string]interface{})
+var Proxy = make(map[reflect.Type]func(call func(string, ...interface{}) []reflect.Value) interface{})
 
 func init() {
     Ctor["Cond"] = GijitShadow_NewStruct_Cond
     Pkg["Locker"] = GijitShadow_InterfaceConvertTo2_Locker
+    Proxy[reflect.TypeOf((*sync.Locker)(nil)).Elem()] = GijitShadow_NewProxy_Locker
     Ctor["Map"] = GijitShadow_NewStruct_Map
     Ctor["Mutex"] = GijitShadow_NewStruct_Mutex
     Pkg["NewCond"] = sync.NewCond
@@ -36,6 +41,23 @@ func GijitShadow_InterfaceConvertTo1_Locker(x interface{}) sync.Locker {
 }
 
 
+type GijitShadow_Proxy_Locker struct {
+	call func(string, ...interface{}) []reflect.Value
+}
+
+func GijitShadow_NewProxy_Locker(call func(string, ...interface{}) []reflect.Value) interface{} {
+	return &GijitShadow_Proxy_Locker{call: call}
+}
+
+func (x *GijitShadow_Proxy_Locker) Lock()  {
+	x.call("Lock")
+}
+
+func (x *GijitShadow_Proxy_Locker) Unlock()  {
+	x.call("Unlock")
+}
+
+
 func GijitShadow_NewStruct_Map(src *sync.Map) *sync.Map {
     if src == nil {
 	   return &sync.Map{}
@@ -93,13 +115,13 @@ func GijitShadow_NewStruct_WaitGroup(src *sync.WaitGroup) *sync.WaitGroup {
 
  func InitLua() string {
   return `
-__type__.sync ={};
+__type__["sync"] ={};
 
 -----------------
 -- struct Cond
 -----------------
 
-__type__.sync.Cond = {
+__type__["sync"].Cond = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Cond",
@@ -109,14 +131,14 @@ __type__.sync.Cond = {
    return __ctor__sync.Cond(src)
  end,
 };
-setmetatable(__type__.sync.Cond, __type__.sync.Cond);
+setmetatable(__type__["sync"].Cond, __type__["sync"].Cond);
 
 
 -----------------
 -- struct Map
 -----------------
 
-__type__.sync.Map = {
+__type__["sync"].Map = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Map",
@@ -126,14 +148,14 @@ __type__.sync.Map = {
    return __ctor__sync.Map(src)
  end,
 };
-setmetatable(__type__.sync.Map, __type__.sync.Map);
+setmetatable(__type__["sync"].Map, __type__["sync"].Map);
 
 
 -----------------
 -- struct Mutex
 -----------------
 
-__type__.sync.Mutex = {
+__type__["sync"].Mutex = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Mutex",
@@ -143,14 +165,14 @@ __type__.sync.Mutex = {
    return __ctor__sync.Mutex(src)
  end,
 };
-setmetatable(__type__.sync.Mutex, __type__.sync.Mutex);
+setmetatable(__type__["sync"].Mutex, __type__["sync"].Mutex);
 
 
 -----------------
 -- struct Once
 -----------------
 
-__type__.sync.Once = {
+__type__["sync"].Once = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Once",
@@ -160,14 +182,14 @@ __type__.sync.Once = {
    return __ctor__sync.Once(src)
  end,
 };
-setmetatable(__type__.sync.Once, __type__.sync.Once);
+setmetatable(__type__["sync"].Once, __type__["sync"].Once);
 
 
 -----------------
 -- struct Pool
 -----------------
 
-__type__.sync.Pool = {
+__type__["sync"].Pool = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Pool",
@@ -177,14 +199,14 @@ __type__.sync.Pool = {
    return __ctor__sync.Pool(src)
  end,
 };
-setmetatable(__type__.sync.Pool, __type__.sync.Pool);
+setmetatable(__type__["sync"].Pool, __type__["sync"].Pool);
 
 
 -----------------
 -- struct RWMutex
 -----------------
 
-__type__.sync.RWMutex = {
+__type__["sync"].RWMutex = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "RWMutex",
@@ -194,14 +216,14 @@ __type__.sync.RWMutex = {
    return __ctor__sync.RWMutex(src)
  end,
 };
-setmetatable(__type__.sync.RWMutex, __type__.sync.RWMutex);
+setmetatable(__type__["sync"].RWMutex, __type__["sync"].RWMutex);
 
 
 -----------------
 -- struct WaitGroup
 -----------------
 
-__type__.sync.WaitGroup = {
+__type__["sync"].WaitGroup = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "WaitGroup",
@@ -211,7 +233,7 @@ __type__.sync.WaitGroup = {
    return __ctor__sync.WaitGroup(src)
  end,
 };
-setmetatable(__type__.sync.WaitGroup, __type__.sync.WaitGroup);
+setmetatable(__type__["sync"].WaitGroup, __type__["sync"].WaitGroup);
 
 
 `}
\ No newline at end of file
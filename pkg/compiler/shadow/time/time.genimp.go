@@ -95,13 +95,13 @@ func GijitShadow_NewStruct_Timer(src *time.Timer) *time.Timer {
 
  func InitLua() string {
   return `
-__type__.time ={};
+__type__["time"] ={};
 
 -----------------
 -- struct Location
 -----------------
 
-__type__.time.Location = {
+__type__["time"].Location = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Location",
@@ -111,14 +111,14 @@ __type__.time.Location = {
    return __ctor__time.Location(src)
  end,
 };
-setmetatable(__type__.time.Location, __type__.time.Location);
+setmetatable(__type__["time"].Location, __type__["time"].Location);
 
 
 -----------------
 -- struct ParseError
 -----------------
 
-__type__.time.ParseError = {
+__type__["time"].ParseError = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "ParseError",
@@ -128,14 +128,14 @@ __type__.time.ParseError = {
    return __ctor__time.ParseError(src)
  end,
 };
-setmetatable(__type__.time.ParseError, __type__.time.ParseError);
+setmetatable(__type__["time"].ParseError, __type__["time"].ParseError);
 
 
 -----------------
 -- struct Ticker
 -----------------
 
-__type__.time.Ticker = {
+__type__["time"].Ticker = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Ticker",
@@ -145,14 +145,14 @@ __type__.time.Ticker = {
    return __ctor__time.Ticker(src)
  end,
 };
-setmetatable(__type__.time.Ticker, __type__.time.Ticker);
+setmetatable(__type__["time"].Ticker, __type__["time"].Ticker);
 
 
 -----------------
 -- struct Time
 -----------------
 
-__type__.time.Time = {
+__type__["time"].Time = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Time",
@@ -162,14 +162,14 @@ __type__.time.Time = {
    return __ctor__time.Time(src)
  end,
 };
-setmetatable(__type__.time.Time, __type__.time.Time);
+setmetatable(__type__["time"].Time, __type__["time"].Time);
 
 
 -----------------
 -- struct Timer
 -----------------
 
-__type__.time.Timer = {
+__type__["time"].Timer = {
  id=0,
  __name = "native_Go_struct_type_wrapper",
  __native_type = "Timer",
@@ -179,7 +179,7 @@ __type__.time.Timer = {
    return __ctor__time.Timer(src)
  end,
 };
-setmetatable(__type__.time.Timer, __type__.time.Timer);
+setmetatable(__type__["time"].Timer, __type__["time"].Timer);
 
 
 `}
\ No newline at end of file
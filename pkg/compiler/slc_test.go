@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	cv "github.com/glycerine/goconvey/convey"
+	"github.com/glycerine/luar"
 )
 
 func Test086SlicesPointToArrays(t *testing.T) {
@@ -168,3 +169,109 @@ func Test091AppendSlices(t *testing.T) {
 	})
 
 }
+
+func Test092NumericSlicesAreFFIBackedAndSharedWithGo(t *testing.T) {
+
+	cv.Convey(`[]float64 and [3]int64 live in calloc'd FFI arrays and keep Go's slice semantics. Go functions get a copy, or, with luar.NumericSliceViews, the same memory; and never the memory of elements of another C type.`, t, func() {
+
+		src := `
+// stubs for the type checker; replaced with Go functions below.
+func scale(xs []float64, k float64) {}
+func sum(a [3]int64) int64 { return 0 }
+func fsum(xs []int64) float64 { return 0 }
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(src))
+		LuaRunAndReport(vm, string(translation))
+
+		tk := vm.goro.newTicket("", false)
+		tk.regmap["scale"] = func(xs []float64, k float64) {
+			for i := range xs {
+				xs[i] *= k
+			}
+		}
+		tk.regmap["sum"] = func(a [3]int64) int64 { return a[0] + a[1] + a[2] }
+		tk.regmap["fsum"] = func(xs []float64) float64 { return xs[0] + xs[1] }
+		panicOn(tk.Do())
+
+		code := `
+s := make([]float64, 2, 2)
+s[0] = 1.5
+s[1] = 2
+s = append(s, 3, 4)
+t := s[1:3]
+t[0] = 20
+copy(s[1:], s)
+scale(s[2:], 10)
+s0, s1, s2, s3 := s[0], s[1], s[2], s[3]
+ln, cp := len(t), cap(t)
+
+a := [3]int64{1, 2, 3}
+b := a
+b[0] = 100
+total := sum(a) + sum(b)
+`
+		translation = inc.trMust([]byte(code))
+		fmt.Printf("\n translation='%s'\n", translation)
+		LuaRunAndReport(vm, string(translation))
+
+		LuaRunAndReport(vm, `sFFI = type(s.__array.__data) == "cdata"; aFFI = type(a.__val.__data) == "cdata"`)
+		LuaMustBool(vm, "sFFI", true)
+		LuaMustBool(vm, "aFFI", true)
+
+		// scale scaled a copy.
+		LuaMustFloat64(vm, "s0", 1.5)
+		LuaMustFloat64(vm, "s1", 1.5)
+		LuaMustFloat64(vm, "s2", 20)
+		LuaMustFloat64(vm, "s3", 3)
+		LuaMustInt(vm, "ln", 2)
+		LuaMustInt(vm, "cp", 3)
+		LuaMustInt64(vm, "total", 111)
+
+		luar.NumericSliceViews = true
+		defer func() { luar.NumericSliceViews = false }()
+
+		code2 := `
+scale(s[2:], 10)
+v2, v3 := s[2], s[3]
+is := []int64{1, 2}
+fs := fsum(is)
+`
+		translation = inc.trMust([]byte(code2))
+		LuaRunAndReport(vm, string(translation))
+
+		// scale scaled s itself.
+		LuaMustFloat64(vm, "v2", 200)
+		LuaMustFloat64(vm, "v3", 30)
+		// the int64s were converted, not read as doubles.
+		LuaMustFloat64(vm, "fs", 3)
+	})
+}
+
+func Test092bFfiViewsFlagAndCommand(t *testing.T) {
+
+	cv.Convey("-ffi-views starts gi handing Go views of numeric slices; ':ffi views off|on' switches between views and copies.", t, func() {
+		r, done := newTestRepl("-ffi-views")
+		defer done()
+		defer func() { luar.NumericSliceViews = false }()
+		cv.So(luar.NumericSliceViews, cv.ShouldBeTrue)
+
+		_, err := r.ffiCmd([]string{"views", "off"})
+		panicOn(err)
+		cv.So(luar.NumericSliceViews, cv.ShouldBeFalse)
+		cv.So(ffiViewsStatus(), cv.ShouldEqual, "Go gets copies of numeric slices.\n")
+
+		_, err = r.ffiCmd([]string{"views", "on"})
+		panicOn(err)
+		cv.So(luar.NumericSliceViews, cv.ShouldBeTrue)
+
+		_, err = r.ffiCmd([]string{"views", "maybe"})
+		cv.So(err, cv.ShouldNotBeNil)
+		_, err = r.ffiCmd(nil)
+		cv.So(err, cv.ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,5 @@
+package spkg_tst6
+
+func Add(a, b int) int {
+	return a + b
+}
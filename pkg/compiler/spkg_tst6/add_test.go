@@ -0,0 +1,36 @@
+package spkg_tst6
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	if Add(2, 2) != 4 {
+		t.Fatal("2 + 2 != 4")
+	}
+	t.Log("add ok")
+}
+
+func TestSub(t *testing.T) {
+	t.Run("one two", func(t *testing.T) {
+		if Add(1, 2) != 3 {
+			t.Error("1 + 2 != 3")
+		}
+	})
+	t.Run("skips", func(t *testing.T) {
+		t.Skip("skipped")
+	})
+}
+
+func BenchmarkAdd(b *testing.B) {
+	tot := 0
+	for i := 0; i < b.N; i++ {
+		tot = Add(tot, i)
+	}
+}
+
+func ExampleAdd() {
+	fmt.Println(Add(2, 2))
+	// Output: 4
+}
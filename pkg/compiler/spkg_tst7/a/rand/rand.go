@@ -0,0 +1,17 @@
+package rand
+
+// Name tells this rand from spkg_tst7/b/rand,
+// which has the same package name.
+func Name() string {
+	return "a"
+}
+
+// Source has the same name as the type in
+// spkg_tst7/b/rand, but is a different type.
+type Source struct {
+	Seed int
+}
+
+func (s Source) Who() string {
+	return "a"
+}
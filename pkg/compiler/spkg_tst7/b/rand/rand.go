@@ -0,0 +1,17 @@
+package rand
+
+// Name tells this rand from spkg_tst7/a/rand,
+// which has the same package name.
+func Name() string {
+	return "b"
+}
+
+// Source has the same name as the type in
+// spkg_tst7/a/rand, but is a different type.
+type Source struct {
+	Seed int
+}
+
+func (s Source) Who() string {
+	return "b"
+}
@@ -0,0 +1,30 @@
+package spkg_tst8
+
+import "github.com/gijit/gi/pkg/compiler/spkg_tst4"
+
+type oops struct{}
+
+func (*oops) Error() string { return "oops" }
+
+// Kind names the dynamic type of x, using type names
+// from the universe and from another package.
+func Kind(x interface{}) string {
+	switch x.(type) {
+	case int:
+		return "int"
+	case string:
+		return "string"
+	case error:
+		return "error"
+	case *spkg_tst4.R:
+		return "R"
+	}
+	return "other"
+}
+
+// Kinds converts and boxes values of each sort.
+func Kinds() string {
+	var e interface{} = spkg_tst4.NewR()
+	return Kind(int(2.0)) + " " + Kind("s") + " " +
+		Kind(&oops{}) + " " + Kind(e) + " " + Kind(1.5)
+}
@@ -24,7 +24,11 @@ func (c *funcContext) translateStmtList(stmts []ast.Stmt) {
 func (c *funcContext) translateStmt(stmt ast.Stmt, label *types.Label) {
 	pp("translateStmt top stmt='%#v'", stmt)
 
-	c.SetPos(stmt.Pos())
+	// statements we make up, as for a send, have no
+	// position; keep that of the one they stand for.
+	if stmt.Pos().IsValid() {
+		c.SetPos(stmt.Pos())
+	}
 
 	stmt = filter.IncDecStmt(stmt, c.p.Info.Info)
 	stmt = filter.Assign(stmt, c.p.Info.Info, c.p.Info.Pkg)
@@ -122,7 +126,9 @@ func (c *funcContext) translateStmt(stmt ast.Stmt, label *types.Label) {
 				return c.formatExpr("%s == __gi_ifaceNil", refVar)
 			}
 			// jea, type assertion place 1
-			return c.formatExpr(`__assertType(%s, %s, 1)`, refVar, c.typeName(c.p.TypeOf(cond), nil))
+			// the ok, since a failed assertion still
+			// gives the zero value, which may be truthy.
+			return c.formatExpr(`select(2, __assertType(%s, %s, 1))`, refVar, c.typeName(c.p.TypeOf(cond), nil))
 			//return c.formatExpr("__assertType(%s, %s, true)[1]", refVar, c.typeName(0, c.p.TypeOf(cond)))
 		}
 		var caseClauses []*ast.CaseClause
@@ -132,10 +138,15 @@ func (c *funcContext) translateStmt(stmt ast.Stmt, label *types.Label) {
 			var bodyPrefix []ast.Stmt
 			if implicit := c.p.Implicits[clause]; implicit != nil {
 				value := refVar
-				if typesutil.IsJsObject(implicit.Type().Underlying()) {
-					value += ".__val.object"
-				} else if _, ok := implicit.Type().Underlying().(*types.Interface); !ok {
-					value += ".__val"
+				switch u := implicit.Type().Underlying().(type) {
+				case *types.Interface, *types.Basic:
+					// basic values are not boxed in gijit.
+				default:
+					if typesutil.IsJsObject(u) {
+						value += ".__val.object"
+					} else {
+						value += ".__val"
+					}
 				}
 				bodyPrefix = []ast.Stmt{&ast.AssignStmt{
 					Lhs: []ast.Expr{c.newIdent(c.objectName(implicit), implicit.Type())},
@@ -578,7 +589,8 @@ __defer_func(%s)
 				channels = append(channels, "{}")
 				hasDefault = true
 			case *ast.ExprStmt:
-				channels = append(channels, c.formatExpr("{%e}", astutil.RemoveParens(comm.X).(*ast.UnaryExpr).X).String())
+				// receive, the value dropped
+				channels = append(channels, c.formatExpr("{c=%e, op=__task.RECV}", astutil.RemoveParens(comm.X).(*ast.UnaryExpr).X).String())
 			case *ast.AssignStmt:
 				// receive
 				channels = append(channels, c.formatExpr("{c=%e, op=__task.RECV}", astutil.RemoveParens(comm.Rhs[0]).(*ast.UnaryExpr).X).String())
@@ -767,6 +779,10 @@ func (c *funcContext) translateLoopingStmt(cond func() string, body *ast.BlockSt
 	c.Printf("while (true) do")
 	//c.PrintCond(!flatten, "while (true) do", fmt.Sprintf("case %d:", data.beginCase))
 	c.Indent(func() {
+		if c.p.emitPreempt {
+			// a safe point, for preemption; see prelude/chan.lua.
+			c.Printf("if __gijit_preemptDue then __task.preempt(); end")
+		}
 		condStr := cond()
 		if condStr != "true" {
 			c.Printf("if (not (%s)) then break; end", condStr)
@@ -1006,6 +1022,10 @@ func (c *funcContext) translateAssign(lhs, rhs ast.Expr, define bool) string {
 	lhsType := c.p.TypeOf(lhs)
 	//pp("lhsType = '%#v'/  lhs=%#v/%T; define=%v.  c='%#v'\n", lhsType, lhs, lhs, define, c)
 	rhsExpr := c.translateImplicitConversion(rhs, lhsType)
+	if c.isCgoPointerField(lhs) {
+		// see cgo.go
+		rhsExpr = c.formatExpr("__cgoPtr(%s)", rhsExpr)
+	}
 	//pp("rhsExpr = '%#v'/'%s'", rhsExpr, rhsExpr)
 	if _, ok := rhs.(*ast.CompositeLit); ok && define {
 		pp("we see a CompositLit, calling translateExpr on it, lhs='%#v', rhsExpr='%#v'", lhs, rhsExpr)
@@ -1027,8 +1047,15 @@ func (c *funcContext) translateAssign(lhs, rhs ast.Expr, define bool) string {
 	pp("rhsExpr = '%#v'; src='%s'", rhsExpr, rhsExpr.str)
 
 	isReflectValue := false
-	if named, ok := lhsType.(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "reflect" && named.Obj().Name() == "Value" {
-		isReflectValue = true
+	if named, ok := lhsType.(*types.Named); ok && named.Obj().Pkg() != nil {
+		switch named.Obj().Pkg().Path() {
+		case "reflect":
+			isReflectValue = named.Obj().Name() == "Value"
+		case "github.com/gijit/gi/pkg/compiler/shadow/reflect":
+			// prelude/reflect.lua makes these, as Lua
+			// tables; they are never Go's structs.
+			isReflectValue = true
+		}
 	}
 	if !isReflectValue { // this is a performance hack, but it is safe since reflect.Value has no exported fields and the reflect package does not violate this assumption
 		pp("not a refelct value")
@@ -1049,7 +1076,7 @@ func (c *funcContext) translateAssign(lhs, rhs ast.Expr, define bool) string {
 				}
 			}
 			// binary shadow struct values need special handling.
-			tn := c.typeName(lhsType, nil)
+			tn := lhsType.String()
 			shortPkg, typ := extractBasePackageName(tn)
 			isShad, shortType := isShadowStruct(tn)
 			pp("here, writing __copy for type = '%s'; shortPkg=%v, typ=%v, isShad=%v, shortType=%v", tn, shortPkg, typ, isShad, shortType)
@@ -223,7 +223,127 @@ func Test125AnonymousStructType(t *testing.T) {
 		fmt.Printf("\n translation='%s'\n", translation)
 
 		LuaRunAndReport(vm, string(translation))
-		LuaMustString(vm, "s", `&struct { x int; nm string }{x: 0LL, nm: "", }`)
+		LuaMustString(vm, "s", `struct { x int; nm string }{x: 0LL, nm: "", }`)
 		cv.So(true, cv.ShouldBeTrue)
 	})
 }
+
+func Test126PlainOldDataStructsAreCstructs(t *testing.T) {
+
+	cv.Convey(`a struct of numbers, bools, arrays of numbers and other such structs is an FFI cstruct, keeping Go's value semantics; one with a string field stays a table`, t, func() {
+
+		code := `
+type Vec struct{ X, Y float64 }
+
+func (v Vec) Len2() float64 { return v.X*v.X + v.Y*v.Y }
+
+type Body struct {
+	Pos, Vel Vec
+	Hist     [3]int64
+	Alive    bool
+}
+
+func (b *Body) Step() { b.Pos.X += b.Vel.X; b.Pos.Y += b.Vel.Y; b.Hist[0]++ }
+
+type Named struct {
+	Name string
+	At   Vec
+}
+
+type Lener interface{ Len2() float64 }
+
+b := Body{Pos: Vec{1, 2}, Vel: Vec{X: 3}, Hist: [3]int64{7, 8, 9}}
+b.Step()
+h := b.Hist
+h[1] = 99
+c := b
+same := b == c
+c.Pos.Y = -1
+differ := b != c
+
+var l Lener = b.Pos
+l2 := l.Len2()
+v, isVec := l.(Vec)
+
+bodies := make([]Body, 2)
+bodies[1].Vel.X = 2
+bodies[1].Step()
+bb := bodies[1]
+bb.Alive = true
+ps := &bodies[1].Pos
+ps.Y = 77
+
+nm := Named{Name: "x", At: Vec{3, 4}}
+nl := nm.At.Len2()
+
+posX, hist0, hist1, velY := b.Pos.X, b.Hist[0], b.Hist[1], c.Vel.Y
+h1, vx := h[1], v.X
+elemY, bbY, alive := bodies[1].Pos.Y, bb.Pos.Y, bodies[1].Alive
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(code))
+		fmt.Printf("\n translation='%s'\n", translation)
+
+		LuaRunAndReport(vm, string(translation))
+
+		LuaRunAndReport(vm, `bIsCdata = type(b.__val) == "cdata"; nmIsTable = type(nm.__val) == "table"; bodiesFFI = type(bodies.__array.__data) == "cdata"`)
+		LuaMustBool(vm, "bIsCdata", true)
+		LuaMustBool(vm, "nmIsTable", true)
+		LuaMustBool(vm, "bodiesFFI", true)
+
+		LuaMustFloat64(vm, "posX", 4)
+		LuaMustInt64(vm, "hist0", 8)
+		LuaMustInt64(vm, "hist1", 8)
+		LuaMustInt64(vm, "h1", 99)
+		LuaMustFloat64(vm, "velY", 0)
+		LuaMustBool(vm, "same", true)
+		LuaMustBool(vm, "differ", true)
+		LuaMustFloat64(vm, "l2", 20)
+		LuaMustBool(vm, "isVec", true)
+		LuaMustFloat64(vm, "vx", 4)
+		LuaMustFloat64(vm, "elemY", 77)
+		LuaMustFloat64(vm, "bbY", 0)
+		LuaMustBool(vm, "alive", false)
+		LuaMustFloat64(vm, "nl", 25)
+
+		// redefined at the REPL, a type gets a new cstruct.
+		translation = inc.trMust([]byte(`type Vec struct{ X, Y, Z float64 }; w := Vec{Z: 5}; wz := w.Z`))
+		LuaRunAndReport(vm, string(translation))
+		LuaMustFloat64(vm, "wz", 5)
+	})
+}
+
+func Test127CstructFieldsStayFieldsInHotLoops(t *testing.T) {
+
+	cv.Convey(`once LuaJIT has compiled a loop that reads a cstruct's struct field, a key that is not a C field of the cstruct, its other fields still read as themselves. This needs the vendored lj_crecord.c patch, and so a libluajit.a rebuilt with it (see the README).`, t, func() {
+
+		code := `
+type Pt struct{ X, Y float64 }
+type Cell struct {
+	P Pt
+	N int64
+}
+a := Cell{N: 1}
+b := Cell{N: 2}
+same := 0
+for i := 0; i < 5000; i++ {
+	if a == b {
+		same++
+	}
+}
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(code))
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustInt64(vm, "same", 0)
+	})
+}
@@ -0,0 +1,135 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2180SyncParksGoroutines(t *testing.T) {
+
+	cv.Convey(`import "sync" gets a Mutex, RWMutex, WaitGroup, Once and Cond that park the waiting goroutine in the scheduler instead of blocking the thread, and show as Go's states when deadlocked; sync/atomic works through gijit pointers.`, t, func() {
+
+		r, done := newTestRepl()
+		defer done()
+
+		panicOn(LuaRun(r.lvm, `__gijit_installSync(); __gijit_installAtomic()`, false))
+
+		// the lock holder yields, and the others
+		// wait their turn; Wait waits for all. Calls
+		// are translated as wg.Wait(), as for any
+		// shadowed package's types.
+		panicOn(LuaRun(r.lvm, `
+local wg = __type__.sync.WaitGroup()
+local mu = __type__.sync.Mutex()
+__syncSum = 0
+for i = 1, 5 do
+   wg.Add(1LL)
+   __task.spawn(function()
+      mu.Lock()
+      local v = __syncSum
+      __task.gosched()
+      __syncSum = v + i
+      mu.Unlock()
+      wg.Done()
+   end, {})
+end
+wg.Wait()
+__syncOut = tostring(__syncSum)
+`, true))
+		cv.So(LuaToString(r.lvm, "__syncOut"), cv.ShouldEqual, "15")
+
+		// Once runs f once; Cond wakes the waiters,
+		// locking L through the sync.Locker interface,
+		// as L:Lock().
+		panicOn(LuaRun(r.lvm, `
+local once = __type__.sync.Once()
+local mu = __type__.sync.Mutex()
+local cond = __packages["sync"].NewCond(mu)
+local wg = __type__.sync.WaitGroup()
+local ready = false
+__onceRuns, __woken = 0, 0
+for i = 1, 3 do
+   wg.Add(1LL)
+   __task.spawn(function()
+      once.Do(function() __onceRuns = __onceRuns + 1 end)
+      mu.Lock()
+      while not ready do
+         cond.Wait()
+      end
+      __woken = __woken + 1
+      mu.Unlock()
+      wg.Done()
+   end, {})
+end
+__task.gosched()
+mu.Lock()
+ready = true
+cond.Broadcast()
+mu.Unlock()
+wg.Wait()
+__syncOut = __onceRuns.." "..__woken
+`, true))
+		cv.So(LuaToString(r.lvm, "__syncOut"), cv.ShouldEqual, "1 3")
+
+		// readers share; a writer waits for them.
+		panicOn(LuaRun(r.lvm, `
+local rw = __type__.sync.RWMutex()
+local wg = __type__.sync.WaitGroup()
+local log = {}
+for _, name in ipairs({"r1", "r2"}) do
+   wg.Add(1LL)
+   __task.spawn(function()
+      rw.RLock()
+      table.insert(log, name)
+      __task.gosched()
+      table.insert(log, "-"..name)
+      rw.RUnlock()
+      wg.Done()
+   end, {})
+end
+__task.gosched()
+rw.Lock()
+table.insert(log, "w")
+rw.Unlock()
+wg.Wait()
+__syncOut = #log..": "..log[#log]..", readers together: "..tostring(log[1]:sub(1,1) == "r" and log[2]:sub(1,1) == "r")
+`, true))
+		cv.So(LuaToString(r.lvm, "__syncOut"), cv.ShouldEqual, "5: w, readers together: true")
+
+		// a Wait no one can end is Go's deadlock.
+		panicOn(LuaRun(r.lvm, `
+local wg = __type__.sync.WaitGroup()
+wg.Add(1LL)
+wg.Wait()
+`, true))
+		fatal := LuaToString(r.lvm, "__lastEvalErr")
+		cv.So(fatal, cv.ShouldStartWith, "fatal error: all goroutines are asleep - deadlock!\n\n")
+		cv.So(fatal, cv.ShouldContainSubstring, "[sync.WaitGroup.Wait]:")
+
+		// from Go source: Wait waits out the goroutine.
+		panicOn(r.Eval("import \"sync\"\nvar wg sync.WaitGroup\nsum := 0\nwg.Add(1)\ngo func() {\n\tsum = 42\n\twg.Done()\n}()\nwg.Wait()"))
+		LuaMustInt64(r.lvm, "sum", 42)
+
+		// unlocking an unlocked mutex is fatal, as in
+		// Go: recover does not stop it.
+		panicOn(r.Eval("var mu sync.Mutex\nrecovered := false\nfunc unlock() {\n\tdefer func() {\n\t\trecovered = recover() != nil\n\t}()\n\tmu.Unlock()\n}\nunlock()"))
+		cv.So(LuaToString(r.lvm, "tostring(__lastEvalErr)"), cv.ShouldEqual, "fatal error: sync: unlock of unlocked mutex")
+		LuaMustBool(r.lvm, "recovered", false)
+
+		// atomics, through a gijit pointer.
+		panicOn(LuaRun(r.lvm, `
+local x = 2147483647LL
+local p = {__get = function() return x end, __set = function(v) x = v end}
+local got = {tostring(__packages["sync/atomic"].AddInt32(p, 1LL))}
+table.insert(got, tostring(__packages["sync/atomic"].CompareAndSwapInt64(p, 0LL, 5LL)))
+table.insert(got, tostring(__packages["sync/atomic"].CompareAndSwapInt64(p, -2147483648LL, 5LL)))
+table.insert(got, tostring(__packages["sync/atomic"].SwapInt64(p, 7LL)))
+table.insert(got, tostring(__packages["sync/atomic"].LoadInt64(p)))
+local u = __type__["sync/atomic"].Uint32()
+table.insert(got, tostring(u.Add(4294967295ULL)))
+__syncOut = table.concat(got, " ")
+`, false))
+		cv.So(LuaToString(r.lvm, "__syncOut"), cv.ShouldEqual, "-2147483648LL false true 5LL 7LL 4294967295ULL")
+	})
+}
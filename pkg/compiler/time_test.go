@@ -58,12 +58,12 @@ func Test923TimeStruct(t *testing.T) {
 		//vv("------------ starting translation2 -----")
 		translation2 := inc.trMust([]byte(code2))
 		LuaRunAndReport(vm, string(translation2))
-		cv.So(string(translation2), matchesLuaSrc, `tm = __type__.time.Time();`)
+		cv.So(string(translation2), matchesLuaSrc, `tm = __type__["time"].Time();`)
 
 		//vv("------------ starting translation3 -----")
 		translation3 := inc.trMust([]byte(code3))
 		LuaRunAndReport(vm, string(translation3))
-		cv.So(string(translation3), matchesLuaSrc, `tm = time.Now();`)
+		cv.So(string(translation3), matchesLuaSrc, `tm = __packages["time"].Now();`)
 
 		//vv("------------ starting translation4 -----")
 		translation4 := inc.trMust([]byte(code4))
@@ -73,7 +73,7 @@ func Test923TimeStruct(t *testing.T) {
 		translation5 := inc.trMust([]byte(code5))
 		LuaRunAndReport(vm, string(translation5))
 		// get the second statement
-		cv.So(string(bytes.Split(translation5, []byte("\n\n"))[1]), matchesLuaSrc, `s = __type__.S.ptrToNewlyConstructed(__type__.time.Time());`)
+		cv.So(string(bytes.Split(translation5, []byte("\n\n"))[1]), matchesLuaSrc, `s = __type__.S.ptrToNewlyConstructed(__type__["time"].Time());`)
 
 		//vv("------------ starting translation6 -----")
 		translation6 := inc.trMust([]byte(code6))
@@ -46,6 +46,23 @@ type IncrState struct {
 	Session *Session
 
 	zlisp *zygo.Zlisp
+
+	// TrackPos requests Go source positions be
+	// tracked through translation, as the REPL does
+	// for :profile, :jit and the goroutine listings.
+	// Each Tr then leaves its map in LastPosMap.
+	TrackPos   bool
+	LastPosMap *ChunkPosMap
+	posEntries int
+
+	// Preempt requests each loop check for preemption,
+	// as the REPL does while :preempt has a budget.
+	// Loops translated without it never yield.
+	Preempt bool
+
+	// cgo is the package C, once there
+	// has been an import "C"; see cgo.go.
+	cgo *cgoState
 }
 
 func NewIncrState(lvm *LuaVm, cfg *GIConfig) *IncrState {
@@ -195,18 +212,30 @@ func (tr *IncrState) TrWithPrepend(src []byte, prependOk bool) (by []byte, err e
 			pp("should prepend is true, trying parse with ans prepend")
 			prev := tr.cfg.CalculatorMode
 			tr.cfg.CalculatorMode = true // force pre-pending of "ans = "
-			src, didPrepend = tr.prependAns(src)
+			withAns, _ := tr.prependAns(src)
 			tr.cfg.CalculatorMode = prev
 
-			file2, err := parser.ParseFile(tr.CurPkg.fileSet, "", src, 0)
+			file2, err := parser.ParseFile(tr.CurPkg.fileSet, "", withAns, 0)
 			if err == nil {
 				file = file2
-			} // else we leave file as in, since it parsed without the prepend..
+				src = withAns
+			} // else we leave file and src as they were, since they parsed without the prepend..
 			pp("after shouldPrepend, ParseFile gave err = '%v'; src='%s'", err, src)
 		}
 	}
 	pp("we got past the ParseFile !")
 
+	if importsC(file) {
+		// the cgo preamble is a comment, which the
+		// parse above drops, so read it again.
+		withComments, err := parser.ParseFile(tr.CurPkg.fileSet, "", src, parser.ImportsOnly|parser.ParseComments)
+		panicOn(err)
+		if tr.cgo == nil {
+			tr.cgo = newCgoState()
+		}
+		tr.cgo.pending = append(tr.cgo.pending, cgoPreambles(withComments)...)
+	}
+
 	if tr.PrintAST {
 		ast.Print(tr.CurPkg.fileSet, file)
 	}
@@ -227,6 +256,13 @@ func (tr *IncrState) TrWithPrepend(src []byte, prependOk bool) (by []byte, err e
 		return nil, fmt.Errorf(msg)
 	}
 	depth := 0
+	tr.LastPosMap = nil
+	tr.CurPkg.importContext.EmitPos = tr.TrackPos
+	tr.CurPkg.importContext.EmitPreempt = tr.Preempt
+	ndecl := 0
+	if tr.CurPkg.Arch != nil {
+		ndecl = len(tr.CurPkg.Arch.Declarations)
+	}
 	tr.CurPkg.Arch, err = IncrementallyCompile(tr.CurPkg.Arch, tr.CurPkg.pack.ImportPath, files, tr.CurPkg.fileSet, tr.CurPkg.importContext, tr.minify, depth)
 	panicOn(err)
 	//pp("archive = '%#v'", tr.CurPkg.Arch)
@@ -242,6 +278,15 @@ func (tr *IncrState) TrWithPrepend(src []byte, prependOk bool) (by []byte, err e
 	}
 	tr.CurPkg.Arch.NewCodeText = nil
 
+	if tr.TrackPos {
+		// strip out the position markers, keeping
+		// their line mapping.
+		tr.posEntries++
+		var clean []byte
+		tr.LastPosMap, clean = newChunkPosMap(tr.posEntries, string(src), res.Bytes(), tr.CurPkg.fileSet, file)
+		tr.LastPosMap.decls = tr.CurPkg.Arch.Declarations[ndecl:]
+		return clean, nil
+	}
 	return res.Bytes(), nil
 }
 
@@ -88,3 +88,34 @@ func Test041RangeOverUtf8BytesInString(t *testing.T) {
 		LuaMustString(vm, "r2", "語")
 	})
 }
+
+func Test2220IndexingAStringGivesAByte(t *testing.T) {
+
+	cv.Convey(`s[i] is the byte at i, not a one byte string, and s[i:j] takes int64 indices, as in Go.`, t, func() {
+
+		code := `
+s := "héllo"
+i := 1
+b0 := s[0]
+isH := s[0] == 'h'
+b1 := s[i]
+tail := s[i:]
+head := s[:i+2]
+mid := s[i : i+2]
+`
+		vm, err := NewLuaVmWithPrelude(nil)
+		panicOn(err)
+		defer vm.Close()
+		inc := NewIncrState(vm, nil)
+
+		translation := inc.trMust([]byte(code))
+		LuaRunAndReport(vm, string(translation))
+
+		LuaMustInt(vm, "b0", 'h')
+		LuaMustBool(vm, "isH", true)
+		LuaMustInt(vm, "b1", 0xc3) // the first byte of é
+		LuaMustString(vm, "tail", "éllo")
+		LuaMustString(vm, "head", "hé")
+		LuaMustString(vm, "mid", "é")
+	})
+}
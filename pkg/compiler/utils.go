@@ -2,7 +2,6 @@ package compiler
 
 import (
 	"bytes"
-	"encoding/binary"
 	"fmt"
 	"github.com/gijit/gi/pkg/ast"
 	"github.com/gijit/gi/pkg/constant"
@@ -49,16 +48,36 @@ func (c *funcContext) SetPos(pos token.Pos) {
 	c.pos = pos
 }
 
+// posMarkerLen is the length of the position marker
+// that writePos embeds in the output: a '\b' followed
+// by the token.Pos in 8 hex digits. GopherJS wrote the
+// 4 raw bytes of the position instead, but those can
+// contain '\n', and incr.go splits output on newlines.
+const posMarkerLen = 9
+
 func (c *funcContext) writePos() {
-	// jea debug: turn off writePos() for now
-	return
+	// jea: markers are only emitted when the IncrState
+	// has TrackPos set, as the REPL always does; Tr then
+	// strips them again, in newChunkPosMap.
+	if !c.p.emitPos {
+		return
+	}
 	if c.posAvailable {
 		c.posAvailable = false
-		c.Write([]byte{'\b'})
-		binary.Write(c, binary.BigEndian, uint32(c.pos))
+		c.Write([]byte(fmt.Sprintf("\b%08x", uint32(c.pos))))
 	}
 }
 
+// trimPosMarkers splits any leading position markers
+// off of s, so the remaining code can be inspected.
+func trimPosMarkers(s string) (markers, rest string) {
+	i := 0
+	for len(s)-i >= posMarkerLen && s[i] == '\b' {
+		i += posMarkerLen
+	}
+	return s[:i], s[i:]
+}
+
 func (c *funcContext) Indent(f func()) {
 	c.p.indentation++
 	f()
@@ -250,9 +269,9 @@ func (c *funcContext) zeroValue(ty types.Type) ast.Expr {
 		// instantiated--we skip the Lua type system.
 		isShadow, shortTyp := isShadowStruct(ty.String())
 		if isShadow {
-			//vv("found shadow '%s'; create call to type ctor, e.g. a __type__.time.Time() call.", shortTyp)
+			//vv("found shadow '%s'; create call to type ctor, e.g. a __type__[\"time\"].Time() call.", shortTyp)
 			return &ast.CallExpr{
-				Fun:  c.newIdent("__type__."+shortTyp, types.NewSignature(nil, nil, types.NewTuple(types.NewVar(0, nil, "", ty)), false)),
+				Fun:  c.newIdent(shortTyp, types.NewSignature(nil, nil, types.NewTuple(types.NewVar(0, nil, "", ty)), false)),
 				Args: []ast.Expr{},
 			}
 		}
@@ -390,7 +409,7 @@ func (c *funcContext) pkgVar(pkg *types.Package) string {
 	pkgVar, found := c.p.pkgVars[pkg.Path()]
 	if !found {
 		pp("not found!")
-		pkgVar = fmt.Sprintf(`__packages["%s"]`, pkg.Path())
+		pkgVar = pkgVarName(pkg.Path())
 	}
 	return pkgVar
 }
@@ -425,6 +444,10 @@ func (c *funcContext) objectName(o types.Object) (nam string) {
 		c.p.dependencies[o] = true
 
 		if o.Pkg() != c.p.Pkg || (isVarOrConst(o) && o.Exported()) {
+			if _, isType := o.(*types.TypeName); isType && o.Pkg() != c.p.Pkg {
+				// types are under __type__, by import path.
+				return typePkgVarName(o.Pkg().Path()) + "." + o.Name()
+			}
 			pkgPrefix := c.pkgVar(o.Pkg())
 			pp("o.Pkg() = '%#v', o.Name()='%#v'; pkgPrefix='%s'. c.p.Pkg='%s'",
 				o.Pkg(), o.Name(), pkgPrefix, c.p.Pkg)
@@ -492,6 +515,9 @@ func (c *funcContext) typeNameReport(level int, ty types.Type) string {
 		if t.Obj().Name() == "error" {
 			return "__type__.error"
 		}
+		if t.Obj().Pkg() != c.p.Pkg {
+			return c.objectName(t.Obj())
+		}
 		return "__type__." + c.objectName(t.Obj())
 	case *types.Interface:
 		if t.Empty() {
@@ -517,18 +543,15 @@ func (c *funcContext) typeName(ty types.Type, parent types.Type) (res string) {
 	return
 }
 
-func (c *funcContext) getPkgName() string {
-	if c.PkgNameOverride {
-		return c.PkgNameOverrideValue
-	}
-	pkgName := c.p.Pkg.Name()
-	//pp("pkgName='%s'", pkgName)
-	if pkgName == "main" {
-		pkgName = ""
-	} else {
-		pkgName += "."
+// typePrefix is the Lua prefix of the types that this
+// package declares. The REPL's main package keeps its
+// types directly under __type__; any other package's
+// are under typePkgVarName of its import path.
+func (c *funcContext) typePrefix() string {
+	if c.PkgNameOverride || c.p.Pkg.Name() == "main" {
+		return "__type__."
 	}
-	return pkgName
+	return typePkgVarName(c.p.Pkg.Path()) + "."
 }
 
 func (c *funcContext) typeNameWithAnonInfo(
@@ -552,7 +575,7 @@ func (c *funcContext) typeNameWithAnonInfo(
 		}
 	}
 
-	pkgName := c.getPkgName()
+	prefix := c.typePrefix()
 	var shortTyp string
 	isShadow, shortTyp = isShadowStruct(ty.String())
 	_ = shortTyp
@@ -565,18 +588,24 @@ func (c *funcContext) typeNameWithAnonInfo(
 	case *types.Basic:
 		jst := toJavaScriptType(t)
 		pp("in typeName, basic, calling toJavaScriptType t='%#v', got jst='%s'", t, jst)
-		res = "__type__." + pkgName + jst
+		// the basic types are universal, not per package.
+		res = "__type__." + jst
 		return
 	case *types.Named:
 		if t.Obj().Name() == "error" {
-			res = "__type__." + pkgName + "error"
+			res = "__type__.error"
+			return
+		}
+		if t.Obj().Pkg() != c.p.Pkg {
+			// objectName has the other package's prefix.
+			res = c.objectName(t.Obj())
 			return
 		}
-		res = "__type__." + pkgName + c.objectName(t.Obj())
+		res = prefix + c.objectName(t.Obj())
 		return
 	case *types.Interface:
 		if t.Empty() {
-			res = "__type__." + pkgName + "emptyInterface"
+			res = "__type__.emptyInterface"
 			return
 		}
 	}
@@ -591,12 +620,12 @@ func (c *funcContext) typeNameWithAnonInfo(
 		pp("----- back from c.initArgs(ty)\n")
 
 		// [6:] takes prefix "__kind" off.
-		low := "__type__." + pkgName + "anon_" + strings.ToLower(typeKind(ty)[6:]) + "Type"
+		low := "anon_" + strings.ToLower(typeKind(ty)[6:]) + "Type"
 
 		// typeKind(ty)='_kindSlice', low='sliceType'
 		pp("typeKind(ty)='%s', low='%s'\n", typeKind(ty), low)
 
-		varName := c.newVariableWithLevel(low, true, true)
+		varName := prefix + c.newVariableWithLevel(low, true, true)
 
 		anonType = types.NewTypeName(token.NoPos, c.p.Pkg, varName, ty) // fake types.TypeName
 		c.p.anonTypes = append(c.p.anonTypes, anonType)
@@ -676,7 +705,12 @@ func (c *funcContext) handleEscapingVars(n ast.Node) {
 }
 
 func fieldName(t *types.Struct, i int) string {
-	name := t.Field(i).Name()
+	f := t.Field(i)
+	name := f.Name()
+	if f.Pkg() != nil && f.Pkg().Path() == "C" {
+		// the FFI struct's own names; see cgo.go.
+		return name
+	}
 	if name == "_" || reservedKeywords[name] {
 		return fmt.Sprintf("%s__%d", name, i)
 	}
@@ -924,8 +958,8 @@ func removeWhitespace(b []byte, minify bool) []byte {
 	for len(b) > 0 {
 		switch b[0] {
 		case '\b':
-			out = append(out, b[:5]...)
-			b = b[5:]
+			out = append(out, b[:posMarkerLen]...)
+			b = b[posMarkerLen:]
 			continue
 		case ' ', '\t', '\n':
 			if (!needsSpace(previous) || !needsSpace(b[1])) && !(previous == '-' && b[1] == '-') {
@@ -1125,9 +1159,17 @@ func extractBasePackageName(pkg string) (shortPkg, typ string) {
 	return
 }
 
+// isShadowStruct reports whether the type named by
+// pkgName, as types.Type.String() gives it, is a struct
+// of a binary Go package, and if so gives the Lua
+// expression for its type.
 func isShadowStruct(pkgName string) (is bool, typeName string) {
 	base, typ := extractBasePackageName(pkgName)
 	is = strings.Contains(pkgName, "/pkg/compiler/shadow/") || binaryPackage[base]
-	typeName = base + "." + typ
+	pth := pkgName
+	if i := strings.LastIndex(pkgName, "."); i >= 0 {
+		pth = pkgName[:i]
+	}
+	typeName = typePkgVarName(pth) + "." + typ
 	return
 }
@@ -32,15 +32,11 @@ chk2 := __tostring(&s)
 		// and verify that it happens correctly
 		LuaRunAndReport(vm, string(translation))
 
-		// By rights, chk should say `main.S{b: 23LL, }`.
-		// But `&main.S{b: 23LL, }` is correct because
-		// in order for pointer comparison to work
-		// (and have two pointers to the same strut
-		// have equal values), even a basic struct
-		// must need be a pointer. *shrug*. That
-		// was the GopherJS design. It works, so
-		// we keep it.
-		LuaMustString(vm, "chk", `&main.S{b: 23LL, }`)
+		// s itself is held by pointer, as even a basic
+		// struct is, for pointer comparison to work;
+		// but a copy of the value is what goes into
+		// the interface __tostring takes.
+		LuaMustString(vm, "chk", `main.S{b: 23LL, }`)
 		LuaMustString(vm, "chk2", `&main.S{b: 23LL, }`)
 
 	})
@@ -0,0 +1,74 @@
+package compiler
+
+import (
+	"testing"
+
+	cv "github.com/glycerine/goconvey/convey"
+)
+
+func Test2100WhosListsDeclarationsWithGoTypes(t *testing.T) {
+
+	vm, err := NewLuaVmWithPrelude(nil)
+	panicOn(err)
+	defer vm.Close()
+	inc := NewIncrState(vm, nil)
+	r := &Repl{lvm: vm, inc: inc, chunks: make(map[string]*ChunkPosMap)}
+
+	cv.Convey(":whos lists the package-level vars, funcs, types and consts with their Go types, len/cap, bytes and a preview; :who filters by kind and name pattern.", t, func() {
+		src := `
+type Pt struct { X, Y int }
+const Big = 1 << 10
+func add(a, b int) int { return a + b }
+a := make([]int, 3, 10)
+m := map[string]int{"x": 1, "y": 2}
+s := "hello"
+p := &Pt{X: 1, Y: 2}
+arr := [4]byte{}
+pts := []Pt{{1, 2}}
+`
+		translation, err := inc.Tr([]byte(src))
+		panicOn(err)
+		panicOn(LuaRun(vm, string(translation), false))
+
+		ents, err := r.who(nil, true)
+		panicOn(err)
+		got := make(map[string]*whoEntry)
+		var names []string
+		for _, e := range ents {
+			got[e.Name] = e
+			names = append(names, e.Name)
+		}
+		cv.So(names, cv.ShouldResemble, []string{"Big", "Pt", "a", "add", "arr", "m", "p", "pts", "s"})
+
+		cv.So(*got["a"], cv.ShouldResemble, whoEntry{Name: "a", Kind: "var", Type: "[]int", Len: 3, Cap: 10, Bytes: 24 + 80, Value: "[0 0 0]"})
+		cv.So(*got["s"], cv.ShouldResemble, whoEntry{Name: "s", Kind: "var", Type: "string", Len: 5, Cap: -1, Bytes: 16 + 5, Value: "hello"})
+		cv.So(*got["arr"], cv.ShouldResemble, whoEntry{Name: "arr", Kind: "var", Type: "[4]byte", Len: 4, Cap: 4, Bytes: 4, Value: "[0 0 0 0]"})
+		cv.So(*got["p"], cv.ShouldResemble, whoEntry{Name: "p", Kind: "var", Type: "*Pt", Len: -1, Cap: -1, Bytes: 8 + 16, Value: "&{1 2}"})
+		cv.So(*got["pts"], cv.ShouldResemble, whoEntry{Name: "pts", Kind: "var", Type: "[]Pt", Len: 1, Cap: 1, Bytes: 24 + 16, Value: "[{1 2}]"})
+		cv.So(got["m"].Type, cv.ShouldEqual, "map[string]int")
+		cv.So(got["m"].Len, cv.ShouldEqual, 2)
+		cv.So(got["m"].Bytes, cv.ShouldBeGreaterThan, 8+2*(16+8))
+		cv.So(got["m"].Value, cv.ShouldEqual, "map[x:1 y:2]")
+		cv.So(*got["add"], cv.ShouldResemble, whoEntry{Name: "add", Kind: "func", Type: "func(a int, b int) int", Len: -1, Cap: -1, Bytes: -1})
+		cv.So(got["Pt"].Type, cv.ShouldEqual, "struct{X int; Y int}")
+		cv.So(got["Big"].Kind, cv.ShouldEqual, "const")
+		cv.So(got["Big"].Value, cv.ShouldEqual, "1024")
+
+		ents, err = r.who([]string{"var", "p*"}, false)
+		panicOn(err)
+		cv.So(whoList(ents), cv.ShouldEqual, "p  pts\n")
+
+		ents, err = r.who([]string{"types", "funcs"}, false)
+		panicOn(err)
+		cv.So(whoList(ents), cv.ShouldEqual, "Pt  add\n")
+
+		_, err = r.who([]string{"[x"}, false)
+		cv.So(err, cv.ShouldNotBeNil)
+
+		tab := whosTable([]*whoEntry{got["a"], got["s"]})
+		cv.So(tab, cv.ShouldEqual, ""+
+			"Name  Kind  Type    Len/Cap  Bytes  Value\n"+
+			"a     var   []int   3/10     104    [0 0 0]\n"+
+			"s     var   string  5        21     hello\n")
+	})
+}
@@ -248,10 +248,9 @@ func (r *reader) fileExports(src *ast.File) {
 			if !r.filterDecl(de) {
 				continue
 			}
-		default:
-			src.Nodes[j] = de
-			j++
 		}
+		src.Nodes[j] = d
+		j++
 	}
 	src.Nodes = src.Nodes[0:j]
 }
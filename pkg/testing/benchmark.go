@@ -0,0 +1,70 @@
+package testing
+
+import "time"
+
+// B is a type passed to Benchmark functions to manage
+// benchmark timing and to specify the number of
+// iterations to run.
+type B struct {
+	common
+	N int
+
+	// in nanoseconds; a field of a type from another
+	// package is not yet supported by gijit.
+	timerOn  bool
+	start    int64
+	duration int64
+}
+
+// StartTimer starts timing a test. This function is called
+// automatically before a benchmark starts, but it can also
+// be used to resume timing after a call to StopTimer.
+func (b *B) StartTimer() {
+	if !b.timerOn {
+		b.start = time.Now().UnixNano()
+		b.timerOn = true
+	}
+}
+
+// StopTimer stops timing a test. This can be used to pause
+// the timer while performing complex initialization that
+// you don't want to measure.
+func (b *B) StopTimer() {
+	if b.timerOn {
+		b.duration += time.Now().UnixNano() - b.start
+		b.timerOn = false
+	}
+}
+
+// ResetTimer zeroes the elapsed benchmark time.
+func (b *B) ResetTimer() {
+	if b.timerOn {
+		b.start = time.Now().UnixNano()
+	}
+	b.duration = 0
+}
+
+// ReportAllocs does nothing; allocations are
+// not counted by `gi test -bench`.
+func (b *B) ReportAllocs() {}
+
+// SetBytes does nothing; MB/s is not reported
+// by `gi test -bench`.
+func (b *B) SetBytes(n int64) {}
+
+// RunBenchmark runs the benchmark f, called name, once
+// with b.N set to n, for `gi test`, which chooses n.
+// It returns the nanoseconds timed, and the output to
+// print after the benchmark's result line.
+func RunBenchmark(name string, f func(*B), n int) (failed, skipped bool, ns int64, output string) {
+	b := &B{common: common{name: name}, N: n}
+	runBenchmark(b, f)
+	b.StopTimer()
+	return b.failed, b.skipped, b.duration, b.output
+}
+
+func runBenchmark(b *B, f func(*B)) {
+	defer b.recoverExit()
+	b.StartTimer()
+	f(b)
+}
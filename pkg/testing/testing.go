@@ -0,0 +1,264 @@
+// Package testing stands in for the standard library's
+// testing package when `gi test` runs a package's tests
+// under the Lua runtime. Test files that import "testing"
+// are given this package instead; it provides the parts
+// of T, B, and TB that tests use, while `gi test` itself
+// plays the part of the generated test main, calling
+// RunTest and RunBenchmark for each function it finds.
+//
+// Tests run one at a time, and t.Parallel does nothing.
+// FailNow, Fatal, and Skip end a test by panicking, which
+// RunTest recovers, so they must be called from the
+// test's own goroutine, as in Go.
+package testing
+
+import (
+	"fmt"
+	"time"
+)
+
+// TB is the interface common to T and B.
+type TB interface {
+	Error(args ...interface{})
+	Errorf(format string, args ...interface{})
+	Fail()
+	FailNow()
+	Failed() bool
+	Fatal(args ...interface{})
+	Fatalf(format string, args ...interface{})
+	Helper()
+	Log(args ...interface{})
+	Logf(format string, args ...interface{})
+	Name() string
+	Skip(args ...interface{})
+	SkipNow()
+	Skipf(format string, args ...interface{})
+	Skipped() bool
+}
+
+var _ TB = (*T)(nil)
+var _ TB = (*B)(nil)
+
+// common holds the state shared by T and B.
+type common struct {
+	name    string
+	depth   int  // 0 for a top level test, 1 for its subtests, ...
+	chatty  bool // -v
+	failed  bool
+	skipped bool
+
+	// running has the "=== RUN" lines of subtests,
+	// under -v; output has the log lines, and the
+	// results of subtests, indented for printing
+	// after the test's own result line.
+	running string
+	output  string
+}
+
+// testExit is panicked by FailNow and SkipNow,
+// to end the test.
+type testExit struct{}
+
+// Name returns the name of the running test or benchmark.
+func (c *common) Name() string {
+	return c.name
+}
+
+// Fail marks the function as having failed but continues execution.
+func (c *common) Fail() {
+	c.failed = true
+}
+
+// Failed reports whether the function has failed.
+func (c *common) Failed() bool {
+	return c.failed
+}
+
+// FailNow marks the function as having failed and stops its execution.
+func (c *common) FailNow() {
+	c.failed = true
+	panic(testExit{})
+}
+
+// Log formats its arguments using default formatting, analogous to Println,
+// and records the text in the error log.
+func (c *common) Log(args ...interface{}) {
+	c.log(fmt.Sprintln(args...))
+}
+
+// Logf formats its arguments according to the format, analogous to Printf,
+// and records the text in the error log.
+func (c *common) Logf(format string, args ...interface{}) {
+	c.log(fmt.Sprintf(format, args...))
+}
+
+// Error is equivalent to Log followed by Fail.
+func (c *common) Error(args ...interface{}) {
+	c.log(fmt.Sprintln(args...))
+	c.Fail()
+}
+
+// Errorf is equivalent to Logf followed by Fail.
+func (c *common) Errorf(format string, args ...interface{}) {
+	c.log(fmt.Sprintf(format, args...))
+	c.Fail()
+}
+
+// Fatal is equivalent to Log followed by FailNow.
+func (c *common) Fatal(args ...interface{}) {
+	c.log(fmt.Sprintln(args...))
+	c.FailNow()
+}
+
+// Fatalf is equivalent to Logf followed by FailNow.
+func (c *common) Fatalf(format string, args ...interface{}) {
+	c.log(fmt.Sprintf(format, args...))
+	c.FailNow()
+}
+
+// Skip is equivalent to Log followed by SkipNow.
+func (c *common) Skip(args ...interface{}) {
+	c.log(fmt.Sprintln(args...))
+	c.SkipNow()
+}
+
+// Skipf is equivalent to Logf followed by SkipNow.
+func (c *common) Skipf(format string, args ...interface{}) {
+	c.log(fmt.Sprintf(format, args...))
+	c.SkipNow()
+}
+
+// SkipNow marks the test as having been skipped and stops its execution.
+func (c *common) SkipNow() {
+	c.skipped = true
+	panic(testExit{})
+}
+
+// Skipped reports whether the test was skipped.
+func (c *common) Skipped() bool {
+	return c.skipped
+}
+
+// Helper does nothing; log lines carry no file:line
+// under gijit, so there are no frames to skip.
+func (c *common) Helper() {}
+
+// log adds s to the output, indented one level
+// past the test's result line.
+func (c *common) log(s string) {
+	c.output += indent(s, c.depth+1)
+}
+
+// indent prefixes each line of s with depth
+// levels of four spaces, ending s with a newline.
+func indent(s string, depth int) string {
+	pre := ""
+	for i := 0; i < depth; i++ {
+		pre += "    "
+	}
+	if len(s) == 0 || s[len(s)-1] != '\n' {
+		s += "\n"
+	}
+	out := ""
+	beg := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			out += pre + s[beg:i+1]
+			beg = i + 1
+		}
+	}
+	return out
+}
+
+// report is the result line for c, followed by its output.
+func (c *common) report(secs float64) string {
+	status := "PASS"
+	switch {
+	case c.failed:
+		status = "FAIL"
+	case c.skipped:
+		status = "SKIP"
+	}
+	line := fmt.Sprintf("--- %s: %s (%.2fs)\n", status, c.name, secs)
+	return indent(line, c.depth) + c.output
+}
+
+// T is a type passed to Test functions to manage test
+// state and support formatted test logs.
+type T struct {
+	common
+}
+
+// Parallel does nothing; gijit runs tests one at a time.
+func (t *T) Parallel() {}
+
+// Run runs f as a subtest of t called name, and reports
+// whether f succeeded. Under gijit, subtests always run,
+// whatever the -run pattern.
+func (t *T) Run(name string, f func(t *T)) bool {
+	sub := &T{common: common{
+		name:   t.name + "/" + rewrite(name),
+		depth:  t.depth + 1,
+		chatty: t.chatty,
+	}}
+	if t.chatty {
+		t.running += "=== RUN   " + sub.name + "\n"
+	}
+	start := time.Now()
+	runTest(sub, f)
+	secs := time.Since(start).Seconds()
+
+	t.running += sub.running
+	if sub.failed {
+		t.failed = true
+	}
+	if sub.failed || t.chatty {
+		t.output += sub.report(secs)
+	}
+	return !sub.failed
+}
+
+// rewrite replaces spaces in a subtest name with
+// underscores, as Go does.
+func rewrite(s string) string {
+	out := ""
+	for i := 0; i < len(s); i++ {
+		if s[i] == ' ' {
+			out += "_"
+		} else {
+			out += s[i : i+1]
+		}
+	}
+	return out
+}
+
+// runTest calls f(t), recovering the panic from
+// FailNow or SkipNow.
+func runTest(t *T, f func(*T)) {
+	defer t.recoverExit()
+	f(t)
+}
+
+// recoverExit, deferred, recovers the panic from
+// FailNow or SkipNow. Any other panic fails the
+// test; in Go it would end the test binary.
+func (c *common) recoverExit() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if _, ok := r.(testExit); !ok {
+		c.failed = true
+		c.log(fmt.Sprintf("panic: %v", r))
+	}
+}
+
+// RunTest runs the test f, called name, for `gi test`.
+// It returns the "=== RUN" lines of any subtests (when
+// chatty, as under -v), and the output to print after
+// the test's own result line.
+func RunTest(name string, f func(*T), chatty bool) (failed, skipped bool, running, output string) {
+	t := &T{common: common{name: name, chatty: chatty}}
+	runTest(t, f)
+	return t.failed, t.skipped, t.running, t.output
+}
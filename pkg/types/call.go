@@ -319,7 +319,7 @@ func (check *Checker) selector(x *operand, e *ast.SelectorExpr) {
 				}
 				goto Error
 			}
-			if !exp.Exported() {
+			if !exp.Exported() && !isCgo(pkg) {
 				check.errorf(e.Pos(), "%s not exported by package %s", sel, pkg.name)
 				// ok to continue
 			}
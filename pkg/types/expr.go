@@ -1144,7 +1144,7 @@ func (check *Checker) exprInternal(x *operand, e ast.Expr, hint Type) exprKind {
 					}
 					// i < len(fields)
 					fld := fields[i]
-					if !fld.Exported() && fld.pkg != check.pkg {
+					if !fld.Exported() && fld.pkg != check.pkg && !isCgo(fld.pkg) {
 						check.errorf(x.pos(), "implicit assignment to unexported field %s in %s literal", fld.name, typ)
 						continue
 					}
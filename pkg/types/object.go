@@ -105,7 +105,7 @@ func (obj *object) sameId(pkg *Package, name string) bool {
 		return false
 	}
 	// obj.Name == name
-	if obj.Exported() {
+	if obj.Exported() || isCgo(obj.pkg) {
 		return true
 	}
 	// not exported, so packages must be the same (pkg == nil for
@@ -41,6 +41,16 @@ func NewPackage(path, name string) *Package {
 // Path returns the package path.
 func (pkg *Package) Path() string { return pkg.path }
 
+// jea add
+// isCgo reports whether pkg is the package "C" that
+// gijit makes for import "C". As with cgo, whose
+// rewritten Go code lives in the importing package,
+// all its names, such as C.sqrt and the fields of
+// C.struct_point, are usable however they are spelled.
+func isCgo(pkg *Package) bool {
+	return pkg != nil && pkg.path == "C"
+}
+
 // jea add
 // SetPath sets the package path.
 func (pkg *Package) SetPath(path string) { pkg.path = path }